@@ -15,6 +15,7 @@ type Router struct {
 // Routes registers all routes on the provided chi.Router
 func (rtr Router) Routes(r chi.Router) {
 	r.Group(rtr.public)
+	r.Group(rtr.assets)
 }
 
 func (rtr Router) public(r chi.Router) {
@@ -23,5 +24,13 @@ func (rtr Router) public(r chi.Router) {
 		urlShortenerSvcName := env.GetAndValidateF("URL_SHORTENER_SERVICE_NAME")
 		r.Post(prefix+"/v1/shorten", proxy.ProxyToService(urlShortenerSvcName))
 		r.Get(prefix+"/v1/redirect/{shortcode}", proxy.ProxyToService(urlShortenerSvcName))
+		r.Post(prefix+"/v1/links/{shortcode}/metadata:refresh", proxy.ProxyToService(urlShortenerSvcName))
+	})
+}
+
+func (rtr Router) assets(r chi.Router) {
+	r.Group(func(r chi.Router) {
+		urlShortenerSvcName := env.GetAndValidateF("URL_SHORTENER_SERVICE_NAME")
+		r.Get("/assets/{hash}", proxy.ProxyToService(urlShortenerSvcName))
 	})
 }
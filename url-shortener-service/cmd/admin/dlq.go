@@ -0,0 +1,423 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/kafka"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/repository/dlqreplay"
+	kafkago "github.com/segmentio/kafka-go"
+)
+
+// dlqCmd runs the DLQ admin commands: reading <topic>.dlq through the broker and replaying its messages,
+// each replay recorded in the DLQ replay audit
+type dlqCmd struct {
+	broker   kafka.Broker
+	producer kafka.Producer
+	replays  dlqreplay.Repository
+	out      io.Writer
+}
+
+// run dispatches a command with its arguments
+func (c dlqCmd) run(ctx context.Context, command string, args []string) error {
+	switch command {
+	case "list":
+		return c.list(ctx, args)
+	case "show":
+		return c.show(ctx, args)
+	case "replay":
+		return c.replay(ctx, args)
+	default:
+		return fmt.Errorf("unknown dlq command %q", command)
+	}
+}
+
+// dlqFilterFlags binds the flags selecting DLQ messages
+type dlqFilterFlags struct {
+	topic    string
+	ids      string
+	eventIDs string
+	errorHas string
+	since    time.Duration
+	from     string
+	to       string
+}
+
+// register adds the filter flags to fs
+func (f *dlqFilterFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.topic, "topic", "", "topic whose DLQ is read, e.g. "+model.TopicMetadataRequestedV1.String())
+	fs.StringVar(&f.ids, "id", "", "comma-separated DLQ message IDs, as listed")
+	fs.StringVar(&f.eventIDs, "event-id", "", "comma-separated outbox event IDs")
+	fs.StringVar(&f.errorHas, "error", "", "error containing, case insensitive")
+	fs.DurationVar(&f.since, "since", 0, "failed within, e.g. 24h")
+	fs.StringVar(&f.from, "from", "", "failed at or after, RFC 3339")
+	fs.StringVar(&f.to, "to", "", "failed before, RFC 3339")
+}
+
+// scoped reports whether any flag narrows the selection within the topic
+func (f dlqFilterFlags) scoped() bool {
+	return f.ids != "" || f.eventIDs != "" || f.errorHas != "" || f.since > 0 || f.from != "" || f.to != ""
+}
+
+// dlqFilter selects DLQ records, a zero field matching every record
+type dlqFilter struct {
+	ids      map[string]bool
+	eventIDs map[string]bool
+	errorHas string
+	from     time.Time
+	to       time.Time
+}
+
+// filter builds the record filter
+func (f dlqFilterFlags) filter() (dlqFilter, error) {
+	if f.topic == "" {
+		return dlqFilter{}, errors.New("-topic is required")
+	}
+
+	rs := dlqFilter{ids: splitSet(f.ids), eventIDs: splitSet(f.eventIDs), errorHas: strings.ToLower(f.errorHas)}
+	if f.since > 0 {
+		rs.from = time.Now().Add(-f.since)
+	}
+	if f.from != "" {
+		t, err := time.Parse(time.RFC3339, f.from)
+		if err != nil {
+			return dlqFilter{}, fmt.Errorf("invalid -from %q", f.from)
+		}
+		if t.After(rs.from) {
+			rs.from = t
+		}
+	}
+	if f.to != "" {
+		t, err := time.Parse(time.RFC3339, f.to)
+		if err != nil {
+			return dlqFilter{}, fmt.Errorf("invalid -to %q", f.to)
+		}
+		rs.to = t
+	}
+
+	return rs, nil
+}
+
+// match reports whether a record is selected
+func (f dlqFilter) match(r kafka.DLQRecord) bool {
+	switch {
+	case f.ids != nil && !f.ids[r.ID]:
+		return false
+	case f.eventIDs != nil && !f.eventIDs[r.EventID()]:
+		return false
+	case f.errorHas != "" && !strings.Contains(strings.ToLower(r.Error), f.errorHas):
+		return false
+	case !f.from.IsZero() && r.Timestamp.Before(f.from):
+		return false
+	case !f.to.IsZero() && !r.Timestamp.Before(f.to):
+		return false
+	}
+	return true
+}
+
+// records reads the DLQ of a topic and returns the records matching the filter, oldest first.
+// Messages which are not DLQ records are reported and skipped.
+func (c dlqCmd) records(ctx context.Context, topic string, filter dlqFilter) ([]kafka.DLQRecord, error) {
+	var rs []kafka.DLQRecord
+	err := c.broker.Scan(ctx, kafka.DLQTopic(topic), func(msg kafkago.Message) error {
+		r, err := kafka.ReadDLQRecord(msg)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "skipped:", err)
+			return nil
+		}
+		if filter.match(r) {
+			rs = append(rs, r)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(rs, func(a, b int) bool { return rs[a].Timestamp.Before(rs[b].Timestamp) })
+	return rs, nil
+}
+
+// replayed returns the replay records of the given DLQ records, by DLQ ID
+func (c dlqCmd) replayed(ctx context.Context, records []kafka.DLQRecord) (map[string]model.DLQReplay, error) {
+	ids := make([]string, 0, len(records))
+	for _, r := range records {
+		ids = append(ids, r.ID)
+	}
+
+	replays, err := c.replays.ListByDLQIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	rs := make(map[string]model.DLQReplay, len(replays))
+	for _, r := range replays {
+		rs[r.DLQID] = r
+	}
+	return rs, nil
+}
+
+// list prints the DLQ messages matching the filter
+func (c dlqCmd) list(ctx context.Context, args []string) error {
+	var ff dlqFilterFlags
+	fs := flag.NewFlagSet("dlq list", flag.ContinueOnError)
+	ff.register(fs)
+	limit := fs.Int("limit", 50, "maximum number of messages listed, the most recent ones")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	filter, err := ff.filter()
+	if err != nil {
+		return err
+	}
+
+	records, err := c.records(ctx, ff.topic, filter)
+	if err != nil {
+		return err
+	}
+	if *limit > 0 && len(records) > *limit {
+		records = records[len(records)-*limit:]
+	}
+
+	replayed, err := c.replayed(ctx, records)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(c.out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tFAILED\tEVENT\tKEY\tATTEMPTS\tREPLAYED\tERROR")
+	for _, r := range records {
+		replayedAt := "-"
+		if rp, ok := replayed[r.ID]; ok {
+			replayedAt = formatTime(rp.ReplayedAt)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\t%s\t%s\n",
+			r.ID, formatTime(r.Timestamp), orDash(r.EventID()), orDash(r.Key), r.Attempts, replayedAt, truncate(r.Error, 60))
+	}
+
+	return w.Flush()
+}
+
+// show prints a DLQ message in full, with its replay
+func (c dlqCmd) show(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("dlq show", flag.ContinueOnError)
+	topic := fs.String("topic", "", "topic whose DLQ is read")
+	id := fs.String("id", "", "DLQ message ID")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *id == "" {
+		return errors.New("-id is required")
+	}
+
+	filter, err := dlqFilterFlags{topic: *topic, ids: *id}.filter()
+	if err != nil {
+		return err
+	}
+
+	records, err := c.records(ctx, *topic, filter)
+	if err != nil {
+		return err
+	}
+	if len(records) == 0 {
+		return fmt.Errorf("DLQ message %s not found in %s", *id, kafka.DLQTopic(*topic))
+	}
+	r := records[0]
+
+	replayed, err := c.replayed(ctx, records[:1])
+	if err != nil {
+		return err
+	}
+
+	payload, err := json.MarshalIndent(r.Payload, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(c.out, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "id:\t%s\n", r.ID)
+	fmt.Fprintf(w, "dlq topic:\t%s\n", r.DLQTopic)
+	fmt.Fprintf(w, "topic:\t%s\n", r.Topic)
+	fmt.Fprintf(w, "key:\t%s\n", r.Key)
+	fmt.Fprintf(w, "event id:\t%s\n", r.EventID())
+	fmt.Fprintf(w, "partition:\t%d\n", r.Partition)
+	fmt.Fprintf(w, "offset:\t%d\n", r.Offset)
+	fmt.Fprintf(w, "attempts:\t%d\n", r.Attempts)
+	fmt.Fprintf(w, "failed:\t%s\n", formatTime(r.Timestamp))
+	fmt.Fprintf(w, "correlation id:\t%s\n", r.CorrelationID)
+	fmt.Fprintf(w, "trace id:\t%s\n", r.TraceID)
+	fmt.Fprintf(w, "error:\t%s\n", r.Error)
+	if rp, ok := replayed[r.ID]; ok {
+		fmt.Fprintf(w, "replayed:\t%s by %s, replay %d (%s)\n", formatTime(rp.ReplayedAt), rp.ReplayedBy, rp.ReplayCount, orDash(rp.Reason))
+	} else {
+		fmt.Fprintf(w, "replayed:\t-\n")
+	}
+
+	keys := make([]string, 0, len(r.Headers))
+	for k := range r.Headers {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Fprintf(w, "header %s:\t%s\n", k, r.Headers[k])
+	}
+	if err = w.Flush(); err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(c.out, "payload:\n%s\n", payload)
+	return err
+}
+
+// replay republishes the DLQ messages matching the filter to their topic, a dry run unless -yes is given.
+// A DLQ message is replayed once: those replayed already, by this run or an earlier one, are skipped.
+func (c dlqCmd) replay(ctx context.Context, args []string) error {
+	var ff dlqFilterFlags
+	fs := flag.NewFlagSet("dlq replay", flag.ContinueOnError)
+	ff.register(fs)
+	all := fs.Bool("all", false, "replay every message of the DLQ")
+	yes := fs.Bool("yes", false, "replay, instead of only listing the messages affected")
+	rate := fs.Int("rate", 10, "maximum number of messages replayed per second")
+	by := fs.String("by", os.Getenv("USER"), "operator recorded in the replay audit")
+	reason := fs.String("reason", "", "reason recorded in the replay audit")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if !ff.scoped() && !*all {
+		return errUnscoped
+	}
+	if *rate <= 0 {
+		return errors.New("-rate must be > 0")
+	}
+	if *yes && *by == "" {
+		return errors.New("-by is required")
+	}
+
+	filter, err := ff.filter()
+	if err != nil {
+		return err
+	}
+
+	records, err := c.records(ctx, ff.topic, filter)
+	if err != nil {
+		return err
+	}
+
+	replayed, err := c.replayed(ctx, records)
+	if err != nil {
+		return err
+	}
+
+	var pending []kafka.DLQRecord
+	for _, r := range records {
+		if _, ok := replayed[r.ID]; !ok {
+			pending = append(pending, r)
+		}
+	}
+	skipped := len(records) - len(pending)
+
+	if !*yes {
+		for _, r := range pending {
+			fmt.Fprintf(c.out, "would replay %s (event %s) to %s\n", r.ID, orDash(r.EventID()), r.Topic)
+		}
+		_, err = fmt.Fprintf(c.out, "dry run: %d messages would be replayed, %d replayed already, re-run with -yes to apply\n",
+			len(pending), skipped)
+		return err
+	}
+
+	tick := time.NewTicker(time.Second / time.Duration(*rate))
+	defer tick.Stop()
+
+	var done, failed int
+	for i, r := range pending {
+		if i > 0 {
+			select {
+			case <-tick.C:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		ok, err := c.replayOne(ctx, r, *by, *reason)
+		switch {
+		case err != nil:
+			failed++
+			fmt.Fprintf(c.out, "failed %s: %v\n", r.ID, err)
+		case !ok:
+			skipped++
+		default:
+			done++
+			fmt.Fprintf(c.out, "replayed %s (event %s) to %s\n", r.ID, orDash(r.EventID()), r.Topic)
+		}
+	}
+
+	_, err = fmt.Fprintf(c.out, "%d messages replayed, %d replayed already, %d failed\n", done, skipped, failed)
+	if err == nil && failed > 0 {
+		err = fmt.Errorf("%d messages not replayed", failed)
+	}
+	return err
+}
+
+// replayOne records the replay of a DLQ message then publishes it, the record deleted when publishing fails.
+// It returns false when the message was replayed meanwhile.
+func (c dlqCmd) replayOne(ctx context.Context, r kafka.DLQRecord, by, reason string) (bool, error) {
+	msg := r.ReplayMessage()
+
+	claimed, err := c.replays.Claim(ctx, model.DLQReplay{
+		DLQID:       r.ID,
+		DLQTopic:    r.DLQTopic,
+		Topic:       r.Topic,
+		Key:         r.Key,
+		EventID:     r.EventID(),
+		Error:       r.Error,
+		ReplayCount: r.ReplayCount() + 1,
+		ReplayedBy:  by,
+		Reason:      reason,
+	})
+	if err != nil || !claimed {
+		return false, err
+	}
+
+	if err = c.producer.Publish(ctx, msg); err != nil {
+		if releaseErr := c.replays.Release(ctx, r.ID); releaseErr != nil {
+			return false, errors.Join(err, releaseErr)
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+// splitSet returns the set of the comma-separated values of s, nil when s is empty
+func splitSet(s string) map[string]bool {
+	if s == "" {
+		return nil
+	}
+
+	rs := map[string]bool{}
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			rs[v] = true
+		}
+	}
+	return rs
+}
+
+// orDash returns s, "-" when empty
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
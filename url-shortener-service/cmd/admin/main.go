@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/db/pg"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/kafka"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/repository/dlqreplay"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/repository/outgoingevent"
+)
+
+const usage = `Usage: admin <resource> <command> [flags]
+
+Resources and commands:
+  outbox list      list events by status, topic or age
+  outbox show      show an event with its payload and last error
+  outbox requeue   turn FAILED events back to PENDING with a fresh retry count
+  outbox ack       acknowledge FAILED events, letting the retention job archive them
+  outbox purge     delete events
+  outbox stats     count events per status and topic, and tell the oldest pending age
+  dlq list         list the DLQ messages of a topic by error, failure time or event ID
+  dlq show         show a DLQ message with its payload, headers and replay
+  dlq replay       republish DLQ messages to their topic, once each, at a limited rate
+
+Run "admin <resource> <command> -h" for the flags of a command.
+Requires PG_URL, and the broker settings of the services for dlq (BROKER_BACKEND, KAFKA_BROKERS...).
+`
+
+func main() {
+	if len(os.Args) < 3 || (os.Args[1] != "outbox" && os.Args[1] != "dlq") {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	cfg := pg.NewConfig()
+	if err := cfg.Validate(); err != nil {
+		log.Fatal("[loadConfig] err: ", err)
+	}
+
+	conn, err := pg.Connect(cfg.PGUrl)
+	if err != nil {
+		log.Fatal("[pg.Connect] err]: ", err)
+	}
+	defer conn.Close()
+
+	var cmd interface {
+		run(ctx context.Context, command string, args []string) error
+	}
+	switch os.Args[1] {
+	case "outbox":
+		cmd = outboxCmd{repo: outgoingevent.New(conn), out: os.Stdout}
+	case "dlq":
+		broker := initBroker()
+		defer broker.Close()
+
+		producer := broker.NewProducer()
+		defer producer.Close()
+
+		cmd = dlqCmd{broker: broker, producer: producer, replays: dlqreplay.New(conn), out: os.Stdout}
+	}
+
+	if err = cmd.run(context.Background(), os.Args[2], os.Args[3:]); err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		conn.Close()
+		os.Exit(1)
+	}
+}
+
+// initBroker connects to the message broker of the services
+func initBroker() kafka.Broker {
+	cfg := kafka.NewConfig()
+	if cfg.ClientID == "" {
+		cfg.ClientID = "url-shortener-admin"
+	}
+	if err := cfg.Validate(); err != nil {
+		log.Fatal("[initBroker] err: ", err)
+	}
+
+	broker, err := kafka.Open(cfg)
+	if err != nil {
+		log.Fatal("[initBroker] err: ", err)
+	}
+	return broker
+}
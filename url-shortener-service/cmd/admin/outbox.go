@@ -0,0 +1,306 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/repository/outgoingevent"
+)
+
+// errUnscoped means a destructive command was given no filter, which would affect every event
+var errUnscoped = errors.New("no filter given, pass -all to target every event")
+
+// outboxCmd runs the outbox admin commands through the outgoing event repository
+type outboxCmd struct {
+	repo outgoingevent.Repository
+	out  io.Writer
+}
+
+// run dispatches a command with its arguments
+func (c outboxCmd) run(ctx context.Context, command string, args []string) error {
+	switch command {
+	case "list":
+		return c.list(ctx, args)
+	case "show":
+		return c.show(ctx, args)
+	case "requeue":
+		return c.requeue(ctx, args)
+	case "ack":
+		return c.ack(ctx, args)
+	case "purge":
+		return c.purge(ctx, args)
+	case "stats":
+		return c.stats(ctx)
+	default:
+		return fmt.Errorf("unknown outbox command %q", command)
+	}
+}
+
+// filterFlags binds the flags selecting events
+type filterFlags struct {
+	ids       string
+	status    string
+	topic     string
+	olderThan time.Duration
+}
+
+// register adds the filter flags to fs
+func (f *filterFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.ids, "id", "", "comma-separated event IDs")
+	fs.StringVar(&f.status, "status", "", "PENDING, PUBLISHED or FAILED")
+	fs.StringVar(&f.topic, "topic", "", "topic, e.g. "+model.TopicMetadataRequestedV1.String())
+	fs.DurationVar(&f.olderThan, "older-than", 0, "created longer ago than, e.g. 24h")
+}
+
+// filter builds the repository filter, empty when no flag is set
+func (f filterFlags) filter() (model.OutgoingEventFilter, error) {
+	var rs model.OutgoingEventFilter
+	if f.ids != "" {
+		for _, s := range strings.Split(f.ids, ",") {
+			id, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+			if err != nil {
+				return model.OutgoingEventFilter{}, fmt.Errorf("invalid event ID %q", s)
+			}
+			rs.IDs = append(rs.IDs, id)
+		}
+	}
+	if f.status != "" {
+		rs.Status = model.OutgoingEventStatus(strings.ToUpper(f.status))
+		switch rs.Status {
+		case model.OutgoingEventStatusPending, model.OutgoingEventStatusPublished, model.OutgoingEventStatusFailed:
+		default:
+			return model.OutgoingEventFilter{}, fmt.Errorf("invalid status %q", f.status)
+		}
+	}
+	rs.Topic = model.Topic(f.topic)
+	if f.olderThan > 0 {
+		rs.CreatedBefore = time.Now().Add(-f.olderThan)
+	}
+
+	return rs, nil
+}
+
+// scoped reports whether any flag narrows the selection
+func (f filterFlags) scoped() bool {
+	return f.ids != "" || f.status != "" || f.topic != "" || f.olderThan > 0
+}
+
+// list prints the events matching the filter
+func (c outboxCmd) list(ctx context.Context, args []string) error {
+	var ff filterFlags
+	fs := flag.NewFlagSet("outbox list", flag.ContinueOnError)
+	ff.register(fs)
+	limit := fs.Int("limit", 50, "maximum number of events listed")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	filter, err := ff.filter()
+	if err != nil {
+		return err
+	}
+
+	events, err := c.repo.List(ctx, filter, *limit)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(c.out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tSTATUS\tTOPIC\tRETRIES\tCREATED\tLAST ERROR")
+	for _, e := range events {
+		fmt.Fprintf(w, "%d\t%s\t%s\t%d\t%s\t%s\n",
+			e.ID, e.Status, e.Topic, e.RetryCount, e.CreatedAt.Format(time.RFC3339), truncate(e.LastError, 60))
+	}
+
+	return w.Flush()
+}
+
+// show prints an event in full
+func (c outboxCmd) show(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("outbox show", flag.ContinueOnError)
+	id := fs.Int64("id", 0, "event ID")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *id == 0 {
+		return errors.New("-id is required")
+	}
+
+	e, err := c.repo.GetByID(ctx, *id)
+	if err != nil {
+		return err
+	}
+
+	payload, err := json.MarshalIndent(e.Payload, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(c.out, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "id:\t%d\n", e.ID)
+	fmt.Fprintf(w, "topic:\t%s\n", e.Topic)
+	fmt.Fprintf(w, "status:\t%s\n", e.Status)
+	fmt.Fprintf(w, "retry count:\t%d\n", e.RetryCount)
+	fmt.Fprintf(w, "next attempt:\t%s\n", formatTime(e.NextAttemptAt))
+	fmt.Fprintf(w, "locked by:\t%s\n", e.LockedBy)
+	fmt.Fprintf(w, "locked until:\t%s\n", formatTime(e.LockedUntil))
+	fmt.Fprintf(w, "acknowledged:\t%s\n", formatTime(e.AcknowledgedAt))
+	fmt.Fprintf(w, "correlation id:\t%s\n", e.CorrelationID)
+	fmt.Fprintf(w, "trace id:\t%s\n", e.TraceID)
+	fmt.Fprintf(w, "created:\t%s\n", formatTime(e.CreatedAt))
+	fmt.Fprintf(w, "updated:\t%s\n", formatTime(e.UpdatedAt))
+	fmt.Fprintf(w, "last error:\t%s\n", e.LastError)
+	if err = w.Flush(); err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(c.out, "payload:\n%s\n", payload)
+	return err
+}
+
+// requeue turns FAILED events back to PENDING, a dry run unless -yes is given
+func (c outboxCmd) requeue(ctx context.Context, args []string) error {
+	var ff filterFlags
+	fs := flag.NewFlagSet("outbox requeue", flag.ContinueOnError)
+	ff.register(fs)
+	all := fs.Bool("all", false, "requeue every FAILED event")
+	yes := fs.Bool("yes", false, "apply, instead of only counting the events affected")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if !ff.scoped() && !*all {
+		return errUnscoped
+	}
+
+	filter, err := ff.filter()
+	if err != nil {
+		return err
+	}
+	if filter.Status != "" && filter.Status != model.OutgoingEventStatusFailed {
+		return errors.New("only FAILED events can be requeued")
+	}
+	filter.Status = model.OutgoingEventStatusFailed
+
+	return c.apply(ctx, "requeued", filter, *yes, c.repo.Requeue)
+}
+
+// ack acknowledges FAILED events, a dry run unless -yes is given
+func (c outboxCmd) ack(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("outbox ack", flag.ContinueOnError)
+	ids := fs.String("id", "", "comma-separated event IDs")
+	yes := fs.Bool("yes", false, "apply, instead of only counting the events affected")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *ids == "" {
+		return errors.New("-id is required")
+	}
+
+	filter, err := filterFlags{ids: *ids}.filter()
+	if err != nil {
+		return err
+	}
+	filter.Status = model.OutgoingEventStatusFailed
+
+	return c.apply(ctx, "acknowledged", filter, *yes, func(ctx context.Context, f model.OutgoingEventFilter) (int64, error) {
+		return c.repo.Acknowledge(ctx, f.IDs)
+	})
+}
+
+// purge deletes events, a dry run unless -yes is given
+func (c outboxCmd) purge(ctx context.Context, args []string) error {
+	var ff filterFlags
+	fs := flag.NewFlagSet("outbox purge", flag.ContinueOnError)
+	ff.register(fs)
+	all := fs.Bool("all", false, "purge every event, pending ones included")
+	yes := fs.Bool("yes", false, "apply, instead of only counting the events affected")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if !ff.scoped() && !*all {
+		return errUnscoped
+	}
+
+	filter, err := ff.filter()
+	if err != nil {
+		return err
+	}
+
+	return c.apply(ctx, "purged", filter, *yes, c.repo.Purge)
+}
+
+// apply counts the events matching filter and, when confirmed, runs fn on them
+func (c outboxCmd) apply(
+	ctx context.Context,
+	verb string,
+	filter model.OutgoingEventFilter,
+	confirmed bool,
+	fn func(context.Context, model.OutgoingEventFilter) (int64, error),
+) error {
+	if !confirmed {
+		n, err := c.repo.Count(ctx, filter)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(c.out, "dry run: %d events would be %s, re-run with -yes to apply\n", n, verb)
+		return err
+	}
+
+	n, err := fn(ctx, filter)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(c.out, "%d events %s\n", n, verb)
+	return err
+}
+
+// stats prints the event counts per status and topic, and the oldest pending age
+func (c outboxCmd) stats(ctx context.Context) error {
+	stats, err := c.repo.GetStats(ctx)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(c.out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "STATUS\tTOPIC\tCOUNT")
+	for _, s := range stats.Counts {
+		fmt.Fprintf(w, "%s\t%s\t%d\n", s.Status, s.Topic, s.Count)
+	}
+	if err = w.Flush(); err != nil {
+		return err
+	}
+
+	if stats.OldestPendingAt.IsZero() {
+		_, err = fmt.Fprintln(c.out, "oldest pending: none")
+		return err
+	}
+	_, err = fmt.Fprintf(c.out, "oldest pending: %s (%s ago)\n",
+		formatTime(stats.OldestPendingAt), time.Since(stats.OldestPendingAt).Truncate(time.Second))
+	return err
+}
+
+// formatTime prints t in RFC 3339, "-" when zero
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Format(time.RFC3339)
+}
+
+// truncate shortens s to n runes, marking the cut
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n-1]) + "…"
+}
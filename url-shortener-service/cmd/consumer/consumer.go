@@ -3,16 +3,19 @@ package main
 
 import (
 	"context"
+	"errors"
 	"os"
+	"sync"
 
 	shortUrlCtrl "github.com/kytruongdev/sturl/url-shortener-service/internal/controller/shorturl"
 	"github.com/kytruongdev/sturl/url-shortener-service/internal/handler/kafka"
 	infraKafka "github.com/kytruongdev/sturl/url-shortener-service/internal/infra/kafka"
 	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/monitoring"
 	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/repository"
 )
 
-// Consumer manages multiple Kafka consumers for different topics.
+// Consumer manages multiple broker consumers for different topics.
 // It coordinates the lifecycle of all consumers and provides a unified
 // interface for starting and stopping them.
 type Consumer struct {
@@ -20,20 +23,28 @@ type Consumer struct {
 }
 
 // New creates a new Consumer instance with handlers for all configured topics.
-func New(cfg infraKafka.Config, shortURLCtrl shortUrlCtrl.Controller, producer infraKafka.Producer) Consumer {
+// Handlers skip the events their consumer group processed already, see kafka.Idempotent.
+func New(
+	broker infraKafka.Broker,
+	repo repository.Registry,
+	shortURLCtrl shortUrlCtrl.Controller,
+	producer infraKafka.Producer,
+) Consumer {
 	consumers := make(map[string]infraKafka.Consumer)
-	consumers[model.TopicMetadataRequestedV1.String()] = infraKafka.NewConsumer(
-		cfg,
+
+	requestedGroup := os.Getenv("METADATA_REQUESTED_CONSUMER_GROUP")
+	consumers[model.TopicMetadataRequestedV1.String()] = broker.NewConsumer(
 		model.TopicMetadataRequestedV1.String(),
-		os.Getenv("METADATA_REQUESTED_CONSUMER_GROUP"),
-		kafka.MetadataRequested(shortURLCtrl),
+		requestedGroup,
+		kafka.Idempotent(repo, requestedGroup, kafka.MetadataRequested(shortURLCtrl)),
 		producer,
 	)
-	consumers[model.TopicMetadataCrawledV1.String()] = infraKafka.NewConsumer(
-		cfg,
+
+	crawledGroup := os.Getenv("METADATA_CRAWLED_CONSUMER_GROUP")
+	consumers[model.TopicMetadataCrawledV1.String()] = broker.NewConsumer(
 		model.TopicMetadataCrawledV1.String(),
-		os.Getenv("METADATA_CRAWLED_CONSUMER_GROUP"),
-		kafka.MetadataCrawled(),
+		crawledGroup,
+		kafka.Idempotent(repo, crawledGroup, kafka.MetadataCrawled()),
 		producer,
 	)
 
@@ -64,10 +75,42 @@ func (m *Consumer) Start(ctx context.Context) error {
 }
 
 // Stop gracefully shuts down all Kafka consumers.
-// It closes each consumer and returns any errors encountered during shutdown.
+// It drains the consumers together until ctx is done, so that the messages in flight are handled and their
+// offsets committed, then closes each consumer and returns any errors encountered during shutdown.
 func (m *Consumer) Stop(ctx context.Context) error {
-	for _, c := range m.consumers {
-		_ = c.Close()
+	log := monitoring.Log(ctx)
+
+	errs := make(chan error, len(m.consumers))
+	var wg sync.WaitGroup
+	for k, c := range m.consumers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := c.Drain(ctx); err != nil {
+				log.Error().Err(err).Msg("[Consumer.Stop] drain consumer with topic: " + k)
+				errs <- err
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	var rs []error
+	for err := range errs {
+		rs = append(rs, err)
 	}
+
+	for k, c := range m.consumers {
+		if err := c.Close(); err != nil {
+			log.Error().Err(err).Msg("[Consumer.Stop] close consumer with topic: " + k)
+			rs = append(rs, err)
+		}
+	}
+
+	if len(rs) > 0 {
+		return errors.Join(rs...)
+	}
+
+	log.Info().Msg("[KafkaManager] all consumers drained")
 	return nil
 }
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	infraKafka "github.com/kytruongdev/sturl/url-shortener-service/internal/infra/kafka"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeConsumer fails its drain and its close with the given errors
+type fakeConsumer struct {
+	drainErr error
+	closeErr error
+	closed   bool
+}
+
+func (c *fakeConsumer) Start(context.Context) error {
+	return nil
+}
+
+func (c *fakeConsumer) Drain(context.Context) error {
+	return c.drainErr
+}
+
+func (c *fakeConsumer) Close() error {
+	c.closed = true
+	return c.closeErr
+}
+
+func TestConsumer_Stop(t *testing.T) {
+	errDrain := errors.New("kafka: drain a of group: context deadline exceeded")
+	errClose := errors.New("kafka: commit failed")
+
+	tcs := map[string]struct {
+		consumers map[string]*fakeConsumer
+		wantErrs  []error
+	}{
+		"success - drained and closed": {
+			consumers: map[string]*fakeConsumer{"a": {}, "b": {}},
+		},
+		"error - drain failed": {
+			consumers: map[string]*fakeConsumer{"a": {drainErr: errDrain}, "b": {}},
+			wantErrs:  []error{errDrain},
+		},
+		"error - close failed": {
+			consumers: map[string]*fakeConsumer{"a": {}, "b": {closeErr: errClose}},
+			wantErrs:  []error{errClose},
+		},
+		"error - drain and close failed": {
+			consumers: map[string]*fakeConsumer{"a": {drainErr: errDrain, closeErr: errClose}},
+			wantErrs:  []error{errDrain, errClose},
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			// Given
+			m := Consumer{consumers: map[string]infraKafka.Consumer{}}
+			for k, c := range tc.consumers {
+				m.consumers[k] = c
+			}
+
+			// When
+			err := m.Stop(context.Background())
+
+			// Then: every consumer is closed, whatever failed
+			for k, c := range tc.consumers {
+				require.True(t, c.closed, k)
+			}
+			if len(tc.wantErrs) == 0 {
+				require.NoError(t, err)
+				return
+			}
+			for _, want := range tc.wantErrs {
+				require.ErrorIs(t, err, want)
+			}
+		})
+	}
+}
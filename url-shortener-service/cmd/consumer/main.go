@@ -9,6 +9,7 @@ import (
 	"github.com/kytruongdev/sturl/url-shortener-service/internal/config"
 	shortUrlCtrl "github.com/kytruongdev/sturl/url-shortener-service/internal/controller/shorturl"
 	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/app"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/blob"
 	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/db/pg"
 	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/id"
 	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/kafka"
@@ -40,18 +41,28 @@ func main() {
 	conn := initDB(globalCfg)
 	defer conn.Close()
 
-	kafkaProducer := kafka.NewProducer(globalCfg.KafkaCfg)
+	broker := initBroker(globalCfg)
+	defer broker.Close()
+
+	kafkaProducer := broker.NewProducer()
 	defer kafkaProducer.Close()
 
 	// --- Setup redis
 	redisClient := initRedis(rootCtx, globalCfg)
 
 	repo := repository.New(conn, redisClient)
-	shortURLCtrl := shortUrlCtrl.New(repo)
+	// Crawls record health checks too, against the same threshold as the linkhealth worker
+	shortURLCtrl := shortUrlCtrl.New(repo,
+		shortUrlCtrl.WithAssetStore(initBlobStore(globalCfg), globalCfg.BlobCfg.PublicBaseURL),
+		shortUrlCtrl.WithBrokenLinkThreshold(globalCfg.ShortURLCfg.BrokenLinkThreshold),
+	)
 
-	consumer := New(globalCfg.KafkaCfg, shortURLCtrl, kafkaProducer)
+	consumer := New(broker, repo, shortURLCtrl, kafkaProducer)
 
-	if err := app.New(globalCfg.KafkaCfg.ClientID+"-consumer").Run(rootCtx, runner{consumer: consumer}); err != nil {
+	if err := app.New(
+		globalCfg.KafkaCfg.ClientID+"-consumer",
+		app.WithShutdownTimeout(globalCfg.AppCfg.ShutdownTimeout),
+	).Run(rootCtx, runner{consumer: consumer}); err != nil {
 		monitoring.Log(rootCtx).Error().Err(err).Msg("consumer exited with error")
 	}
 }
@@ -65,6 +76,24 @@ func initDB(cfg config.GlobalConfig) *sql.DB {
 	return conn
 }
 
+func initBroker(cfg config.GlobalConfig) kafka.Broker {
+	broker, err := kafka.Open(cfg.KafkaCfg)
+	if err != nil {
+		log.Fatal("[initBroker] err: ", err)
+	}
+
+	return broker
+}
+
+func initBlobStore(cfg config.GlobalConfig) blob.Store {
+	store, err := blob.New(cfg.BlobCfg)
+	if err != nil {
+		log.Fatal("[blob.New] err: ", err)
+	}
+
+	return store
+}
+
 func initRedis(ctx context.Context, cfg config.GlobalConfig) redisRepo.RedisClient {
 	const (
 		selectedDB   = 0
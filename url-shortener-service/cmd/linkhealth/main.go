@@ -0,0 +1,136 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/config"
+	shortUrlCtrl "github.com/kytruongdev/sturl/url-shortener-service/internal/controller/shorturl"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/app"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/db/pg"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/id"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/monitoring"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/repository"
+)
+
+func main() {
+	rootCtx := context.Background()
+
+	// --- Load global config
+	globalCfg := loadGlobalConfig()
+
+	if err := id.Init(1); err != nil {
+		panic(err)
+	}
+
+	// --- Setup monitoring
+	shutdown, err := initMonitoring(rootCtx, globalCfg.MonitoringCfg)
+	if err != nil {
+		panic(err)
+	}
+	defer shutdown(rootCtx)
+
+	// --- Setup db
+	conn := initDB(globalCfg)
+	defer conn.Close()
+
+	worker := New(
+		shortUrlCtrl.New(repository.New(conn, nil), shortUrlCtrl.WithBrokenLinkThreshold(globalCfg.ShortURLCfg.BrokenLinkThreshold)),
+		initWorkerConfig(),
+	)
+
+	// --- Start Worker
+	if err = app.New(
+		globalCfg.AppCfg.ServiceName+"-linkhealth",
+		app.WithShutdownTimeout(globalCfg.AppCfg.ShutdownTimeout),
+	).Run(rootCtx, runner{worker}); err != nil {
+		monitoring.Log(rootCtx).Error().Err(err).Msg("link health worker exited with error")
+	}
+}
+
+func loadGlobalConfig() config.GlobalConfig {
+	cfg := config.NewGlobalConfig()
+
+	if err := cfg.Validate(); err != nil {
+		log.Fatal("[loadGlobalConfig] err: ", err)
+	}
+
+	return cfg
+}
+
+func initWorkerConfig() WorkerConfig {
+	pim, err := strconv.Atoi(os.Getenv("LINK_HEALTH_POLLING_INTERVAL_MS"))
+	if err != nil {
+		panic(err)
+	}
+
+	mah, err := strconv.Atoi(os.Getenv("LINK_HEALTH_MAX_AGE_HOURS"))
+	if err != nil {
+		panic(err)
+	}
+
+	bs, err := strconv.Atoi(os.Getenv("LINK_HEALTH_BATCH_SIZE"))
+	if err != nil {
+		panic(err)
+	}
+
+	c, err := strconv.Atoi(os.Getenv("LINK_HEALTH_CONCURRENCY"))
+	if err != nil {
+		panic(err)
+	}
+
+	hdm, err := strconv.Atoi(os.Getenv("LINK_HEALTH_HOST_DELAY_MS"))
+	if err != nil {
+		panic(err)
+	}
+
+	return WorkerConfig{
+		pollingInterval: time.Duration(pim) * time.Millisecond,
+		maxAge:          time.Duration(mah) * time.Hour,
+		batchSize:       bs,
+		concurrency:     c,
+		hostDelay:       time.Duration(hdm) * time.Millisecond,
+	}
+}
+
+func initMonitoring(ctx context.Context, cfg monitoring.Config) (func(context.Context) error, error) {
+	shutdown, err := monitoring.Init(ctx, monitoring.Config{
+		ServiceName:     cfg.ServiceName,
+		Env:             cfg.Env,
+		OTLPEndpointURL: cfg.OTLPEndpointURL,
+		LogPretty:       os.Getenv("LOG_PRETTY") == "true",
+	})
+
+	if err != nil {
+		log.Fatal("[initMonitoring] err: ", err)
+	}
+
+	return shutdown, nil
+}
+
+func initDB(cfg config.GlobalConfig) *sql.DB {
+	conn, err := pg.Connect(cfg.PGCfg.PGUrl)
+	if err != nil {
+		log.Fatal("[pg.Connect] err]: ", err)
+	}
+
+	return conn
+}
+
+// runner is an adapter to make the Worker implement app.Service
+type runner struct {
+	worker Worker
+}
+
+func (r runner) Run(ctx context.Context) error {
+	return r.worker.start(ctx)
+}
+
+// Shutdown here can be a no-op because ctx cancellation will cause start to return.
+func (r runner) Shutdown(_ context.Context) error {
+	return nil
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/controller/shorturl"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/monitoring"
+)
+
+// Worker periodically re-checks the destinations of active links and flags the ones which keep failing.
+type Worker struct {
+	shortURLCtrl shorturl.Controller
+	config       WorkerConfig
+}
+
+// WorkerConfig defines the behavior of the link health Worker.
+type WorkerConfig struct {
+	// pollingInterval defines how frequently the Worker looks for links due for a check.
+	pollingInterval time.Duration
+	// maxAge is the age after which the health of a link is checked again.
+	maxAge time.Duration
+	// batchSize controls how many links are checked per cycle.
+	batchSize int
+	// concurrency controls how many hosts are checked in parallel.
+	concurrency int
+	// hostDelay is the delay between two requests to the same host.
+	hostDelay time.Duration
+}
+
+// New creates a new Worker instance.
+func New(shortURLCtrl shorturl.Controller, config WorkerConfig) Worker {
+	return Worker{
+		shortURLCtrl: shortURLCtrl,
+		config:       config,
+	}
+}
+
+// start begins the checking loop.
+// This loop follows pattern: run batch → sleep → repeat, like the re-crawl scheduler.
+func (w *Worker) start(ctx context.Context) error {
+	monitoring.Log(ctx).Info().
+		Dur("polling_interval", w.config.pollingInterval).
+		Dur("max_age", w.config.maxAge).
+		Int("batch_size", w.config.batchSize).
+		Int("concurrency", w.config.concurrency).
+		Dur("host_delay", w.config.hostDelay).
+		Msg("[Worker.Start] link health worker started")
+
+	for {
+		start := time.Now()
+		log := monitoring.Log(ctx).
+			Field("max_age", w.config.maxAge).
+			Field("batch_size", w.config.batchSize)
+
+		w.runOnce(ctx)
+
+		log.Info().Msgf("[Worker.Start] link health batch completed in %s", time.Since(start))
+
+		select {
+		case <-ctx.Done():
+			log.Info().Msg("[Worker.Start] context canceled, stopping link health loop")
+			return nil
+		case <-time.After(w.config.pollingInterval):
+		}
+	}
+}
+
+// runOnce checks a single batch of links.
+func (w *Worker) runOnce(ctx context.Context) {
+	checked, err := w.shortURLCtrl.CheckLinksHealth(ctx, shorturl.CheckLinksHealthInput{
+		MaxAge:      w.config.maxAge,
+		Limit:       w.config.batchSize,
+		Concurrency: w.config.concurrency,
+		HostDelay:   w.config.hostDelay,
+	})
+	if err != nil {
+		monitoring.Log(ctx).Error().Err(err).Msg("[Worker.runOnce] failed to check link health")
+		return
+	}
+
+	monitoring.Log(ctx).Info().Int("checked", checked).Msg("[Worker.runOnce] link health checked")
+}
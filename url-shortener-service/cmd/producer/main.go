@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"log"
 	"os"
 	"strconv"
@@ -14,7 +15,9 @@ import (
 	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/id"
 	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/kafka"
 	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/monitoring"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/pkg/retry"
 	"github.com/kytruongdev/sturl/url-shortener-service/internal/repository"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/repository/outgoingevent"
 )
 
 func main() {
@@ -38,17 +41,27 @@ func main() {
 	conn := initDB(globalCfg)
 	defer conn.Close()
 
-	kafkaProducer := kafka.NewProducer(globalCfg.KafkaCfg)
+	broker := initBroker(globalCfg)
+	defer broker.Close()
+
+	kafkaProducer := broker.NewProducer()
 	defer kafkaProducer.Close()
 
+	// Dedicated connection, LISTEN holds it for as long as the producer runs
+	listener := pg.NewListener(globalCfg.PGCfg.PGUrl, outgoingevent.NotifyChannel)
+
 	producer := New(
 		repository.New(conn, nil),
 		kafkaProducer,
-		initProducerConfig(),
+		listener,
+		initProducerConfig(globalCfg.MonitoringCfg.ServiceName),
 	)
 
 	// --- Start Producer
-	if err = app.New(globalCfg.KafkaCfg.ClientID).Run(rootCtx, runner{producer}); err != nil {
+	if err = app.New(
+		globalCfg.KafkaCfg.ClientID,
+		app.WithShutdownTimeout(globalCfg.AppCfg.ShutdownTimeout),
+	).Run(rootCtx, runner{producer}); err != nil {
 		monitoring.Log(rootCtx).Error().Err(err).Msg("outbox worker exited with error")
 	}
 }
@@ -63,7 +76,16 @@ func loadGlobalConfig() config.GlobalConfig {
 	return cfg
 }
 
-func initProducerConfig() ProducerConfig {
+func initBroker(cfg config.GlobalConfig) kafka.Broker {
+	broker, err := kafka.Open(cfg.KafkaCfg)
+	if err != nil {
+		log.Fatal("[initBroker] err: ", err)
+	}
+
+	return broker
+}
+
+func initProducerConfig(serviceName string) ProducerConfig {
 	pim, err := strconv.Atoi(os.Getenv("POLLING_INTERVAL_MS"))
 	if err != nil {
 		panic(err)
@@ -74,24 +96,51 @@ func initProducerConfig() ProducerConfig {
 		panic(err)
 	}
 
-	mr, err := strconv.Atoi(os.Getenv("MAX_RETRY"))
+	retryCfg := retry.NewConfig()
+	if err := retryCfg.Validate(); err != nil {
+		panic(err)
+	}
+	rs, err := retryCfg.Schedule()
 	if err != nil {
 		panic(err)
 	}
 
-	// Parse max concurrency (default: 20)
-	mc := 20
-	if mcEnv := os.Getenv("PRODUCER_MAX_CONCURRENCY"); mcEnv != "" {
-		if val, err := strconv.Atoi(mcEnv); err == nil && val > 0 {
-			mc = val
+	// Parse lease duration (default: 30s)
+	ls := 30
+	if lsEnv := os.Getenv("PRODUCER_LEASE_SECONDS"); lsEnv != "" {
+		if val, err := strconv.Atoi(lsEnv); err == nil && val > 0 {
+			ls = val
+		}
+	}
+
+	// CloudEvents envelope (default: off), sourced by the service unless overridden
+	ce, err := kafka.ParseCloudEventsMode(os.Getenv("PRODUCER_CLOUDEVENTS_MODE"))
+	if err != nil {
+		panic(err)
+	}
+	ces := os.Getenv("CLOUDEVENTS_SOURCE")
+	if ces == "" {
+		ces = "/" + serviceName
+	}
+
+	// Worker ID defaults to host and pid, unique among replicas
+	wid := os.Getenv("PRODUCER_WORKER_ID")
+	if wid == "" {
+		host, err := os.Hostname()
+		if err != nil {
+			panic(err)
 		}
+		wid = fmt.Sprintf("%s-%d", host, os.Getpid())
 	}
 
 	return ProducerConfig{
-		pollingInterval: time.Duration(pim) * time.Millisecond,
-		batchSize:       bs,
-		maxRetry:        mr,
-		maxConcurrency:  mc,
+		pollingInterval:   time.Duration(pim) * time.Millisecond,
+		batchSize:         bs,
+		retry:             rs,
+		cloudEvents:       ce,
+		cloudEventsSource: ces,
+		workerID:          wid,
+		leaseDuration:     time.Duration(ls) * time.Second,
 	}
 }
 
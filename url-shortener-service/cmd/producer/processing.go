@@ -3,24 +3,35 @@ package main
 import (
 	"context"
 	"encoding/json"
-	"fmt"
-	"sync"
+	"strconv"
+	"time"
 
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/kafka"
 	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/monitoring"
 	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/pkg/eventschema"
+	"go.opentelemetry.io/otel/trace"
 )
 
-// process loads a batch of pending events and attempts to publish
-// each event to Kafka, updating their status accordingly.
+// releaseTimeout bounds the status update which follows a publish attempt
+const releaseTimeout = 5 * time.Second
+
+// process claims a batch of pending events, publishes them to Kafka in a single write
+// and records the outcome of every event in a single update.
 //
-// It is designed to be called repeatedly by the producer loop.
+// It is designed to be called repeatedly by the producer loop, by as many
+// producer instances as needed: claimed events are leased to this instance
+// so that the others skip them.
 func (p *Producer) process(ctx context.Context) error {
 	log := monitoring.Log(ctx)
 
+	// Taken before claiming, so that publishing stops no later than the lease expires
+	leaseDeadline := time.Now().Add(p.config.leaseDuration)
+
 	events, err := p.repo.OutgoingEvent().
-		GetPendingEventsToRetry(ctx, model.OutgoingEventStatusPending.String(), p.config.batchSize)
+		ClaimPending(ctx, p.config.workerID, p.config.leaseDuration, p.config.batchSize)
 	if err != nil {
-		log.Error().Err(err).Msg("[processPendingBatch] GetPendingEventsToRetry err")
+		log.Error().Err(err).Msg("[processPendingBatch] ClaimPending err")
 		return err
 	}
 
@@ -29,129 +40,194 @@ func (p *Producer) process(ctx context.Context) error {
 		return nil
 	}
 
-	// Process events concurrently with worker pool
-	// Use semaphore pattern to limit concurrent workers
-	maxConcurrency := p.config.maxConcurrency
-	if len(events) < maxConcurrency {
-		maxConcurrency = len(events)
-	}
-
-	sem := make(chan struct{}, maxConcurrency)
-	var wg sync.WaitGroup
-
 	log.Info().
 		Int("total_events", len(events)).
-		Int("max_concurrency", maxConcurrency).
-		Msg("[processPendingBatch] processing events concurrently")
-
-	for _, e := range events {
-		wg.Add(1)
-		sem <- struct{}{} // Acquire semaphore
+		Msg("[processPendingBatch] publishing batch")
 
-		go func(event model.OutgoingEvent) {
-			defer wg.Done()
-			defer func() { <-sem }() // Release semaphore
+	// Past the lease another instance may claim the events, stop rather than publish them twice
+	publishCtx, cancel := context.WithDeadline(ctx, leaseDeadline)
+	defer cancel()
 
-			producerCtx, err := toProducerContext(event)
-			if err != nil {
-				log.Error().Err(err).Msg("[processPendingBatch] toProducerContext err")
-				producerCtx = ctx
-			}
+	outcomes := p.publishBatch(publishCtx, events)
 
-			p.publishMessageToKafka(producerCtx, event)
-		}(e)
+	released, err := p.releaseClaims(ctx, outcomes)
+	if err != nil {
+		log.Error().Err(err).Msg("[processPendingBatch] ReleaseClaims err")
+		return err
 	}
 
-	// Wait for all goroutines to complete
-	wg.Wait()
-
 	log.Info().
 		Int("total_events", len(events)).
+		Int("released", len(released)).
+		Int("lease_lost", len(outcomes)-len(released)).
 		Msg("[processPendingBatch] batch completed")
 
 	return nil
 }
 
-// publishMessageToKafka handles the publish lifecycle of a single outbox event:
-//  1. Publish to Kafka.
-//  2. On success, mark as PUBLISHED.
-//  3. On failure, increment retry counter or mark as FAILED.
-func (p *Producer) publishMessageToKafka(
-	ctx context.Context,
-	m model.OutgoingEvent,
-) {
-	var err error
-	spanCtx, span := monitoring.Start(ctx, "Producer.PublishMessage | Topic: "+m.Topic.String())
-	defer monitoring.End(span, &err)
-
-	log := monitoring.Log(spanCtx).
-		Field("event_id", m.ID).
-		Field("topic", m.Topic)
+// publishBatch publishes the events to Kafka and returns the outcome of each one, to record on the event:
+//  1. On success, mark as PUBLISHED.
+//  2. On failure, increment retry counter and back off per the topic policy, or mark as FAILED.
+//  3. When the data does not match the topic schema or the message cannot be encoded, mark as FAILED without publishing.
+func (p *Producer) publishBatch(ctx context.Context, events []model.OutgoingEvent) []model.OutgoingEvent {
+	log := monitoring.Log(ctx)
+
+	var (
+		batch    []model.OutgoingEvent
+		msgs     []kafka.Message
+		spans    []trace.Span
+		outcomes []model.OutgoingEvent
+	)
+	for _, m := range events {
+		// Data which does not match the topic schema would break consumers, retrying cannot fix it
+		if err := eventschema.Validate(m.Topic, m.Payload.Data); err != nil {
+			log.Error().Err(err).Int64("event_id", m.ID).Msg("[publishBatch] invalid event data → mark FAILED")
+			outcomes = append(outcomes, model.OutgoingEvent{
+				ID:        m.ID,
+				LastError: err.Error(),
+				Status:    model.OutgoingEventStatusFailed,
+			})
+			continue
+		}
 
-	if val, ok := m.Payload.Data["short_code"]; ok {
-		log = log.Field("short_code", val)
+		// Encoding depends on the stored event only, retrying would fail the same way
+		msg, err := p.toKafkaMessage(m)
+		if err != nil {
+			log.Error().Err(err).Int64("event_id", m.ID).Msg("[publishBatch] toKafkaMessage err → mark FAILED")
+			outcomes = append(outcomes, model.OutgoingEvent{
+				ID:        m.ID,
+				LastError: err.Error(),
+				Status:    model.OutgoingEventStatusFailed,
+			})
+			continue
+		}
+
+		producerCtx, err := toProducerContext(m)
+		if err != nil {
+			log.Error().Err(err).Int64("event_id", m.ID).Msg("[publishBatch] toProducerContext err")
+			producerCtx = ctx
+		}
+
+		// Each message gets its PRODUCER span, in the trace of the request which emitted the event
+		_, span := kafka.StartPublishSpan(producerCtx, &msg)
+
+		batch = append(batch, m)
+		msgs = append(msgs, msg)
+		spans = append(spans, span)
 	}
 
-	// Ensure payload carries trace + correlation info
-	m.Payload.CorrelationID = m.CorrelationID
-	m.Payload.TraceID = m.TraceID
-	m.Payload.SpanID = m.SpanID
+	errs := p.producer.PublishBatch(ctx, msgs)
 
-	fmt.Printf("[publishMessageToKafka] payload: %+v\n", m)
+	for i, m := range batch {
+		err := errs[i]
+		monitoring.End(spans[i], &err)
+		outcomes = append(outcomes, p.outcome(ctx, m, err))
+	}
 
-	payload, err := json.Marshal(m.Payload)
-	if err != nil {
-		log.Error().Err(err).Msg("[publishMessageToKafka] json.Marshal err")
-		return
+	return outcomes
+}
+
+// outcome returns the update recording the result of publishing an event, err nil when it was published
+func (p *Producer) outcome(ctx context.Context, m model.OutgoingEvent, err error) model.OutgoingEvent {
+	log := monitoring.Log(ctx).
+		Field("event_id", m.ID).
+		Field("topic", m.Topic)
+
+	if m.Key != "" {
+		log = log.Field("key", m.Key)
 	}
 
-	// publish
-	log.Info().Msg("[publishMessageToKafka] starting publishing message with topic: " + m.Topic.String())
-	if err = p.producer.Publish(spanCtx, m.Topic.String(), payload); err != nil {
-		errMsg := err.Error()
-		nextRetry := m.RetryCount + 1
-
-		// retry reached
-		if nextRetry > p.config.maxRetry {
-			log.Warn().
-				Int("attempt", nextRetry).
-				Str("error", errMsg).
-				Msgf("[publishMessageToKafka] unable to publish message to topic %v after %v attempts, update status FAILED and last err to db", m.Topic.String(), p.config.maxRetry)
-			u := model.OutgoingEvent{
-				LastError: errMsg,
-				Status:    model.OutgoingEventStatusFailed,
-			}
-			if err = p.repo.OutgoingEvent().Update(spanCtx, u, m.ID); err != nil {
-				log.Error().Err(err).Msg("[publishMessageToKafka] mark FAILED error")
-			}
-			return
-		}
+	if err == nil {
+		log.Info().Msg("[publishBatch] message published to topic: " + m.Topic.String() + " successfully")
+		return model.OutgoingEvent{ID: m.ID, Status: model.OutgoingEventStatusPublished}
+	}
 
-		// retry
+	errMsg := err.Error()
+	nextRetry := m.RetryCount + 1
+	policy := p.config.retry.For(m.Topic.String())
+
+	// retry reached
+	if policy.Exhausted(nextRetry) {
 		log.Warn().
-			Int("retry", nextRetry).
+			Int("attempt", nextRetry).
 			Str("error", errMsg).
-			Msg("[publishMessageToKafka] publish failed → increment retry")
-
-		u := model.OutgoingEvent{
-			LastError:  errMsg,
-			RetryCount: nextRetry,
+			Msgf("[publishBatch] unable to publish message to topic %v after %v attempts, update status FAILED and last err to db", m.Topic.String(), policy.MaxRetry)
+		return model.OutgoingEvent{
+			ID:        m.ID,
+			LastError: errMsg,
+			Status:    model.OutgoingEventStatusFailed,
 		}
-		if err = p.repo.OutgoingEvent().Update(spanCtx, u, m.ID); err != nil {
-			log.Error().Err(err).Msg("[publishMessageToKafka] retry update error")
-		}
-		return
 	}
 
-	log.Info().Msg("[publishMessageToKafka] message published to topic: " + m.Topic.String() + " successfully")
+	// retry, once the backoff delay elapsed
+	delay := policy.Delay(nextRetry)
+	log.Warn().
+		Int("retry", nextRetry).
+		Dur("retry_in", delay).
+		Str("error", errMsg).
+		Msg("[publishBatch] publish failed → increment retry")
+
+	return model.OutgoingEvent{
+		ID:            m.ID,
+		LastError:     errMsg,
+		RetryCount:    nextRetry,
+		NextAttemptAt: time.Now().Add(delay),
+	}
+}
+
+// releaseClaims records the outcomes of a batch and releases the leases of its events in one update.
+// The write runs on a fresh deadline, as the publish one may be spent already.
+func (p *Producer) releaseClaims(ctx context.Context, outcomes []model.OutgoingEvent) ([]int64, error) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), releaseTimeout)
+	defer cancel()
+
+	return p.repo.OutgoingEvent().ReleaseClaims(ctx, outcomes, p.config.workerID)
+}
+
+// toKafkaMessage keys the message by the event key, so that the events of a link keep their order,
+// and carries the event and correlation IDs as headers. The value is the legacy payload, or a CloudEvent
+// when an envelope mode is configured.
+func (p *Producer) toKafkaMessage(m model.OutgoingEvent) (kafka.Message, error) {
+	msg := kafka.Message{
+		Topic: m.Topic.String(),
+		Headers: []kafka.Header{
+			{Key: kafka.HeaderEventID, Value: []byte(strconv.FormatInt(m.Payload.EventID, 10))},
+			{Key: kafka.HeaderCorrelationID, Value: []byte(m.CorrelationID)},
+		},
+	}
+	if m.Key != "" {
+		msg.Key = []byte(m.Key)
+	}
+
+	if p.config.cloudEvents != kafka.CloudEventsOff {
+		return msg, kafka.EncodeCloudEvent(p.config.cloudEvents, toCloudEvent(m, p.config.cloudEventsSource), &msg)
+	}
+
+	// Trace context travels as message headers, the payload copy is kept for consumers not reading them yet
+	m.Payload.CorrelationID = m.CorrelationID
+	m.Payload.TraceID = m.TraceID
+	m.Payload.SpanID = m.SpanID
+
+	payload, err := json.Marshal(m.Payload)
+	if err != nil {
+		return kafka.Message{}, err
+	}
+	msg.Value = payload
+
+	return msg, nil
+}
 
-	// success
-	if err = p.repo.OutgoingEvent().Update(
-		spanCtx,
-		model.OutgoingEvent{Status: model.OutgoingEventStatusPublished},
-		m.ID,
-	); err != nil {
-		log.Error().Err(err).Msg("[publishMessageToKafka] mark PUBLISHED error")
+// toCloudEvent maps an outgoing event to a CloudEvent published by source
+func toCloudEvent(m model.OutgoingEvent, source string) kafka.CloudEvent {
+	return kafka.CloudEvent{
+		ID:              strconv.FormatInt(m.Payload.EventID, 10),
+		Source:          source,
+		Type:            m.Topic.String(),
+		Subject:         m.Key,
+		Time:            m.Payload.OccurredAt,
+		DataContentType: "application/json",
+		Data:            m.Payload.Data,
+		Extensions:      map[string]string{"correlationid": m.CorrelationID},
 	}
 }
 
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/kafka"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/pkg/retry"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeProducer fails the messages of the keys in errs and records the published batches
+type fakeProducer struct {
+	errs    map[string]error
+	batches [][]kafka.Message
+}
+
+func (p *fakeProducer) Publish(context.Context, kafka.Message) error {
+	return errors.New("not used")
+}
+
+func (p *fakeProducer) PublishBatch(_ context.Context, msgs []kafka.Message) []error {
+	p.batches = append(p.batches, msgs)
+
+	errs := make([]error, len(msgs))
+	for i, msg := range msgs {
+		errs[i] = p.errs[string(msg.Key)]
+	}
+	return errs
+}
+
+func (p *fakeProducer) Close() error {
+	return nil
+}
+
+func TestProducer_publishBatch(t *testing.T) {
+	errBroker := errors.New("kafka: failed to publish message: leader not available")
+	data := json.RawMessage(`{"short_code":"abc","original_url":"https://example.com"}`)
+	event := func(id int64, key string, retryCount int) model.OutgoingEvent {
+		return model.OutgoingEvent{
+			ID:         id,
+			Topic:      model.TopicMetadataRequestedV1,
+			Key:        key,
+			RetryCount: retryCount,
+			Payload:    model.Payload{EventID: id, OccurredAt: time.Now(), Data: data},
+		}
+	}
+
+	invalid := event(4, "invalid", 0)
+	invalid.Payload.Data = json.RawMessage(`{"short_code":123}`)
+	unencodable := event(5, "unencodable", 0)
+	unencodable.Payload.OccurredAt = time.Date(10000, 1, 1, 0, 0, 0, 0, time.UTC) // beyond RFC 3339
+
+	// Given
+	producer := &fakeProducer{errs: map[string]error{"retry": errBroker, "exhausted": errBroker}}
+	p := New(nil, producer, nil, ProducerConfig{
+		cloudEvents: kafka.CloudEventsOff,
+		retry: retry.Schedule{Default: retry.Policy{
+			MaxRetry:     3,
+			InitialDelay: time.Second,
+			MaxDelay:     time.Minute,
+			Multiplier:   2,
+		}},
+	})
+
+	// When
+	outcomes := p.publishBatch(context.Background(), []model.OutgoingEvent{
+		event(1, "published", 0),
+		event(2, "retry", 1),
+		event(3, "exhausted", 3),
+		invalid,
+		unencodable,
+	})
+
+	// Then: the valid events are published in one batch, in order
+	require.Len(t, producer.batches, 1)
+	var keys []string
+	for _, msg := range producer.batches[0] {
+		keys = append(keys, string(msg.Key))
+	}
+	require.Equal(t, []string{"published", "retry", "exhausted"}, keys)
+
+	// Then: every event has its outcome
+	byID := map[int64]model.OutgoingEvent{}
+	for _, o := range outcomes {
+		byID[o.ID] = o
+	}
+	require.Len(t, byID, 5)
+
+	require.Equal(t, model.OutgoingEvent{ID: 1, Status: model.OutgoingEventStatusPublished}, byID[1])
+
+	retried := byID[2]
+	require.Empty(t, retried.Status, "stays PENDING")
+	require.Equal(t, 2, retried.RetryCount)
+	require.Equal(t, errBroker.Error(), retried.LastError)
+	require.WithinDuration(t, time.Now().Add(2*time.Second), retried.NextAttemptAt, 500*time.Millisecond)
+
+	require.Equal(t, model.OutgoingEvent{
+		ID:        3,
+		Status:    model.OutgoingEventStatusFailed,
+		LastError: errBroker.Error(),
+	}, byID[3])
+
+	for _, id := range []int64{4, 5} {
+		require.Equal(t, model.OutgoingEventStatusFailed, byID[id].Status, "event %d", id)
+		require.NotEmpty(t, byID[id].LastError, "event %d", id)
+		require.Zero(t, byID[id].RetryCount, "event %d", id)
+	}
+}
@@ -2,55 +2,87 @@ package main
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/kafka"
 	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/monitoring"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/pkg/retry"
 	"github.com/kytruongdev/sturl/url-shortener-service/internal/repository"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/repository/outgoingevent"
 )
 
 // Producer handles the publishing loop for Kafka outbox events.
-// It periodically checks for pending events and publishes them.
+// It publishes pending events as soon as the outbox notifies an insert,
+// and periodically checks for pending events in case a notification was missed.
 type Producer struct {
 	repo     repository.Registry
 	producer kafka.Producer
+	listener notifier
 	config   ProducerConfig
 }
 
+// notifier wakes the Producer up when events are inserted, a pg.Listener on the outbox channel
+type notifier interface {
+	Run(ctx context.Context) error
+	Notifications() <-chan struct{}
+}
+
 // ProducerConfig defines the behavior of the Outbox Producer.
 // These values control how frequently the producer scans the outbox table
 // and how many events it processes per cycle.
 type ProducerConfig struct {
 	// pollingInterval defines how frequently the Producer checks the outbox table
-	// for new pending events to publish.
+	// for pending events to publish when no insert was notified and no retry is due meanwhile.
 	pollingInterval time.Duration
 	// batchSize controls how many events are processed per cycle.
 	batchSize int
-	// maxRetry specifies how many times the producer should retry publishing
-	// an event before marking it as FAILED.
-	maxRetry int
-	// maxConcurrency controls how many events are published concurrently.
-	maxConcurrency int
+	// retry schedules the retries of events which failed to publish, by topic:
+	// how long to back off and how many times to retry before marking the event as FAILED.
+	retry retry.Schedule
+	// cloudEvents selects the CloudEvents envelope of published messages, off publishing the legacy payload.
+	cloudEvents kafka.CloudEventsMode
+	// cloudEventsSource is the source attribute of the published CloudEvents.
+	cloudEventsSource string
+	// workerID identifies this producer instance on the events it claims.
+	workerID string
+	// leaseDuration is how long claimed events stay reserved to this instance. Events of a crashed
+	// instance are claimed again by the others once their lease expired.
+	leaseDuration time.Duration
 }
 
-// New creates a new Producer instance.
-func New(repo repository.Registry, producer kafka.Producer, config ProducerConfig) Producer {
+// New creates a new Producer instance. A nil listener leaves the Producer polling only.
+func New(repo repository.Registry, producer kafka.Producer, listener notifier, config ProducerConfig) Producer {
 	return Producer{
 		repo:     repo,
 		producer: producer,
+		listener: listener,
 		config:   config,
 	}
 }
 
 // start begins the publishing loop.
-// This loop follows pattern: run batch → sleep → repeat.
+// This loop follows pattern: run batch → sleep until notified or the next event is due → repeat.
 // No ticker is used to avoid overlapping batches.
 func (p *Producer) start(ctx context.Context) error {
 	monitoring.Log(ctx).Info().
 		Dur("polling_interval", p.config.pollingInterval).
 		Int("batch_size", p.config.batchSize).
+		Str("worker_id", p.config.workerID).
+		Dur("lease_duration", p.config.leaseDuration).
+		Str("cloud_events", string(p.config.cloudEvents)).
+		Bool("listening", p.listener != nil).
 		Msg("[Producer.Start] Producer started")
 
+	// A nil channel never fires, leaving the polling interval alone
+	var wakeups <-chan struct{}
+	if p.listener != nil {
+		go func() {
+			_ = p.listener.Run(ctx)
+		}()
+		wakeups = p.listener.Notifications()
+	}
+
 	for {
 		select {
 		case <-ctx.Done():
@@ -69,17 +101,33 @@ func (p *Producer) start(ctx context.Context) error {
 
 			log.Info().Msgf("[Producer.Start] Producer batch completed in %s", time.Since(start))
 
-			// Sleep after finishing batch (Beaver style)
+			// Sleep after finishing batch (Beaver style), until events are inserted or a retry is due.
+			// An insert notified while the batch ran is kept, so the next batch starts right away.
 			select {
 			case <-ctx.Done():
 				log.Info().Msg("[Producer.Start] Producer stopped during sleep period")
 				return nil
-			case <-time.After(p.config.pollingInterval):
+			case <-wakeups:
+			case <-time.After(p.sleepDuration(ctx)):
 			}
 		}
 	}
 }
 
+// sleepDuration returns how long to sleep until the next pending event can be claimed, the polling interval
+// at most: events released by other instances or claimable past a missed notification are not known otherwise.
+func (p *Producer) sleepDuration(ctx context.Context) time.Duration {
+	delay, err := p.repo.OutgoingEvent().GetNextAttemptDelay(ctx)
+	if err != nil {
+		if !errors.Is(err, outgoingevent.ErrNotFound) {
+			monitoring.Log(ctx).Error().Err(err).Msg("[Producer.sleepDuration] GetNextAttemptDelay err")
+		}
+		return p.config.pollingInterval
+	}
+
+	return min(delay, p.config.pollingInterval)
+}
+
 // runOnce processes a single batch of events.
 func (p *Producer) runOnce(ctx context.Context) {
 	if err := p.process(ctx); err != nil {
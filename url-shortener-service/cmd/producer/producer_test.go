@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/repository"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/repository/outgoingevent"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeNotifier wakes the producer up on demand
+type fakeNotifier struct {
+	notify chan struct{}
+}
+
+func (n fakeNotifier) Run(ctx context.Context) error {
+	<-ctx.Done()
+	return nil
+}
+
+func (n fakeNotifier) Notifications() <-chan struct{} {
+	return n.notify
+}
+
+func TestProducer_start(t *testing.T) {
+	tcs := map[string]struct {
+		nextAttemptDelay time.Duration
+		nextAttemptErr   error
+		notified         bool
+		wantRuns         int
+	}{
+		"success - notification interrupts the sleep": {
+			nextAttemptErr: outgoingevent.ErrNotFound,
+			notified:       true,
+			wantRuns:       2,
+		},
+		"success - sleeps the polling interval without notification": {
+			nextAttemptErr: outgoingevent.ErrNotFound,
+			wantRuns:       1,
+		},
+		"success - sleeps until the next retry is due": {
+			nextAttemptDelay: 150 * time.Millisecond,
+			wantRuns:         2,
+		},
+		"success - sleeps the polling interval at most": {
+			nextAttemptDelay: 2 * time.Hour,
+			wantRuns:         1,
+		},
+		"error - next attempt unknown, sleeps the polling interval": {
+			nextAttemptErr: errors.New("db error"),
+			wantRuns:       1,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			// Given: nothing to claim, and a polling interval longer than the test
+			runs := make(chan struct{}, 10)
+			mockOutgoingEventRepo := new(outgoingevent.MockRepository)
+			mockOutgoingEventRepo.On("ClaimPending", mock.Anything, "worker-1", time.Minute, 10).
+				Run(func(mock.Arguments) { runs <- struct{}{} }).
+				Return([]model.OutgoingEvent(nil), nil)
+			mockOutgoingEventRepo.On("GetNextAttemptDelay", mock.Anything).Return(tc.nextAttemptDelay, tc.nextAttemptErr)
+
+			repo := new(repository.MockRegistry)
+			repo.On("OutgoingEvent").Return(mockOutgoingEventRepo)
+
+			n := fakeNotifier{notify: make(chan struct{}, 1)}
+			p := New(repo, nil, n, ProducerConfig{
+				pollingInterval: time.Hour,
+				batchSize:       10,
+				workerID:        "worker-1",
+				leaseDuration:   time.Minute,
+			})
+
+			ctx, cancel := context.WithCancel(context.Background())
+			done := make(chan error, 1)
+			go func() {
+				done <- p.start(ctx)
+			}()
+
+			<-runs
+
+			// When
+			if tc.notified {
+				n.notify <- struct{}{}
+			}
+
+			// Then
+			got := 1
+			timeout := time.After(300 * time.Millisecond)
+		wait:
+			for {
+				select {
+				case <-runs:
+					got++
+				case <-timeout:
+					break wait
+				}
+			}
+			require.Equal(t, tc.wantRuns, got)
+
+			cancel()
+			require.NoError(t, <-done)
+		})
+	}
+}
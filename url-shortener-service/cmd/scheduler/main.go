@@ -0,0 +1,227 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/config"
+	shortUrlCtrl "github.com/kytruongdev/sturl/url-shortener-service/internal/controller/shorturl"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/app"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/db/pg"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/id"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/monitoring"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/pkg/policy"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/repository"
+	redisRepo "github.com/kytruongdev/sturl/url-shortener-service/internal/repository/redis"
+	"github.com/redis/go-redis/v9"
+)
+
+func main() {
+	rootCtx := context.Background()
+
+	// --- Load global config
+	globalCfg := loadGlobalConfig()
+
+	if err := id.Init(1); err != nil {
+		panic(err)
+	}
+
+	// --- Setup monitoring
+	shutdown, err := initMonitoring(rootCtx, globalCfg.MonitoringCfg)
+	if err != nil {
+		panic(err)
+	}
+	defer shutdown(rootCtx)
+
+	// --- Setup db
+	conn := initDB(globalCfg)
+	defer conn.Close()
+
+	// --- Setup redis, counting the clicks flushed by the scheduler
+	redisClient := initRedis(rootCtx, globalCfg)
+
+	repo := repository.New(conn, redisClient)
+	engine := initPolicy(rootCtx, globalCfg.PolicyCfg, repo)
+	scheduler := New(
+		shortUrlCtrl.New(repo, shortUrlCtrl.WithPolicy(engine)),
+		engine,
+		repo.ShortUrl(),
+		repo.OutgoingEvent(),
+		repo.ProcessedEvent(),
+		initSchedulerConfig(),
+	)
+
+	// --- Start Scheduler
+	if err = app.New(
+		globalCfg.AppCfg.ServiceName+"-scheduler",
+		app.WithShutdownTimeout(globalCfg.AppCfg.ShutdownTimeout),
+	).Run(rootCtx, runner{scheduler}); err != nil {
+		monitoring.Log(rootCtx).Error().Err(err).Msg("scheduler exited with error")
+	}
+}
+
+func loadGlobalConfig() config.GlobalConfig {
+	cfg := config.NewGlobalConfig()
+
+	if err := cfg.Validate(); err != nil {
+		log.Fatal("[loadGlobalConfig] err: ", err)
+	}
+
+	return cfg
+}
+
+func initSchedulerConfig() SchedulerConfig {
+	pim, err := strconv.Atoi(os.Getenv("RECRAWL_POLLING_INTERVAL_MS"))
+	if err != nil {
+		panic(err)
+	}
+
+	mah, err := strconv.Atoi(os.Getenv("RECRAWL_MAX_AGE_HOURS"))
+	if err != nil {
+		panic(err)
+	}
+
+	bs, err := strconv.Atoi(os.Getenv("RECRAWL_BATCH_SIZE"))
+	if err != nil {
+		panic(err)
+	}
+
+	sbs, err := strconv.Atoi(os.Getenv("POLICY_SWEEP_BATCH_SIZE"))
+	if err != nil {
+		panic(err)
+	}
+
+	// Outbox retention: archive events a week after publication by default, keep a year of archive
+	rm := retentionModeArchive
+	if rmEnv := os.Getenv("OUTBOX_RETENTION_MODE"); rmEnv != "" {
+		rm = retentionMode(rmEnv)
+	}
+	if !rm.IsValid() {
+		panic("env variable 'OUTBOX_RETENTION_MODE' must be one of archive, delete or off")
+	}
+
+	rh := 7 * 24
+	if rhEnv := os.Getenv("OUTBOX_RETENTION_HOURS"); rhEnv != "" {
+		if val, err := strconv.Atoi(rhEnv); err == nil && val > 0 {
+			rh = val
+		}
+	}
+
+	rbs := 500
+	if rbsEnv := os.Getenv("OUTBOX_RETENTION_BATCH_SIZE"); rbsEnv != "" {
+		if val, err := strconv.Atoi(rbsEnv); err == nil && val > 0 {
+			rbs = val
+		}
+	}
+
+	arm := 12
+	if armEnv := os.Getenv("OUTBOX_ARCHIVE_RETENTION_MONTHS"); armEnv != "" {
+		if val, err := strconv.Atoi(armEnv); err == nil && val >= 0 {
+			arm = val
+		}
+	}
+
+	// Consumer inbox retention: redeliveries and outbox duplicates come within minutes, a week is plenty
+	irh := 7 * 24
+	if irhEnv := os.Getenv("PROCESSED_EVENTS_RETENTION_HOURS"); irhEnv != "" {
+		if val, err := strconv.Atoi(irhEnv); err == nil && val > 0 {
+			irh = val
+		}
+	}
+
+	return SchedulerConfig{
+		pollingInterval:        time.Duration(pim) * time.Millisecond,
+		maxAge:                 time.Duration(mah) * time.Hour,
+		batchSize:              bs,
+		sweepBatchSize:         sbs,
+		retentionMode:          rm,
+		retentionAge:           time.Duration(rh) * time.Hour,
+		retentionBatchSize:     rbs,
+		archiveRetentionMonths: arm,
+		inboxRetentionAge:      time.Duration(irh) * time.Hour,
+	}
+}
+
+// initPolicy loads the destination blocklist from the configured files and the database,
+// and keeps reloading it in the background.
+func initPolicy(ctx context.Context, cfg policy.Config, repo repository.Registry) *policy.Engine {
+	engine := policy.NewEngine(append(cfg.Sources(), policy.SourceFunc(repo.BlockedDestination().List))...)
+	if err := engine.Reload(ctx); err != nil {
+		log.Fatal("[policy.Engine.Reload] err: ", err)
+	}
+
+	go engine.Watch(ctx, cfg.ReloadInterval)
+
+	return engine
+}
+
+func initMonitoring(ctx context.Context, cfg monitoring.Config) (func(context.Context) error, error) {
+	shutdown, err := monitoring.Init(ctx, monitoring.Config{
+		ServiceName:     cfg.ServiceName,
+		Env:             cfg.Env,
+		OTLPEndpointURL: cfg.OTLPEndpointURL,
+		LogPretty:       os.Getenv("LOG_PRETTY") == "true",
+	})
+
+	if err != nil {
+		log.Fatal("[initMonitoring] err: ", err)
+	}
+
+	return shutdown, nil
+}
+
+func initDB(cfg config.GlobalConfig) *sql.DB {
+	conn, err := pg.Connect(cfg.PGCfg.PGUrl)
+	if err != nil {
+		log.Fatal("[pg.Connect] err]: ", err)
+	}
+
+	return conn
+}
+
+func initRedis(ctx context.Context, cfg config.GlobalConfig) redisRepo.RedisClient {
+	const (
+		selectedDB   = 0
+		dialTimeout  = 5 * time.Second
+		readTimeout  = 3 * time.Second
+		writeTimeout = 3 * time.Second
+		poolSize     = 2
+		minIdleConns = 1
+		maxRetries   = 3
+	)
+
+	redisClient, err := redisRepo.NewRedisClient(ctx, &redis.Options{
+		Addr:         cfg.ServerCfg.RedisAddr,
+		DB:           selectedDB,
+		DialTimeout:  dialTimeout,
+		ReadTimeout:  readTimeout,
+		WriteTimeout: writeTimeout,
+		PoolSize:     poolSize,
+		MinIdleConns: minIdleConns,
+		MaxRetries:   maxRetries,
+	})
+
+	if err != nil {
+		log.Fatal("[initRedis] err: ", err)
+	}
+
+	return redisClient
+}
+
+// runner is an adapter to make the Scheduler implement app.Service
+type runner struct {
+	scheduler Scheduler
+}
+
+func (r runner) Run(ctx context.Context) error {
+	return r.scheduler.start(ctx)
+}
+
+// Shutdown here can be a no-op because ctx cancellation will cause start to return.
+func (r runner) Shutdown(_ context.Context) error {
+	return nil
+}
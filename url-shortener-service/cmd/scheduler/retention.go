@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/monitoring"
+)
+
+// retentionMode tells what becomes of the outbox events past the retention age
+type retentionMode string
+
+const (
+	// retentionModeArchive moves them to the monthly partitions of outgoing_events_archive
+	retentionModeArchive retentionMode = "archive"
+	// retentionModeDelete deletes them
+	retentionModeDelete retentionMode = "delete"
+	// retentionModeOff keeps them forever
+	retentionModeOff retentionMode = "off"
+
+	// maxRetentionBatches bounds the batches run per cycle, leaving a large backlog to the next cycles
+	maxRetentionBatches = 20
+)
+
+// IsValid checks if the retention mode is known
+func (m retentionMode) IsValid() bool {
+	switch m {
+	case retentionModeArchive, retentionModeDelete, retentionModeOff:
+		return true
+	}
+	return false
+}
+
+// retentionOnce archives or deletes, in small batches, the outbox events published or acknowledged
+// past the retention age, then drops the archive partitions past the archive retention.
+func (s *Scheduler) retentionOnce(ctx context.Context) {
+	if s.config.retentionMode == retentionModeOff {
+		return
+	}
+
+	log := monitoring.Log(ctx).Field("retention_mode", s.config.retentionMode)
+	now := time.Now()
+	before := now.Add(-s.config.retentionAge)
+
+	process := s.outbox.DeleteProcessed
+	if s.config.retentionMode == retentionModeArchive {
+		// This month's partition receives the batches, next month's is ready ahead of the month change
+		for _, month := range []time.Time{now, now.AddDate(0, 1, 0)} {
+			if err := s.outbox.EnsureArchivePartition(ctx, month); err != nil {
+				log.Error().Err(err).Msg("[Scheduler.retentionOnce] failed to create outbox archive partition")
+				return
+			}
+		}
+		process = s.outbox.ArchiveProcessed
+	}
+
+	var total int64
+	for i := 0; i < maxRetentionBatches; i++ {
+		n, err := process(ctx, before, s.config.retentionBatchSize)
+		if err != nil {
+			log.Error().Err(err).Msg("[Scheduler.retentionOnce] failed to clean up processed outbox events")
+			return
+		}
+		total += n
+		if n < int64(s.config.retentionBatchSize) {
+			break
+		}
+	}
+	log.Info().Int64("events", total).Msg("[Scheduler.retentionOnce] processed outbox events cleaned up")
+
+	if s.config.retentionMode != retentionModeArchive || s.config.archiveRetentionMonths <= 0 {
+		return
+	}
+
+	dropped, err := s.outbox.DropArchivePartitionsBefore(ctx, now.AddDate(0, -s.config.archiveRetentionMonths, 0))
+	if err != nil {
+		log.Error().Err(err).Msg("[Scheduler.retentionOnce] failed to drop expired outbox archive partitions")
+		return
+	}
+	if len(dropped) > 0 {
+		log.Info().Strs("partitions", dropped).Msg("[Scheduler.retentionOnce] expired outbox archive partitions dropped")
+	}
+}
+
+// inboxRetentionOnce deletes, in small batches, the events handled by the consumers past the inbox retention age.
+func (s *Scheduler) inboxRetentionOnce(ctx context.Context) {
+	log := monitoring.Log(ctx)
+	before := time.Now().Add(-s.config.inboxRetentionAge)
+
+	var total int64
+	for i := 0; i < maxRetentionBatches; i++ {
+		n, err := s.inbox.DeleteBefore(ctx, before, s.config.retentionBatchSize)
+		if err != nil {
+			log.Error().Err(err).Msg("[Scheduler.inboxRetentionOnce] failed to clean up processed events")
+			return
+		}
+		total += n
+		if n < int64(s.config.retentionBatchSize) {
+			break
+		}
+	}
+	log.Info().Int64("events", total).Msg("[Scheduler.inboxRetentionOnce] processed events cleaned up")
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/controller/shorturl"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/monitoring"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/pkg/policy"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/repository/outgoingevent"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/repository/processedevent"
+	shortURLRepo "github.com/kytruongdev/sturl/url-shortener-service/internal/repository/shorturl"
+)
+
+// Scheduler periodically flushes the click counts of links, enqueues metadata re-crawls of links whose
+// metadata went stale, flags the links whose destination got blocked since the last sweep, and cleans up
+// the outbox and the consumer inbox.
+type Scheduler struct {
+	shortURLCtrl shorturl.Controller
+	policy       *policy.Engine
+	shortURLs    shortURLRepo.Repository
+	outbox       outgoingevent.Repository
+	inbox        processedevent.Repository
+	config       SchedulerConfig
+	// sweptFingerprint identifies the destination policy rules of the last complete sweep
+	sweptFingerprint string
+}
+
+// SchedulerConfig defines the behavior of the re-crawl Scheduler.
+type SchedulerConfig struct {
+	// pollingInterval defines how frequently the Scheduler looks for stale metadata.
+	pollingInterval time.Duration
+	// maxAge is the age after which crawled metadata is considered stale.
+	maxAge time.Duration
+	// batchSize controls how many re-crawls are enqueued per cycle.
+	batchSize int
+	// sweepBatchSize controls how many links are loaded at once by the destination policy sweep.
+	sweepBatchSize int
+	// retentionMode tells whether processed outbox events are archived, deleted or kept.
+	retentionMode retentionMode
+	// retentionAge is how long outbox events are kept once published, or once acknowledged when FAILED.
+	retentionAge time.Duration
+	// retentionBatchSize controls how many outbox events are archived or deleted per transaction.
+	retentionBatchSize int
+	// archiveRetentionMonths is how many months of archived outbox events are kept, 0 keeps them forever.
+	archiveRetentionMonths int
+	// inboxRetentionAge is how long the events handled by the consumers are remembered, to skip their redeliveries.
+	inboxRetentionAge time.Duration
+}
+
+// New creates a new Scheduler instance.
+func New(
+	shortURLCtrl shorturl.Controller,
+	policy *policy.Engine,
+	shortURLs shortURLRepo.Repository,
+	outbox outgoingevent.Repository,
+	inbox processedevent.Repository,
+	config SchedulerConfig,
+) Scheduler {
+	return Scheduler{
+		shortURLCtrl: shortURLCtrl,
+		policy:       policy,
+		shortURLs:    shortURLs,
+		outbox:       outbox,
+		inbox:        inbox,
+		config:       config,
+	}
+}
+
+// start begins the scheduling loop.
+// This loop follows pattern: run batch → sleep → repeat, like the outbox producer.
+func (s *Scheduler) start(ctx context.Context) error {
+	monitoring.Log(ctx).Info().
+		Dur("polling_interval", s.config.pollingInterval).
+		Dur("max_age", s.config.maxAge).
+		Int("batch_size", s.config.batchSize).
+		Msg("[Scheduler.Start] Scheduler started")
+
+	for {
+		start := time.Now()
+		log := monitoring.Log(ctx).
+			Field("max_age", s.config.maxAge).
+			Field("batch_size", s.config.batchSize)
+
+		s.flushClickCountsOnce(ctx)
+		s.runOnce(ctx)
+		s.sweepOnce(ctx)
+		s.retentionOnce(ctx)
+		s.inboxRetentionOnce(ctx)
+
+		log.Info().Msgf("[Scheduler.Start] Scheduler batch completed in %s", time.Since(start))
+
+		select {
+		case <-ctx.Done():
+			log.Info().Msg("[Scheduler.Start] context canceled, stopping scheduler loop")
+			return nil
+		case <-time.After(s.config.pollingInterval):
+		}
+	}
+}
+
+// flushClickCountsOnce adds the clicks counted in Redis since the last cycle to the links, before re-crawls
+// are ranked by them.
+func (s *Scheduler) flushClickCountsOnce(ctx context.Context) {
+	updated, err := s.shortURLs.FlushClickCounts(ctx)
+	if err != nil {
+		monitoring.Log(ctx).Error().Err(err).Msg("[Scheduler.flushClickCountsOnce] failed to flush click counts")
+		return
+	}
+
+	monitoring.Log(ctx).Info().Int64("links", updated).Msg("[Scheduler.flushClickCountsOnce] click counts flushed")
+}
+
+// runOnce enqueues a single batch of re-crawls.
+func (s *Scheduler) runOnce(ctx context.Context) {
+	enqueued, err := s.shortURLCtrl.EnqueueStaleRecrawls(ctx, s.config.maxAge, s.config.batchSize)
+	if err != nil {
+		monitoring.Log(ctx).Error().Err(err).Msg("[Scheduler.runOnce] failed to enqueue stale metadata re-crawls")
+		return
+	}
+
+	monitoring.Log(ctx).Info().Int("enqueued", enqueued).Msg("[Scheduler.runOnce] stale metadata re-crawls enqueued")
+}
+
+// sweepOnce flags the links matching the destination policy, only when its rules changed since the last sweep.
+func (s *Scheduler) sweepOnce(ctx context.Context) {
+	fingerprint := s.policy.Fingerprint()
+	if fingerprint == s.sweptFingerprint {
+		return
+	}
+
+	flagged, err := s.shortURLCtrl.FlagBlockedDestinations(ctx, s.config.sweepBatchSize)
+	if err != nil {
+		monitoring.Log(ctx).Error().Err(err).Msg("[Scheduler.sweepOnce] failed to flag blocked destinations")
+		return
+	}
+
+	s.sweptFingerprint = fingerprint
+	monitoring.Log(ctx).Info().Int("flagged", flagged).Msg("[Scheduler.sweepOnce] blocked destinations flagged")
+}
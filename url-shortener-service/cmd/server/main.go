@@ -12,10 +12,13 @@ import (
 	shortUrlCtrl "github.com/kytruongdev/sturl/url-shortener-service/internal/controller/shorturl"
 	"github.com/kytruongdev/sturl/url-shortener-service/internal/handler"
 	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/app"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/blob"
 	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/db/pg"
 	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/httpserver"
 	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/id"
 	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/monitoring"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/pkg/policy"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/pkg/validator"
 	"github.com/kytruongdev/sturl/url-shortener-service/internal/repository"
 	redisRepo "github.com/kytruongdev/sturl/url-shortener-service/internal/repository/redis"
 	"github.com/redis/go-redis/v9"
@@ -48,13 +51,13 @@ func main() {
 	redisClient := initRedis(rootCtx, globalCfg)
 
 	// --- Setup routers
-	rtr := initRouter(conn, redisClient)
+	rtr := initRouter(rootCtx, globalCfg, conn, redisClient)
 
 	l.Info().Msgf("%v service started", globalCfg.ServerCfg.ServiceName)
 
 	// --- Start server
 	svcName := globalCfg.ServerCfg.ServiceName
-	if err = app.New(svcName).Run(
+	if err = app.New(svcName, app.WithShutdownTimeout(globalCfg.AppCfg.ShutdownTimeout)).Run(
 		rootCtx,
 		runner{s: initHTTPServer(globalCfg, rtr, redisClient, conn)}); err != nil {
 		l.Error().Err(err).Msgf("%v exited with error", svcName)
@@ -150,14 +153,37 @@ func initHTTPServer(globalCfg config.GlobalConfig, rtr handler.Router, redisClie
 	}
 }
 
-func initRouter(conn *sql.DB, redisClient redisRepo.RedisClient) handler.Router {
+func initRouter(ctx context.Context, cfg config.GlobalConfig, conn *sql.DB, redisClient redisRepo.RedisClient) handler.Router {
+	assetStore, err := blob.New(cfg.BlobCfg)
+	if err != nil {
+		log.Fatal("[blob.New] err: ", err)
+	}
+
 	repo := repository.New(conn, redisClient)
-	shortURLCtrl := shortUrlCtrl.New(repo)
+	shortURLCtrl := shortUrlCtrl.New(repo,
+		shortUrlCtrl.WithMetadataRefreshInterval(cfg.ShortURLCfg.MetadataRefreshInterval),
+		shortUrlCtrl.WithAssetStore(assetStore, cfg.BlobCfg.PublicBaseURL),
+		shortUrlCtrl.WithPolicy(initPolicy(ctx, cfg.PolicyCfg, repo)))
 
 	return handler.Router{
 		CorsOrigins:  []string{"*"},
 		ShortURLCtrl: shortURLCtrl,
+		URLValidator: validator.New(cfg.ValidatorCfg.Mode),
+		AdminToken:   cfg.ServerCfg.AdminToken,
+	}
+}
+
+// initPolicy loads the destination blocklist from the configured files and the database,
+// and keeps reloading it in the background.
+func initPolicy(ctx context.Context, cfg policy.Config, repo repository.Registry) *policy.Engine {
+	engine := policy.NewEngine(append(cfg.Sources(), policy.SourceFunc(repo.BlockedDestination().List))...)
+	if err := engine.Reload(ctx); err != nil {
+		log.Fatal("[policy.Engine.Reload] err: ", err)
 	}
+
+	go engine.Watch(ctx, cfg.ReloadInterval)
+
+	return engine
 }
 
 // runner is an adapter to make http.Server implement app.Service
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_short_urls_crawl_status;
+
+ALTER TABLE short_urls
+    DROP COLUMN IF EXISTS last_crawl_error_code,
+    DROP COLUMN IF EXISTS last_crawled_at,
+    DROP COLUMN IF EXISTS crawl_attempts,
+    DROP COLUMN IF EXISTS crawl_status;
@@ -0,0 +1,10 @@
+ALTER TABLE short_urls
+    ADD COLUMN IF NOT EXISTS crawl_status          TEXT NOT NULL DEFAULT 'PENDING', -- PENDING | CRAWLED | FAILED | SKIPPED
+    ADD COLUMN IF NOT EXISTS crawl_attempts        INT  NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS last_crawled_at       TIMESTAMP WITH TIME ZONE NULL,
+    ADD COLUMN IF NOT EXISTS last_crawl_error_code TEXT NULL;                       -- TIMEOUT | NON_HTML | HTTP_4XX | HTTP_5XX | TLS | DNS | UNKNOWN
+
+-- Links crawled before the status existed
+UPDATE short_urls SET crawl_status = 'CRAWLED' WHERE metadata IS NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_short_urls_crawl_status ON short_urls(crawl_status);
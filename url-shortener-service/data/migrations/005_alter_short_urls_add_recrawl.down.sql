@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_short_urls_recrawl;
+
+ALTER TABLE short_urls
+    DROP COLUMN IF EXISTS metadata_last_modified,
+    DROP COLUMN IF EXISTS metadata_etag,
+    DROP COLUMN IF EXISTS crawl_requested_at,
+    DROP COLUMN IF EXISTS click_count;
@@ -0,0 +1,8 @@
+ALTER TABLE short_urls
+    ADD COLUMN IF NOT EXISTS click_count            BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS crawl_requested_at     TIMESTAMP WITH TIME ZONE NULL, -- last time a metadata.requested event was enqueued
+    ADD COLUMN IF NOT EXISTS metadata_etag          TEXT NULL,                     -- validators of the last crawled response,
+    ADD COLUMN IF NOT EXISTS metadata_last_modified TEXT NULL;                     -- sent back as If-None-Match / If-Modified-Since
+
+-- Stale link selection: most clicked active links first
+CREATE INDEX IF NOT EXISTS idx_short_urls_recrawl ON short_urls(click_count DESC) WHERE status = 'ACTIVE';
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_short_urls_unflagged;
+
+ALTER TABLE short_urls
+    DROP COLUMN IF EXISTS flag_reason,
+    DROP COLUMN IF EXISTS flagged_at;
+
+DROP TABLE IF EXISTS blocked_destinations;
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS blocked_destinations (
+    id         BIGINT PRIMARY KEY,
+    kind       TEXT NOT NULL,                                  -- DOMAIN | REGEX | SHORTENER
+    pattern    TEXT NOT NULL,                                  -- domain (subdomains included) or regular expression on the URL
+    reason     TEXT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    UNIQUE (kind, pattern)
+);
+
+ALTER TABLE short_urls
+    ADD COLUMN IF NOT EXISTS flagged_at  TIMESTAMP WITH TIME ZONE NULL, -- set when the destination matches the blocklist
+    ADD COLUMN IF NOT EXISTS flag_reason TEXT NULL;
+
+-- Policy sweep: active links not flagged yet, walked by short code
+CREATE INDEX IF NOT EXISTS idx_short_urls_unflagged ON short_urls(short_code) WHERE status = 'ACTIVE' AND flagged_at IS NULL;
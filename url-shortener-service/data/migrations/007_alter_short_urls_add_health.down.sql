@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_short_urls_health_status;
+
+ALTER TABLE short_urls
+    DROP COLUMN IF EXISTS last_ok_at,
+    DROP COLUMN IF EXISTS health_checked_at,
+    DROP COLUMN IF EXISTS health_http_status,
+    DROP COLUMN IF EXISTS health_status;
@@ -0,0 +1,7 @@
+ALTER TABLE short_urls
+    ADD COLUMN IF NOT EXISTS health_status      TEXT NOT NULL DEFAULT 'UNKNOWN', -- UNKNOWN | REACHABLE | UNREACHABLE
+    ADD COLUMN IF NOT EXISTS health_http_status INT  NULL,                       -- status code of the last answer, NULL when unreachable
+    ADD COLUMN IF NOT EXISTS health_checked_at  TIMESTAMP WITH TIME ZONE NULL,
+    ADD COLUMN IF NOT EXISTS last_ok_at         TIMESTAMP WITH TIME ZONE NULL;
+
+CREATE INDEX IF NOT EXISTS idx_short_urls_health_status ON short_urls(health_status);
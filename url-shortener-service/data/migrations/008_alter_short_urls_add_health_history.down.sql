@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_short_urls_health_checked_at;
+
+UPDATE short_urls SET health_status = 'UNREACHABLE' WHERE health_status = 'BROKEN';
+
+ALTER TABLE short_urls
+    DROP COLUMN IF EXISTS health_consecutive_failures,
+    DROP COLUMN IF EXISTS health_redirect_chain;
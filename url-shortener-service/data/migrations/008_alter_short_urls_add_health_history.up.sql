@@ -0,0 +1,8 @@
+ALTER TABLE short_urls
+    ADD COLUMN IF NOT EXISTS health_redirect_chain       JSONB NULL,                -- URLs followed after the original one
+    ADD COLUMN IF NOT EXISTS health_consecutive_failures INT   NOT NULL DEFAULT 0;  -- reset by the first successful check
+
+-- health_status also takes BROKEN, once consecutive failures reach the threshold
+
+-- Link health worker: active links walked from the least recently checked
+CREATE INDEX IF NOT EXISTS idx_short_urls_health_checked_at ON short_urls(health_checked_at NULLS FIRST) WHERE status = 'ACTIVE';
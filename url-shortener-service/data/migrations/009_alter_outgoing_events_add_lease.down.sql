@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_outgoing_events_pending;
+
+ALTER TABLE outgoing_events
+    DROP COLUMN IF EXISTS locked_until,
+    DROP COLUMN IF EXISTS locked_by;
@@ -0,0 +1,6 @@
+ALTER TABLE outgoing_events
+    ADD COLUMN IF NOT EXISTS locked_by    TEXT                     NULL,  -- producer instance currently publishing the event
+    ADD COLUMN IF NOT EXISTS locked_until TIMESTAMP WITH TIME ZONE NULL;  -- lease expiry, past which any producer may claim the event again
+
+-- Producers claim the oldest pending events whose lease is free or expired
+CREATE INDEX IF NOT EXISTS idx_outgoing_events_pending ON outgoing_events(id) WHERE status = 'PENDING';
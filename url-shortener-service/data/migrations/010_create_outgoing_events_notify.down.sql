@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS outgoing_events_notify ON outgoing_events;
+
+DROP FUNCTION IF EXISTS notify_outgoing_events();
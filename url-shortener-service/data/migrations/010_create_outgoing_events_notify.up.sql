@@ -0,0 +1,12 @@
+-- Wakes up the producers as soon as events are committed. NOTIFY is transactional: listeners are told
+-- on commit of the inserting transaction, never about rolled back events.
+CREATE OR REPLACE FUNCTION notify_outgoing_events() RETURNS TRIGGER AS $$
+BEGIN
+    PERFORM pg_notify('outgoing_events', '');
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER outgoing_events_notify
+    AFTER INSERT ON outgoing_events
+    FOR EACH STATEMENT EXECUTE FUNCTION notify_outgoing_events();
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_outgoing_events_pending_next_attempt;
+CREATE INDEX IF NOT EXISTS idx_outgoing_events_pending ON outgoing_events(id) WHERE status = 'PENDING';
+
+ALTER TABLE outgoing_events
+    DROP COLUMN IF EXISTS next_attempt_at;
@@ -0,0 +1,6 @@
+ALTER TABLE outgoing_events
+    ADD COLUMN IF NOT EXISTS next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW();  -- pushed back after each failed publish
+
+-- Producers claim the pending events which are due
+DROP INDEX IF EXISTS idx_outgoing_events_pending;
+CREATE INDEX IF NOT EXISTS idx_outgoing_events_pending_next_attempt ON outgoing_events(next_attempt_at, id) WHERE status = 'PENDING';
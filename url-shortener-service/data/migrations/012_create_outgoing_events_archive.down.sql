@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS outgoing_events_archive;
+
+DROP INDEX IF EXISTS idx_outgoing_events_acknowledged;
+DROP INDEX IF EXISTS idx_outgoing_events_published;
+
+ALTER TABLE outgoing_events
+    DROP COLUMN IF EXISTS acknowledged_at;
@@ -0,0 +1,25 @@
+ALTER TABLE outgoing_events
+    ADD COLUMN IF NOT EXISTS acknowledged_at TIMESTAMP WITH TIME ZONE NULL;  -- set once a FAILED event was looked at, allowing its archival
+
+-- Retention: events published, or failed and acknowledged, before the retention age
+CREATE INDEX IF NOT EXISTS idx_outgoing_events_published ON outgoing_events(updated_at) WHERE status = 'PUBLISHED';
+CREATE INDEX IF NOT EXISTS idx_outgoing_events_acknowledged ON outgoing_events(acknowledged_at) WHERE status = 'FAILED';
+
+-- Archived events, partitioned by month of archival so that expired months are dropped whole.
+-- Monthly partitions (outgoing_events_archive_YYYYMM) are created ahead by the retention job.
+CREATE TABLE IF NOT EXISTS outgoing_events_archive (
+    id                 BIGINT      NOT NULL,
+    payload            JSONB       NOT NULL,
+    topic              TEXT        NOT NULL,
+    status             TEXT        NOT NULL,
+    last_error         TEXT            NULL,
+    correlation_id     TEXT        NOT NULL,
+    trace_id           TEXT        NOT NULL,
+    span_id            TEXT        NOT NULL,
+    retry_count        INT         NOT NULL,
+    acknowledged_at    TIMESTAMP WITH TIME ZONE     NULL,
+    created_at         TIMESTAMP WITH TIME ZONE NOT NULL,
+    updated_at         TIMESTAMP WITH TIME ZONE NOT NULL,
+    archived_at        TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (id, archived_at)
+) PARTITION BY RANGE (archived_at);
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_outgoing_events_pending_message_key;
+
+ALTER TABLE outgoing_events_archive
+    DROP COLUMN IF EXISTS message_key;
+
+ALTER TABLE outgoing_events
+    DROP COLUMN IF EXISTS message_key;
@@ -0,0 +1,14 @@
+ALTER TABLE outgoing_events
+    ADD COLUMN IF NOT EXISTS message_key TEXT NULL;  -- Kafka message key, the short code: events of a link share a partition, in order
+
+ALTER TABLE outgoing_events_archive
+    ADD COLUMN IF NOT EXISTS message_key TEXT NULL;
+
+-- Events not published yet keep their link ordering
+UPDATE outgoing_events
+SET message_key = payload->'data'->>'short_code'
+WHERE message_key IS NULL
+  AND status = 'PENDING';
+
+-- Producers skip the events of a key while an older one of the key is pending
+CREATE INDEX IF NOT EXISTS idx_outgoing_events_pending_message_key ON outgoing_events(message_key, id) WHERE status = 'PENDING';
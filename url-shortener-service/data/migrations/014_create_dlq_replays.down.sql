@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_dlq_replays_replayed_at;
+
+DROP TABLE IF EXISTS dlq_replays;
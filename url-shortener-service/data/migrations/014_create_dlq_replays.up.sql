@@ -0,0 +1,16 @@
+-- Audit of the DLQ messages replayed to their topic, one row per DLQ message so that it is replayed once
+CREATE TABLE IF NOT EXISTS dlq_replays (
+    id           BIGSERIAL PRIMARY KEY,
+    dlq_id       TEXT NOT NULL UNIQUE,                           -- digest of the DLQ message, see kafka.DLQRecord
+    dlq_topic    TEXT NOT NULL,
+    topic        TEXT NOT NULL,                                  -- topic the message was replayed to
+    message_key  TEXT NULL,
+    event_id     TEXT NULL,                                      -- outbox event ID of the message, when known
+    error        TEXT NOT NULL,                                  -- error which sent the message to the DLQ
+    replay_count INT  NOT NULL,                                  -- replay_count header of the replayed message
+    replayed_by  TEXT NOT NULL,
+    reason       TEXT NULL,
+    replayed_at  TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_dlq_replays_replayed_at ON dlq_replays(replayed_at);
@@ -0,0 +1,9 @@
+-- Inbox of the consumers: the events each consumer group handled, recorded in the transaction of the handler's work
+-- so that a redelivered or duplicated event is not handled twice
+CREATE TABLE IF NOT EXISTS processed_events (
+    consumer_group TEXT   NOT NULL,
+    event_id       BIGINT NOT NULL,                                -- outbox event ID, Payload.EventID
+    topic          TEXT   NOT NULL,
+    processed_at   TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (consumer_group, event_id)
+);
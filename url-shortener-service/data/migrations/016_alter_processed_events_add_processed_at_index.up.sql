@@ -0,0 +1,2 @@
+-- Expired inbox entries are pruned by the scheduler, oldest first
+CREATE INDEX IF NOT EXISTS idx_processed_events_processed_at ON processed_events (processed_at);
@@ -1,12 +1,16 @@
 package config
 
 import (
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/controller/shorturl"
 	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/app"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/blob"
 	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/db/pg"
 	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/httpserver"
 	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/kafka"
 	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/monitoring"
 	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/transportmeta"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/pkg/policy"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/pkg/validator"
 )
 
 // GlobalConfig represents the aggregated configuration for the URL shortener service.
@@ -21,19 +25,33 @@ type GlobalConfig struct {
 	MonitoringCfg    monitoring.Config    // Observability configuration (logging, tracing, metrics)
 	TransportMetaCfg transportmeta.Config // Request metadata propagation configuration
 	KafkaCfg         kafka.Config
+	BlobCfg          blob.Config      // Blob store of cached thumbnails
+	PolicyCfg        policy.Config    // Destination blocklist
+	ValidatorCfg     validator.Config // How far submitted URLs are validated
+	ShortURLCfg      shorturl.Config  // Short URL rules shared by the binaries, e.g. when a link is BROKEN
 }
 
 // NewGlobalConfig creates and loads a new GlobalConfig instance from environment variables.
 // It initializes all infrastructure component configurations by calling their respective NewConfig functions.
 // Each component config is loaded independently, allowing for modular configuration management.
 func NewGlobalConfig() GlobalConfig {
+	appCfg := app.NewConfig()
+
+	// The commits of the messages handled while a consumer drain aborts get as long as the drain had
+	kafkaCfg := kafka.NewConfig()
+	kafkaCfg.CommitTimeout = appCfg.ShutdownTimeout
+
 	return GlobalConfig{
-		AppCfg:           app.NewConfig(),
+		AppCfg:           appCfg,
 		PGCfg:            pg.NewConfig(),
 		ServerCfg:        httpserver.NewConfig(),
 		MonitoringCfg:    monitoring.NewConfig(),
 		TransportMetaCfg: transportmeta.NewConfig(),
-		KafkaCfg:         kafka.NewConfig(),
+		KafkaCfg:         kafkaCfg,
+		BlobCfg:          blob.NewConfig(),
+		PolicyCfg:        policy.NewConfig(),
+		ValidatorCfg:     validator.NewConfig(),
+		ShortURLCfg:      shorturl.NewConfig(),
 	}
 }
 
@@ -59,6 +77,18 @@ func (c GlobalConfig) Validate() error {
 	if err := c.KafkaCfg.Validate(); err != nil {
 		return err
 	}
+	if err := c.BlobCfg.Validate(); err != nil {
+		return err
+	}
+	if err := c.PolicyCfg.Validate(); err != nil {
+		return err
+	}
+	if err := c.ValidatorCfg.Validate(); err != nil {
+		return err
+	}
+	if err := c.ShortURLCfg.Validate(); err != nil {
+		return err
+	}
 
 	return nil
 }
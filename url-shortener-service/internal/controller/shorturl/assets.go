@@ -0,0 +1,144 @@
+package shorturl
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/blob"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/monitoring"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/pkg/thumbnail"
+	pkgerrors "github.com/pkg/errors"
+)
+
+const (
+	// maxImageBytes is the largest og:image downloaded for a thumbnail
+	maxImageBytes = 5 * 1024 * 1024
+	// maxFaviconBytes is the largest favicon downloaded for a thumbnail
+	maxFaviconBytes = 512 * 1024
+)
+
+// assetHash matches the content-address of a cached asset: the hex-encoded SHA-256 of the thumbnail
+var assetHash = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// errAssetTooLarge means the remote image exceeds the download limit
+var errAssetTooLarge = errors.New("asset too large")
+
+// assetCache downloads the preview images of a page, resizes them to thumbnails and stores them
+// in the blob store, so that previews never hotlink the destination
+type assetCache struct {
+	store   blob.Store
+	baseURL string
+}
+
+// cache replaces the image and favicon of md by cached thumbnails. It is best-effort: an image which
+// cannot be cached keeps its original URL.
+func (a assetCache) cache(ctx context.Context, client *http.Client, md model.UrlMetadata) model.UrlMetadata {
+	log := monitoring.Log(ctx)
+
+	if md.Image != "" {
+		if u, err := a.put(ctx, client, md.Image, maxImageBytes, thumbnail.SizePreview); err != nil {
+			log.Warn().Err(err).Str("image", md.Image).Msg("[assetCache.cache] caching image err")
+		} else {
+			md.OriginalImage, md.Image = md.Image, u
+		}
+	}
+
+	if md.Favicon != "" {
+		if u, err := a.put(ctx, client, md.Favicon, maxFaviconBytes, thumbnail.SizeFavicon); err != nil {
+			log.Warn().Err(err).Str("favicon", md.Favicon).Msg("[assetCache.cache] caching favicon err")
+		} else {
+			md.OriginalFavicon, md.Favicon = md.Favicon, u
+		}
+	}
+
+	return md
+}
+
+// put downloads the image at rawURL, stores its thumbnail and returns the URL it is served from
+func (a assetCache) put(ctx context.Context, client *http.Client, rawURL string, limit int64, size thumbnail.Size) (string, error) {
+	src, err := download(ctx, client, rawURL, limit)
+	if err != nil {
+		return "", err
+	}
+
+	thumb, err := thumbnail.Make(src, size)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(thumb.Data)
+	key := hex.EncodeToString(sum[:])
+	if err = a.store.Put(ctx, key, thumb.Data, thumb.ContentType); err != nil {
+		return "", err
+	}
+
+	return a.baseURL + "/assets/" + key, nil
+}
+
+// download fetches an image, refusing anything which is not an image or is larger than limit bytes
+func download(ctx context.Context, client *http.Client, rawURL string, limit int64) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpStatusError{StatusCode: resp.StatusCode, URL: rawURL}
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if !strings.HasPrefix(mediaType, "image/") {
+		return nil, fmt.Errorf("unexpected content type %q from %s", mediaType, rawURL)
+	}
+
+	// Fail fast on announced sizes, and still cap the read for chunked or lying responses
+	if resp.ContentLength > limit {
+		return nil, fmt.Errorf("%w: %d bytes from %s", errAssetTooLarge, resp.ContentLength, rawURL)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > limit {
+		return nil, fmt.Errorf("%w: more than %d bytes from %s", errAssetTooLarge, limit, rawURL)
+	}
+
+	return data, nil
+}
+
+// GetAsset returns a cached thumbnail by its content hash.
+func (i impl) GetAsset(ctx context.Context, hash string) ([]byte, error) {
+	var err error
+	ctx, span := monitoring.Start(ctx, "ShortURLController.GetAsset")
+	defer monitoring.End(span, &err)
+
+	if i.assetStore == nil || !assetHash.MatchString(hash) {
+		return nil, ErrAssetNotFound
+	}
+
+	data, err := i.assetStore.Get(ctx, hash)
+	if err != nil {
+		if errors.Is(err, blob.ErrNotFound) {
+			return nil, ErrAssetNotFound
+		}
+		return nil, pkgerrors.WithStack(err)
+	}
+
+	return data, nil
+}
@@ -0,0 +1,160 @@
+package shorturl
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/png"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/blob"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssetCache(t *testing.T) {
+	photo := encodeTestPNG(t, 1200, 630)
+	icon := encodeTestPNG(t, 32, 32)
+
+	tcs := map[string]struct {
+		input          model.UrlMetadata
+		wantCachedImg  bool
+		wantCachedIcon bool
+	}{
+		"success - image and favicon cached": {
+			input:          model.UrlMetadata{Image: "https://example.com/og.png", Favicon: "https://example.com/favicon.png"},
+			wantCachedImg:  true,
+			wantCachedIcon: true,
+		},
+		"success - oversized favicon keeps original url": {
+			input:         model.UrlMetadata{Image: "https://example.com/og.png", Favicon: "https://example.com/huge.png"},
+			wantCachedImg: true,
+		},
+		"success - non-image keeps original url": {
+			input:          model.UrlMetadata{Image: "https://example.com/page.html", Favicon: "https://example.com/favicon.png"},
+			wantCachedIcon: true,
+		},
+		"success - missing image keeps original url": {
+			input: model.UrlMetadata{Image: "https://example.com/missing.png"},
+		},
+		"success - nothing to cache": {
+			input: model.UrlMetadata{Title: "Example"},
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			store, err := blob.NewFilesystem(t.TempDir())
+			require.NoError(t, err)
+
+			crawler := newTestCrawler(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/og.png":
+					w.Header().Set("Content-Type", "image/png")
+					_, _ = w.Write(photo)
+				case "/favicon.png":
+					w.Header().Set("Content-Type", "image/png")
+					_, _ = w.Write(icon)
+				case "/huge.png":
+					w.Header().Set("Content-Type", "image/png")
+					w.Header().Set("Content-Length", strconv.Itoa(maxFaviconBytes+1))
+					_, _ = w.Write(make([]byte, maxFaviconBytes+1))
+				case "/page.html":
+					w.Header().Set("Content-Type", "text/html")
+					_, _ = w.Write([]byte("<html></html>"))
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+
+			i := New(nil, WithAssetStore(store, "https://sturl.example")).(*impl)
+			i.crawler.client = crawler.client
+
+			actual := i.crawler.assets.cache(context.Background(), i.crawler.client, tc.input)
+
+			assertCached := func(cached bool, got, original, gotOriginal string) {
+				if !cached {
+					require.Equal(t, original, got)
+					require.Empty(t, gotOriginal)
+					return
+				}
+				require.Regexp(t, `^https://sturl\.example/assets/[0-9a-f]{64}$`, got)
+				require.Equal(t, original, gotOriginal)
+
+				data, err := i.GetAsset(context.Background(), got[len("https://sturl.example/assets/"):])
+				require.NoError(t, err)
+				require.NotEmpty(t, data)
+			}
+			assertCached(tc.wantCachedImg, actual.Image, tc.input.Image, actual.OriginalImage)
+			assertCached(tc.wantCachedIcon, actual.Favicon, tc.input.Favicon, actual.OriginalFavicon)
+			require.Equal(t, tc.input.Title, actual.Title)
+		})
+	}
+}
+
+func TestGetAsset(t *testing.T) {
+	const hash = "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+	store, err := blob.NewFilesystem(t.TempDir())
+	require.NoError(t, err)
+	require.NoError(t, store.Put(context.Background(), hash, []byte("thumbnail"), "image/png"))
+
+	tcs := map[string]struct {
+		hash     string
+		noStore  bool
+		wantData []byte
+		wantErr  error
+	}{
+		"success": {
+			hash:     hash,
+			wantData: []byte("thumbnail"),
+		},
+		"fail - unknown hash": {
+			hash:    "f" + hash[1:],
+			wantErr: ErrAssetNotFound,
+		},
+		"fail - malformed hash": {
+			hash:    "../../etc/passwd",
+			wantErr: ErrAssetNotFound,
+		},
+		"fail - asset cache disabled": {
+			hash:    hash,
+			noStore: true,
+			wantErr: ErrAssetNotFound,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			var opts []Option
+			if !tc.noStore {
+				opts = append(opts, WithAssetStore(store, ""))
+			}
+
+			actual, err := New(nil, opts...).GetAsset(context.Background(), tc.hash)
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.wantData, actual)
+		})
+	}
+}
+
+func encodeTestPNG(t *testing.T, w, h int) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for x := 0; x < w; x++ {
+		for y := 0; y < h; y++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
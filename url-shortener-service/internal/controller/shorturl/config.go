@@ -0,0 +1,45 @@
+package shorturl
+
+import (
+	"errors"
+	"os"
+	"strconv"
+	"time"
+)
+
+// Config represents the short URL rules shared by the binaries applying them, so that they agree on them.
+type Config struct {
+	BrokenLinkThreshold     int           // Failed health checks in a row after which a link is BROKEN, by crawls and the linkhealth worker alike
+	MetadataRefreshInterval time.Duration // Minimum delay between two on-demand metadata refreshes of a link
+}
+
+// NewConfig creates a new short URL configuration from environment variables.
+func NewConfig() Config {
+	cfg := Config{
+		BrokenLinkThreshold:     defaultBrokenLinkThreshold,
+		MetadataRefreshInterval: defaultMetadataRefreshInterval,
+	}
+
+	// Invalid values are kept as 0 and rejected by Validate
+	if v := os.Getenv("LINK_HEALTH_BROKEN_AFTER"); v != "" {
+		cfg.BrokenLinkThreshold, _ = strconv.Atoi(v)
+	}
+	if v := os.Getenv("METADATA_REFRESH_INTERVAL_SECONDS"); v != "" {
+		sec, _ := strconv.Atoi(v)
+		cfg.MetadataRefreshInterval = time.Duration(sec) * time.Second
+	}
+
+	return cfg
+}
+
+// Validate checks that the short URL configuration is usable.
+func (c Config) Validate() error {
+	if c.BrokenLinkThreshold <= 0 {
+		return errors.New("[shorturl.Config] env variable 'LINK_HEALTH_BROKEN_AFTER' must be positive")
+	}
+	if c.MetadataRefreshInterval <= 0 {
+		return errors.New("[shorturl.Config] env variable 'METADATA_REFRESH_INTERVAL_SECONDS' must be positive")
+	}
+
+	return nil
+}
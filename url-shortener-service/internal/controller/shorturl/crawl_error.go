@@ -0,0 +1,64 @@
+package shorturl
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/safehttp"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/pkg/extractor"
+)
+
+// errNotModified means the crawled destination did not change since the previous crawl
+var errNotModified = errors.New("not modified")
+
+// httpStatusError means the crawled destination answered with a non-success status code
+type httpStatusError struct {
+	StatusCode int
+	URL        string
+}
+
+// Error implements error
+func (e httpStatusError) Error() string {
+	return fmt.Sprintf("unexpected status code %d from %s", e.StatusCode, e.URL)
+}
+
+// classifyCrawlError maps a crawl error to the error code recorded on the link
+func classifyCrawlError(err error) model.CrawlErrorCode {
+	var (
+		statusErr  httpStatusError
+		dnsErr     *net.DNSError
+		netErr     net.Error
+		certErr    *tls.CertificateVerificationError
+		recordErr  tls.RecordHeaderError
+		alertErr   tls.AlertError
+		unknownCA  x509.UnknownAuthorityError
+		hostErr    x509.HostnameError
+		invalidErr x509.CertificateInvalidError
+	)
+
+	switch {
+	case errors.As(err, &statusErr):
+		if statusErr.StatusCode >= 500 {
+			return model.CrawlErrorHTTP5xx
+		}
+		return model.CrawlErrorHTTP4xx
+	case errors.Is(err, extractor.ErrUnsupportedContentType):
+		return model.CrawlErrorNonHTML
+	case errors.Is(err, safehttp.ErrBlockedAddress):
+		return model.CrawlErrorBlocked
+	case errors.As(err, &dnsErr):
+		return model.CrawlErrorDNS
+	case errors.As(err, &certErr), errors.As(err, &recordErr), errors.As(err, &alertErr),
+		errors.As(err, &unknownCA), errors.As(err, &hostErr), errors.As(err, &invalidErr):
+		return model.CrawlErrorTLS
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &netErr) && netErr.Timeout():
+		return model.CrawlErrorTimeout
+	default:
+		return model.CrawlErrorUnknown
+	}
+}
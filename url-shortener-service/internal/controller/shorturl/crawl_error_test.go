@@ -0,0 +1,67 @@
+package shorturl
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"testing"
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/safehttp"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/pkg/extractor"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassifyCrawlError(t *testing.T) {
+	tcs := map[string]struct {
+		err  error
+		want model.CrawlErrorCode
+	}{
+		"404": {
+			err:  httpStatusError{StatusCode: 404, URL: "https://example.com"},
+			want: model.CrawlErrorHTTP4xx,
+		},
+		"503": {
+			err:  httpStatusError{StatusCode: 503, URL: "https://example.com"},
+			want: model.CrawlErrorHTTP5xx,
+		},
+		"unsupported content type": {
+			err:  fmt.Errorf("%w: application/zip", extractor.ErrUnsupportedContentType),
+			want: model.CrawlErrorNonHTML,
+		},
+		"dns": {
+			err:  &url.Error{Op: "Get", URL: "https://nope.invalid", Err: &net.OpError{Op: "dial", Err: &net.DNSError{Err: "no such host", Name: "nope.invalid", IsNotFound: true}}},
+			want: model.CrawlErrorDNS,
+		},
+		"private address": {
+			err:  &url.Error{Op: "Get", URL: "http://169.254.169.254", Err: &net.OpError{Op: "dial", Err: fmt.Errorf("%w: 169.254.169.254", safehttp.ErrBlockedAddress)}},
+			want: model.CrawlErrorBlocked,
+		},
+		"tls certificate": {
+			err:  &url.Error{Op: "Get", URL: "https://self-signed.example", Err: &tls.CertificateVerificationError{Err: x509.UnknownAuthorityError{}}},
+			want: model.CrawlErrorTLS,
+		},
+		"tls alert": {
+			err:  &url.Error{Op: "Get", URL: "https://example.com", Err: tls.AlertError(40)},
+			want: model.CrawlErrorTLS,
+		},
+		"client timeout": {
+			err:  &url.Error{Op: "Get", URL: "https://example.com", Err: context.DeadlineExceeded},
+			want: model.CrawlErrorTimeout,
+		},
+		"unknown": {
+			err:  errors.New("connection reset by peer"),
+			want: model.CrawlErrorUnknown,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.want, classifyCrawlError(tc.err))
+		})
+	}
+}
@@ -1,21 +1,22 @@
 package shorturl
 
 import (
-	"bytes"
 	"context"
 	"database/sql"
 	"errors"
 	"fmt"
 	"io"
+	"mime"
 	"net/http"
 	"net/url"
 	"strings"
 	"time"
 
 	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/monitoring"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/safehttp"
 	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/pkg/extractor"
 	"github.com/kytruongdev/sturl/url-shortener-service/internal/repository"
-	"golang.org/x/net/html"
 	"golang.org/x/net/html/charset"
 )
 
@@ -37,9 +38,46 @@ func (i impl) CrawlURLMetadata(ctx context.Context, shortCode string) (model.Url
 		return model.UrlMetadata{}, err
 	}
 
-	crawledMetadata, err := newURLMetadataCrawler().crawl(ctx, su.OriginalURL)
+	// Only active links are worth a preview
+	if su.Status != model.ShortUrlStatusActive {
+		log.Info().Str("status", su.Status.String()).Msg("[CrawlURLMetadata] link is not active, skipping")
+		if err = i.repo.ShortUrl().RecordCrawlAttempt(ctx, shortCode, model.CrawlStatusSkipped, ""); err != nil {
+			log.Error().Err(err).Msg("[CrawlURLMetadata] shortUrlRepo.RecordCrawlAttempt err")
+			return model.UrlMetadata{}, err
+		}
+		return model.UrlMetadata{}, nil
+	}
+
+	// Conditional re-crawl: validators are only worth sending when there is metadata to keep
+	var validators cacheValidators
+	if su.Metadata.IsNotEmpty() {
+		validators = cacheValidators{ETag: su.Crawl.ETag, LastModified: su.Crawl.LastModified}
+	}
+
+	crawledMetadata, resp, err := i.crawler.crawl(ctx, su.OriginalURL, validators)
+
+	// Reachability is observed here rather than at shorten time, so creating a link never waits on its destination
+	if err := i.recordHealthCheck(ctx, su, model.HealthCheck{HTTPStatus: resp.StatusCode, RedirectChain: resp.RedirectChain}); err != nil {
+		// Best-effort: link health must not fail the crawl
+		log.Error().Err(err).Msg("[CrawlURLMetadata] recordHealthCheck err")
+	}
+
+	if errors.Is(err, errNotModified) {
+		log.Info().Msg("[CrawlURLMetadata] page not modified since last crawl, keeping metadata")
+		if err = i.repo.ShortUrl().RecordCrawlAttempt(ctx, shortCode, model.CrawlStatusCrawled, ""); err != nil {
+			log.Error().Err(err).Msg("[CrawlURLMetadata] shortUrlRepo.RecordCrawlAttempt err")
+			return model.UrlMetadata{}, err
+		}
+		return su.Metadata, nil
+	}
 	if err != nil {
-		log.Error().Err(err).Msg("[CrawlMetadata] i.crawl err")
+		errCode := classifyCrawlError(err)
+		log.Error().Err(err).Str("error_code", errCode.String()).Msg("[CrawlMetadata] i.crawl err")
+
+		// Best-effort: the crawl error is what the caller needs to decide on retrying
+		if recordErr := i.repo.ShortUrl().RecordCrawlAttempt(ctx, shortCode, model.CrawlStatusFailed, errCode); recordErr != nil {
+			log.Error().Err(recordErr).Msg("[CrawlURLMetadata] shortUrlRepo.RecordCrawlAttempt err")
+		}
 		return model.UrlMetadata{}, err
 	}
 
@@ -48,34 +86,33 @@ func (i impl) CrawlURLMetadata(ctx context.Context, shortCode string) (model.Url
 		Field("description", crawledMetadata.Description).
 		Field("image", crawledMetadata.Image).
 		Field("favicon", crawledMetadata.Favicon).
+		Field("extractor", crawledMetadata.Extractor).
 		Info().Msg("[CrawlURLMetadata] crawler completed")
 
 	if err = i.repo.DoInTx(ctx, nil, func(txCtx context.Context, txRepo repository.Registry) error {
 		txLog := monitoring.Log(txCtx)
 		txLog.Info().Msg("[CrawlURLMetadata] starting DoInTx")
 
-		if err = i.updateMetadata(txCtx, txRepo, crawledMetadata, su.ShortCode); err != nil {
+		if err = i.updateMetadata(txCtx, txRepo, crawledMetadata, resp.Validators, su.ShortCode); err != nil {
 			txLog.Error().Err(err).Msg("[DoInTx] updateMetadata err")
 			return err
 		}
 
-		meta := monitoring.SpanMetadataFromContext(txCtx)
-		if err = i.insertOutgoingEvent(txCtx, txRepo, model.OutgoingEvent{
-			ID:            newIDFunc(),
-			Topic:         model.TopicMetadataCrawledV1,
-			Status:        model.OutgoingEventStatusPending,
-			CorrelationID: meta.CorrelationID,
-			TraceID:       meta.TraceID,
-			SpanID:        meta.SpanID,
-			Payload: model.Payload{
-				EventID:    newIDFunc(),
-				OccurredAt: time.Now().UTC(),
-				Data: map[string]string{
-					"short_code":   su.ShortCode,
-					"original_url": su.OriginalURL,
-				},
-			},
-		}); err != nil {
+		if err = txRepo.ShortUrl().RecordCrawlAttempt(txCtx, su.ShortCode, model.CrawlStatusCrawled, ""); err != nil {
+			txLog.Error().Err(err).Msg("[DoInTx] RecordCrawlAttempt err")
+			return err
+		}
+
+		event, err := newOutgoingEvent(txCtx, model.TopicMetadataCrawledV1, su.ShortCode, model.MetadataCrawledV1{
+			ShortCode:   su.ShortCode,
+			OriginalURL: su.OriginalURL,
+		})
+		if err != nil {
+			txLog.Error().Err(err).Msg("[DoInTx] newOutgoingEvent err")
+			return err
+		}
+
+		if err = i.insertOutgoingEvent(txCtx, txRepo, event); err != nil {
 			txLog.Error().Err(err).Msg("[DoInTx] insertOutgoingEvent err")
 			return err
 		}
@@ -89,9 +126,13 @@ func (i impl) CrawlURLMetadata(ctx context.Context, shortCode string) (model.Url
 	return crawledMetadata, nil
 }
 
-func (i impl) updateMetadata(ctx context.Context, txRepo repository.Registry, metadata model.UrlMetadata, shortCode string) error {
+func (i impl) updateMetadata(ctx context.Context, txRepo repository.Registry, metadata model.UrlMetadata, validators cacheValidators, shortCode string) error {
 	return txRepo.ShortUrl().Update(ctx, model.ShortUrl{
 		Metadata: metadata,
+		Crawl: model.CrawlState{
+			ETag:         validators.ETag,
+			LastModified: validators.LastModified,
+		},
 	}, shortCode)
 }
 
@@ -100,47 +141,71 @@ func (i impl) insertOutgoingEvent(ctx context.Context, txRepo repository.Registr
 	return err
 }
 
+// cacheValidators are the HTTP validators of a crawled response, sent back on re-crawls
+// so unchanged pages answer 304 Not Modified.
+type cacheValidators struct {
+	ETag         string
+	LastModified string
+}
+
+// crawlResponse describes how the destination answered a crawl
+type crawlResponse struct {
+	// StatusCode is the status of the final response, 0 when the destination could not be reached.
+	// It is set even when the crawl fails past the response, e.g. on an error status or an unsupported document.
+	StatusCode    int
+	RedirectChain []string
+	Validators    cacheValidators
+}
+
 type urlMetadataCrawler struct {
-	client http.Client
+	client     *http.Client
+	extractors *extractor.Registry
+	assets     *assetCache // nil when preview images are hotlinked
 }
 
-func newURLMetadataCrawler() urlMetadataCrawler {
+func newURLMetadataCrawler(opts ...safehttp.Option) urlMetadataCrawler {
+	// Destinations are user-provided: never reach the internal network, prevent long-hanging crawls
+	// and reject excessive redirects to avoid loops, the default limit of safehttp
+	client := safehttp.NewClient(append([]safehttp.Option{
+		safehttp.WithTimeout(5 * time.Second),
+	}, opts...)...)
+
 	return urlMetadataCrawler{
-		client: http.Client{
-			Timeout: 5 * time.Second, // Prevent long-hanging crawls
-			CheckRedirect: func(req *http.Request, via []*http.Request) error {
-				// Reject excessive redirects to avoid loops
-				if len(via) >= 5 {
-					return errors.New("too many redirects")
-				}
-				return nil
-			},
-		},
+		client:     client,
+		extractors: extractor.NewDefaultRegistry(client),
 	}
 }
 
-// crawl fetches HTML head → parse metadata → build result
-func (i urlMetadataCrawler) crawl(ctx context.Context, rawURL string) (model.UrlMetadata, error) {
+// crawl fetches the document → run the extractor matching its kind and host → build result.
+// It returns errNotModified when the document still matches the given validators.
+func (i urlMetadataCrawler) crawl(ctx context.Context, rawURL string, validators cacheValidators) (model.UrlMetadata, crawlResponse, error) {
 	rawURL = upgradeToHTTPS(rawURL) // Auto-upgrade http→https for reliability
 
-	body, baseURL, err := i.fetchHeadHTML(ctx, rawURL)
+	page, resp, err := i.fetchPage(ctx, rawURL, validators)
 	if err != nil {
-		return model.UrlMetadata{}, err
+		return model.UrlMetadata{}, resp, err
 	}
 
-	// parse <head> to extract meta tags, title, og:*, favicon
-	head := parseHeadMetadata(body)
+	// file extractor for PDFs, images and media; for HTML, site-specific extractor when one
+	// matches the host, generic <head> parser otherwise
+	md, err := i.extractors.Extract(ctx, page)
+	if err != nil {
+		return model.UrlMetadata{}, crawlResponse{StatusCode: resp.StatusCode, RedirectChain: resp.RedirectChain}, err
+	}
+
+	if i.assets != nil {
+		md = i.assets.cache(ctx, i.client, md)
+	}
 
-	// build final strongly-typed metadata struct
-	return buildMetadata(head, baseURL), nil
+	return md, resp, nil
 }
 
-// fetchHeadHTML fetches only the HEAD portion of the HTML (limited bytes) for faster crawling.
-// Many sites place metadata within the first ~100KB of HTML.
-func (i urlMetadataCrawler) fetchHeadHTML(ctx context.Context, rawURL string) ([]byte, *url.URL, error) {
+// fetchPage fetches only the beginning of the document (limited bytes) for faster crawling.
+// Many sites place metadata within the first ~100KB of HTML, and file formats keep their headers first.
+func (i urlMetadataCrawler) fetchPage(ctx context.Context, rawURL string, validators cacheValidators) (extractor.Page, crawlResponse, error) {
 	req, err := http.NewRequestWithContext(ctx, "GET", rawURL, nil)
 	if err != nil {
-		return nil, nil, err
+		return extractor.Page{}, crawlResponse{}, err
 	}
 
 	// Pretend to be a normal desktop Chrome browser
@@ -150,155 +215,69 @@ func (i urlMetadataCrawler) fetchHeadHTML(ctx context.Context, rawURL string) ([
 
 	req.Header.Set("User-Agent", defaultUA)
 
+	// Conditional request: let the destination answer 304 when the page did not change
+	if validators.ETag != "" {
+		req.Header.Set("If-None-Match", validators.ETag)
+	}
+	if validators.LastModified != "" {
+		req.Header.Set("If-Modified-Since", validators.LastModified)
+	}
+
 	resp, err := i.client.Do(req)
 	if err != nil {
-		return nil, nil, err
+		return extractor.Page{}, crawlResponse{}, err
 	}
 	defer resp.Body.Close()
 
+	answered := crawlResponse{StatusCode: resp.StatusCode, RedirectChain: redirectChain(resp)}
+
+	if resp.StatusCode == http.StatusNotModified {
+		return extractor.Page{}, answered, errNotModified
+	}
+
 	// Reject non-success HTTP codes
 	if resp.StatusCode < 200 || resp.StatusCode >= 400 {
-		return nil, nil, fmt.Errorf("unexpected status code %d from %s", resp.StatusCode, rawURL)
+		return extractor.Page{}, answered, httpStatusError{StatusCode: resp.StatusCode, URL: rawURL}
 	}
 
-	// Read-only the first N bytes, usually enough to include <head>
-	const headReadLimit = 256 * 1024
-	limited := io.LimitReader(resp.Body, headReadLimit)
-
-	// Attempt charset decoding when possible (UTF-8, windows-1258, etc.)
+	// Only download documents an extractor can preview
 	contentType := resp.Header.Get("Content-Type")
-	reader, err := charset.NewReader(limited, contentType)
-	if err != nil {
-		// Fallback: read raw bytes if charset conversion fails
-		limited = io.LimitReader(resp.Body, headReadLimit)
-		reader = limited
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	kind := extractor.KindOf(mediaType)
+	if kind == "" {
+		return extractor.Page{}, answered, fmt.Errorf("%w: %s from %s", extractor.ErrUnsupportedContentType, mediaType, rawURL)
 	}
 
-	body, err := io.ReadAll(reader)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	baseURL, _ := url.Parse(resp.Request.URL.String())
-	return body, baseURL, nil
-}
-
-// headMeta is internal model storing extracted <head> metadata
-type headMeta struct {
-	Title       string
-	Description string
-	OgTitle     string
-	OgDesc      string
-	OgImage     string
-	Favicon     string
-}
-
-func parseHeadMetadata(body []byte) headMeta {
-	z := html.NewTokenizer(bytes.NewReader(body))
-	h := headMeta{}
-	var inTitle bool
-
-	for {
-		tt := z.Next()
-		if tt == html.ErrorToken {
-			break
-		}
+	// Read-only the first N bytes, usually enough to include <head> or the file headers
+	const headReadLimit = 256 * 1024
+	var reader io.Reader = io.LimitReader(resp.Body, headReadLimit)
 
-		tok := z.Token()
-		switch tt {
-
-		case html.StartTagToken, html.SelfClosingTagToken:
-			if tok.Data == "title" {
-				inTitle = true
-			}
-
-			if tok.Data == "meta" {
-				parseMetaTag(tok, &h)
-			}
-
-			if tok.Data == "link" {
-				parseFavicon(tok, &h)
-			}
-
-		case html.TextToken:
-			// Capture <title>text</title>
-			if inTitle && h.Title == "" {
-				h.Title = strings.TrimSpace(tok.Data)
-			}
-
-		case html.EndTagToken:
-			if tok.Data == "title" {
-				inTitle = false
-			}
-			// Stop parsing once </head> is reached; body is irrelevant
-			if tok.Data == "head" {
-				return h
-			}
+	// Attempt charset decoding of HTML when possible (UTF-8, windows-1258, etc.), binary files are kept raw
+	if kind == model.MetadataKindHTML {
+		if decoded, err := charset.NewReader(reader, contentType); err == nil {
+			reader = decoded
 		}
 	}
 
-	return h
-}
-
-func buildMetadata(h headMeta, base *url.URL) model.UrlMetadata {
-	return model.UrlMetadata{
-		FinalURL:    base.String(),
-		Title:       firstNonEmpty(h.OgTitle, h.Title),
-		Description: firstNonEmpty(h.OgDesc, h.Description),
-		Image:       resolveURL(h.OgImage, base),
-		Favicon:     resolveURL(h.Favicon, base),
-	}
-}
-
-// Extract <meta> tags such as:
-//
-//	<meta property="og:title" content="...">
-//	<meta name="description" content="...">
-func parseMetaTag(tok html.Token, h *headMeta) {
-	var name, prop, content string
-	for _, a := range tok.Attr {
-		switch strings.ToLower(a.Key) {
-		case "name":
-			name = strings.ToLower(a.Val)
-		case "property":
-			prop = strings.ToLower(a.Val)
-		case "content":
-			content = a.Val
-		}
+	body, err := io.ReadAll(reader)
+	if err != nil {
+		return extractor.Page{}, answered, err
 	}
 
-	if prop == "og:title" {
-		h.OgTitle = content
-	}
-	if prop == "og:description" {
-		h.OgDesc = content
-	}
-	if prop == "og:image" {
-		h.OgImage = content
-	}
-	if name == "description" {
-		h.Description = content
+	baseURL, _ := url.Parse(resp.Request.URL.String())
+	page := extractor.Page{
+		URL:           baseURL,
+		Body:          body,
+		ContentType:   mediaType,
+		ContentLength: max(resp.ContentLength, 0), // -1 when unknown
 	}
-}
 
-// Extract favicon from:
-//
-//	<link rel="icon" href="...">
-//	<link rel="shortcut icon" href="...">
-func parseFavicon(tok html.Token, h *headMeta) {
-	var rel, href string
-	for _, a := range tok.Attr {
-		switch strings.ToLower(a.Key) {
-		case "rel":
-			rel = a.Val
-		case "href":
-			href = a.Val
-		}
+	answered.Validators = cacheValidators{
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
 	}
 
-	if rel == "icon" || rel == "shortcut icon" {
-		h.Favicon = href
-	}
+	return page, answered, nil
 }
 
 // Upgrade http:// links to https:// for better security and reliability
@@ -308,33 +287,3 @@ func upgradeToHTTPS(url string) string {
 	}
 	return url
 }
-
-// Utility selecting the first non-empty string
-func firstNonEmpty(values ...string) string {
-	for _, v := range values {
-		if strings.TrimSpace(v) != "" {
-			return v
-		}
-	}
-	return ""
-}
-
-// Resolve relative URLs against base:
-//
-//	"/image.jpg" → "https://example.com/image.jpg"
-func resolveURL(resource string, base *url.URL) string {
-	if resource == "" {
-		return ""
-	}
-
-	u, err := url.Parse(resource)
-	if err != nil {
-		return resource
-	}
-
-	if u.IsAbs() {
-		return u.String()
-	}
-
-	return base.ResolveReference(u).String()
-}
@@ -4,13 +4,16 @@ import (
 	"context"
 	"database/sql"
 	"errors"
-	"net/url"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
 	"testing"
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
-	"github.com/google/go-cmp/cmp"
 	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/id"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/safehttp"
 	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
 	"github.com/kytruongdev/sturl/url-shortener-service/internal/repository"
 	"github.com/kytruongdev/sturl/url-shortener-service/internal/repository/outgoingevent"
@@ -27,6 +30,13 @@ func TestCrawlURLMetadata(t *testing.T) {
 		mockUpdateErr               error
 		mockInsertOutgoingEventWant model.OutgoingEvent
 		mockInsertOutgoingEventErr  error
+		pageStatus                  int
+		pageContentType             string
+		wantMetadata                *model.UrlMetadata
+		wantNotModified             bool
+		wantCrawlStatus             model.CrawlStatus
+		wantCrawlErrCode            model.CrawlErrorCode
+		wantHealthCheck             *model.HealthCheck
 		wantErr                     error
 	}{
 		"success - crawl and save metadata": {
@@ -46,9 +56,86 @@ func TestCrawlURLMetadata(t *testing.T) {
 				Status: model.OutgoingEventStatusPending,
 			},
 			mockInsertOutgoingEventErr: nil,
+			wantCrawlStatus:            model.CrawlStatusCrawled,
+			wantHealthCheck:            &model.HealthCheck{HTTPStatus: http.StatusOK},
 			wantErr:                    nil,
 		},
 
+		"success - unchanged page keeps metadata": {
+			shortCode: "abc123",
+			mockGetByShortCodeWant: model.ShortUrl{
+				ShortCode:   "abc123",
+				OriginalURL: "https://example.com",
+				Status:      model.ShortUrlStatusActive,
+				Metadata:    model.UrlMetadata{Title: "Example Domain"},
+				Crawl:       model.CrawlState{Status: model.CrawlStatusCrawled, ETag: `"v1"`},
+			},
+			wantNotModified: true,
+			wantCrawlStatus: model.CrawlStatusCrawled,
+			wantHealthCheck: &model.HealthCheck{HTTPStatus: http.StatusNotModified},
+			wantErr:         nil,
+		},
+
+		"success - pdf destination": {
+			shortCode: "abc123",
+			mockGetByShortCodeWant: model.ShortUrl{
+				ShortCode:   "abc123",
+				OriginalURL: "https://example.com/files/report.pdf",
+				Status:      model.ShortUrlStatusActive,
+			},
+			mockInsertOutgoingEventWant: model.OutgoingEvent{ID: 123},
+			pageContentType:             "application/pdf",
+			wantMetadata: &model.UrlMetadata{
+				FinalURL:      "https://example.com/files/report.pdf",
+				Title:         "report.pdf",
+				Extractor:     "pdf",
+				Kind:          model.MetadataKindPDF,
+				ContentType:   "application/pdf",
+				ContentLength: 9,
+			},
+			wantCrawlStatus: model.CrawlStatusCrawled,
+			wantHealthCheck: &model.HealthCheck{HTTPStatus: http.StatusOK},
+		},
+
+		"fail - unsupported content type is recorded": {
+			shortCode: "abc123",
+			mockGetByShortCodeWant: model.ShortUrl{
+				ShortCode:   "abc123",
+				OriginalURL: "https://example.com/archive.zip",
+				Status:      model.ShortUrlStatusActive,
+			},
+			pageContentType:  "application/zip",
+			wantCrawlStatus:  model.CrawlStatusFailed,
+			wantCrawlErrCode: model.CrawlErrorNonHTML,
+			wantHealthCheck:  &model.HealthCheck{HTTPStatus: http.StatusOK},
+			wantErr:          errors.New("unsupported content type"),
+		},
+
+		"success - inactive link is skipped": {
+			shortCode: "abc123",
+			mockGetByShortCodeWant: model.ShortUrl{
+				ShortCode:   "abc123",
+				OriginalURL: "https://example.com",
+				Status:      model.ShortUrlStatusInactive,
+			},
+			wantCrawlStatus: model.CrawlStatusSkipped,
+			wantErr:         nil,
+		},
+
+		"fail - crawl error is recorded": {
+			shortCode: "abc123",
+			mockGetByShortCodeWant: model.ShortUrl{
+				ShortCode:   "abc123",
+				OriginalURL: "https://example.com",
+				Status:      model.ShortUrlStatusActive,
+			},
+			pageStatus:       http.StatusServiceUnavailable,
+			wantCrawlStatus:  model.CrawlStatusFailed,
+			wantCrawlErrCode: model.CrawlErrorHTTP5xx,
+			wantHealthCheck:  &model.HealthCheck{HTTPStatus: http.StatusServiceUnavailable},
+			wantErr:          errors.New("unexpected status code 503"),
+		},
+
 		"fail - short code not found": {
 			shortCode:             "notfound",
 			mockGetByShortCodeErr: sql.ErrNoRows,
@@ -70,6 +157,7 @@ func TestCrawlURLMetadata(t *testing.T) {
 			},
 			mockGetByShortCodeErr: nil,
 			mockUpdateErr:         errors.New("update failed"),
+			wantHealthCheck:       &model.HealthCheck{HTTPStatus: http.StatusOK},
 			wantErr:               errors.New("update failed"),
 		},
 
@@ -83,6 +171,8 @@ func TestCrawlURLMetadata(t *testing.T) {
 			mockGetByShortCodeErr:      nil,
 			mockUpdateErr:              nil,
 			mockInsertOutgoingEventErr: errors.New("outbox insert failed"),
+			wantCrawlStatus:            model.CrawlStatusCrawled,
+			wantHealthCheck:            &model.HealthCheck{HTTPStatus: http.StatusOK},
 			wantErr:                    errors.New("outbox insert failed"),
 		},
 	}
@@ -107,6 +197,16 @@ func TestCrawlURLMetadata(t *testing.T) {
 					Return(tc.mockUpdateErr)
 			}
 
+			if tc.wantCrawlStatus != "" {
+				mockShort.On("RecordCrawlAttempt", mock.Anything, tc.shortCode, tc.wantCrawlStatus, tc.wantCrawlErrCode).
+					Return(nil)
+			}
+
+			if tc.mockGetByShortCodeErr == nil {
+				mockShort.On("RecordHealthCheck", mock.Anything, tc.shortCode, mock.Anything, defaultBrokenLinkThreshold).
+					Return(model.LinkHealth{}, nil)
+			}
+
 			// Mock Outbox repo
 			mockOutbox := new(outgoingevent.MockRepository)
 			if tc.mockGetByShortCodeErr == nil && tc.mockUpdateErr == nil {
@@ -125,9 +225,48 @@ func TestCrawlURLMetadata(t *testing.T) {
 					return fn(ctx, mockReg)
 				})
 
-			i := New(mockReg)
+			i := impl{
+				repo:                mockReg,
+				brokenLinkThreshold: defaultBrokenLinkThreshold,
+				crawler: newTestCrawler(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					if tc.pageStatus != 0 {
+						w.WriteHeader(tc.pageStatus)
+						return
+					}
+					if r.Header.Get("If-None-Match") == `"v1"` {
+						w.WriteHeader(http.StatusNotModified)
+						return
+					}
+					w.Header().Set("ETag", `"v1"`)
+					if tc.pageContentType != "" {
+						w.Header().Set("Content-Type", tc.pageContentType)
+						_, _ = w.Write([]byte("%PDF-1.7\n"))
+						return
+					}
+					w.Header().Set("Content-Type", "text/html; charset=utf-8")
+					_, _ = w.Write([]byte(`<html><head><title>Example Domain</title></head><body></body></html>`))
+				})),
+			}
 
-			_, err := i.CrawlURLMetadata(ctx, tc.shortCode)
+			actual, err := i.CrawlURLMetadata(ctx, tc.shortCode)
+
+			if tc.wantCrawlStatus != "" {
+				mockShort.AssertCalled(t, "RecordCrawlAttempt", mock.Anything, tc.shortCode, tc.wantCrawlStatus, tc.wantCrawlErrCode)
+			}
+
+			if tc.wantHealthCheck != nil {
+				mockShort.AssertCalled(t, "RecordHealthCheck", mock.Anything, tc.shortCode, *tc.wantHealthCheck, defaultBrokenLinkThreshold)
+			} else {
+				mockShort.AssertNotCalled(t, "RecordHealthCheck", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			}
+
+			if tc.wantNotModified {
+				mockShort.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+				mockOutbox.AssertNotCalled(t, "Insert", mock.Anything, mock.Anything)
+				require.NoError(t, err)
+				require.Equal(t, tc.mockGetByShortCodeWant.Metadata, actual)
+				return
+			}
 
 			if tc.wantErr != nil {
 				require.Error(t, err)
@@ -136,10 +275,36 @@ func TestCrawlURLMetadata(t *testing.T) {
 			}
 
 			require.NoError(t, err)
+			if tc.wantMetadata != nil {
+				require.Equal(t, *tc.wantMetadata, actual)
+			}
 		})
 	}
 }
 
+// newTestCrawler returns a crawler sending every request to a local TLS server running h,
+// whatever the requested host is. The address guard stays in place, with loopback allowed.
+func newTestCrawler(t *testing.T, h http.Handler) urlMetadataCrawler {
+	return newURLMetadataCrawler(testServerOptions(t, h)...)
+}
+
+// testServerOptions returns client options sending every request to a local TLS server running h
+func testServerOptions(t *testing.T, h http.Handler) []safehttp.Option {
+	srv := httptest.NewTLSServer(h)
+	t.Cleanup(srv.Close)
+
+	return []safehttp.Option{
+		safehttp.WithAllowedPrefixes(netip.MustParsePrefix("127.0.0.0/8")),
+		safehttp.WithTransport(func(transport *http.Transport) {
+			transport.TLSClientConfig = srv.Client().Transport.(*http.Transport).TLSClientConfig
+			dial := transport.DialContext
+			transport.DialContext = func(ctx context.Context, network, _ string) (net.Conn, error) {
+				return dial(ctx, network, srv.Listener.Addr().String())
+			}
+		}),
+	}
+}
+
 func TestUpgradeToHTTPS(t *testing.T) {
 	tcs := map[string]struct {
 		input string
@@ -170,278 +335,3 @@ func TestUpgradeToHTTPS(t *testing.T) {
 		})
 	}
 }
-
-func TestFirstNonEmpty(t *testing.T) {
-	tcs := map[string]struct {
-		values []string
-		want   string
-	}{
-		"first value is non-empty": {
-			values: []string{"first", "second", "third"},
-			want:   "first",
-		},
-		"first value is empty, second is not": {
-			values: []string{"", "second", "third"},
-			want:   "second",
-		},
-		"all empty": {
-			values: []string{"", "", ""},
-			want:   "",
-		},
-		"only whitespace values": {
-			values: []string{"  ", "\t", "\n"},
-			want:   "",
-		},
-		"mixed empty and whitespace": {
-			values: []string{"", "  ", "valid"},
-			want:   "valid",
-		},
-		"no values": {
-			values: []string{},
-			want:   "",
-		},
-	}
-
-	for name, tc := range tcs {
-		t.Run(name, func(t *testing.T) {
-			actual := firstNonEmpty(tc.values...)
-			require.Equal(t, tc.want, actual)
-		})
-	}
-}
-
-func TestResolveURL(t *testing.T) {
-	tcs := map[string]struct {
-		resource string
-		baseURL  string
-		want     string
-	}{
-		"absolute URL": {
-			resource: "https://cdn.example.com/image.jpg",
-			baseURL:  "https://example.com",
-			want:     "https://cdn.example.com/image.jpg",
-		},
-		"relative path": {
-			resource: "/image.jpg",
-			baseURL:  "https://example.com",
-			want:     "https://example.com/image.jpg",
-		},
-		"relative path with subdirectory": {
-			resource: "/assets/image.jpg",
-			baseURL:  "https://example.com/page",
-			want:     "https://example.com/assets/image.jpg",
-		},
-		"relative without leading slash": {
-			resource: "image.jpg",
-			baseURL:  "https://example.com/page/",
-			want:     "https://example.com/page/image.jpg",
-		},
-		"empty resource": {
-			resource: "",
-			baseURL:  "https://example.com",
-			want:     "",
-		},
-		"protocol-relative URL": {
-			resource: "//cdn.example.com/image.jpg",
-			baseURL:  "https://example.com",
-			want:     "https://cdn.example.com/image.jpg",
-		},
-	}
-
-	for name, tc := range tcs {
-		t.Run(name, func(t *testing.T) {
-			// Parse base URL
-			base, err := parseURL(tc.baseURL)
-			require.NoError(t, err)
-
-			actual := resolveURL(tc.resource, base)
-			require.Equal(t, tc.want, actual)
-		})
-	}
-}
-
-// Helper function for tests
-func parseURL(rawURL string) (*url.URL, error) {
-	return url.Parse(rawURL)
-}
-
-func TestParseHeadMetadata(t *testing.T) {
-	tcs := map[string]struct {
-		htmlBody string
-		want     headMeta
-	}{
-		"complete metadata": {
-			htmlBody: `
-<!DOCTYPE html>
-<html>
-<head>
-	<title>Example Title</title>
-	<meta name="description" content="Example description">
-	<meta property="og:title" content="OG Title">
-	<meta property="og:description" content="OG Description">
-	<meta property="og:image" content="https://example.com/image.jpg">
-	<link rel="icon" href="/favicon.ico">
-</head>
-<body>Content</body>
-</html>`,
-			want: headMeta{
-				Title:       "Example Title",
-				Description: "Example description",
-				OgTitle:     "OG Title",
-				OgDesc:      "OG Description",
-				OgImage:     "https://example.com/image.jpg",
-				Favicon:     "/favicon.ico",
-			},
-		},
-		"minimal metadata": {
-			htmlBody: `
-<!DOCTYPE html>
-<html>
-<head>
-	<title>Simple Title</title>
-</head>
-<body>Content</body>
-</html>`,
-			want: headMeta{
-				Title:       "Simple Title",
-				Description: "",
-				OgTitle:     "",
-				OgDesc:      "",
-				OgImage:     "",
-				Favicon:     "",
-			},
-		},
-		"og tags only": {
-			htmlBody: `
-<!DOCTYPE html>
-<html>
-<head>
-	<meta property="og:title" content="Only OG">
-	<meta property="og:description" content="Only OG Desc">
-</head>
-<body>Content</body>
-</html>`,
-			want: headMeta{
-				Title:       "",
-				Description: "",
-				OgTitle:     "Only OG",
-				OgDesc:      "Only OG Desc",
-				OgImage:     "",
-				Favicon:     "",
-			},
-		},
-		"shortcut icon": {
-			htmlBody: `
-<!DOCTYPE html>
-<html>
-<head>
-	<link rel="shortcut icon" href="/favicon.png">
-</head>
-<body>Content</body>
-</html>`,
-			want: headMeta{
-				Title:       "",
-				Description: "",
-				OgTitle:     "",
-				OgDesc:      "",
-				OgImage:     "",
-				Favicon:     "/favicon.png",
-			},
-		},
-		"empty html": {
-			htmlBody: ``,
-			want: headMeta{
-				Title:       "",
-				Description: "",
-				OgTitle:     "",
-				OgDesc:      "",
-				OgImage:     "",
-				Favicon:     "",
-			},
-		},
-	}
-
-	for name, tc := range tcs {
-		t.Run(name, func(t *testing.T) {
-			actual := parseHeadMetadata([]byte(tc.htmlBody))
-			require.True(t,
-				cmp.Equal(tc.want, actual),
-				"diff: %v",
-				cmp.Diff(tc.want, actual),
-			)
-		})
-	}
-}
-
-func TestBuildMetadata(t *testing.T) {
-	tcs := map[string]struct {
-		head    headMeta
-		baseURL string
-		want    model.UrlMetadata
-	}{
-		"prefers og tags over regular tags": {
-			head: headMeta{
-				Title:       "Regular Title",
-				Description: "Regular Description",
-				OgTitle:     "OG Title",
-				OgDesc:      "OG Description",
-				OgImage:     "/image.jpg",
-				Favicon:     "/favicon.ico",
-			},
-			baseURL: "https://example.com",
-			want: model.UrlMetadata{
-				FinalURL:    "https://example.com",
-				Title:       "OG Title",
-				Description: "OG Description",
-				Image:       "https://example.com/image.jpg",
-				Favicon:     "https://example.com/favicon.ico",
-			},
-		},
-		"fallback to regular tags when og tags empty": {
-			head: headMeta{
-				Title:       "Regular Title",
-				Description: "Regular Description",
-				OgTitle:     "",
-				OgDesc:      "",
-				OgImage:     "",
-				Favicon:     "/favicon.ico",
-			},
-			baseURL: "https://example.com",
-			want: model.UrlMetadata{
-				FinalURL:    "https://example.com",
-				Title:       "Regular Title",
-				Description: "Regular Description",
-				Image:       "",
-				Favicon:     "https://example.com/favicon.ico",
-			},
-		},
-		"absolute URLs preserved": {
-			head: headMeta{
-				OgImage: "https://cdn.example.com/image.jpg",
-				Favicon: "https://cdn.example.com/favicon.ico",
-			},
-			baseURL: "https://example.com",
-			want: model.UrlMetadata{
-				FinalURL:    "https://example.com",
-				Title:       "",
-				Description: "",
-				Image:       "https://cdn.example.com/image.jpg",
-				Favicon:     "https://cdn.example.com/favicon.ico",
-			},
-		},
-	}
-
-	for name, tc := range tcs {
-		t.Run(name, func(t *testing.T) {
-			base, err := parseURL(tc.baseURL)
-			require.NoError(t, err)
-
-			actual := buildMetadata(tc.head, base)
-			require.True(t,
-				cmp.Equal(tc.want, actual),
-				"diff: %v",
-				cmp.Diff(tc.want, actual),
-			)
-		})
-	}
-}
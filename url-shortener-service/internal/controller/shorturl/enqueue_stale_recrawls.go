@@ -0,0 +1,38 @@
+package shorturl
+
+import (
+	"context"
+	"time"
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/monitoring"
+)
+
+// EnqueueStaleRecrawls enqueues metadata crawls of up to limit active links whose metadata is older than maxAge,
+// most clicked links first. It returns the number of enqueued crawls.
+func (i impl) EnqueueStaleRecrawls(ctx context.Context, maxAge time.Duration, limit int) (int, error) {
+	var err error
+	ctx, span := monitoring.Start(ctx, "ShortURLController.EnqueueStaleRecrawls")
+	defer monitoring.End(span, &err)
+
+	l := monitoring.Log(ctx)
+
+	stale, err := i.repo.ShortUrl().ListStaleForRecrawl(ctx, time.Now().Add(-maxAge), limit)
+	if err != nil {
+		l.Error().Err(err).Msg("[EnqueueStaleRecrawls] shortUrlRepo.ListStaleForRecrawl err")
+		return 0, err
+	}
+
+	var enqueued int
+	for _, su := range stale {
+		// One failing link must not block the rest of the batch, it is picked up again on the next run
+		if enqueueErr := i.enqueueCrawl(ctx, su); enqueueErr != nil {
+			l.Error().Err(enqueueErr).Str("short_code", su.ShortCode).Msg("[EnqueueStaleRecrawls] enqueueCrawl err")
+			continue
+		}
+		enqueued++
+	}
+
+	l.Info().Int("stale", len(stale)).Int("enqueued", enqueued).Msg("[EnqueueStaleRecrawls] completed")
+
+	return enqueued, nil
+}
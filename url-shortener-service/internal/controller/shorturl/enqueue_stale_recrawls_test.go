@@ -0,0 +1,96 @@
+package shorturl
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/id"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/pkg/eventschema"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/repository"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/repository/outgoingevent"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/repository/shorturl"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnqueueStaleRecrawls(t *testing.T) {
+	tcs := map[string]struct {
+		mockListWant        []model.ShortUrl
+		mockListErr         error
+		mockInsertOutboxErr map[string]error
+		want                int
+		wantErr             error
+	}{
+		"success - all stale links enqueued": {
+			mockListWant: []model.ShortUrl{
+				{ShortCode: "hot", OriginalURL: "https://google.com", Status: model.ShortUrlStatusActive},
+				{ShortCode: "warm", OriginalURL: "https://github.com", Status: model.ShortUrlStatusActive},
+			},
+			want: 2,
+		},
+		"success - failing link does not block the batch": {
+			mockListWant: []model.ShortUrl{
+				{ShortCode: "hot", OriginalURL: "https://google.com", Status: model.ShortUrlStatusActive},
+				{ShortCode: "warm", OriginalURL: "https://github.com", Status: model.ShortUrlStatusActive},
+			},
+			mockInsertOutboxErr: map[string]error{"hot": errors.New("outbox insert failed")},
+			want:                1,
+		},
+		"success - nothing stale": {
+			want: 0,
+		},
+		"fail - list stale links fails": {
+			mockListErr: errors.New("db error"),
+			wantErr:     errors.New("db error"),
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			newIDFunc = func() int64 {
+				return 123
+			}
+			defer func() { newIDFunc = id.New }()
+
+			mockShort := new(shorturl.MockRepository)
+			mockShort.On("ListStaleForRecrawl", mock.Anything, mock.MatchedBy(func(before time.Time) bool {
+				return time.Since(before) >= 24*time.Hour
+			}), 50).Return(tc.mockListWant, tc.mockListErr)
+			mockShort.On("MarkCrawlRequested", mock.Anything, mock.Anything).Return(nil)
+
+			mockOutbox := new(outgoingevent.MockRepository)
+			for _, su := range tc.mockListWant {
+				code := su.ShortCode
+				mockOutbox.On("Insert", mock.Anything, mock.MatchedBy(func(e model.OutgoingEvent) bool {
+					var data model.MetadataRequestedV1
+					return eventschema.Decode(e.Topic, e.Payload.Data, &data) == nil && data.ShortCode == code
+				})).Return(model.OutgoingEvent{}, tc.mockInsertOutboxErr[code])
+			}
+
+			mockReg := new(repository.MockRegistry)
+			mockReg.On("ShortUrl").Return(mockShort)
+			mockReg.On("OutgoingEvent").Return(mockOutbox)
+			mockReg.On("DoInTx", mock.Anything, mock.Anything, mock.Anything).
+				Return(func(ctx context.Context, _ backoff.BackOff, fn func(context.Context, repository.Registry) error) error {
+					return fn(ctx, mockReg)
+				})
+
+			i := New(mockReg)
+			actual, err := i.EnqueueStaleRecrawls(ctx, 24*time.Hour, 50)
+
+			if tc.wantErr != nil {
+				require.ErrorContains(t, err, tc.wantErr.Error())
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.want, actual)
+		})
+	}
+}
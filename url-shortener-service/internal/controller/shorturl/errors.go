@@ -7,4 +7,10 @@ var (
 	ErrInactiveURL = errors.New("URL is inactive")
 	// ErrURLNotfound means URL not found
 	ErrURLNotfound = errors.New("URL not found")
+	// ErrRefreshRateLimited means the URL metadata was already refreshed recently
+	ErrRefreshRateLimited = errors.New("URL metadata refresh rate limited")
+	// ErrDestinationBlocked means the original URL matches the destination blocklist
+	ErrDestinationBlocked = errors.New("destination blocked")
+	// ErrAssetNotFound means no cached asset matches the hash
+	ErrAssetNotFound = errors.New("asset not found")
 )
@@ -0,0 +1,56 @@
+package shorturl
+
+import (
+	"context"
+	"errors"
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/monitoring"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/pkg/policy"
+)
+
+// FlagBlockedDestinations walks every active link not flagged yet, batchSize links at a time, and flags
+// the ones whose destination matches the current destination policy. It returns the number of flagged links.
+func (i impl) FlagBlockedDestinations(ctx context.Context, batchSize int) (int, error) {
+	var err error
+	ctx, span := monitoring.Start(ctx, "ShortURLController.FlagBlockedDestinations")
+	defer monitoring.End(span, &err)
+
+	l := monitoring.Log(ctx)
+
+	var flagged, checked int
+	for after := ""; ; {
+		var links []model.ShortUrl
+		links, err = i.repo.ShortUrl().ListUnflagged(ctx, after, batchSize)
+		if err != nil {
+			l.Error().Err(err).Msg("[FlagBlockedDestinations] shortUrlRepo.ListUnflagged err")
+			return flagged, err
+		}
+
+		for _, su := range links {
+			var blockedErr policy.BlockedError
+			if !errors.As(i.policy.Check(su.OriginalURL), &blockedErr) {
+				continue
+			}
+
+			// One failing link must not stop the sweep, it is picked up again on the next run
+			if flagErr := i.repo.ShortUrl().Flag(ctx, su.ShortCode, blockedErr.Reason()); flagErr != nil {
+				l.Error().Err(flagErr).Str("short_code", su.ShortCode).Msg("[FlagBlockedDestinations] shortUrlRepo.Flag err")
+				continue
+			}
+
+			l.Warn().Str("short_code", su.ShortCode).Str("reason", blockedErr.Reason()).Msg("[FlagBlockedDestinations] link flagged")
+			flagged++
+		}
+
+		checked += len(links)
+		if len(links) < batchSize {
+			break
+		}
+		after = links[len(links)-1].ShortCode
+	}
+
+	l.Info().Int("checked", checked).Int("flagged", flagged).Msg("[FlagBlockedDestinations] completed")
+
+	return flagged, nil
+}
@@ -0,0 +1,73 @@
+package shorturl
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/pkg/policy"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/repository"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/repository/shorturl"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlagBlockedDestinations(t *testing.T) {
+	firstPage := []model.ShortUrl{
+		{ShortCode: "aaa", OriginalURL: "https://google.com"},
+		{ShortCode: "bbb", OriginalURL: "https://login.phishing.example/"},
+	}
+	secondPage := []model.ShortUrl{
+		{ShortCode: "ccc", OriginalURL: "https://bit.ly/abc"},
+	}
+
+	tcs := map[string]struct {
+		mockFlagErr error
+		mockListErr error
+		wantFlagged int
+		wantErr     error
+	}{
+		"success - walks every page": {
+			wantFlagged: 2,
+		},
+		"success - flag failure is skipped": {
+			mockFlagErr: errors.New("database error"),
+			wantFlagged: 0,
+		},
+		"fail - list error": {
+			mockListErr: errors.New("database error"),
+			wantErr:     errors.New("database error"),
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			mockShort := new(shorturl.MockRepository)
+			mockShort.On("ListUnflagged", mock.Anything, "", 2).Return(firstPage, tc.mockListErr)
+			mockShort.On("ListUnflagged", mock.Anything, "bbb", 2).Return(secondPage, nil)
+			mockShort.On("Flag", mock.Anything, "bbb", "DOMAIN phishing.example: reported phishing").Return(tc.mockFlagErr)
+			mockShort.On("Flag", mock.Anything, "ccc", "SHORTENER bit.ly").Return(tc.mockFlagErr)
+
+			mockReg := new(repository.MockRegistry)
+			mockReg.On("ShortUrl").Return(mockShort)
+
+			engine := policy.NewEngine(policy.SourceFunc(func(context.Context) ([]model.BlockRule, error) {
+				return []model.BlockRule{{Kind: model.BlockRuleKindDomain, Pattern: "phishing.example", Reason: "reported phishing"}}, nil
+			}))
+			require.NoError(t, engine.Reload(ctx))
+
+			actual, err := New(mockReg, WithPolicy(engine)).FlagBlockedDestinations(ctx, 2)
+			if tc.wantErr != nil {
+				require.EqualError(t, err, tc.wantErr.Error())
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.wantFlagged, actual)
+			mockShort.AssertNotCalled(t, "Flag", mock.Anything, "aaa", mock.Anything)
+		})
+	}
+}
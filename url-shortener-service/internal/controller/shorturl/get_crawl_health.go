@@ -0,0 +1,25 @@
+package shorturl
+
+import (
+	"context"
+	"strings"
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/monitoring"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+)
+
+// GetCrawlHealth returns metadata crawl stats per destination domain.
+// An empty domain returns the stats of every domain.
+func (i impl) GetCrawlHealth(ctx context.Context, domain string) ([]model.CrawlDomainStats, error) {
+	var err error
+	ctx, span := monitoring.Start(ctx, "ShortURLController.GetCrawlHealth")
+	defer monitoring.End(span, &err)
+
+	rs, err := i.repo.ShortUrl().GetCrawlStatsByDomain(ctx, strings.TrimSpace(domain))
+	if err != nil {
+		monitoring.Log(ctx).Error().Err(err).Msg("[GetCrawlHealth] shortUrlRepo.GetCrawlStatsByDomain err")
+		return nil, err
+	}
+
+	return rs, nil
+}
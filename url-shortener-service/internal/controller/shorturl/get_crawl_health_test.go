@@ -0,0 +1,61 @@
+package shorturl
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/repository"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/repository/shorturl"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetCrawlHealth(t *testing.T) {
+	tcs := map[string]struct {
+		domain     string
+		repoDomain string
+		mockResult []model.CrawlDomainStats
+		mockErr    error
+		wantErr    error
+	}{
+		"success - all domains": {
+			mockResult: []model.CrawlDomainStats{
+				{Domain: "github.com", Crawled: 10, Failed: 2, LastCrawledAt: time.Now()},
+				{Domain: "google.com", Crawled: 3},
+			},
+		},
+		"success - domain is trimmed": {
+			domain:     " github.com ",
+			repoDomain: "github.com",
+			mockResult: []model.CrawlDomainStats{
+				{Domain: "github.com", Crawled: 10, Failed: 2},
+			},
+		},
+		"fail - repository error": {
+			mockErr: errors.New("database error"),
+			wantErr: errors.New("database error"),
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			mockShort := new(shorturl.MockRepository)
+			mockShort.On("GetCrawlStatsByDomain", mock.Anything, tc.repoDomain).Return(tc.mockResult, tc.mockErr)
+
+			mockReg := new(repository.MockRegistry)
+			mockReg.On("ShortUrl").Return(mockShort)
+
+			actual, err := New(mockReg).GetCrawlHealth(context.Background(), tc.domain)
+			if tc.wantErr != nil {
+				require.EqualError(t, err, tc.wantErr.Error())
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.mockResult, actual)
+		})
+	}
+}
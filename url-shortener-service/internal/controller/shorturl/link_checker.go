@@ -0,0 +1,61 @@
+package shorturl
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/safehttp"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+)
+
+// linkChecker checks that link destinations still answer
+type linkChecker struct {
+	client *http.Client
+}
+
+func newLinkChecker(opts ...safehttp.Option) linkChecker {
+	// Destinations are user-provided: never reach the internal network, and follow a few more redirects
+	// than the crawler as the chain itself is worth recording
+	return linkChecker{
+		client: safehttp.NewClient(append([]safehttp.Option{
+			safehttp.WithTimeout(10 * time.Second),
+			safehttp.WithMaxRedirects(10),
+		}, opts...)...),
+	}
+}
+
+// check requests the destination with HEAD, or GET for sites refusing HEAD, following redirects.
+// The body is never read.
+func (c linkChecker) check(ctx context.Context, rawURL string) model.HealthCheck {
+	check := c.request(ctx, http.MethodHead, rawURL)
+	if check.HTTPStatus == http.StatusMethodNotAllowed || check.HTTPStatus == http.StatusNotImplemented {
+		check = c.request(ctx, http.MethodGet, rawURL)
+	}
+	return check
+}
+
+func (c linkChecker) request(ctx context.Context, method, rawURL string) model.HealthCheck {
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, nil)
+	if err != nil {
+		return model.HealthCheck{}
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return model.HealthCheck{}
+	}
+	resp.Body.Close()
+
+	return model.HealthCheck{HTTPStatus: resp.StatusCode, RedirectChain: redirectChain(resp)}
+}
+
+// redirectChain returns the URLs followed after the original one to get resp, empty when there was no redirect
+func redirectChain(resp *http.Response) []string {
+	var chain []string
+	// Each request reached through a redirect keeps the response which caused it
+	for req := resp.Request; req != nil && req.Response != nil; req = req.Response.Request {
+		chain = append([]string{req.URL.String()}, chain...)
+	}
+	return chain
+}
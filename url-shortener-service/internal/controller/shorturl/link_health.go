@@ -0,0 +1,171 @@
+package shorturl
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/monitoring"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/repository"
+	pkgerrors "github.com/pkg/errors"
+)
+
+// defaultBrokenLinkThreshold is the number of failed checks in a row after which a link is BROKEN
+const defaultBrokenLinkThreshold = 3
+
+// CheckLinksHealthInput defines a link health check cycle
+type CheckLinksHealthInput struct {
+	MaxAge      time.Duration // links checked more recently than this are skipped
+	Limit       int           // links checked per cycle
+	Concurrency int           // hosts checked in parallel
+	HostDelay   time.Duration // delay between two requests to the same host
+}
+
+// CheckLinksHealth re-checks the destinations of up to inp.Limit active links last checked more than inp.MaxAge ago,
+// least recently checked first. Hosts are checked in parallel up to inp.Concurrency, but the links of a host
+// are checked one after the other, inp.HostDelay apart, so that no destination gets hammered.
+// It returns the number of recorded checks.
+func (i impl) CheckLinksHealth(ctx context.Context, inp CheckLinksHealthInput) (int, error) {
+	var err error
+	ctx, span := monitoring.Start(ctx, "ShortURLController.CheckLinksHealth")
+	defer monitoring.End(span, &err)
+
+	l := monitoring.Log(ctx)
+
+	due, err := i.repo.ShortUrl().ListDueForHealthCheck(ctx, time.Now().Add(-inp.MaxAge), inp.Limit)
+	if err != nil {
+		l.Error().Err(err).Msg("[CheckLinksHealth] shortUrlRepo.ListDueForHealthCheck err")
+		return 0, err
+	}
+
+	var hosts []string
+	byHost := map[string][]model.ShortUrl{}
+	for _, su := range due {
+		h := hostOf(su.OriginalURL)
+		if _, ok := byHost[h]; !ok {
+			hosts = append(hosts, h)
+		}
+		byHost[h] = append(byHost[h], su)
+	}
+
+	var (
+		checked atomic.Int64
+		wg      sync.WaitGroup
+		queue   = make(chan []model.ShortUrl)
+	)
+	for w := 0; w < max(inp.Concurrency, 1); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for links := range queue {
+				checked.Add(int64(i.checkHostLinks(ctx, links, inp.HostDelay)))
+			}
+		}()
+	}
+
+feed:
+	for _, h := range hosts {
+		select {
+		case queue <- byHost[h]:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(queue)
+	wg.Wait()
+
+	l.Info().Int("due", len(due)).Int("hosts", len(hosts)).Int64("checked", checked.Load()).Msg("[CheckLinksHealth] completed")
+
+	return int(checked.Load()), nil
+}
+
+// checkHostLinks checks links of a single host sequentially and returns the number of recorded checks
+func (i impl) checkHostLinks(ctx context.Context, links []model.ShortUrl, delay time.Duration) int {
+	var checked int
+	for n, su := range links {
+		if n > 0 {
+			select {
+			case <-ctx.Done():
+				return checked
+			case <-time.After(delay):
+			}
+		}
+
+		// One failing link must not block the rest of the batch, it is picked up again on the next run
+		if err := i.recordHealthCheck(ctx, su, i.linkChecker.check(ctx, su.OriginalURL)); err != nil {
+			monitoring.Log(ctx).Error().Err(err).Str("short_code", su.ShortCode).Msg("[CheckLinksHealth] recordHealthCheck err")
+			continue
+		}
+		checked++
+	}
+	return checked
+}
+
+// recordHealthCheck stores the outcome of a reachability check, and emits a link.broken event
+// along when the check breaks the link. It never records the event being consumed, if any: the check
+// commits whatever the outcome of the crawl, which must be retried on redelivery when it fails.
+func (i impl) recordHealthCheck(ctx context.Context, su model.ShortUrl, check model.HealthCheck) error {
+	return pkgerrors.WithStack(i.repo.DoInTx(repository.WithoutInboxEvent(ctx), nil, func(txCtx context.Context, txRepo repository.Registry) error {
+		health, err := txRepo.ShortUrl().RecordHealthCheck(txCtx, su.ShortCode, check, i.brokenLinkThreshold)
+		if err != nil {
+			return err
+		}
+
+		// Only the failure reaching the threshold emits, the next failures of a broken link do not
+		if health.Status != model.LinkHealthBroken || health.ConsecutiveFailures != i.brokenLinkThreshold {
+			return nil
+		}
+
+		event, err := newLinkBrokenEvent(txCtx, su, health)
+		if err != nil {
+			return err
+		}
+
+		_, err = txRepo.OutgoingEvent().Insert(txCtx, event)
+		return err
+	}))
+}
+
+// ListLinksByHealth returns up to limit active links of the given health status, with a short code after
+// afterShortCode.
+func (i impl) ListLinksByHealth(ctx context.Context, status model.LinkHealthStatus, afterShortCode string, limit int) ([]model.ShortUrl, error) {
+	var err error
+	ctx, span := monitoring.Start(ctx, "ShortURLController.ListLinksByHealth")
+	defer monitoring.End(span, &err)
+
+	links, err := i.repo.ShortUrl().ListByHealth(ctx, status, afterShortCode, limit)
+	if err != nil {
+		monitoring.Log(ctx).Error().Err(err).Msg("[ListLinksByHealth] shortUrlRepo.ListByHealth err")
+		return nil, err
+	}
+
+	return links, nil
+}
+
+func newLinkBrokenEvent(ctx context.Context, su model.ShortUrl, health model.LinkHealth) (model.OutgoingEvent, error) {
+	var lastOKAt string
+	if !health.LastOKAt.IsZero() {
+		lastOKAt = health.LastOKAt.UTC().Format(time.RFC3339)
+	}
+
+	return newOutgoingEvent(ctx, model.TopicLinkBrokenV1, su.ShortCode, model.LinkBrokenV1{
+		ShortCode:           su.ShortCode,
+		OriginalURL:         su.OriginalURL,
+		HTTPStatus:          health.HTTPStatus,
+		ConsecutiveFailures: health.ConsecutiveFailures,
+		LastOKAt:            lastOKAt,
+	})
+}
+
+// hostOf returns the lowercased host of a URL, the URL itself when it cannot be parsed
+func hostOf(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Hostname() == "" {
+		return rawURL
+	}
+	return strings.ToLower(u.Hostname())
+}
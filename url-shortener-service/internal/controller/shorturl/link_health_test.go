@@ -0,0 +1,243 @@
+package shorturl
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/id"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/pkg/eventschema"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/repository"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/repository/outgoingevent"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/repository/shorturl"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckLinksHealth(t *testing.T) {
+	tcs := map[string]struct {
+		mockListWant    []model.ShortUrl
+		mockListErr     error
+		mockRecordWant  map[string]model.LinkHealth
+		mockRecordErr   map[string]error
+		wantChecks      map[string]model.HealthCheck
+		wantBrokenEvent string
+		want            int
+		wantErr         error
+	}{
+		"success - due links checked": {
+			mockListWant: []model.ShortUrl{
+				{ShortCode: "ok", OriginalURL: "https://a.example.com/ok"},
+				{ShortCode: "moved", OriginalURL: "https://a.example.com/moved"},
+				{ShortCode: "nohead", OriginalURL: "https://b.example.com/no-head"},
+				{ShortCode: "gone", OriginalURL: "https://c.example.com/gone"},
+			},
+			wantChecks: map[string]model.HealthCheck{
+				"ok":     {HTTPStatus: http.StatusOK},
+				"moved":  {HTTPStatus: http.StatusOK, RedirectChain: []string{"https://a.example.com/ok"}},
+				"nohead": {HTTPStatus: http.StatusOK},
+				"gone":   {HTTPStatus: http.StatusNotFound},
+			},
+			want: 4,
+		},
+		"success - link reaching the threshold emits link broken event": {
+			mockListWant: []model.ShortUrl{
+				{ShortCode: "gone", OriginalURL: "https://c.example.com/gone"},
+			},
+			mockRecordWant: map[string]model.LinkHealth{
+				"gone": {Status: model.LinkHealthBroken, HTTPStatus: http.StatusNotFound, ConsecutiveFailures: 3},
+			},
+			wantChecks:      map[string]model.HealthCheck{"gone": {HTTPStatus: http.StatusNotFound}},
+			wantBrokenEvent: "gone",
+			want:            1,
+		},
+		"success - already broken link does not emit again": {
+			mockListWant: []model.ShortUrl{
+				{ShortCode: "gone", OriginalURL: "https://c.example.com/gone"},
+			},
+			mockRecordWant: map[string]model.LinkHealth{
+				"gone": {Status: model.LinkHealthBroken, HTTPStatus: http.StatusNotFound, ConsecutiveFailures: 4},
+			},
+			wantChecks: map[string]model.HealthCheck{"gone": {HTTPStatus: http.StatusNotFound}},
+			want:       1,
+		},
+		"success - failing link does not block the batch": {
+			mockListWant: []model.ShortUrl{
+				{ShortCode: "ok", OriginalURL: "https://a.example.com/ok"},
+				{ShortCode: "gone", OriginalURL: "https://c.example.com/gone"},
+			},
+			mockRecordErr: map[string]error{"ok": errors.New("db error")},
+			wantChecks: map[string]model.HealthCheck{
+				"ok":   {HTTPStatus: http.StatusOK},
+				"gone": {HTTPStatus: http.StatusNotFound},
+			},
+			want: 1,
+		},
+		"success - nothing due": {
+			want: 0,
+		},
+		"fail - list due links fails": {
+			mockListErr: errors.New("db error"),
+			wantErr:     errors.New("db error"),
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			newIDFunc = func() int64 {
+				return 123
+			}
+			defer func() { newIDFunc = id.New }()
+
+			mockShort := new(shorturl.MockRepository)
+			mockShort.On("ListDueForHealthCheck", mock.Anything, mock.MatchedBy(func(before time.Time) bool {
+				return time.Since(before) >= time.Hour
+			}), 50).Return(tc.mockListWant, tc.mockListErr)
+			for _, su := range tc.mockListWant {
+				mockShort.On("RecordHealthCheck", mock.Anything, su.ShortCode, mock.Anything, defaultBrokenLinkThreshold).
+					Return(tc.mockRecordWant[su.ShortCode], tc.mockRecordErr[su.ShortCode])
+			}
+
+			mockOutbox := new(outgoingevent.MockRepository)
+			mockOutbox.On("Insert", mock.Anything, mock.Anything).Return(model.OutgoingEvent{}, nil)
+
+			mockReg := new(repository.MockRegistry)
+			mockReg.On("ShortUrl").Return(mockShort)
+			mockReg.On("OutgoingEvent").Return(mockOutbox)
+			mockReg.On("DoInTx", mock.Anything, mock.Anything, mock.Anything).
+				Return(func(ctx context.Context, _ backoff.BackOff, fn func(context.Context, repository.Registry) error) error {
+					return fn(ctx, mockReg)
+				})
+
+			i := impl{
+				repo:                mockReg,
+				linkChecker:         newLinkChecker(testServerOptions(t, newTestDestination(nil))...),
+				brokenLinkThreshold: defaultBrokenLinkThreshold,
+			}
+
+			actual, err := i.CheckLinksHealth(ctx, CheckLinksHealthInput{
+				MaxAge:      time.Hour,
+				Limit:       50,
+				Concurrency: 2,
+			})
+			if tc.wantErr != nil {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.wantErr.Error())
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.want, actual)
+			for shortCode, check := range tc.wantChecks {
+				mockShort.AssertCalled(t, "RecordHealthCheck", mock.Anything, shortCode, check, defaultBrokenLinkThreshold)
+			}
+
+			if tc.wantBrokenEvent == "" {
+				mockOutbox.AssertNotCalled(t, "Insert", mock.Anything, mock.Anything)
+				return
+			}
+			mockOutbox.AssertCalled(t, "Insert", mock.Anything, mock.MatchedBy(func(e model.OutgoingEvent) bool {
+				var data model.LinkBrokenV1
+				return e.Topic == model.TopicLinkBrokenV1 &&
+					eventschema.Decode(e.Topic, e.Payload.Data, &data) == nil &&
+					data.ShortCode == tc.wantBrokenEvent &&
+					data.HTTPStatus == 404 &&
+					data.ConsecutiveFailures == 3
+			}))
+		})
+	}
+}
+
+func TestCheckLinksHealth_HostPoliteness(t *testing.T) {
+	const delay = 30 * time.Millisecond
+
+	var (
+		mu       sync.Mutex
+		inFlight = map[string]int{}
+		lastSeen = map[string]time.Time{}
+		overlaps int
+		tooSoon  int
+	)
+	h := newTestDestination(func(r *http.Request) func() {
+		mu.Lock()
+		defer mu.Unlock()
+		if inFlight[r.Host] > 0 {
+			overlaps++
+		}
+		if last, ok := lastSeen[r.Host]; ok && time.Since(last) < delay {
+			tooSoon++
+		}
+		inFlight[r.Host]++
+		return func() {
+			mu.Lock()
+			defer mu.Unlock()
+			inFlight[r.Host]--
+			lastSeen[r.Host] = time.Now()
+		}
+	})
+
+	var links []model.ShortUrl
+	for _, u := range []string{"https://a.example.com/ok", "https://a.example.com/ok?2", "https://a.example.com/ok?3", "https://b.example.com/ok", "https://b.example.com/ok?2"} {
+		links = append(links, model.ShortUrl{ShortCode: u, OriginalURL: u})
+	}
+
+	mockShort := new(shorturl.MockRepository)
+	mockShort.On("ListDueForHealthCheck", mock.Anything, mock.Anything, mock.Anything).Return(links, nil)
+	mockShort.On("RecordHealthCheck", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(model.LinkHealth{}, nil)
+
+	mockReg := new(repository.MockRegistry)
+	mockReg.On("ShortUrl").Return(mockShort)
+	mockReg.On("DoInTx", mock.Anything, mock.Anything, mock.Anything).
+		Return(func(ctx context.Context, _ backoff.BackOff, fn func(context.Context, repository.Registry) error) error {
+			return fn(ctx, mockReg)
+		})
+
+	i := impl{
+		repo:                mockReg,
+		linkChecker:         newLinkChecker(testServerOptions(t, h)...),
+		brokenLinkThreshold: defaultBrokenLinkThreshold,
+	}
+
+	actual, err := i.CheckLinksHealth(context.Background(), CheckLinksHealthInput{
+		MaxAge:      time.Hour,
+		Limit:       50,
+		Concurrency: 10,
+		HostDelay:   delay,
+	})
+	require.NoError(t, err)
+	require.Equal(t, len(links), actual)
+	require.Zero(t, overlaps, "concurrent requests to the same host")
+	require.Zero(t, tooSoon, "requests to the same host closer than the host delay")
+}
+
+// newTestDestination serves the destinations of link health tests. track, when set, is called when a request
+// starts and the function it returns when the request ends.
+func newTestDestination(track func(r *http.Request) func()) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if track != nil {
+			defer track(r)()
+		}
+
+		switch r.URL.Path {
+		case "/ok":
+			w.WriteHeader(http.StatusOK)
+		case "/moved":
+			http.Redirect(w, r, "/ok", http.StatusFound)
+		case "/no-head":
+			if r.Method == http.MethodHead {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+}
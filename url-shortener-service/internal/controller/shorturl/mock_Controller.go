@@ -7,6 +7,8 @@ import (
 
 	model "github.com/kytruongdev/sturl/url-shortener-service/internal/model"
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // MockController is an autogenerated mock type for the Controller type
@@ -14,6 +16,34 @@ type MockController struct {
 	mock.Mock
 }
 
+// CheckLinksHealth provides a mock function with given fields: ctx, inp
+func (_m *MockController) CheckLinksHealth(ctx context.Context, inp CheckLinksHealthInput) (int, error) {
+	ret := _m.Called(ctx, inp)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckLinksHealth")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, CheckLinksHealthInput) (int, error)); ok {
+		return rf(ctx, inp)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, CheckLinksHealthInput) int); ok {
+		r0 = rf(ctx, inp)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, CheckLinksHealthInput) error); ok {
+		r1 = rf(ctx, inp)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CrawlURLMetadata provides a mock function with given fields: ctx, shortCode
 func (_m *MockController) CrawlURLMetadata(ctx context.Context, shortCode string) (model.UrlMetadata, error) {
 	ret := _m.Called(ctx, shortCode)
@@ -42,6 +72,170 @@ func (_m *MockController) CrawlURLMetadata(ctx context.Context, shortCode string
 	return r0, r1
 }
 
+// EnqueueStaleRecrawls provides a mock function with given fields: ctx, maxAge, limit
+func (_m *MockController) EnqueueStaleRecrawls(ctx context.Context, maxAge time.Duration, limit int) (int, error) {
+	ret := _m.Called(ctx, maxAge, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnqueueStaleRecrawls")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Duration, int) (int, error)); ok {
+		return rf(ctx, maxAge, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Duration, int) int); ok {
+		r0 = rf(ctx, maxAge, limit)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Duration, int) error); ok {
+		r1 = rf(ctx, maxAge, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FlagBlockedDestinations provides a mock function with given fields: ctx, batchSize
+func (_m *MockController) FlagBlockedDestinations(ctx context.Context, batchSize int) (int, error) {
+	ret := _m.Called(ctx, batchSize)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FlagBlockedDestinations")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (int, error)); ok {
+		return rf(ctx, batchSize)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) int); ok {
+		r0 = rf(ctx, batchSize)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, batchSize)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAsset provides a mock function with given fields: ctx, hash
+func (_m *MockController) GetAsset(ctx context.Context, hash string) ([]byte, error) {
+	ret := _m.Called(ctx, hash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAsset")
+	}
+
+	var r0 []byte
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]byte, error)); ok {
+		return rf(ctx, hash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []byte); ok {
+		r0 = rf(ctx, hash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCrawlHealth provides a mock function with given fields: ctx, domain
+func (_m *MockController) GetCrawlHealth(ctx context.Context, domain string) ([]model.CrawlDomainStats, error) {
+	ret := _m.Called(ctx, domain)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCrawlHealth")
+	}
+
+	var r0 []model.CrawlDomainStats
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]model.CrawlDomainStats, error)); ok {
+		return rf(ctx, domain)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []model.CrawlDomainStats); ok {
+		r0 = rf(ctx, domain)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.CrawlDomainStats)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, domain)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListLinksByHealth provides a mock function with given fields: ctx, status, afterShortCode, limit
+func (_m *MockController) ListLinksByHealth(ctx context.Context, status model.LinkHealthStatus, afterShortCode string, limit int) ([]model.ShortUrl, error) {
+	ret := _m.Called(ctx, status, afterShortCode, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListLinksByHealth")
+	}
+
+	var r0 []model.ShortUrl
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.LinkHealthStatus, string, int) ([]model.ShortUrl, error)); ok {
+		return rf(ctx, status, afterShortCode, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.LinkHealthStatus, string, int) []model.ShortUrl); ok {
+		r0 = rf(ctx, status, afterShortCode, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.ShortUrl)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.LinkHealthStatus, string, int) error); ok {
+		r1 = rf(ctx, status, afterShortCode, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RefreshMetadata provides a mock function with given fields: ctx, shortCode
+func (_m *MockController) RefreshMetadata(ctx context.Context, shortCode string) error {
+	ret := _m.Called(ctx, shortCode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RefreshMetadata")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, shortCode)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Retrieve provides a mock function with given fields: _a0, _a1
 func (_m *MockController) Retrieve(_a0 context.Context, _a1 string) (model.ShortUrl, error) {
 	ret := _m.Called(_a0, _a1)
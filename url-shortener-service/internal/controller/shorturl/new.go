@@ -2,8 +2,11 @@ package shorturl
 
 import (
 	"context"
+	"time"
 
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/blob"
 	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/pkg/policy"
 	"github.com/kytruongdev/sturl/url-shortener-service/internal/repository"
 )
 
@@ -13,17 +16,80 @@ type Controller interface {
 	Shorten(context.Context, ShortenInput) (model.ShortUrl, error)
 	Retrieve(context.Context, string) (model.ShortUrl, error)
 	CrawlURLMetadata(ctx context.Context, shortCode string) (model.UrlMetadata, error)
+	GetCrawlHealth(ctx context.Context, domain string) ([]model.CrawlDomainStats, error)
+	RefreshMetadata(ctx context.Context, shortCode string) error
+	EnqueueStaleRecrawls(ctx context.Context, maxAge time.Duration, limit int) (int, error)
+	GetAsset(ctx context.Context, hash string) ([]byte, error)
+	FlagBlockedDestinations(ctx context.Context, batchSize int) (int, error)
+	CheckLinksHealth(ctx context.Context, inp CheckLinksHealthInput) (int, error)
+	ListLinksByHealth(ctx context.Context, status model.LinkHealthStatus, afterShortCode string, limit int) ([]model.ShortUrl, error)
 }
 
+// defaultMetadataRefreshInterval is the minimum delay between two on-demand metadata refreshes of a link
+const defaultMetadataRefreshInterval = time.Minute
+
 // impl is the implementation of the controller
 type impl struct {
-	repo repository.Registry
+	repo                    repository.Registry
+	crawler                 urlMetadataCrawler
+	metadataRefreshInterval time.Duration
+	assetStore              blob.Store
+	policy                  *policy.Engine
+	linkChecker             linkChecker
+	brokenLinkThreshold     int
 }
 
 // New creates and returns a new Controller instance with the provided repository.
 // It returns a new instance of the controller for handling short URL operations.
-func New(repo repository.Registry) Controller {
-	return &impl{
-		repo: repo,
+func New(repo repository.Registry, opts ...Option) Controller {
+	i := &impl{
+		repo:                    repo,
+		crawler:                 newURLMetadataCrawler(),
+		metadataRefreshInterval: defaultMetadataRefreshInterval,
+		policy:                  policy.NewEngine(),
+		linkChecker:             newLinkChecker(),
+		brokenLinkThreshold:     defaultBrokenLinkThreshold,
+	}
+
+	for _, o := range opts {
+		o(i)
+	}
+
+	return i
+}
+
+// Option enables tweaking the controller
+type Option func(*impl)
+
+// WithMetadataRefreshInterval overrides the minimum delay between two on-demand metadata refreshes of a link
+func WithMetadataRefreshInterval(d time.Duration) Option {
+	return func(i *impl) {
+		if d > 0 {
+			i.metadataRefreshInterval = d
+		}
+	}
+}
+
+// WithAssetStore enables caching preview images as thumbnails in store, served under baseURL + "/assets/{hash}"
+func WithAssetStore(store blob.Store, baseURL string) Option {
+	return func(i *impl) {
+		i.assetStore = store
+		i.crawler.assets = &assetCache{store: store, baseURL: baseURL}
+	}
+}
+
+// WithPolicy overrides the destination policy, which blocks the well-known URL shorteners only by default
+func WithPolicy(p *policy.Engine) Option {
+	return func(i *impl) {
+		i.policy = p
+	}
+}
+
+// WithBrokenLinkThreshold overrides the number of failed health checks in a row after which a link is BROKEN
+func WithBrokenLinkThreshold(n int) Option {
+	return func(i *impl) {
+		if n > 0 {
+			i.brokenLinkThreshold = n
+		}
 	}
 }
@@ -0,0 +1,37 @@
+package shorturl
+
+import (
+	"context"
+	"time"
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/monitoring"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/pkg/eventschema"
+	pkgerrors "github.com/pkg/errors"
+)
+
+// newOutgoingEvent builds a pending outgoing event of topic, keyed by key, carrying the trace and correlation
+// of ctx. data must be of the type registered for the topic, it is validated against the topic schema.
+func newOutgoingEvent(ctx context.Context, topic model.Topic, key string, data any) (model.OutgoingEvent, error) {
+	meta := monitoring.SpanMetadataFromContext(ctx)
+
+	b, err := eventschema.Encode(topic, data)
+	if err != nil {
+		return model.OutgoingEvent{}, pkgerrors.WithStack(err)
+	}
+
+	return model.OutgoingEvent{
+		ID:            newIDFunc(),
+		Topic:         topic,
+		Key:           key,
+		Status:        model.OutgoingEventStatusPending,
+		CorrelationID: meta.CorrelationID,
+		TraceID:       meta.TraceID,
+		SpanID:        meta.SpanID,
+		Payload: model.Payload{
+			EventID:    newIDFunc(),
+			OccurredAt: time.Now().UTC(),
+			Data:       b,
+		},
+	}, nil
+}
@@ -0,0 +1,76 @@
+package shorturl
+
+import (
+	"context"
+	"errors"
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/monitoring"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/repository"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/repository/shorturl"
+	pkgerrors "github.com/pkg/errors"
+)
+
+// RefreshMetadata enqueues an on-demand metadata crawl of the given short code.
+// A link can be refreshed at most once per metadata refresh interval.
+func (i impl) RefreshMetadata(ctx context.Context, shortCode string) error {
+	var err error
+	ctx, span := monitoring.Start(ctx, "ShortURLController.RefreshMetadata")
+	defer monitoring.End(span, &err)
+
+	l := monitoring.Log(ctx).Field("short_code", shortCode)
+
+	su, err := i.repo.ShortUrl().GetByShortCode(ctx, shortCode)
+	if err != nil {
+		l.Error().Err(err).Msg("[RefreshMetadata] shortUrlRepo.GetByShortCode err")
+		if errors.Is(err, shorturl.ErrNotFound) {
+			return ErrURLNotfound
+		}
+		return err
+	}
+
+	if su.Status != model.ShortUrlStatusActive {
+		return ErrInactiveURL
+	}
+
+	acquired, err := i.repo.ShortUrl().AcquireMetadataRefresh(ctx, shortCode, i.metadataRefreshInterval)
+	if err != nil {
+		l.Error().Err(err).Msg("[RefreshMetadata] shortUrlRepo.AcquireMetadataRefresh err")
+		return err
+	}
+
+	if !acquired {
+		l.Info().Msg("[RefreshMetadata] refreshed recently, rejecting")
+		return ErrRefreshRateLimited
+	}
+
+	if err = i.enqueueCrawl(ctx, su); err != nil {
+		l.Error().Err(err).Msg("[RefreshMetadata] enqueueCrawl err")
+
+		// Nothing was enqueued: let the user try again right away rather than wait for the interval
+		if releaseErr := i.repo.ShortUrl().ReleaseMetadataRefresh(ctx, shortCode); releaseErr != nil {
+			l.Error().Err(releaseErr).Msg("[RefreshMetadata] shortUrlRepo.ReleaseMetadataRefresh err")
+		}
+		return err
+	}
+
+	l.Info().Msg("[RefreshMetadata] metadata crawl enqueued")
+
+	return nil
+}
+
+// enqueueCrawl emits a metadata.requested event for the given short URL and stamps the request on the link.
+func (i impl) enqueueCrawl(ctx context.Context, su model.ShortUrl) error {
+	return pkgerrors.WithStack(i.repo.DoInTx(ctx, nil, func(txCtx context.Context, txRepo repository.Registry) error {
+		event, err := newMetadataRequestedEvent(txCtx, su)
+		if err != nil {
+			return err
+		}
+
+		if _, err = txRepo.OutgoingEvent().Insert(txCtx, event); err != nil {
+			return err
+		}
+
+		return txRepo.ShortUrl().MarkCrawlRequested(txCtx, su.ShortCode)
+	}))
+}
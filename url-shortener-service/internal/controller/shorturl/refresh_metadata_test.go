@@ -0,0 +1,140 @@
+package shorturl
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/id"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/pkg/eventschema"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/repository"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/repository/outgoingevent"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/repository/shorturl"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRefreshMetadata(t *testing.T) {
+	activeURL := model.ShortUrl{
+		ShortCode:   "abc123",
+		OriginalURL: "https://example.com",
+		Status:      model.ShortUrlStatusActive,
+	}
+
+	tcs := map[string]struct {
+		mockGetByShortCodeWant model.ShortUrl
+		mockGetByShortCodeErr  error
+		mockAcquireWant        bool
+		mockAcquireErr         error
+		mockInsertOutboxErr    error
+		mockMarkRequestedErr   error
+		mockReleaseErr         error
+		wantEnqueued           bool
+		wantReleased           bool
+		wantErr                error
+	}{
+		"success": {
+			mockGetByShortCodeWant: activeURL,
+			mockAcquireWant:        true,
+			wantEnqueued:           true,
+		},
+		"fail - short code not found": {
+			mockGetByShortCodeErr: shorturl.ErrNotFound,
+			wantErr:               ErrURLNotfound,
+		},
+		"fail - inactive url": {
+			mockGetByShortCodeWant: model.ShortUrl{ShortCode: "abc123", Status: model.ShortUrlStatusInactive},
+			wantErr:                ErrInactiveURL,
+		},
+		"fail - refreshed recently": {
+			mockGetByShortCodeWant: activeURL,
+			mockAcquireWant:        false,
+			wantErr:                ErrRefreshRateLimited,
+		},
+		"fail - acquire refresh error": {
+			mockGetByShortCodeWant: activeURL,
+			mockAcquireErr:         errors.New("redis down"),
+			wantErr:                errors.New("redis down"),
+		},
+		"fail - insert outbox event fails": {
+			mockGetByShortCodeWant: activeURL,
+			mockAcquireWant:        true,
+			mockInsertOutboxErr:    errors.New("outbox insert failed"),
+			wantReleased:           true,
+			wantErr:                errors.New("outbox insert failed"),
+		},
+		"fail - mark crawl requested fails": {
+			mockGetByShortCodeWant: activeURL,
+			mockAcquireWant:        true,
+			mockMarkRequestedErr:   errors.New("update failed"),
+			wantReleased:           true,
+			wantErr:                errors.New("update failed"),
+		},
+		"fail - enqueue fails, release fails too": {
+			mockGetByShortCodeWant: activeURL,
+			mockAcquireWant:        true,
+			mockInsertOutboxErr:    errors.New("outbox insert failed"),
+			mockReleaseErr:         errors.New("redis down"),
+			wantReleased:           true,
+			wantErr:                errors.New("outbox insert failed"),
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			newIDFunc = func() int64 {
+				return 123
+			}
+			defer func() { newIDFunc = id.New }()
+
+			mockShort := new(shorturl.MockRepository)
+			mockShort.On("GetByShortCode", mock.Anything, "abc123").
+				Return(tc.mockGetByShortCodeWant, tc.mockGetByShortCodeErr)
+			mockShort.On("AcquireMetadataRefresh", mock.Anything, "abc123", 10*time.Minute).
+				Return(tc.mockAcquireWant, tc.mockAcquireErr)
+			mockShort.On("MarkCrawlRequested", mock.Anything, "abc123").
+				Return(tc.mockMarkRequestedErr)
+			mockShort.On("ReleaseMetadataRefresh", mock.Anything, "abc123").
+				Return(tc.mockReleaseErr)
+
+			mockOutbox := new(outgoingevent.MockRepository)
+			mockOutbox.On("Insert", mock.Anything, mock.MatchedBy(func(e model.OutgoingEvent) bool {
+				var data model.MetadataRequestedV1
+				return e.Topic == model.TopicMetadataRequestedV1 &&
+					eventschema.Decode(e.Topic, e.Payload.Data, &data) == nil && data.ShortCode == "abc123"
+			})).Return(model.OutgoingEvent{}, tc.mockInsertOutboxErr)
+
+			mockReg := new(repository.MockRegistry)
+			mockReg.On("ShortUrl").Return(mockShort)
+			mockReg.On("OutgoingEvent").Return(mockOutbox)
+			mockReg.On("DoInTx", mock.Anything, mock.Anything, mock.Anything).
+				Return(func(ctx context.Context, _ backoff.BackOff, fn func(context.Context, repository.Registry) error) error {
+					return fn(ctx, mockReg)
+				})
+
+			i := New(mockReg, WithMetadataRefreshInterval(10*time.Minute))
+			err := i.RefreshMetadata(ctx, "abc123")
+
+			if tc.wantReleased {
+				mockShort.AssertCalled(t, "ReleaseMetadataRefresh", mock.Anything, "abc123")
+			} else {
+				mockShort.AssertNotCalled(t, "ReleaseMetadataRefresh", mock.Anything, "abc123")
+			}
+			if tc.wantErr != nil {
+				require.ErrorContains(t, err, tc.wantErr.Error())
+				return
+			}
+
+			require.NoError(t, err)
+			if tc.wantEnqueued {
+				mockOutbox.AssertNumberOfCalls(t, "Insert", 1)
+				mockShort.AssertCalled(t, "MarkCrawlRequested", mock.Anything, "abc123")
+			}
+		})
+	}
+}
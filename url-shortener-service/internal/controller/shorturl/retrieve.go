@@ -32,5 +32,15 @@ func (i impl) Retrieve(ctx context.Context, shortCode string) (model.ShortUrl, e
 		return model.ShortUrl{}, ErrInactiveURL
 	}
 
+	// Links flagged by the destination policy sweep are kept for review but never followed again
+	if !m.FlaggedAt.IsZero() {
+		return model.ShortUrl{}, ErrDestinationBlocked
+	}
+
+	// Best-effort: click counts only prioritize metadata re-crawls, a redirect must not fail on them
+	if countErr := i.repo.ShortUrl().IncrementClickCount(ctx, shortCode); countErr != nil {
+		l.Error().Err(countErr).Msg("[Retrieve] shortUrlRepo.IncrementClickCount err")
+	}
+
 	return m, err
 }
@@ -2,6 +2,7 @@ package shorturl
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -17,11 +18,12 @@ import (
 
 func TestRetrieve(t *testing.T) {
 	tcs := map[string]struct {
-		shortCode                string
-		mockGetByShortCodeResult model.ShortUrl
-		mockGetByShortCodeErr    error
-		want                     model.ShortUrl
-		wantErr                  error
+		shortCode                  string
+		mockGetByShortCodeResult   model.ShortUrl
+		mockGetByShortCodeErr      error
+		mockIncrementClickCountErr error
+		want                       model.ShortUrl
+		wantErr                    error
 	}{
 		"success": {
 			shortCode: "abc",
@@ -39,6 +41,20 @@ func TestRetrieve(t *testing.T) {
 				Status:      model.ShortUrlStatusActive,
 			},
 		},
+		"success - click count failure does not fail the redirect": {
+			shortCode: "abc",
+			mockGetByShortCodeResult: model.ShortUrl{
+				ShortCode:   "abc",
+				OriginalURL: "https://abc.com/123",
+				Status:      model.ShortUrlStatusActive,
+			},
+			mockIncrementClickCountErr: errors.New("db error"),
+			want: model.ShortUrl{
+				ShortCode:   "abc",
+				OriginalURL: "https://abc.com/123",
+				Status:      model.ShortUrlStatusActive,
+			},
+		},
 		"fail - URL is inactive": {
 			shortCode: "abc",
 			mockGetByShortCodeResult: model.ShortUrl{
@@ -51,6 +67,17 @@ func TestRetrieve(t *testing.T) {
 			mockGetByShortCodeErr: nil,
 			wantErr:               ErrInactiveURL,
 		},
+		"fail - destination flagged as blocked": {
+			shortCode: "abc",
+			mockGetByShortCodeResult: model.ShortUrl{
+				ShortCode:   "abc",
+				OriginalURL: "https://phishing.example/login",
+				Status:      model.ShortUrlStatusActive,
+				FlaggedAt:   time.Now(),
+				FlagReason:  "DOMAIN phishing.example",
+			},
+			wantErr: ErrDestinationBlocked,
+		},
 		"not found": {
 			shortCode:             "abc",
 			mockGetByShortCodeErr: shorturl.ErrNotFound,
@@ -64,6 +91,7 @@ func TestRetrieve(t *testing.T) {
 			mockShortURLRepo := new(shorturl.MockRepository)
 			mockShortURLRepo.ExpectedCalls = []*mock.Call{
 				mockShortURLRepo.On("GetByShortCode", mock.Anything, tc.shortCode).Return(tc.mockGetByShortCodeResult, tc.mockGetByShortCodeErr),
+				mockShortURLRepo.On("IncrementClickCount", mock.Anything, tc.shortCode).Return(tc.mockIncrementClickCountErr),
 			}
 
 			repo := new(repository.MockRegistry)
@@ -38,6 +38,12 @@ func (i impl) Shorten(ctx context.Context, inp ShortenInput) (model.ShortUrl, er
 
 	l := monitoring.Log(ctx)
 
+	// Refuse blocked destinations before anything is stored or returned
+	if err = i.policy.Check(inp.OriginalURL); err != nil {
+		l.Warn().Err(err).Str("original_url", inp.OriginalURL).Msg("[Shorten] destination blocked")
+		return model.ShortUrl{}, ErrDestinationBlocked
+	}
+
 	// Check if the original URL already has a short code (idempotency check)
 	shortUrl, err := i.repo.ShortUrl().GetByOriginalURL(ctx, inp.OriginalURL)
 	if err != nil {
@@ -79,24 +85,13 @@ func (i impl) createShortURL(ctx context.Context, inp ShortenInput) (model.Short
 			return err
 		}
 
-		meta := monitoring.SpanMetadataFromContext(ctx)
-
-		oe, err := regRepo.OutgoingEvent().Insert(newCtx, model.OutgoingEvent{
-			ID:            newIDFunc(),
-			Topic:         model.TopicMetadataRequestedV1,
-			Status:        model.OutgoingEventStatusPending,
-			CorrelationID: meta.CorrelationID,
-			TraceID:       meta.TraceID,
-			SpanID:        meta.SpanID,
-			Payload: model.Payload{
-				EventID:    newIDFunc(),
-				OccurredAt: time.Now().UTC(),
-				Data: map[string]string{
-					"short_code":   m.ShortCode,
-					"original_url": m.OriginalURL,
-				},
-			},
-		})
+		event, err := newMetadataRequestedEvent(ctx, m)
+		if err != nil {
+			l.Error().Err(err).Msg("[Shorten] newMetadataRequestedEvent err")
+			return err
+		}
+
+		oe, err := regRepo.OutgoingEvent().Insert(newCtx, event)
 		if err != nil {
 			l.Error().Err(err).Msg("[Shorten] OutgoingEventRepo.Insert err")
 			return err
@@ -121,6 +116,14 @@ func (i impl) createShortURL(ctx context.Context, inp ShortenInput) (model.Short
 	return m, nil
 }
 
+// newMetadataRequestedEvent builds the outgoing event asking the consumer to crawl the metadata of the given short URL.
+func newMetadataRequestedEvent(ctx context.Context, m model.ShortUrl) (model.OutgoingEvent, error) {
+	return newOutgoingEvent(ctx, model.TopicMetadataRequestedV1, m.ShortCode, model.MetadataRequestedV1{
+		ShortCode:   m.ShortCode,
+		OriginalURL: m.OriginalURL,
+	})
+}
+
 // generateShortCode generates a random alphanumeric short code of the specified length.
 func generateShortCode(n int) string {
 	const charset = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
@@ -66,6 +66,11 @@ func TestShorten(t *testing.T) {
 			},
 		},
 
+		"fail - destination blocked": {
+			inp:     ShortenInput{OriginalURL: "https://bit.ly/abc"},
+			wantErr: ErrDestinationBlocked,
+		},
+
 		"fail - getByOriginal returns error": {
 			inp:                     ShortenInput{OriginalURL: "http://google.com"},
 			mockGetByOriginalURLErr: errors.New("db error"),
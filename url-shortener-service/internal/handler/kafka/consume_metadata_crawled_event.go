@@ -2,11 +2,11 @@ package kafka
 
 import (
 	"context"
-	"encoding/json"
 
 	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/kafka"
 	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/monitoring"
 	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/pkg/eventschema"
 	kafkago "github.com/segmentio/kafka-go"
 )
 
@@ -15,35 +15,32 @@ func MetadataCrawled(
 // TODO: inject notify service later
 ) kafka.MessageHandler {
 	return kafka.HandlerFunc(func(ctx context.Context, msg kafkago.Message) *kafka.KafkaError {
-		var payload model.Payload
-		if err := json.Unmarshal(msg.Value, &payload); err != nil {
+		payload, err := decodePayload(msg)
+		if err != nil {
 			monitoring.Log(ctx).Error().Err(err).
 				Msg("[MetadataCrawled] failed to unmarshal payload")
 			return kafka.NewKafkaError(err, false)
 		}
 
-		// Rebuild tracing/correlation context
-		newCtx, err := monitoring.EnrichContextWithSpanMetadata(ctx, monitoring.SpanMetadata{
-			TraceID:       payload.TraceID,
-			SpanID:        payload.SpanID,
-			CorrelationID: payload.CorrelationID,
-		})
-		if err != nil {
+		var data model.MetadataCrawledV1
+		if err := eventschema.Decode(model.Topic(msg.Topic), payload.Data, &data); err != nil {
+			monitoring.Log(ctx).Error().Err(err).Msg("[MetadataCrawled] invalid event data")
 			return kafka.NewKafkaError(err, false)
 		}
 
-		spanCtx, span := monitoring.Start(newCtx, "Consumer.ConsumeMessage | Topic: "+msg.Topic)
-		defer monitoring.End(span, &err)
+		// Trace context comes from the message headers, the consumer span is started by the consumer
+		ctx = withPayloadCorrelationID(ctx, payload)
 
-		log := monitoring.Log(spanCtx).
+		log := monitoring.Log(ctx).
 			Field("topic", msg.Topic).
 			Field("partition", msg.Partition).
 			Field("offset", msg.Offset).
-			Field("event_id", payload.EventID)
+			Field("event_id", payload.EventID).
+			Field("short_code", data.ShortCode)
 
 		log.Info().Msg("[MetadataCrawled] handling message")
 
-		if err = notifyMetadataCrawled(spanCtx); err != nil {
+		if err := notifyMetadataCrawled(ctx); err != nil {
 			log.Error().Err(err).Msg("[MetadataCrawled] failed to notify user")
 			return kafka.NewKafkaError(err, true)
 		}
@@ -27,10 +27,10 @@ func TestMetadataCrawled(t *testing.T) {
 				Value: mustMarshal(model.Payload{
 					EventID:    123,
 					OccurredAt: testTime,
-					Data: map[string]string{
-						"short_code":   "abc123",
-						"original_url": "https://example.com",
-					},
+					Data: mustMarshal(model.MetadataCrawledV1{
+						ShortCode:   "abc123",
+						OriginalURL: "https://example.com",
+					}),
 					TraceID:       "12345678901234567890123456789012",
 					SpanID:        "1234567890123456",
 					CorrelationID: "corr-789",
@@ -59,9 +59,9 @@ func TestMetadataCrawled(t *testing.T) {
 				Value: mustMarshal(model.Payload{
 					EventID:    456,
 					OccurredAt: testTime,
-					Data: map[string]string{
-						"short_code": "xyz789",
-					},
+					Data: mustMarshal(model.MetadataCrawledV1{
+						ShortCode: "xyz789",
+					}),
 					TraceID:       "12345678901234567890123456789012",
 					SpanID:        "1234567890123456",
 					CorrelationID: "corr-123",
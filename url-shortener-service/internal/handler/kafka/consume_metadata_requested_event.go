@@ -2,15 +2,14 @@ package kafka
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
-	"fmt"
 
 	shortUrlCtrl "github.com/kytruongdev/sturl/url-shortener-service/internal/controller/shorturl"
 	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/id"
 	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/kafka"
 	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/monitoring"
 	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/pkg/eventschema"
 	kafkago "github.com/segmentio/kafka-go"
 )
 
@@ -23,32 +22,27 @@ func MetadataRequested(
 	shortURLCtrl shortUrlCtrl.Controller,
 ) kafka.MessageHandler {
 	return kafka.HandlerFunc(func(ctx context.Context, msg kafkago.Message) *kafka.KafkaError {
-		var payload model.Payload
-		if err := json.Unmarshal(msg.Value, &payload); err != nil {
+		payload, err := decodePayload(msg)
+		if err != nil {
 			monitoring.Log(ctx).Error().Err(err).Msg("[MetadataRequested] failed to unmarshal payload")
 			return kafka.NewKafkaError(err, false)
 		}
 
-		shortCode := payload.Data["short_code"]
-		if shortCode == "" {
-			return kafka.NewKafkaError(errors.New("[MetadataRequested] short_code is empty"), false)
+		var data model.MetadataRequestedV1
+		if err := eventschema.Decode(model.Topic(msg.Topic), payload.Data, &data); err != nil {
+			monitoring.Log(ctx).Error().Err(err).Msg("[MetadataRequested] invalid event data")
+			return kafka.NewKafkaError(err, false)
 		}
 
-		metadata := monitoring.SpanMetadata{
-			TraceID:       payload.TraceID,
-			SpanID:        payload.SpanID,
-			CorrelationID: payload.CorrelationID,
-		}
-		fmt.Printf("[MetadataRequested] metadata: %+v\n", metadata)
-		newCtx, err := monitoring.EnrichContextWithSpanMetadata(ctx, metadata)
-		if err != nil {
-			return kafka.NewKafkaError(err, false)
+		shortCode := data.ShortCode
+		if shortCode == "" {
+			return kafka.NewKafkaError(errors.New("[MetadataRequested] short_code is empty"), false)
 		}
 
-		spanCtx, span := monitoring.Start(newCtx, "Consumer.ConsumeMessage | Topic: "+msg.Topic)
-		defer monitoring.End(span, &err)
+		// Trace context comes from the message headers, the consumer span is started by the consumer
+		ctx = withPayloadCorrelationID(ctx, payload)
 
-		log := monitoring.Log(spanCtx).
+		log := monitoring.Log(ctx).
 			Field("topic", msg.Topic).
 			Field("partition", msg.Partition).
 			Field("offset", msg.Offset).
@@ -56,8 +50,7 @@ func MetadataRequested(
 
 		log.Info().Msg("[MetadataRequested] handling message")
 
-		_, err = shortURLCtrl.CrawlURLMetadata(spanCtx, shortCode)
-		if err != nil {
+		if _, err := shortURLCtrl.CrawlURLMetadata(ctx, shortCode); err != nil {
 			log.Error().Err(err).Msg("[MetadataRequested] failed to crawl url")
 			return kafka.NewKafkaError(err, true)
 		}
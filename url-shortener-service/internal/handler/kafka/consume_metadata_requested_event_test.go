@@ -28,10 +28,10 @@ func TestMetadataRequested(t *testing.T) {
 				Value: mustMarshal(model.Payload{
 					EventID:    123,
 					OccurredAt: testTime,
-					Data: map[string]string{
-						"short_code":   "abc123",
-						"original_url": "https://example.com",
-					},
+					Data: mustMarshal(model.MetadataRequestedV1{
+						ShortCode:   "abc123",
+						OriginalURL: "https://example.com",
+					}),
 					TraceID:       "12345678901234567890123456789012",
 					SpanID:        "1234567890123456",
 					CorrelationID: "corr-789",
@@ -45,6 +45,74 @@ func TestMetadataRequested(t *testing.T) {
 			wantErr:              false,
 		},
 
+		"success - message without trace IDs": {
+			message: kafkago.Message{
+				Topic:     "urlshortener.metadata.requested.v1",
+				Partition: 0,
+				Offset:    2,
+				Value: mustMarshal(model.Payload{
+					EventID:    124,
+					OccurredAt: testTime,
+					Data: mustMarshal(model.MetadataRequestedV1{
+						ShortCode:   "abc124",
+						OriginalURL: "https://example.com",
+					}),
+				}),
+			},
+			mockCrawlMetadataResponse: model.UrlMetadata{Title: "Example"},
+		},
+
+		"success - structured cloud event": {
+			message: kafkago.Message{
+				Topic:     "urlshortener.metadata.requested.v1",
+				Partition: 0,
+				Offset:    3,
+				Headers:   []kafkago.Header{{Key: "content-type", Value: []byte("application/cloudevents+json")}},
+				Value: []byte(`{"specversion":"1.0","id":"125","source":"/url-shortener-service",` +
+					`"type":"urlshortener.metadata.requested.v1","subject":"abc125","time":"2024-01-01T00:00:00Z",` +
+					`"datacontenttype":"application/json","correlationid":"corr-789",` +
+					`"data":{"short_code":"abc125","original_url":"https://example.com"}}`),
+			},
+			mockCrawlMetadataResponse: model.UrlMetadata{Title: "Example"},
+		},
+
+		"success - binary cloud event": {
+			message: kafkago.Message{
+				Topic:     "urlshortener.metadata.requested.v1",
+				Partition: 0,
+				Offset:    4,
+				Headers: []kafkago.Header{
+					{Key: "content-type", Value: []byte("application/json")},
+					{Key: "ce_specversion", Value: []byte("1.0")},
+					{Key: "ce_id", Value: []byte("126")},
+					{Key: "ce_source", Value: []byte("/url-shortener-service")},
+					{Key: "ce_type", Value: []byte("urlshortener.metadata.requested.v1")},
+					{Key: "ce_subject", Value: []byte("abc126")},
+				},
+				Value: mustMarshal(model.MetadataRequestedV1{
+					ShortCode:   "abc126",
+					OriginalURL: "https://example.com",
+				}),
+			},
+			mockCrawlMetadataResponse: model.UrlMetadata{Title: "Example"},
+		},
+
+		"fail - cloud event with non numeric id": {
+			message: kafkago.Message{
+				Topic:     "urlshortener.metadata.requested.v1",
+				Partition: 0,
+				Offset:    5,
+				Headers: []kafkago.Header{
+					{Key: "ce_specversion", Value: []byte("1.0")},
+					{Key: "ce_id", Value: []byte("not-a-number")},
+					{Key: "ce_source", Value: []byte("/url-shortener-service")},
+					{Key: "ce_type", Value: []byte("urlshortener.metadata.requested.v1")},
+				},
+				Value: mustMarshal(model.MetadataRequestedV1{ShortCode: "abc127"}),
+			},
+			wantErr: true,
+		},
+
 		"fail - invalid JSON payload": {
 			message: kafkago.Message{
 				Topic:     "urlshortener.metadata.requested.v1",
@@ -63,10 +131,10 @@ func TestMetadataRequested(t *testing.T) {
 				Value: mustMarshal(model.Payload{
 					EventID:    123,
 					OccurredAt: testTime,
-					Data: map[string]string{
-						"short_code":   "",
-						"original_url": "https://example.com",
-					},
+					Data: mustMarshal(model.MetadataRequestedV1{
+						ShortCode:   "",
+						OriginalURL: "https://example.com",
+					}),
 					TraceID:       "12345678901234567890123456789012",
 					SpanID:        "1234567890123456",
 					CorrelationID: "corr-789",
@@ -83,10 +151,10 @@ func TestMetadataRequested(t *testing.T) {
 				Value: mustMarshal(model.Payload{
 					EventID:    123,
 					OccurredAt: testTime,
-					Data: map[string]string{
-						"short_code":   "abc123",
-						"original_url": "https://example.com",
-					},
+					Data: mustMarshal(model.MetadataRequestedV1{
+						ShortCode:   "abc123",
+						OriginalURL: "https://example.com",
+					}),
 					TraceID:       "12345678901234567890123456789012",
 					SpanID:        "1234567890123456",
 					CorrelationID: "corr-789",
@@ -104,9 +172,10 @@ func TestMetadataRequested(t *testing.T) {
 			// Mock controller
 			mockCtrl := new(shortUrlCtrl.MockController)
 			if tc.mockCrawlMetadataErr != nil || !tc.wantErr {
-				var payload model.Payload
-				_ = json.Unmarshal(tc.message.Value, &payload)
-				shortCode := payload.Data["short_code"]
+				payload, _ := decodePayload(tc.message)
+				var data model.MetadataRequestedV1
+				_ = json.Unmarshal(payload.Data, &data)
+				shortCode := data.ShortCode
 
 				if shortCode != "" {
 					mockCtrl.On("CrawlURLMetadata", mock.Anything, shortCode).
@@ -0,0 +1,17 @@
+package kafka
+
+import (
+	"context"
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/transportmeta"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+)
+
+// withPayloadCorrelationID falls back on the correlation ID of the payload for messages published
+// before the correlation_id header existed
+func withPayloadCorrelationID(ctx context.Context, payload model.Payload) context.Context {
+	if transportmeta.FromContext(ctx).CorrelationID != "" || payload.CorrelationID == "" {
+		return ctx
+	}
+	return transportmeta.WithValue(ctx, "correlation_id", payload.CorrelationID)
+}
@@ -0,0 +1,65 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/kafka"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/monitoring"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/repository"
+	kafkago "github.com/segmentio/kafka-go"
+)
+
+// Idempotent wraps a handler so that a consumer group handles each event once, however many times it is delivered:
+// Kafka delivers at least once and the outbox may publish an event twice.
+//
+// Events are identified by Payload.EventID and recorded in processed_events by the first transaction of the handler,
+// along with its work, see repository.WithInboxEvent and repository.WithoutInboxEvent. Handlers without transaction get their events recorded once
+// they succeed. Messages without event ID are handled every time.
+func Idempotent(repo repository.Registry, groupID string, handler kafka.MessageHandler) kafka.MessageHandler {
+	return kafka.HandlerFunc(func(ctx context.Context, msg kafkago.Message) *kafka.KafkaError {
+		payload, err := decodePayload(msg)
+		if err != nil || payload.EventID == 0 {
+			// Left to the handler, which rejects the payloads it cannot decode
+			return handler.ConsumeMessage(ctx, msg)
+		}
+
+		log := monitoring.Log(ctx).
+			Field("topic", msg.Topic).
+			Field("consumer_group", groupID).
+			Field("event_id", payload.EventID)
+
+		processed, err := repo.ProcessedEvent().Exists(ctx, groupID, payload.EventID)
+		if err != nil {
+			log.Error().Err(err).Msg("[Idempotent] processedEventRepo.Exists err")
+			return kafka.NewKafkaError(err, true)
+		}
+		if processed {
+			log.Info().Msg("[Idempotent] event processed already, skipping")
+			return nil
+		}
+
+		event := model.ProcessedEvent{ConsumerGroup: groupID, EventID: payload.EventID, Topic: model.Topic(msg.Topic)}
+		ctx = repository.WithInboxEvent(ctx, event)
+
+		if kerr := handler.ConsumeMessage(ctx, msg); kerr != nil {
+			if errors.Is(kerr, repository.ErrEventProcessed) {
+				log.Info().Msg("[Idempotent] event processed concurrently, skipping")
+				return nil
+			}
+			return kerr
+		}
+
+		if repository.InboxEventRecorded(ctx) {
+			return nil
+		}
+
+		if _, err = repo.ProcessedEvent().Insert(ctx, event); err != nil {
+			// The work is done: retrying would do it again, the next delivery of the event will
+			log.Error().Err(err).Msg("[Idempotent] processedEventRepo.Insert err")
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,185 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/db/pg"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/kafka"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/repository"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/repository/processedevent"
+	kafkago "github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdempotent(t *testing.T) {
+	errHandler := errors.New("crawl failed")
+	event := model.ProcessedEvent{ConsumerGroup: "metadata-requested", EventID: 123, Topic: model.TopicMetadataRequestedV1}
+
+	tcs := map[string]struct {
+		message       kafkago.Message
+		mockExists    bool
+		mockExistsErr error
+		handlerErr    error
+		wantHandled   bool
+		wantInsert    bool
+		wantErr       error
+	}{
+		"success - event handled then recorded": {
+			message:     newRequestedMessage(123),
+			wantHandled: true,
+			wantInsert:  true,
+		},
+		"success - event processed already skipped": {
+			message:    newRequestedMessage(123),
+			mockExists: true,
+		},
+		"success - event processed concurrently": {
+			message:     newRequestedMessage(123),
+			handlerErr:  repository.ErrEventProcessed,
+			wantHandled: true,
+		},
+		"success - message without event ID handled every time": {
+			message:     newRequestedMessage(0),
+			wantHandled: true,
+		},
+		"error - handler failed, event not recorded": {
+			message:     newRequestedMessage(123),
+			handlerErr:  errHandler,
+			wantHandled: true,
+			wantErr:     errHandler,
+		},
+		"error - inbox unavailable": {
+			message:       newRequestedMessage(123),
+			mockExistsErr: errors.New("connection refused"),
+			wantErr:       errors.New("connection refused"),
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			// Given
+			ctx := context.Background()
+
+			mockProcessed := processedevent.NewMockRepository(t)
+			mockReg := repository.NewMockRegistry(t)
+			mockReg.On("ProcessedEvent").Return(mockProcessed).Maybe()
+			if tc.message.Value != nil && event.EventID == eventIDOf(t, tc.message) {
+				mockProcessed.On("Exists", mock.Anything, event.ConsumerGroup, event.EventID).
+					Return(tc.mockExists, tc.mockExistsErr)
+			}
+			if tc.wantInsert {
+				mockProcessed.On("Insert", mock.Anything, event).Return(true, nil)
+			}
+
+			var handled bool
+			handler := kafka.HandlerFunc(func(ctx context.Context, msg kafkago.Message) *kafka.KafkaError {
+				handled = true
+				if tc.handlerErr != nil {
+					return kafka.NewKafkaError(tc.handlerErr, true)
+				}
+				return nil
+			})
+
+			// When
+			err := Idempotent(mockReg, event.ConsumerGroup, handler).ConsumeMessage(ctx, tc.message)
+
+			// Then
+			require.Equal(t, tc.wantHandled, handled)
+			if tc.wantErr != nil {
+				require.NotNil(t, err)
+				require.EqualError(t, err, tc.wantErr.Error())
+				return
+			}
+			require.Nil(t, err)
+		})
+	}
+}
+
+func TestIdempotent_redelivery(t *testing.T) {
+	const groupID = "idempotent-test"
+	errCrawl := errors.New("crawl failed")
+
+	db, err := pg.Connect(os.Getenv("PG_URL"))
+	require.NoError(t, err)
+	defer db.Close()
+
+	eventID := time.Now().UnixNano()
+	defer db.Exec("DELETE FROM processed_events WHERE consumer_group = $1 AND event_id = $2", groupID, eventID)
+
+	// Given: a handler committing a side transaction, as the link health check, before its work fails once
+	repo := repository.New(db, nil)
+	var runs int
+	handler := kafka.HandlerFunc(func(ctx context.Context, msg kafkago.Message) *kafka.KafkaError {
+		runs++
+		if err := repo.DoInTx(repository.WithoutInboxEvent(ctx), nil, func(context.Context, repository.Registry) error {
+			return nil
+		}); err != nil {
+			return kafka.NewKafkaError(err, true)
+		}
+
+		if runs == 1 {
+			return kafka.NewKafkaError(errCrawl, true)
+		}
+
+		if err := repo.DoInTx(ctx, nil, func(context.Context, repository.Registry) error {
+			return nil
+		}); err != nil {
+			return kafka.NewKafkaError(err, true)
+		}
+		return nil
+	})
+	consumer := Idempotent(repo, groupID, handler)
+	msg := newRequestedMessage(eventID)
+
+	// When: delivered, the work failing
+	kerr := consumer.ConsumeMessage(context.Background(), msg)
+
+	// Then: the event is not recorded
+	require.NotNil(t, kerr)
+	require.EqualError(t, kerr, errCrawl.Error())
+	processed, err := repo.ProcessedEvent().Exists(context.Background(), groupID, eventID)
+	require.NoError(t, err)
+	require.False(t, processed)
+
+	// When: redelivered
+	kerr = consumer.ConsumeMessage(context.Background(), msg)
+
+	// Then: handled again, and recorded along with the work
+	require.Nil(t, kerr)
+	require.Equal(t, 2, runs)
+	processed, err = repo.ProcessedEvent().Exists(context.Background(), groupID, eventID)
+	require.NoError(t, err)
+	require.True(t, processed)
+
+	// When: redelivered once done
+	kerr = consumer.ConsumeMessage(context.Background(), msg)
+
+	// Then: skipped
+	require.Nil(t, kerr)
+	require.Equal(t, 2, runs)
+}
+
+// newRequestedMessage returns a metadata requested message of an event, without event ID when 0
+func newRequestedMessage(eventID int64) kafkago.Message {
+	return kafkago.Message{
+		Topic: model.TopicMetadataRequestedV1.String(),
+		Value: mustMarshal(model.Payload{
+			EventID:    eventID,
+			OccurredAt: testTime,
+			Data:       mustMarshal(model.MetadataRequestedV1{ShortCode: "abc123", OriginalURL: "https://example.com"}),
+		}),
+	}
+}
+
+// eventIDOf returns the event ID of a message
+func eventIDOf(t *testing.T, msg kafkago.Message) int64 {
+	payload, err := decodePayload(msg)
+	require.NoError(t, err)
+	return payload.EventID
+}
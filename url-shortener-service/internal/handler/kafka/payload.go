@@ -0,0 +1,40 @@
+package kafka
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/kafka"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+	kafkago "github.com/segmentio/kafka-go"
+)
+
+// decodePayload reads the payload of a message published either as a CloudEvent, in structured or binary mode,
+// or as a legacy payload, so that handlers keep working while producers migrate
+func decodePayload(msg kafkago.Message) (model.Payload, error) {
+	ce, ok, err := kafka.DecodeCloudEvent(msg)
+	if err != nil {
+		return model.Payload{}, err
+	}
+
+	if !ok {
+		var payload model.Payload
+		if err = json.Unmarshal(msg.Value, &payload); err != nil {
+			return model.Payload{}, err
+		}
+		return payload, nil
+	}
+
+	eventID, err := strconv.ParseInt(ce.ID, 10, 64)
+	if err != nil {
+		return model.Payload{}, fmt.Errorf("%w: id %q is not an event ID", kafka.ErrInvalidCloudEvent, ce.ID)
+	}
+
+	return model.Payload{
+		EventID:       eventID,
+		CorrelationID: ce.Extensions["correlationid"],
+		OccurredAt:    ce.Time,
+		Data:          ce.Data,
+	}, nil
+}
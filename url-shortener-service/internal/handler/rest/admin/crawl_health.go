@@ -0,0 +1,70 @@
+package admin
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/httpserver"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/monitoring"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+)
+
+// CrawlHealthResponse represents the HTTP response of the crawl health endpoint.
+type CrawlHealthResponse struct {
+	Domains []DomainCrawlHealth `json:"domains"`
+}
+
+// DomainCrawlHealth represents metadata crawl stats of the links pointing to a domain.
+type DomainCrawlHealth struct {
+	Domain        string     `json:"domain"`
+	Pending       int64      `json:"pending"`
+	Crawled       int64      `json:"crawled"`
+	Failed        int64      `json:"failed"`
+	Skipped       int64      `json:"skipped"`
+	FailureRate   float64    `json:"failure_rate"`
+	LastCrawledAt *time.Time `json:"last_crawled_at,omitempty"`
+}
+
+// CrawlHealth creates an HTTP handler function returning crawl stats per destination domain.
+// The optional `domain` query parameter narrows the result to a single domain.
+func (h *Handler) CrawlHealth() http.HandlerFunc {
+	return httpserver.HandlerErr(func(w http.ResponseWriter, r *http.Request) error {
+		var err error
+		ctx := r.Context()
+		ctx, span := monitoring.Start(ctx, "Handler.CrawlHealth")
+		defer monitoring.End(span, &err)
+
+		domain := r.URL.Query().Get("domain")
+
+		rs, err := h.shortUrlCtrl.GetCrawlHealth(ctx, domain)
+		if err != nil {
+			monitoring.Log(ctx).Error().Stack().Err(err).Str("domain", domain).Msg("[CrawlHealth] h.shortUrlCtrl.GetCrawlHealth err")
+			return err
+		}
+
+		httpserver.RespondJSON(w, toCrawlHealthResponse(rs))
+
+		return nil
+	})
+}
+
+func toCrawlHealthResponse(stats []model.CrawlDomainStats) CrawlHealthResponse {
+	rs := CrawlHealthResponse{Domains: make([]DomainCrawlHealth, 0, len(stats))}
+	for _, s := range stats {
+		d := DomainCrawlHealth{
+			Domain:  s.Domain,
+			Pending: s.Pending,
+			Crawled: s.Crawled,
+			Failed:  s.Failed,
+			Skipped: s.Skipped,
+		}
+		if attempted := s.Crawled + s.Failed; attempted > 0 {
+			d.FailureRate = float64(s.Failed) / float64(attempted)
+		}
+		if !s.LastCrawledAt.IsZero() {
+			d.LastCrawledAt = &s.LastCrawledAt
+		}
+		rs.Domains = append(rs.Domains, d)
+	}
+	return rs
+}
@@ -0,0 +1,78 @@
+package admin
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/controller/shorturl"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCrawlHealth(t *testing.T) {
+	type mockCtrl struct {
+		domain string
+		output []model.CrawlDomainStats
+		err    error
+	}
+
+	tcs := map[string]struct {
+		target   string
+		mockCtrl mockCtrl
+		wantCode int
+		wantResp string
+	}{
+		"success - all domains": {
+			target: "/api/admin/v1/crawl-health",
+			mockCtrl: mockCtrl{
+				output: []model.CrawlDomainStats{
+					{Domain: "github.com", Crawled: 3, Failed: 1, Skipped: 2, LastCrawledAt: time.Date(2025, 10, 20, 0, 0, 0, 0, time.UTC)},
+					{Domain: "example.com", Pending: 1},
+				},
+			},
+			wantCode: http.StatusOK,
+			wantResp: `{"domains":[` +
+				`{"domain":"github.com","pending":0,"crawled":3,"failed":1,"skipped":2,"failure_rate":0.25,"last_crawled_at":"2025-10-20T00:00:00Z"},` +
+				`{"domain":"example.com","pending":1,"crawled":0,"failed":0,"skipped":0,"failure_rate":0}]}`,
+		},
+		"success - filtered by domain": {
+			target: "/api/admin/v1/crawl-health?domain=unknown.org",
+			mockCtrl: mockCtrl{
+				domain: "unknown.org",
+				output: []model.CrawlDomainStats{},
+			},
+			wantCode: http.StatusOK,
+			wantResp: `{"domains":[]}`,
+		},
+		"fail - controller error": {
+			target: "/api/admin/v1/crawl-health",
+			mockCtrl: mockCtrl{
+				err: errors.New("error"),
+			},
+			wantCode: http.StatusInternalServerError,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tc.target, nil)
+			rec := httptest.NewRecorder()
+
+			ctrl := new(shorturl.MockController)
+			ctrl.ExpectedCalls = []*mock.Call{
+				ctrl.On("GetCrawlHealth", mock.Anything, tc.mockCtrl.domain).Return(tc.mockCtrl.output, tc.mockCtrl.err),
+			}
+
+			handler := Handler{shortUrlCtrl: ctrl}
+			handler.CrawlHealth().ServeHTTP(rec, req)
+			require.Equal(t, tc.wantCode, rec.Code)
+			if tc.wantResp != "" {
+				require.Equal(t, tc.wantResp, rec.Body.String())
+			}
+		})
+	}
+}
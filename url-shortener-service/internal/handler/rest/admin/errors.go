@@ -0,0 +1,14 @@
+package admin
+
+import (
+	"net/http"
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/httpserver"
+)
+
+var (
+	// WebErrInvalidHealth means the health filter is not a link health status
+	WebErrInvalidHealth = &httpserver.Error{Status: http.StatusBadRequest, Code: "invalid_health", Desc: "health must be one of unknown, reachable, unreachable or broken"}
+	// WebErrInvalidLimit means the page size is not a positive number within bounds
+	WebErrInvalidLimit = &httpserver.Error{Status: http.StatusBadRequest, Code: "invalid_limit", Desc: "limit must be between 1 and 500"}
+)
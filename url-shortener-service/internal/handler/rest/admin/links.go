@@ -0,0 +1,102 @@
+package admin
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/httpserver"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/monitoring"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+)
+
+const (
+	defaultLinksLimit = 50
+	maxLinksLimit     = 500
+)
+
+// LinksResponse represents the HTTP response of the links endpoint.
+type LinksResponse struct {
+	Links []Link `json:"links"`
+	// NextAfter is the `after` value of the next page, empty on the last page
+	NextAfter string `json:"next_after,omitempty"`
+}
+
+// Link represents a short URL and the health of its destination.
+type Link struct {
+	ShortCode   string     `json:"short_code"`
+	OriginalURL string     `json:"original_url"`
+	Health      LinkHealth `json:"health"`
+}
+
+// LinkHealth represents the health of a link destination.
+type LinkHealth struct {
+	Status              string     `json:"status"`
+	HTTPStatus          int        `json:"http_status,omitempty"`
+	RedirectChain       []string   `json:"redirect_chain,omitempty"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	CheckedAt           *time.Time `json:"checked_at,omitempty"`
+	LastOKAt            *time.Time `json:"last_ok_at,omitempty"`
+}
+
+// Links creates an HTTP handler function listing links by destination health, e.g. `?health=broken`.
+// Results are ordered by short code and paged with the `after` and `limit` query parameters.
+func (h *Handler) Links() http.HandlerFunc {
+	return httpserver.HandlerErr(func(w http.ResponseWriter, r *http.Request) error {
+		var err error
+		ctx := r.Context()
+		ctx, span := monitoring.Start(ctx, "Handler.Links")
+		defer monitoring.End(span, &err)
+
+		q := r.URL.Query()
+
+		status := model.LinkHealthStatus(strings.ToUpper(q.Get("health")))
+		if !status.IsValid() {
+			return WebErrInvalidHealth
+		}
+
+		limit := defaultLinksLimit
+		if raw := q.Get("limit"); raw != "" {
+			if limit, err = strconv.Atoi(raw); err != nil || limit < 1 || limit > maxLinksLimit {
+				return WebErrInvalidLimit
+			}
+		}
+
+		rs, err := h.shortUrlCtrl.ListLinksByHealth(ctx, status, q.Get("after"), limit)
+		if err != nil {
+			monitoring.Log(ctx).Error().Stack().Err(err).Str("health", status.String()).Msg("[Links] h.shortUrlCtrl.ListLinksByHealth err")
+			return err
+		}
+
+		httpserver.RespondJSON(w, toLinksResponse(rs, limit))
+
+		return nil
+	})
+}
+
+func toLinksResponse(links []model.ShortUrl, limit int) LinksResponse {
+	rs := LinksResponse{Links: make([]Link, 0, len(links))}
+	for _, su := range links {
+		health := LinkHealth{
+			Status:              su.Health.Status.String(),
+			HTTPStatus:          su.Health.HTTPStatus,
+			RedirectChain:       su.Health.RedirectChain,
+			ConsecutiveFailures: su.Health.ConsecutiveFailures,
+		}
+		if !su.Health.CheckedAt.IsZero() {
+			health.CheckedAt = &su.Health.CheckedAt
+		}
+		if !su.Health.LastOKAt.IsZero() {
+			health.LastOKAt = &su.Health.LastOKAt
+		}
+		rs.Links = append(rs.Links, Link{ShortCode: su.ShortCode, OriginalURL: su.OriginalURL, Health: health})
+	}
+
+	// A full page may be followed by another one
+	if len(links) == limit {
+		rs.NextAfter = links[len(links)-1].ShortCode
+	}
+
+	return rs
+}
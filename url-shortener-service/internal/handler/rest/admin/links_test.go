@@ -0,0 +1,132 @@
+package admin
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/controller/shorturl"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/httpserver"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLinks(t *testing.T) {
+	type mockCtrl struct {
+		status model.LinkHealthStatus
+		after  string
+		limit  int
+		output []model.ShortUrl
+		err    error
+	}
+
+	tcs := map[string]struct {
+		target   string
+		mockCtrl *mockCtrl
+		wantCode int
+		wantResp string
+		wantErr  *httpserver.Error
+	}{
+		"success - broken links": {
+			target: "/api/admin/v1/links?health=broken",
+			mockCtrl: &mockCtrl{
+				status: model.LinkHealthBroken,
+				limit:  50,
+				output: []model.ShortUrl{
+					{
+						ShortCode:   "abc123",
+						OriginalURL: "http://example.com/old",
+						Health: model.LinkHealth{
+							Status:              model.LinkHealthBroken,
+							HTTPStatus:          404,
+							RedirectChain:       []string{"https://example.com/old"},
+							ConsecutiveFailures: 3,
+							CheckedAt:           time.Date(2025, 10, 20, 0, 0, 0, 0, time.UTC),
+							LastOKAt:            time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC),
+						},
+					},
+					{
+						ShortCode:   "def456",
+						OriginalURL: "https://gone.example",
+						Health: model.LinkHealth{
+							Status:              model.LinkHealthBroken,
+							ConsecutiveFailures: 5,
+							CheckedAt:           time.Date(2025, 10, 20, 0, 0, 0, 0, time.UTC),
+						},
+					},
+				},
+			},
+			wantCode: http.StatusOK,
+			wantResp: `{"links":[` +
+				`{"short_code":"abc123","original_url":"http://example.com/old","health":{"status":"BROKEN","http_status":404,"redirect_chain":["https://example.com/old"],"consecutive_failures":3,"checked_at":"2025-10-20T00:00:00Z","last_ok_at":"2025-10-01T00:00:00Z"}},` +
+				`{"short_code":"def456","original_url":"https://gone.example","health":{"status":"BROKEN","consecutive_failures":5,"checked_at":"2025-10-20T00:00:00Z"}}]}`,
+		},
+		"success - full page has a next page": {
+			target: "/api/admin/v1/links?health=unreachable&after=aaa&limit=1",
+			mockCtrl: &mockCtrl{
+				status: model.LinkHealthUnreachable,
+				after:  "aaa",
+				limit:  1,
+				output: []model.ShortUrl{
+					{ShortCode: "bbb", OriginalURL: "https://example.com", Health: model.LinkHealth{Status: model.LinkHealthUnreachable, ConsecutiveFailures: 1}},
+				},
+			},
+			wantCode: http.StatusOK,
+			wantResp: `{"links":[{"short_code":"bbb","original_url":"https://example.com","health":{"status":"UNREACHABLE","consecutive_failures":1}}],"next_after":"bbb"}`,
+		},
+		"fail - missing health": {
+			target:   "/api/admin/v1/links",
+			wantCode: http.StatusBadRequest,
+			wantErr:  WebErrInvalidHealth,
+		},
+		"fail - unknown health": {
+			target:   "/api/admin/v1/links?health=dead",
+			wantCode: http.StatusBadRequest,
+			wantErr:  WebErrInvalidHealth,
+		},
+		"fail - invalid limit": {
+			target:   "/api/admin/v1/links?health=broken&limit=1000",
+			wantCode: http.StatusBadRequest,
+			wantErr:  WebErrInvalidLimit,
+		},
+		"fail - controller error": {
+			target: "/api/admin/v1/links?health=broken",
+			mockCtrl: &mockCtrl{
+				status: model.LinkHealthBroken,
+				limit:  50,
+				err:    errors.New("error"),
+			},
+			wantCode: http.StatusInternalServerError,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tc.target, nil)
+			rec := httptest.NewRecorder()
+
+			ctrl := new(shorturl.MockController)
+			if tc.mockCtrl != nil {
+				ctrl.ExpectedCalls = []*mock.Call{
+					ctrl.On("ListLinksByHealth", mock.Anything, tc.mockCtrl.status, tc.mockCtrl.after, tc.mockCtrl.limit).
+						Return(tc.mockCtrl.output, tc.mockCtrl.err),
+				}
+			}
+
+			handler := Handler{shortUrlCtrl: ctrl}
+			handler.Links().ServeHTTP(rec, req)
+			require.Equal(t, tc.wantCode, rec.Code)
+			if tc.wantResp != "" {
+				require.Equal(t, tc.wantResp, rec.Body.String())
+			}
+			if tc.wantErr != nil {
+				var actErr httpserver.Error
+				require.Error(t, httpserver.ParseJSON(rec.Result().Body, &actErr))
+				require.Equal(t, tc.wantErr.Code, actErr.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package public
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/controller/shorturl"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/httpserver"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/monitoring"
+)
+
+// Asset creates an HTTP handler function serving a cached thumbnail by its content hash.
+// Assets are content-addressed and never change, so they are cached forever by clients and CDNs.
+func (h *Handler) Asset() http.HandlerFunc {
+	return httpserver.HandlerErr(func(w http.ResponseWriter, r *http.Request) error {
+		var err error
+		ctx := r.Context()
+		ctx, span := monitoring.Start(ctx, "Handler.Asset")
+		defer monitoring.End(span, &err)
+
+		hash := chi.URLParam(r, "hash")
+		etag := `"` + hash + `"`
+
+		// The hash is the content, a client holding it is up to date
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return nil
+		}
+
+		data, err := h.shortUrlCtrl.GetAsset(ctx, hash)
+		if err != nil {
+			// A thumbnail not cached (yet) is a cache miss, not a failure
+			if errors.Is(err, shorturl.ErrAssetNotFound) {
+				monitoring.Log(ctx).Debug().Str("hash", hash).Msg("[Asset] asset not found")
+			} else {
+				monitoring.Log(ctx).Error().Stack().Err(err).Msg("[Asset] h.shortUrlCtrl.GetAsset err")
+			}
+			return convertControllerError(err)
+		}
+
+		w.Header().Set("Content-Type", http.DetectContentType(data))
+		w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+		w.Header().Set("ETag", etag)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(data)
+
+		return nil
+	})
+}
@@ -0,0 +1,92 @@
+package public
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/controller/shorturl"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/httpserver"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAsset(t *testing.T) {
+	const hash = "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+	png := []byte("\x89PNG\r\n\x1a\nthumbnail")
+
+	tcs := map[string]struct {
+		ifNoneMatch     string
+		mockCtrlData    []byte
+		mockCtrlErr     error
+		wantCode        int
+		wantContentType string
+		wantErr         *httpserver.Error
+	}{
+		"success": {
+			mockCtrlData:    png,
+			wantCode:        http.StatusOK,
+			wantContentType: "image/png",
+		},
+		"success - client copy is up to date": {
+			ifNoneMatch: `"` + hash + `"`,
+			wantCode:    http.StatusNotModified,
+		},
+		"fail - asset not found": {
+			mockCtrlErr: shorturl.ErrAssetNotFound,
+			wantCode:    http.StatusNotFound,
+			wantErr:     WebErrAssetNotFound,
+		},
+		"fail - internal server error": {
+			mockCtrlErr: errors.New("some error"),
+			wantCode:    http.StatusInternalServerError,
+			wantErr:     httpserver.ErrDefaultInternal,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/assets/"+hash, nil)
+			if tc.ifNoneMatch != "" {
+				req.Header.Set("If-None-Match", tc.ifNoneMatch)
+			}
+			routeCtx := chi.NewRouteContext()
+			routeCtx.URLParams.Add("hash", hash)
+
+			ctx := context.WithValue(req.Context(), chi.RouteCtxKey, routeCtx)
+			rec := httptest.NewRecorder()
+			req = req.WithContext(ctx)
+
+			ctrl := new(shorturl.MockController)
+			ctrl.ExpectedCalls = []*mock.Call{
+				ctrl.On("GetAsset", mock.Anything, hash).Return(tc.mockCtrlData, tc.mockCtrlErr),
+			}
+
+			handler := Handler{shortUrlCtrl: ctrl}
+			handler.Asset().ServeHTTP(rec, req)
+			require.Equal(t, tc.wantCode, rec.Code)
+
+			if tc.wantErr != nil {
+				var actErr httpserver.Error
+				require.Error(t, httpserver.ParseJSON(rec.Result().Body, &actErr))
+				require.Equal(t, tc.wantErr.Code, actErr.Code)
+				require.Equal(t, tc.wantErr.Desc, actErr.Desc)
+				return
+			}
+
+			if tc.wantCode == http.StatusNotModified {
+				ctrl.AssertNotCalled(t, "GetAsset", mock.Anything, mock.Anything)
+				require.Empty(t, rec.Body.Bytes())
+				return
+			}
+
+			require.Equal(t, tc.wantContentType, rec.Header().Get("Content-Type"))
+			require.Equal(t, "public, max-age=31536000, immutable", rec.Header().Get("Cache-Control"))
+			require.Equal(t, `"`+hash+`"`, rec.Header().Get("ETag"))
+			require.Equal(t, tc.mockCtrlData, rec.Body.Bytes())
+		})
+	}
+}
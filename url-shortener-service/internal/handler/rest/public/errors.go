@@ -18,6 +18,12 @@ var (
 	WebErrInactiveOriginalURL = &httpserver.Error{Status: http.StatusBadRequest, Code: "inactive_url", Desc: "URL is inactive"}
 	// WebErrURLNotFound means URL not found
 	WebErrURLNotFound = &httpserver.Error{Status: http.StatusBadRequest, Code: "url_not_found", Desc: "URL not found"}
+	// WebErrRefreshRateLimited means the URL metadata was already refreshed recently
+	WebErrRefreshRateLimited = &httpserver.Error{Status: http.StatusTooManyRequests, Code: "refresh_rate_limited", Desc: "URL metadata was refreshed recently, try again later"}
+	// WebErrDestinationBlocked means the original_url matches the destination blocklist
+	WebErrDestinationBlocked = &httpserver.Error{Status: http.StatusForbidden, Code: "destination_blocked", Desc: "URL destination is not allowed"}
+	// WebErrAssetNotFound means no cached asset matches the hash
+	WebErrAssetNotFound = &httpserver.Error{Status: http.StatusNotFound, Code: "asset_not_found", Desc: "Asset not found"}
 )
 
 func convertControllerError(err error) error {
@@ -26,6 +32,12 @@ func convertControllerError(err error) error {
 		return WebErrInactiveOriginalURL
 	case shorturl.ErrURLNotfound:
 		return WebErrURLNotFound
+	case shorturl.ErrRefreshRateLimited:
+		return WebErrRefreshRateLimited
+	case shorturl.ErrDestinationBlocked:
+		return WebErrDestinationBlocked
+	case shorturl.ErrAssetNotFound:
+		return WebErrAssetNotFound
 	default:
 		return err
 	}
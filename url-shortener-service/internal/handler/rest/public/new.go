@@ -2,14 +2,34 @@ package public
 
 import (
 	"github.com/kytruongdev/sturl/url-shortener-service/internal/controller/shorturl"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/pkg/validator"
 )
 
 // Handler represents the HTTP handler for public short URL endpoints.
 type Handler struct {
 	shortUrlCtrl shorturl.Controller
+	urlValidator validator.Validator
+}
+
+// Option enables tweaking the handler
+type Option func(*Handler)
+
+// WithURLValidator overrides the validator of submitted URLs, which only checks their syntax by default
+func WithURLValidator(v validator.Validator) Option {
+	return func(h *Handler) {
+		h.urlValidator = v
+	}
 }
 
 // New creates and returns a new Handler instance with the provided controller.
-func New(shortUrlCtrl shorturl.Controller) *Handler {
-	return &Handler{shortUrlCtrl: shortUrlCtrl}
+func New(shortUrlCtrl shorturl.Controller, opts ...Option) *Handler {
+	h := &Handler{
+		shortUrlCtrl: shortUrlCtrl,
+		urlValidator: validator.New(validator.ModeSyntax),
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	return h
 }
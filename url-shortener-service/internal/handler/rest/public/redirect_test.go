@@ -58,6 +58,15 @@ func TestRedirect(t *testing.T) {
 			wantCode: http.StatusBadRequest,
 			wantErr:  WebErrInactiveOriginalURL,
 		},
+		"fail - destination flagged as blocked": {
+			shortCode: "gg",
+			mockCtrl: mockCtrl{
+				inp: "gg",
+				err: shorturl.ErrDestinationBlocked,
+			},
+			wantCode: http.StatusForbidden,
+			wantErr:  WebErrDestinationBlocked,
+		},
 		"fail - internal server error": {
 			shortCode: "gg",
 			mockCtrl: mockCtrl{
@@ -0,0 +1,47 @@
+package public
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/httpserver"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/monitoring"
+)
+
+// RefreshMetadataResponse represents the HTTP response for an accepted metadata refresh.
+type RefreshMetadataResponse struct {
+	ShortCode string `json:"short_code"`
+	Status    string `json:"status"`
+}
+
+// RefreshMetadata creates an HTTP handler function for re-crawling the metadata of a short code on demand.
+// The crawl runs asynchronously, so it answers HTTP 202 once the crawl is enqueued.
+func (h *Handler) RefreshMetadata() http.HandlerFunc {
+	return httpserver.HandlerErr(func(w http.ResponseWriter, r *http.Request) error {
+		var err error
+		ctx := r.Context()
+		ctx, span := monitoring.Start(ctx, "Handler.RefreshMetadata")
+		defer monitoring.End(span, &err)
+
+		l := monitoring.Log(ctx)
+
+		shortCode := chi.URLParam(r, "shortcode")
+		l.Info().Str("shortcode", shortCode).Msg("[RefreshMetadata] starting metadata refresh")
+
+		if shortCode == "" {
+			return WebErrEmptyShortCode
+		}
+
+		if err = h.shortUrlCtrl.RefreshMetadata(ctx, shortCode); err != nil {
+			l.Error().Stack().Err(err).Msg("[RefreshMetadata] h.shortUrlCtrl.RefreshMetadata err")
+			return convertControllerError(err)
+		}
+
+		httpserver.RespondJSON(w, httpserver.Response{
+			Status: http.StatusAccepted,
+			Data:   RefreshMetadataResponse{ShortCode: shortCode, Status: "ACCEPTED"},
+		})
+
+		return nil
+	})
+}
@@ -0,0 +1,93 @@
+package public
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/controller/shorturl"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/httpserver"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRefreshMetadata(t *testing.T) {
+	tcs := map[string]struct {
+		shortCode   string
+		mockCtrlErr error
+		wantCode    int
+		wantResp    string
+		wantErr     *httpserver.Error
+	}{
+		"success": {
+			shortCode: "gg",
+			wantCode:  http.StatusAccepted,
+			wantResp:  `{"short_code":"gg","status":"ACCEPTED"}`,
+		},
+		"fail - empty short code": {
+			shortCode: "",
+			wantCode:  http.StatusBadRequest,
+			wantErr:   WebErrEmptyShortCode,
+		},
+		"fail - url not found": {
+			shortCode:   "gg",
+			mockCtrlErr: shorturl.ErrURLNotfound,
+			wantCode:    http.StatusBadRequest,
+			wantErr:     WebErrURLNotFound,
+		},
+		"fail - inactive url": {
+			shortCode:   "gg",
+			mockCtrlErr: shorturl.ErrInactiveURL,
+			wantCode:    http.StatusBadRequest,
+			wantErr:     WebErrInactiveOriginalURL,
+		},
+		"fail - refreshed recently": {
+			shortCode:   "gg",
+			mockCtrlErr: shorturl.ErrRefreshRateLimited,
+			wantCode:    http.StatusTooManyRequests,
+			wantErr:     WebErrRefreshRateLimited,
+		},
+		"fail - internal server error": {
+			shortCode:   "gg",
+			mockCtrlErr: errors.New("some error"),
+			wantCode:    http.StatusInternalServerError,
+			wantErr:     httpserver.ErrDefaultInternal,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api/public/v1/links/"+tc.shortCode+"/metadata:refresh", nil)
+			routeCtx := chi.NewRouteContext()
+			routeCtx.URLParams.Add("shortcode", tc.shortCode)
+
+			ctx := context.WithValue(req.Context(), chi.RouteCtxKey, routeCtx)
+			rec := httptest.NewRecorder()
+			req = req.WithContext(ctx)
+
+			ctrl := new(shorturl.MockController)
+			ctrl.ExpectedCalls = []*mock.Call{
+				ctrl.On("RefreshMetadata", mock.Anything, tc.shortCode).Return(tc.mockCtrlErr),
+			}
+
+			handler := Handler{shortUrlCtrl: ctrl}
+			handler.RefreshMetadata().ServeHTTP(rec, req)
+			require.Equal(t, tc.wantCode, rec.Code)
+
+			var actErr httpserver.Error
+			err := httpserver.ParseJSON(rec.Result().Body, &actErr)
+			if tc.wantErr != nil {
+				require.Error(t, err)
+				require.Equal(t, tc.wantErr.Code, actErr.Code)
+				require.Equal(t, tc.wantErr.Desc, actErr.Desc)
+			} else {
+				require.Empty(t, actErr.Code)
+				require.Empty(t, actErr.Desc)
+				require.Equal(t, tc.wantResp, rec.Body.String())
+			}
+		})
+	}
+}
@@ -10,7 +10,6 @@ import (
 	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/httpserver"
 	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/monitoring"
 	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
-	"github.com/kytruongdev/sturl/url-shortener-service/internal/pkg/validator"
 )
 
 // ShortenRequest represents the HTTP request payload for creating a short URL.
@@ -38,7 +37,7 @@ func (h *Handler) Shorten() http.HandlerFunc {
 
 		l := monitoring.Log(ctx)
 
-		inp, err := validateAndMapToShortenInput(ctx, r)
+		inp, err := h.validateAndMapToShortenInput(ctx, r)
 		if err != nil {
 			l.Error().Stack().Err(err).Msg("[Shorten] mapToShortenInput err")
 			return err
@@ -60,7 +59,7 @@ func (h *Handler) Shorten() http.HandlerFunc {
 	})
 }
 
-func validateAndMapToShortenInput(ctx context.Context, r *http.Request) (shorturl.ShortenInput, error) {
+func (h *Handler) validateAndMapToShortenInput(ctx context.Context, r *http.Request) (shorturl.ShortenInput, error) {
 	l := monitoring.Log(ctx)
 	defer l.TimeTrack(time.Now(), "[Shorten] validate and map to ShortenInput")
 
@@ -73,7 +72,9 @@ func validateAndMapToShortenInput(ctx context.Context, r *http.Request) (shortur
 		return shorturl.ShortenInput{}, WebErrEmptyOriginalURL
 	}
 
-	if err := validator.ValidateURL(req.OriginalURL); err != nil {
+	// Syntax only by default: reachability is recorded later by the crawler as link health
+	if err := h.urlValidator.ValidateURL(ctx, req.OriginalURL); err != nil {
+		l.Warn().Err(err).Msg("[Shorten] original url rejected")
 		return shorturl.ShortenInput{}, WebErrInvalidOriginalURL
 	}
 
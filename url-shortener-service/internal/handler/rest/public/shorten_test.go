@@ -62,6 +62,17 @@ func TestShorten(t *testing.T) {
 			wantCode:    http.StatusBadRequest,
 			wantErr:     WebErrInvalidOriginalURL,
 		},
+		"destination blocked": {
+			requestBody: `{"original_url": "https://google.com"}`,
+			mockCtrl: mockCtrl{
+				inp: shorturl.ShortenInput{
+					OriginalURL: "https://google.com",
+				},
+				err: shorturl.ErrDestinationBlocked,
+			},
+			wantCode: http.StatusForbidden,
+			wantErr:  WebErrDestinationBlocked,
+		},
 		"controller returns error": {
 			requestBody: `{"original_url": "https://google.com"}`,
 			mockCtrl: mockCtrl{
@@ -3,27 +3,56 @@ package handler
 import (
 	"github.com/go-chi/chi/v5"
 	"github.com/kytruongdev/sturl/url-shortener-service/internal/controller/shorturl"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/handler/rest/admin"
 	"github.com/kytruongdev/sturl/url-shortener-service/internal/handler/rest/public"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/httpserver"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/pkg/validator"
 )
 
 // Router represents the HTTP router configuration for the URL shortener service.
 type Router struct {
 	CorsOrigins  []string
 	ShortURLCtrl shorturl.Controller
+	URLValidator validator.Validator
+	AdminToken   string // bearer token required by the admin routes
 }
 
 // Routes registers all routes on the provided chi.Router.
 func (rtr Router) Routes(r chi.Router) {
 	r.Group(rtr.public)
+	r.Group(rtr.assets)
+	r.Group(rtr.admin)
 }
 
 // public registers public API routes under the /api/public prefix.
-// It sets up handlers for URL shortening and redirection endpoints.
+// It sets up handlers for URL shortening, redirection and metadata refresh endpoints.
 func (rtr Router) public(r chi.Router) {
 	const prefix = "/api/public"
 	r.Group(func(r chi.Router) {
-		shortURLHandler := public.New(rtr.ShortURLCtrl)
+		shortURLHandler := public.New(rtr.ShortURLCtrl, public.WithURLValidator(rtr.URLValidator))
 		r.Post(prefix+"/v1/shorten", shortURLHandler.Shorten())
 		r.Get(prefix+"/v1/redirect/{shortcode}", shortURLHandler.Redirect())
+		r.Post(prefix+"/v1/links/{shortcode}/metadata:refresh", shortURLHandler.RefreshMetadata())
+	})
+}
+
+// assets registers the routes serving cached thumbnails of link previews.
+func (rtr Router) assets(r chi.Router) {
+	r.Group(func(r chi.Router) {
+		shortURLHandler := public.New(rtr.ShortURLCtrl)
+		r.Get("/assets/{hash}", shortURLHandler.Asset())
+	})
+}
+
+// admin registers operational API routes under the /api/admin prefix.
+// These routes are meant for internal access only: they require the admin bearer token, and reject every
+// request when none is configured.
+func (rtr Router) admin(r chi.Router) {
+	const prefix = "/api/admin"
+	r.Group(func(r chi.Router) {
+		r.Use(httpserver.BearerAuth(rtr.AdminToken))
+		adminHandler := admin.New(rtr.ShortURLCtrl)
+		r.Get(prefix+"/v1/crawl-health", adminHandler.CrawlHealth())
+		r.Get(prefix+"/v1/links", adminHandler.Links())
 	})
 }
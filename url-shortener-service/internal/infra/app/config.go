@@ -3,23 +3,36 @@ package app
 import (
 	"errors"
 	"os"
+	"time"
 )
 
 // Config represents application-level configuration for business logic.
 // It contains service identification and environment settings.
 type Config struct {
-	ServiceName string
-	AppEnv      string
+	ServiceName     string
+	AppEnv          string
+	ShutdownTimeout time.Duration // How long a service may take to shut down once signaled (default: 5s)
 }
 
 // NewConfig creates a new app configuration from environment variables.
 func NewConfig() Config {
 	return Config{
-		ServiceName: os.Getenv("SERVICE_NAME"),
-		AppEnv:      os.Getenv("APP_ENV"),
+		ServiceName:     os.Getenv("SERVICE_NAME"),
+		AppEnv:          os.Getenv("APP_ENV"),
+		ShutdownTimeout: getDurationEnv("SHUTDOWN_TIMEOUT", DefaultShutdownTimeout),
 	}
 }
 
+// getDurationEnv parses a duration from environment variable with a default fallback.
+// Returns the parsed value if valid and positive, otherwise returns the default.
+func getDurationEnv(key string, defaultVal time.Duration) time.Duration {
+	d, err := time.ParseDuration(os.Getenv(key))
+	if err != nil || d <= 0 {
+		return defaultVal
+	}
+	return d
+}
+
 // Validate checks that the app configuration contains a valid fields.
 func (cfg Config) Validate() error {
 	if cfg.ServiceName == "" {
@@ -16,23 +16,45 @@ type Service interface {
 	Shutdown(context.Context) error
 }
 
+// DefaultShutdownTimeout is how long Shutdown may take unless overridden with WithShutdownTimeout
+const DefaultShutdownTimeout = 5 * time.Second
+
 // runner coordinates the application lifecycle: receives context from main,
 // listens for signals, and calls Run and Shutdown.
 type runner struct {
-	Name string // optional, can be used for logging later
+	Name            string // optional, can be used for logging later
+	shutdownTimeout time.Duration
+}
+
+// Option enables tweaking the runner
+type Option func(*runner)
+
+// WithShutdownTimeout overrides how long Shutdown may take, ignored unless positive
+func WithShutdownTimeout(d time.Duration) Option {
+	return func(r *runner) {
+		if d > 0 {
+			r.shutdownTimeout = d
+		}
+	}
 }
 
 // New creates and returns a new runner instance with the provided service name.
-func New(name string) runner {
-	return runner{
-		Name: name,
+func New(name string, opts ...Option) runner {
+	r := runner{
+		Name:            name,
+		shutdownTimeout: DefaultShutdownTimeout,
 	}
+	for _, opt := range opts {
+		opt(&r)
+	}
+
+	return r
 }
 
 // Run runs the service with:
 // - ctx from main (does not create Background context)
 // - signal.Notify to handle SIGINT/SIGTERM
-// - graceful shutdown with 5s timeout
+// - graceful shutdown with the shutdown timeout, 5s by default
 func (r runner) Run(ctx context.Context, svc Service) error {
 	// Wrap the original context from main with signal handling
 	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGINT, syscall.SIGTERM)
@@ -63,7 +85,7 @@ func (r runner) Run(ctx context.Context, svc Service) error {
 	}
 
 	// Whether it's a signal or service error, always attempt graceful shutdown
-	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), r.shutdownTimeout)
 	defer cancel()
 
 	shutdownErr := svc.Shutdown(shutdownCtx)
@@ -0,0 +1,86 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// service records the time left to Shutdown
+type service struct {
+	runErr      error
+	shutdownErr error
+	timeLeft    time.Duration
+}
+
+func (s *service) Run(ctx context.Context) error {
+	if s.runErr != nil {
+		return s.runErr
+	}
+	<-ctx.Done()
+	return nil
+}
+
+func (s *service) Shutdown(ctx context.Context) error {
+	deadline, _ := ctx.Deadline()
+	s.timeLeft = time.Until(deadline)
+	return s.shutdownErr
+}
+
+func TestRunner_Run(t *testing.T) {
+	errRun := errors.New("run failed")
+	errShutdown := errors.New("shutdown failed")
+
+	tcs := map[string]struct {
+		opts        []Option
+		svc         *service
+		wantTimeout time.Duration
+		wantErr     error
+	}{
+		"success - default shutdown timeout": {
+			svc:         &service{},
+			wantTimeout: DefaultShutdownTimeout,
+		},
+		"success - shutdown timeout overridden": {
+			opts:        []Option{WithShutdownTimeout(30 * time.Second)},
+			svc:         &service{},
+			wantTimeout: 30 * time.Second,
+		},
+		"success - invalid shutdown timeout ignored": {
+			opts:        []Option{WithShutdownTimeout(0)},
+			svc:         &service{},
+			wantTimeout: DefaultShutdownTimeout,
+		},
+		"error - run failed, shut down anyway": {
+			svc:         &service{runErr: errRun, shutdownErr: errShutdown},
+			wantTimeout: DefaultShutdownTimeout,
+			wantErr:     errRun,
+		},
+		"error - shutdown failed": {
+			svc:         &service{shutdownErr: errShutdown},
+			wantTimeout: DefaultShutdownTimeout,
+			wantErr:     errShutdown,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			// Given: signaled unless the service fails on its own
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tc.svc.runErr == nil {
+				cancel()
+			}
+
+			// When
+			err := New("test", tc.opts...).Run(ctx, tc.svc)
+
+			// Then
+			require.Equal(t, tc.wantErr, err)
+			require.InDelta(t, tc.wantTimeout, tc.svc.timeLeft, float64(time.Second))
+		})
+	}
+}
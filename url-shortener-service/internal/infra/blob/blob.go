@@ -0,0 +1,45 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+var (
+	// ErrNotFound means no blob is stored under the key
+	ErrNotFound = errors.New("blob not found")
+	// ErrInvalidKey means the key contains characters which are not allowed
+	ErrInvalidKey = errors.New("invalid blob key")
+)
+
+// validKey restricts keys to a flat namespace, so a key can never escape the store root
+var validKey = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// Store defines the interface for storing immutable binary objects by key.
+type Store interface {
+	// Put stores data under key, overriding any existing blob
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+	// Get returns the data stored under key, or ErrNotFound
+	Get(ctx context.Context, key string) ([]byte, error)
+}
+
+// New creates the Store selected by the configuration.
+func New(cfg Config) (Store, error) {
+	switch cfg.Driver {
+	case DriverFilesystem:
+		return NewFilesystem(cfg.FSDir)
+	case DriverS3:
+		return NewS3(cfg.S3), nil
+	default:
+		return nil, fmt.Errorf("unsupported blob store driver %q", cfg.Driver)
+	}
+}
+
+func checkKey(key string) error {
+	if !validKey.MatchString(key) {
+		return fmt.Errorf("%w: %q", ErrInvalidKey, key)
+	}
+	return nil
+}
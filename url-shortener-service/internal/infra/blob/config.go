@@ -0,0 +1,84 @@
+package blob
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+const (
+	// DriverFilesystem stores blobs in a local directory
+	DriverFilesystem = "fs"
+	// DriverS3 stores blobs in an S3-compatible bucket
+	DriverS3 = "s3"
+)
+
+// Config represents the blob store configuration.
+type Config struct {
+	Driver string // Storage backend, DriverFilesystem (default) or DriverS3
+	FSDir  string // Root directory of the filesystem store
+	S3     S3Config
+	// PublicBaseURL prefixes the /assets/{hash} URLs blobs are served from, empty for same-origin relative URLs
+	PublicBaseURL string
+}
+
+// S3Config represents the configuration of an S3-compatible bucket.
+type S3Config struct {
+	Endpoint        string // e.g. https://s3.amazonaws.com or http://minio:9000
+	Region          string
+	Bucket          string
+	AccessKeyID     string
+	SecretAccessKey string
+	PathStyle       bool // address the bucket as endpoint/bucket instead of bucket.endpoint, as most S3-compatible servers expect
+}
+
+// NewConfig creates a new blob store configuration from environment variables.
+func NewConfig() Config {
+	cfg := Config{
+		Driver: strings.ToLower(os.Getenv("BLOB_STORE")),
+		FSDir:  os.Getenv("BLOB_FS_DIR"),
+		S3: S3Config{
+			Endpoint:        os.Getenv("S3_ENDPOINT"),
+			Region:          os.Getenv("S3_REGION"),
+			Bucket:          os.Getenv("S3_BUCKET"),
+			AccessKeyID:     os.Getenv("S3_ACCESS_KEY_ID"),
+			SecretAccessKey: os.Getenv("S3_SECRET_ACCESS_KEY"),
+			PathStyle:       os.Getenv("S3_PATH_STYLE") == "true",
+		},
+		PublicBaseURL: strings.TrimRight(os.Getenv("ASSETS_BASE_URL"), "/"),
+	}
+
+	if cfg.Driver == "" {
+		cfg.Driver = DriverFilesystem
+	}
+	if cfg.FSDir == "" {
+		cfg.FSDir = "data/assets"
+	}
+	if cfg.S3.Region == "" {
+		cfg.S3.Region = "us-east-1"
+	}
+
+	return cfg
+}
+
+// Validate checks that the blob store configuration is complete for the selected driver.
+func (c Config) Validate() error {
+	switch c.Driver {
+	case DriverFilesystem:
+		return nil
+	case DriverS3:
+		for env, v := range map[string]string{
+			"S3_ENDPOINT":          c.S3.Endpoint,
+			"S3_BUCKET":            c.S3.Bucket,
+			"S3_ACCESS_KEY_ID":     c.S3.AccessKeyID,
+			"S3_SECRET_ACCESS_KEY": c.S3.SecretAccessKey,
+		} {
+			if v == "" {
+				return fmt.Errorf("[blob.Config] required env variable '%s' not found", env)
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("[blob.Config] unsupported BLOB_STORE '%s'", c.Driver)
+	}
+}
@@ -0,0 +1,77 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	pkgerrors "github.com/pkg/errors"
+)
+
+// filesystem stores blobs as files, sharded by the first two characters of the key
+type filesystem struct {
+	root string
+}
+
+// NewFilesystem creates a Store keeping blobs under the root directory, which is created when missing.
+func NewFilesystem(root string) (Store, error) {
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, pkgerrors.WithStack(err)
+	}
+	return filesystem{root: root}, nil
+}
+
+// Put implements Store. The file is written to a temporary name first, so readers never see a partial blob.
+func (f filesystem) Put(_ context.Context, key string, data []byte, _ string) error {
+	if err := checkKey(key); err != nil {
+		return err
+	}
+
+	path := f.path(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return pkgerrors.WithStack(err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return pkgerrors.WithStack(err)
+	}
+	defer os.Remove(tmp.Name()) // no-op once renamed
+
+	if _, err = tmp.Write(data); err != nil {
+		tmp.Close()
+		return pkgerrors.WithStack(err)
+	}
+	if err = tmp.Close(); err != nil {
+		return pkgerrors.WithStack(err)
+	}
+
+	return pkgerrors.WithStack(os.Rename(tmp.Name(), path))
+}
+
+// Get implements Store
+func (f filesystem) Get(_ context.Context, key string) ([]byte, error) {
+	if err := checkKey(key); err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(f.path(key))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, pkgerrors.WithStack(ErrNotFound)
+		}
+		return nil, pkgerrors.WithStack(err)
+	}
+
+	return data, nil
+}
+
+func (f filesystem) path(key string) string {
+	shard := key
+	if len(shard) > 2 {
+		shard = shard[:2]
+	}
+	return filepath.Join(f.root, shard, key)
+}
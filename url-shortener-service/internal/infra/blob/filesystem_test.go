@@ -0,0 +1,50 @@
+package blob
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilesystem(t *testing.T) {
+	tcs := map[string]struct {
+		putKey  string
+		getKey  string
+		wantErr error
+	}{
+		"put then get": {
+			putKey: "ab12cd",
+			getKey: "ab12cd",
+		},
+		"get missing key": {
+			getKey:  "ffff",
+			wantErr: ErrNotFound,
+		},
+		"reject path traversal": {
+			getKey:  "../etc/passwd",
+			wantErr: ErrInvalidKey,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			store, err := NewFilesystem(t.TempDir())
+			require.NoError(t, err)
+
+			if tc.putKey != "" {
+				require.NoError(t, store.Put(ctx, tc.putKey, []byte("thumbnail"), "image/png"))
+			}
+
+			actual, err := store.Get(ctx, tc.getKey)
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, []byte("thumbnail"), actual)
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package pg
+
+import (
+	"context"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/jackc/pgx/v5"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/monitoring"
+	pkgerrors "github.com/pkg/errors"
+)
+
+// Listener receives the notifications of a Postgres channel on a dedicated connection,
+// outside of the database/sql pool, and reconnects whenever the connection drops.
+//
+// Notifications are coalesced: Notifications only tells that at least one arrived since the last read,
+// which is all a caller needs to go and look for new rows.
+type Listener struct {
+	dbURL   string
+	channel string
+	notify  chan struct{}
+}
+
+// NewListener creates a Listener on channel. It does not connect until Run is called.
+func NewListener(dbURL, channel string) *Listener {
+	return &Listener{
+		dbURL:   dbURL,
+		channel: channel,
+		notify:  make(chan struct{}, 1),
+	}
+}
+
+// Notifications returns the channel signaled on every notification, and on every (re)connection
+// since notifications sent while disconnected are lost.
+func (l *Listener) Notifications() <-chan struct{} {
+	return l.notify
+}
+
+// Run listens until ctx is canceled, reconnecting with an exponential backoff.
+func (l *Listener) Run(ctx context.Context) error {
+	log := monitoring.Log(ctx).Field("channel", l.channel)
+
+	b := backoff.NewExponentialBackOff()
+	b.InitialInterval = time.Second
+	b.MaxInterval = 30 * time.Second
+	b.MaxElapsedTime = 0 // never give up, callers keep polling meanwhile
+
+	for {
+		connected, err := l.listen(ctx)
+		if ctx.Err() != nil {
+			return nil
+		}
+		if connected {
+			b.Reset()
+		}
+
+		wait := b.NextBackOff()
+		log.Warn().Err(err).Dur("retry_in", wait).Msg("[Listener.Run] connection lost, reconnecting")
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(wait):
+		}
+	}
+}
+
+// listen connects, subscribes to the channel and forwards notifications until the connection fails.
+// It reports whether the subscription was established.
+func (l *Listener) listen(ctx context.Context) (bool, error) {
+	conn, err := pgx.Connect(ctx, l.dbURL)
+	if err != nil {
+		return false, pkgerrors.WithStack(err)
+	}
+	defer conn.Close(context.Background())
+
+	if _, err = conn.Exec(ctx, "LISTEN "+pgx.Identifier{l.channel}.Sanitize()); err != nil {
+		return false, pkgerrors.WithStack(err)
+	}
+
+	monitoring.Log(ctx).Field("channel", l.channel).Info().Msg("[Listener.listen] listening")
+	l.signal()
+
+	for {
+		if _, err = conn.WaitForNotification(ctx); err != nil {
+			return true, pkgerrors.WithStack(err)
+		}
+		l.signal()
+	}
+}
+
+// signal wakes up the reader without blocking, a pending wake-up already covers this one
+func (l *Listener) signal() {
+	select {
+	case l.notify <- struct{}{}:
+	default:
+	}
+}
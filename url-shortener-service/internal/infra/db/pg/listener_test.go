@@ -0,0 +1,74 @@
+package pg
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestListener_Run(t *testing.T) {
+	const channel = "listener_test"
+
+	db, err := Connect(os.Getenv("PG_URL"))
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	l := NewListener(os.Getenv("PG_URL"), channel)
+	done := make(chan error, 1)
+	go func() {
+		done <- l.Run(ctx)
+	}()
+
+	// wake waits for a wake-up of the listener
+	wake := func(what string) {
+		select {
+		case <-l.Notifications():
+		case <-time.After(10 * time.Second):
+			require.FailNow(t, "no wake-up: "+what)
+		}
+	}
+	notify := func() {
+		_, err := db.ExecContext(ctx, "SELECT pg_notify($1, '')", channel)
+		require.NoError(t, err)
+	}
+
+	// Given: listening
+	wake("connected")
+
+	// When: notified
+	notify()
+
+	// Then
+	wake("notified")
+
+	// When: the connection is killed
+	rs, err := db.ExecContext(ctx,
+		"SELECT pg_terminate_backend(pid) FROM pg_stat_activity WHERE query = $1",
+		`LISTEN "`+channel+`"`)
+	require.NoError(t, err)
+	terminated, err := rs.RowsAffected()
+	require.NoError(t, err)
+	require.Equal(t, int64(1), terminated)
+
+	// Then: woken up by the reconnection, as notifications sent meanwhile are lost, and notified again
+	wake("reconnected")
+	notify()
+	wake("notified after reconnection")
+
+	// When: stopped
+	cancel()
+
+	// Then
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "listener did not stop")
+	}
+}
@@ -0,0 +1,33 @@
+package httpserver
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+)
+
+var (
+	// ErrUnauthorized is returned when a request lacks the expected bearer token
+	ErrUnauthorized = &Error{
+		Status: http.StatusUnauthorized,
+		Code:   "unauthorized",
+		Desc:   "Missing or invalid bearer token",
+	}
+)
+
+// BearerAuth creates an HTTP middleware that only lets through requests carrying token in their
+// Authorization header. An empty token rejects every request, so that routes are never left open by mistake.
+func BearerAuth(token string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			got, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if !ok || token == "" || subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+				w.Header().Set("WWW-Authenticate", `Bearer realm="admin"`)
+				RespondJSON(w, ErrUnauthorized)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package httpserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBearerAuth(t *testing.T) {
+	tcs := map[string]struct {
+		token      string
+		header     string
+		wantStatus int
+	}{
+		"success - token matches": {
+			token:      "s3cret",
+			header:     "Bearer s3cret",
+			wantStatus: http.StatusOK,
+		},
+		"error - no header": {
+			token:      "s3cret",
+			wantStatus: http.StatusUnauthorized,
+		},
+		"error - wrong token": {
+			token:      "s3cret",
+			header:     "Bearer guess",
+			wantStatus: http.StatusUnauthorized,
+		},
+		"error - not a bearer token": {
+			token:      "s3cret",
+			header:     "Basic s3cret",
+			wantStatus: http.StatusUnauthorized,
+		},
+		"error - no token configured": {
+			header:     "Bearer ",
+			wantStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			// Given
+			next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusOK) })
+			req := httptest.NewRequest(http.MethodGet, "/api/admin/v1/links", nil)
+			if tc.header != "" {
+				req.Header.Set("Authorization", tc.header)
+			}
+			w := httptest.NewRecorder()
+
+			// When
+			BearerAuth(tc.token)(next).ServeHTTP(w, req)
+
+			// Then
+			require.Equal(t, tc.wantStatus, w.Code)
+			if tc.wantStatus == http.StatusUnauthorized {
+				require.JSONEq(t, `{"error":"unauthorized","error_description":"Missing or invalid bearer token"}`, w.Body.String())
+			}
+		})
+	}
+}
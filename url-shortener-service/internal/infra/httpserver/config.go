@@ -11,6 +11,7 @@ type Config struct {
 	ServiceName string
 	AppEnv      string
 	RedisAddr   string
+	AdminToken  string // Bearer token of the /api/admin routes, which reject every request without it
 }
 
 // NewConfig creates a new HTTP server configuration from environment variables
@@ -20,6 +21,7 @@ func NewConfig() Config {
 		ServiceName: os.Getenv("SERVICE_NAME"),
 		AppEnv:      os.Getenv("APP_ENV"),
 		RedisAddr:   os.Getenv("REDIS_ADDR"),
+		AdminToken:  os.Getenv("ADMIN_API_TOKEN"),
 	}
 }
 
@@ -0,0 +1,153 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	kafkago "github.com/segmentio/kafka-go"
+)
+
+// Backend names the message broker behind Producer and Consumer
+type Backend string
+
+const (
+	// BackendKafka uses a Kafka cluster, see KAFKA_BROKERS
+	BackendKafka Backend = "kafka"
+	// BackendRedis uses Redis Streams and their consumer groups on the Redis of the service, see REDIS_ADDR
+	BackendRedis Backend = "redis"
+	// BackendNATS uses NATS JetStream, see NATS_URL
+	BackendNATS Backend = "nats"
+	// BackendMemory keeps messages in process memory. Producers and consumers must share the Broker,
+	// it is meant for tests and single process runs.
+	BackendMemory Backend = "memory"
+)
+
+// ParseBackend parses a backend name, the empty name meaning kafka
+func ParseBackend(s string) (Backend, error) {
+	switch b := Backend(strings.ToLower(strings.TrimSpace(s))); b {
+	case "":
+		return BackendKafka, nil
+	case BackendKafka, BackendRedis, BackendNATS, BackendMemory:
+		return b, nil
+	default:
+		return "", fmt.Errorf("unknown broker backend %q", s)
+	}
+}
+
+// Broker creates the producers and consumers of a backend. Whatever the backend, consumers share the same
+// processing: worker pool, retry topics, DLQ on <topic>.dlq and acknowledgement once handled.
+type Broker interface {
+	// NewProducer returns a producer publishing to the broker.
+	NewProducer() Producer
+	// NewConsumer returns a consumer of topic, and of its retry topics, for the consumer group. Messages the
+	// handler fails on are published to the retry topics, then to the DLQ, with producer.
+	NewConsumer(topic, groupID string, handler MessageHandler, producer Producer) Consumer
+	// Scan calls fn with the messages of topic, from the first to the last published when called, outside of
+	// any consumer group. A topic never published to has none. It stops at the first error of fn.
+	Scan(ctx context.Context, topic string, fn func(kafkago.Message) error) error
+	// Close releases the connection to the broker, once its producers and consumers are closed.
+	Close() error
+}
+
+// Open connects to the backend selected by the config
+func Open(cfg Config) (Broker, error) {
+	switch cfg.Backend {
+	case BackendKafka, "":
+		return kafkaBroker{cfg: cfg}, nil
+	case BackendRedis:
+		return openRedisBroker(cfg)
+	case BackendNATS:
+		return openNATSBroker(cfg)
+	case BackendMemory:
+		return NewMemoryBroker(cfg), nil
+	default:
+		return nil, fmt.Errorf("unknown broker backend %q", cfg.Backend)
+	}
+}
+
+// kafkaBroker is the Kafka backend, its producers and consumers hold their own connections
+type kafkaBroker struct {
+	cfg Config
+}
+
+// NewProducer implements Broker
+func (b kafkaBroker) NewProducer() Producer {
+	return NewProducer(b.cfg)
+}
+
+// NewConsumer implements Broker
+func (b kafkaBroker) NewConsumer(topic, groupID string, handler MessageHandler, producer Producer) Consumer {
+	return NewConsumer(b.cfg, topic, groupID, handler, producer)
+}
+
+// Scan implements Broker, reading each partition from its first offset to its last one when called
+func (b kafkaBroker) Scan(ctx context.Context, topic string, fn func(kafkago.Message) error) error {
+	conn, err := kafkago.DialContext(ctx, "tcp", b.cfg.Brokers[0])
+	if err != nil {
+		return fmt.Errorf("kafka: %w", err)
+	}
+	defer conn.Close()
+
+	partitions, err := conn.ReadPartitions(topic)
+	if errors.Is(err, kafkago.UnknownTopicOrPartition) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("kafka: read partitions of %s: %w", topic, err)
+	}
+
+	for _, p := range partitions {
+		if err = b.scanPartition(ctx, topic, p.ID, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// scanPartition calls fn with the messages of a partition, up to its last offset when called
+func (b kafkaBroker) scanPartition(ctx context.Context, topic string, partition int, fn func(kafkago.Message) error) error {
+	leader, err := kafkago.DialLeader(ctx, "tcp", b.cfg.Brokers[0], topic, partition)
+	if err != nil {
+		return fmt.Errorf("kafka: dial leader of %s/%d: %w", topic, partition, err)
+	}
+	first, last, err := leader.ReadOffsets()
+	_ = leader.Close()
+	if err != nil {
+		return fmt.Errorf("kafka: read offsets of %s/%d: %w", topic, partition, err)
+	}
+	if first >= last {
+		return nil
+	}
+
+	r := kafkago.NewReader(kafkago.ReaderConfig{
+		Brokers:   b.cfg.Brokers,
+		Topic:     topic,
+		Partition: partition,
+		MinBytes:  1,
+		MaxBytes:  b.cfg.MaxBytes,
+	})
+	defer r.Close()
+
+	if err = r.SetOffset(first); err != nil {
+		return fmt.Errorf("kafka: seek %s/%d: %w", topic, partition, err)
+	}
+	for {
+		msg, err := r.ReadMessage(ctx)
+		if err != nil {
+			return fmt.Errorf("kafka: read %s/%d: %w", topic, partition, err)
+		}
+		if err = fn(msg); err != nil {
+			return err
+		}
+		if msg.Offset >= last-1 {
+			return nil
+		}
+	}
+}
+
+// Close implements Broker
+func (kafkaBroker) Close() error {
+	return nil
+}
@@ -0,0 +1,133 @@
+package kafka
+
+import (
+	"context"
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/monitoring"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/transportmeta"
+	kafkago "github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// MessageCarrier adapts the headers of a kafka-go message to propagation.TextMapCarrier,
+// so that the traceparent, tracestate and baggage headers travel with the message
+type MessageCarrier struct {
+	msg *kafkago.Message
+}
+
+var _ propagation.TextMapCarrier = MessageCarrier{}
+
+// NewMessageCarrier returns a carrier reading and writing the headers of msg
+func NewMessageCarrier(msg *kafkago.Message) MessageCarrier {
+	return MessageCarrier{msg: msg}
+}
+
+// Get returns the value of the first header named key, "" when there is none
+func (c MessageCarrier) Get(key string) string {
+	for _, h := range c.msg.Headers {
+		if h.Key == key {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
+
+// Set replaces the header named key, so that re-publishing a message does not pile up trace headers
+func (c MessageCarrier) Set(key, value string) {
+	for i, h := range c.msg.Headers {
+		if h.Key == key {
+			c.msg.Headers[i].Value = []byte(value)
+			return
+		}
+	}
+	c.msg.Headers = append(c.msg.Headers, kafkago.Header{Key: key, Value: []byte(value)})
+}
+
+// Keys lists the header names
+func (c MessageCarrier) Keys() []string {
+	keys := make([]string, 0, len(c.msg.Headers))
+	for _, h := range c.msg.Headers {
+		keys = append(keys, h.Key)
+	}
+	return keys
+}
+
+// headersCarrier adapts the headers of a Message to propagation.TextMapCarrier
+type headersCarrier struct {
+	headers *[]Header
+}
+
+// Get returns the value of the first header named key, "" when there is none
+func (c headersCarrier) Get(key string) string {
+	for _, h := range *c.headers {
+		if h.Key == key {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
+
+// Set replaces the header named key
+func (c headersCarrier) Set(key, value string) {
+	for i, h := range *c.headers {
+		if h.Key == key {
+			(*c.headers)[i].Value = []byte(value)
+			return
+		}
+	}
+	*c.headers = append(*c.headers, Header{Key: key, Value: []byte(value)})
+}
+
+// Keys lists the header names
+func (c headersCarrier) Keys() []string {
+	keys := make([]string, 0, len(*c.headers))
+	for _, h := range *c.headers {
+		keys = append(keys, h.Key)
+	}
+	return keys
+}
+
+// StartPublishSpan starts the PRODUCER span of msg and injects its trace context into the message headers
+// as traceparent/tracestate/baggage, so that consumers link their span to it. End the span once the message
+// is published.
+func StartPublishSpan(ctx context.Context, msg *Message) (context.Context, trace.Span) {
+	ctx, span := monitoring.Start(ctx, "Producer.Publish | Topic: "+msg.Topic,
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(
+			attribute.String("messaging.system", "kafka"),
+			attribute.String("messaging.destination.name", msg.Topic),
+		),
+	)
+	otel.GetTextMapPropagator().Inject(ctx, headersCarrier{headers: &msg.Headers})
+
+	return ctx, span
+}
+
+// startConsumeSpan starts the CONSUMER span of a message. The trace context published in its headers,
+// when there is one, becomes the parent of the span and is linked to it; a message without trace headers
+// starts a new trace. The correlation ID header is restored into the context as well.
+func startConsumeSpan(ctx context.Context, msg kafkago.Message) (context.Context, trace.Span) {
+	ctx = otel.GetTextMapPropagator().Extract(ctx, NewMessageCarrier(&msg))
+
+	if corrID := NewMessageCarrier(&msg).Get(HeaderCorrelationID); corrID != "" {
+		ctx = transportmeta.WithValue(ctx, "correlation_id", corrID)
+	}
+
+	opts := []trace.SpanStartOption{
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			attribute.String("messaging.system", "kafka"),
+			attribute.String("messaging.destination.name", msg.Topic),
+			attribute.Int("messaging.kafka.destination.partition", msg.Partition),
+			attribute.Int64("messaging.kafka.message.offset", msg.Offset),
+		),
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		opts = append(opts, trace.WithLinks(trace.Link{SpanContext: sc}))
+	}
+
+	return monitoring.Start(ctx, "Consumer.ConsumeMessage | Topic: "+msg.Topic, opts...)
+}
@@ -0,0 +1,93 @@
+package kafka
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/transportmeta"
+	kafkago "github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestMessageCarrier(t *testing.T) {
+	msg := kafkago.Message{Headers: []kafkago.Header{{Key: HeaderEventID, Value: []byte("1")}}}
+	c := NewMessageCarrier(&msg)
+
+	c.Set("traceparent", "00-aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa-bbbbbbbbbbbbbbbb-01")
+	c.Set("traceparent", "00-cccccccccccccccccccccccccccccccc-dddddddddddddddd-01")
+
+	require.Equal(t, "00-cccccccccccccccccccccccccccccccc-dddddddddddddddd-01", c.Get("traceparent"))
+	require.Equal(t, "1", c.Get(HeaderEventID))
+	require.Empty(t, c.Get("tracestate"))
+	require.Equal(t, []string{HeaderEventID, "traceparent"}, c.Keys())
+}
+
+func TestStartConsumeSpan(t *testing.T) {
+	tp := sdktrace.NewTracerProvider()
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+	t.Cleanup(func() { _ = tp.Shutdown(context.Background()) })
+
+	producerCtx, producerSpan := tp.Tracer("test").Start(context.Background(), "publish",
+		trace.WithSpanKind(trace.SpanKindProducer))
+	producerSpan.End()
+
+	traced := kafkago.Message{
+		Topic:   "urlshortener.metadata.requested.v1",
+		Headers: []kafkago.Header{{Key: HeaderCorrelationID, Value: []byte("corr-789")}},
+	}
+	otel.GetTextMapPropagator().Inject(producerCtx, NewMessageCarrier(&traced))
+
+	tcs := map[string]struct {
+		msg             kafkago.Message
+		wantParent      bool
+		wantCorrelation string
+	}{
+		"success - trace headers": {
+			msg:             traced,
+			wantParent:      true,
+			wantCorrelation: "corr-789",
+		},
+		"success - no trace headers starts a new trace": {
+			msg: kafkago.Message{Topic: "urlshortener.metadata.requested.v1"},
+		},
+		"success - malformed traceparent starts a new trace": {
+			msg: kafkago.Message{
+				Topic:   "urlshortener.metadata.requested.v1",
+				Headers: []kafkago.Header{{Key: "traceparent", Value: []byte("not-a-traceparent")}},
+			},
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			ctx, span := startConsumeSpan(context.Background(), tc.msg)
+			span.End()
+
+			ro, ok := span.(sdktrace.ReadOnlySpan)
+			require.True(t, ok)
+			require.Equal(t, trace.SpanKindConsumer, ro.SpanKind())
+			require.True(t, ro.SpanContext().IsValid())
+			require.Equal(t, tc.wantCorrelation, transportmeta.FromContext(ctx).CorrelationID)
+
+			if !tc.wantParent {
+				require.False(t, ro.Parent().IsValid())
+				require.Empty(t, ro.Links())
+				return
+			}
+
+			want := producerSpan.SpanContext()
+			require.Equal(t, want.TraceID(), ro.SpanContext().TraceID())
+			require.Equal(t, want.SpanID(), ro.Parent().SpanID())
+			require.Len(t, ro.Links(), 1)
+			require.Equal(t, want.SpanID(), ro.Links()[0].SpanContext.SpanID())
+		})
+	}
+}
@@ -0,0 +1,234 @@
+package kafka
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	kafkago "github.com/segmentio/kafka-go"
+)
+
+// CloudEventsMode selects how messages are wrapped in a CloudEvents 1.0 envelope, following the Kafka protocol binding
+type CloudEventsMode string
+
+const (
+	// CloudEventsOff publishes the data without envelope
+	CloudEventsOff CloudEventsMode = "off"
+	// CloudEventsStructured publishes the whole event, attributes and data, as a JSON message value
+	CloudEventsStructured CloudEventsMode = "structured"
+	// CloudEventsBinary publishes the data as message value and the attributes as ce_ headers
+	CloudEventsBinary CloudEventsMode = "binary"
+)
+
+const (
+	// CloudEventsSpecVersion is the CloudEvents version of the envelopes
+	CloudEventsSpecVersion = "1.0"
+
+	// headerContentType is the Kafka binding header of the content type of the message value
+	headerContentType = "content-type"
+	// headerCloudEventsPrefix prefixes the attributes carried as headers in binary mode
+	headerCloudEventsPrefix = "ce_"
+	// contentTypeCloudEventsJSON is the content type of structured mode messages
+	contentTypeCloudEventsJSON = "application/cloudevents+json"
+)
+
+// ErrInvalidCloudEvent means a message announced as a CloudEvent lacks required attributes or is malformed
+var ErrInvalidCloudEvent = errors.New("invalid cloud event")
+
+// ParseCloudEventsMode parses a mode name, the empty name meaning off
+func ParseCloudEventsMode(s string) (CloudEventsMode, error) {
+	switch m := CloudEventsMode(strings.ToLower(strings.TrimSpace(s))); m {
+	case "":
+		return CloudEventsOff, nil
+	case CloudEventsOff, CloudEventsStructured, CloudEventsBinary:
+		return m, nil
+	default:
+		return "", fmt.Errorf("unknown cloud events mode %q", s)
+	}
+}
+
+// CloudEvent is a CloudEvents 1.0 event with JSON data
+type CloudEvent struct {
+	ID              string
+	Source          string
+	Type            string
+	Subject         string
+	Time            time.Time
+	DataContentType string
+	Data            json.RawMessage
+	// Extensions are the attributes outside the spec, e.g. correlationid. Names are lowercase alphanumeric.
+	Extensions map[string]string
+}
+
+// cloudEventAttributes lists the context attributes of the spec, which extensions cannot override
+var cloudEventAttributes = map[string]bool{
+	"specversion": true, "id": true, "source": true, "type": true, "subject": true,
+	"time": true, "datacontenttype": true, "dataschema": true, "data": true, "data_base64": true,
+}
+
+// attributes returns the context attributes of the event as strings, extensions included
+func (e CloudEvent) attributes() map[string]string {
+	attrs := map[string]string{
+		"specversion": CloudEventsSpecVersion,
+		"id":          e.ID,
+		"source":      e.Source,
+		"type":        e.Type,
+	}
+	if e.Subject != "" {
+		attrs["subject"] = e.Subject
+	}
+	if !e.Time.IsZero() {
+		attrs["time"] = e.Time.UTC().Format(time.RFC3339Nano)
+	}
+	for k, v := range e.Extensions {
+		if !cloudEventAttributes[k] && v != "" {
+			attrs[k] = v
+		}
+	}
+	return attrs
+}
+
+// setAttribute sets a context attribute read from a message, unknown attributes become extensions
+func (e *CloudEvent) setAttribute(name, value string) error {
+	switch name {
+	case "specversion":
+		if value != CloudEventsSpecVersion {
+			return fmt.Errorf("%w: unsupported specversion %q", ErrInvalidCloudEvent, value)
+		}
+	case "id":
+		e.ID = value
+	case "source":
+		e.Source = value
+	case "type":
+		e.Type = value
+	case "subject":
+		e.Subject = value
+	case "time":
+		t, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return fmt.Errorf("%w: time: %v", ErrInvalidCloudEvent, err)
+		}
+		e.Time = t
+	case "datacontenttype":
+		e.DataContentType = value
+	case "dataschema":
+	default:
+		if e.Extensions == nil {
+			e.Extensions = map[string]string{}
+		}
+		e.Extensions[name] = value
+	}
+	return nil
+}
+
+// validate checks the required attributes once read
+func (e CloudEvent) validate() error {
+	if e.ID == "" || e.Source == "" || e.Type == "" {
+		return fmt.Errorf("%w: id, source and type are required", ErrInvalidCloudEvent)
+	}
+	return nil
+}
+
+// EncodeCloudEvent sets the value and headers of msg to carry e in the given mode. Headers already set on msg,
+// such as trace context, are kept. Off mode leaves msg untouched.
+func EncodeCloudEvent(mode CloudEventsMode, e CloudEvent, msg *Message) error {
+	contentType := e.DataContentType
+	if contentType == "" {
+		contentType = "application/json"
+	}
+
+	switch mode {
+	case CloudEventsOff, "":
+		return nil
+
+	case CloudEventsStructured:
+		doc := map[string]any{}
+		for k, v := range e.attributes() {
+			doc[k] = v
+		}
+		doc["datacontenttype"] = contentType
+		if len(e.Data) > 0 {
+			doc["data"] = e.Data
+		}
+
+		b, err := json.Marshal(doc)
+		if err != nil {
+			return err
+		}
+		msg.Value = b
+		headersCarrier{headers: &msg.Headers}.Set(headerContentType, contentTypeCloudEventsJSON)
+
+	case CloudEventsBinary:
+		c := headersCarrier{headers: &msg.Headers}
+		for k, v := range e.attributes() {
+			c.Set(headerCloudEventsPrefix+k, v)
+		}
+		c.Set(headerContentType, contentType)
+		msg.Value = e.Data
+
+	default:
+		return fmt.Errorf("unknown cloud events mode %q", mode)
+	}
+
+	return nil
+}
+
+// DecodeCloudEvent reads the CloudEvent carried by a message in structured or binary mode. It returns false,
+// without error, for messages which are not CloudEvents, e.g. legacy payloads.
+func DecodeCloudEvent(msg kafkago.Message) (CloudEvent, bool, error) {
+	c := NewMessageCarrier(&msg)
+
+	switch {
+	case strings.HasPrefix(c.Get(headerContentType), contentTypeCloudEventsJSON):
+		var doc map[string]json.RawMessage
+		if err := json.Unmarshal(msg.Value, &doc); err != nil {
+			return CloudEvent{}, true, fmt.Errorf("%w: %v", ErrInvalidCloudEvent, err)
+		}
+
+		var e CloudEvent
+		for name, raw := range doc {
+			if name == "data" {
+				e.Data = raw
+				continue
+			}
+			if name == "data_base64" {
+				return CloudEvent{}, true, fmt.Errorf("%w: binary data is not supported", ErrInvalidCloudEvent)
+			}
+
+			var value string
+			if err := json.Unmarshal(raw, &value); err != nil {
+				// Non-string extensions keep their JSON form
+				value = string(raw)
+			}
+			if err := e.setAttribute(name, value); err != nil {
+				return CloudEvent{}, true, err
+			}
+		}
+		if _, ok := doc["specversion"]; !ok {
+			return CloudEvent{}, true, fmt.Errorf("%w: specversion is required", ErrInvalidCloudEvent)
+		}
+
+		return e, true, e.validate()
+
+	case c.Get(headerCloudEventsPrefix+"specversion") != "":
+		var e CloudEvent
+		for _, h := range msg.Headers {
+			name, ok := strings.CutPrefix(h.Key, headerCloudEventsPrefix)
+			if !ok {
+				continue
+			}
+			if err := e.setAttribute(name, string(h.Value)); err != nil {
+				return CloudEvent{}, true, err
+			}
+		}
+		e.DataContentType = c.Get(headerContentType)
+		e.Data = msg.Value
+
+		return e, true, e.validate()
+
+	default:
+		return CloudEvent{}, false, nil
+	}
+}
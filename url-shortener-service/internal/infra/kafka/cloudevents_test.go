@@ -0,0 +1,148 @@
+package kafka
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	kafkago "github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCloudEventRoundTrip(t *testing.T) {
+	event := CloudEvent{
+		ID:              "123",
+		Source:          "/url-shortener-service",
+		Type:            "urlshortener.metadata.requested.v1",
+		Subject:         "abc123",
+		Time:            time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+		DataContentType: "application/json",
+		Data:            json.RawMessage(`{"short_code":"abc123"}`),
+		Extensions:      map[string]string{"correlationid": "corr-789"},
+	}
+
+	tcs := map[string]struct {
+		mode        CloudEventsMode
+		wantHeaders map[string]string
+		wantValue   string
+	}{
+		"structured": {
+			mode:        CloudEventsStructured,
+			wantHeaders: map[string]string{"content-type": "application/cloudevents+json"},
+			wantValue: `{"specversion":"1.0","id":"123","source":"/url-shortener-service","type":"urlshortener.metadata.requested.v1",
+				"subject":"abc123","time":"2025-01-02T03:04:05Z","datacontenttype":"application/json","correlationid":"corr-789",
+				"data":{"short_code":"abc123"}}`,
+		},
+		"binary": {
+			mode: CloudEventsBinary,
+			wantHeaders: map[string]string{
+				"content-type":     "application/json",
+				"ce_specversion":   "1.0",
+				"ce_id":            "123",
+				"ce_source":        "/url-shortener-service",
+				"ce_type":          "urlshortener.metadata.requested.v1",
+				"ce_subject":       "abc123",
+				"ce_time":          "2025-01-02T03:04:05Z",
+				"ce_correlationid": "corr-789",
+			},
+			wantValue: `{"short_code":"abc123"}`,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			msg := Message{
+				Topic:   event.Type,
+				Headers: []Header{{Key: "traceparent", Value: []byte("00-aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa-bbbbbbbbbbbbbbbb-01")}},
+			}
+			require.NoError(t, EncodeCloudEvent(tc.mode, event, &msg))
+
+			headers := map[string]string{}
+			for _, h := range msg.Headers {
+				headers[h.Key] = string(h.Value)
+			}
+			for k, v := range tc.wantHeaders {
+				require.Equal(t, v, headers[k], k)
+			}
+			require.Contains(t, headers, "traceparent")
+			require.JSONEq(t, tc.wantValue, string(msg.Value))
+
+			actual, ok, err := DecodeCloudEvent(toKafkaMessage(msg))
+			require.NoError(t, err)
+			require.True(t, ok)
+			require.Equal(t, event.ID, actual.ID)
+			require.Equal(t, event.Source, actual.Source)
+			require.Equal(t, event.Type, actual.Type)
+			require.Equal(t, event.Subject, actual.Subject)
+			require.True(t, event.Time.Equal(actual.Time))
+			require.Equal(t, event.DataContentType, actual.DataContentType)
+			require.JSONEq(t, string(event.Data), string(actual.Data))
+			require.Equal(t, event.Extensions, actual.Extensions)
+		})
+	}
+}
+
+func TestDecodeCloudEvent(t *testing.T) {
+	tcs := map[string]struct {
+		msg     kafkago.Message
+		wantOK  bool
+		wantErr error
+	}{
+		"success - legacy payload is not a cloud event": {
+			msg: kafkago.Message{Value: []byte(`{"event_id":1,"data":{"short_code":"abc123"}}`)},
+		},
+		"fail - structured without required attributes": {
+			msg: kafkago.Message{
+				Headers: []kafkago.Header{{Key: "content-type", Value: []byte("application/cloudevents+json; charset=utf-8")}},
+				Value:   []byte(`{"specversion":"1.0","id":"1"}`),
+			},
+			wantOK:  true,
+			wantErr: ErrInvalidCloudEvent,
+		},
+		"fail - structured with malformed value": {
+			msg: kafkago.Message{
+				Headers: []kafkago.Header{{Key: "content-type", Value: []byte("application/cloudevents+json")}},
+				Value:   []byte(`not json`),
+			},
+			wantOK:  true,
+			wantErr: ErrInvalidCloudEvent,
+		},
+		"fail - binary with unsupported spec version": {
+			msg: kafkago.Message{
+				Headers: []kafkago.Header{
+					{Key: "ce_specversion", Value: []byte("0.3")},
+					{Key: "ce_id", Value: []byte("1")},
+					{Key: "ce_source", Value: []byte("/svc")},
+					{Key: "ce_type", Value: []byte("t")},
+				},
+			},
+			wantOK:  true,
+			wantErr: ErrInvalidCloudEvent,
+		},
+		"fail - binary with malformed time": {
+			msg: kafkago.Message{
+				Headers: []kafkago.Header{
+					{Key: "ce_specversion", Value: []byte("1.0")},
+					{Key: "ce_id", Value: []byte("1")},
+					{Key: "ce_source", Value: []byte("/svc")},
+					{Key: "ce_type", Value: []byte("t")},
+					{Key: "ce_time", Value: []byte("yesterday")},
+				},
+			},
+			wantOK:  true,
+			wantErr: ErrInvalidCloudEvent,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			_, ok, err := DecodeCloudEvent(tc.msg)
+			require.Equal(t, tc.wantOK, ok)
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package kafka
+
+import (
+	"context"
+	"hash/fnv"
+	"sync"
+	"time"
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/monitoring"
+)
+
+// commitTracker acknowledges the messages of each partition in the order they were fetched, whatever the order
+// workers complete them in. A message completed while one fetched before it is still in flight waits for it:
+// Kafka commits are cumulative, committing its offset would skip the message in flight if the consumer crashed.
+// Each commit is therefore the highest contiguous completed offset of its partition.
+type commitTracker struct {
+	mu         sync.Mutex
+	partitions map[int]*partitionCommits
+	// timeout bounds each acknowledgement, run whether or not the work of the consumer was aborted
+	timeout time.Duration
+}
+
+// partitionCommits are the messages of a partition fetched but not acknowledged yet, in fetch order
+type partitionCommits struct {
+	// mu is held while acknowledging, so that the acks of a partition never go backwards
+	mu      sync.Mutex
+	pending []*trackedDelivery
+}
+
+// trackedDelivery is a delivery waiting for the messages fetched before it to be acknowledged
+type trackedDelivery struct {
+	delivery
+	partition *partitionCommits
+	done      bool
+}
+
+// newCommitTracker creates an empty commitTracker, each acknowledgement taking up to timeout
+func newCommitTracker(timeout time.Duration) *commitTracker {
+	return &commitTracker{partitions: map[int]*partitionCommits{}, timeout: timeout}
+}
+
+// track records a delivery as in flight. It must be called in fetch order, before the delivery is dispatched.
+func (t *commitTracker) track(d delivery) *trackedDelivery {
+	t.mu.Lock()
+	p, ok := t.partitions[d.msg.Partition]
+	if !ok {
+		p = &partitionCommits{}
+		t.partitions[d.msg.Partition] = p
+	}
+	t.mu.Unlock()
+
+	td := &trackedDelivery{delivery: d, partition: p}
+
+	p.mu.Lock()
+	p.pending = append(p.pending, td)
+	p.mu.Unlock()
+
+	return td
+}
+
+// complete marks a delivery as handled then acknowledges the contiguous completed deliveries at the head of its
+// partition, in order, or only the last of them when its ack is cumulative. It returns how many were acknowledged,
+// 0 while one fetched before is in flight.
+//
+// Acknowledgements outlive the cancellation of ctx: a message handled while the consumer is aborted is
+// acknowledged still, within the timeout of the tracker, rather than delivered again.
+func (t *commitTracker) complete(ctx context.Context, td *trackedDelivery) int {
+	p := td.partition
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	td.done = true
+
+	n := 0
+	for n < len(p.pending) && p.pending[n].done {
+		n++
+	}
+	ready := p.pending[:n]
+	p.pending = p.pending[n:]
+	if n > 0 && ready[n-1].cumulative {
+		ready = ready[n-1:]
+	}
+
+	ackCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), t.timeout)
+	defer cancel()
+
+	for _, d := range ready {
+		if err := d.ack(ackCtx); err != nil {
+			// Delivered again to the group, unless a later commit of the partition covers it
+			monitoring.Log(ctx).Error().
+				Err(err).
+				Str("topic", d.msg.Topic).
+				Int("partition", d.msg.Partition).
+				Int64("offset", d.msg.Offset).
+				Msg("[KafkaConsumer] commit failed")
+		}
+	}
+
+	return n
+}
+
+// shard returns the worker, among workerCount, of a message. Messages of a key always go to the same worker so
+// that they are handled in order; those without key go round the workers, next being the count of those before.
+func shard(key []byte, workerCount int, next uint64) int {
+	if len(key) == 0 {
+		return int(next % uint64(workerCount))
+	}
+
+	h := fnv.New32a()
+	_, _ = h.Write(key)
+	return int(h.Sum32() % uint32(workerCount))
+}
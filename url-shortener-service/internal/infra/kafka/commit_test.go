@@ -0,0 +1,102 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	kafkago "github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommitTracker(t *testing.T) {
+	// Given
+	var acked []string
+	newDelivery := func(partition int, offset int64) delivery {
+		msg := kafkago.Message{Partition: partition, Offset: offset, Value: []byte{byte('0' + partition), byte('0' + offset)}}
+		return delivery{msg: msg, ack: func(context.Context) error {
+			acked = append(acked, string(msg.Value))
+			if offset == 3 {
+				return errors.New("connection refused")
+			}
+			return nil
+		}}
+	}
+
+	tr := newCommitTracker(time.Second)
+	p0 := []*trackedDelivery{tr.track(newDelivery(0, 1)), tr.track(newDelivery(0, 2)), tr.track(newDelivery(0, 3))}
+	p1 := tr.track(newDelivery(1, 1))
+	ctx := context.Background()
+
+	// When, Then
+	require.Equal(t, 0, tr.complete(ctx, p0[2]))
+	require.Equal(t, 0, tr.complete(ctx, p0[1]))
+	require.Empty(t, acked)
+
+	require.Equal(t, 1, tr.complete(ctx, p1))
+	require.Equal(t, []string{"11"}, acked)
+
+	require.Equal(t, 3, tr.complete(ctx, p0[0]))
+	require.Equal(t, []string{"11", "01", "02", "03"}, acked)
+
+	p0 = append(p0, tr.track(newDelivery(0, 5)))
+	require.Equal(t, 1, tr.complete(ctx, p0[3]))
+	require.Equal(t, []string{"11", "01", "02", "03", "05"}, acked)
+}
+
+func TestCommitTracker_cumulative(t *testing.T) {
+	// Given: Kafka commits, each covering the offsets before it
+	var committed []int64
+	newDelivery := func(offset int64) delivery {
+		return delivery{
+			msg: kafkago.Message{Offset: offset},
+			ack: func(ctx context.Context) error {
+				if err := ctx.Err(); err != nil {
+					return err
+				}
+				committed = append(committed, offset)
+				return nil
+			},
+			cumulative: true,
+		}
+	}
+
+	tr := newCommitTracker(time.Second)
+	tds := []*trackedDelivery{tr.track(newDelivery(1)), tr.track(newDelivery(2)), tr.track(newDelivery(3))}
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// When, Then: the highest contiguous offset only is committed
+	require.Equal(t, 0, tr.complete(ctx, tds[1]))
+	require.Equal(t, 2, tr.complete(ctx, tds[0]))
+	require.Equal(t, []int64{2}, committed)
+
+	// When, Then: committed still once the work of the consumer is aborted
+	cancel()
+	require.Equal(t, 1, tr.complete(ctx, tds[2]))
+	require.Equal(t, []int64{2, 3}, committed)
+}
+
+func TestShard(t *testing.T) {
+	t.Run("success - messages of a key go to the same worker", func(t *testing.T) {
+		for next := uint64(0); next < 5; next++ {
+			require.Equal(t, shard([]byte("abc123"), 4, 0), shard([]byte("abc123"), 4, next))
+		}
+	})
+
+	t.Run("success - messages without key go round the workers", func(t *testing.T) {
+		var got []int
+		for next := uint64(0); next < 5; next++ {
+			got = append(got, shard(nil, 4, next))
+		}
+		require.Equal(t, []int{0, 1, 2, 3, 0}, got)
+	})
+
+	t.Run("success - keys spread between the workers", func(t *testing.T) {
+		workers := map[int]bool{}
+		for _, key := range []string{"a", "b", "c", "d", "e", "f", "g", "h"} {
+			workers[shard([]byte(key), 4, 0)] = true
+		}
+		require.Greater(t, len(workers), 1)
+	})
+}
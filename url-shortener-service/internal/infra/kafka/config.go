@@ -2,20 +2,30 @@ package kafka
 
 import (
 	"errors"
+	"fmt"
 	"os"
 	"strconv"
 	"strings"
+	"time"
 )
 
-// Config holds configuration values for connecting to and optimizing a Kafka cluster.
+// Config holds configuration values for connecting to and optimizing the message broker, a Kafka cluster by default.
 type Config struct {
+	Backend       Backend  // Message broker backend (default: kafka)
 	Brokers       []string // List of Kafka bootstrap brokers
+	RedisAddr     string   // Redis address of the redis backend, the Redis of the service
+	RedisMaxLen   int64    // Approximate maximum length of the Redis streams, 0 for unbounded
+	NATSURL       string   // NATS server URLs of the nats backend, comma separated
 	ClientID      string   // Logical name of this client
 	WorkerCount   int      // Number of concurrent consumer workers (default: 10)
 	MinBytes      int      // Minimum bytes to fetch per request (default: 10KB)
 	MaxBytes      int      // Maximum bytes to fetch per request (default: 10MB)
 	MaxWait       int      // Maximum wait time in ms for MinBytes (default: 1000ms)
-	ChannelBuffer int      // Size of the message channel buffer (default: workerCount * 2)
+	ChannelBuffer int      // Total size of the worker queues (default: workerCount * 2)
+	// Delays of the retry topics a failed message goes through before the DLQ (default: 30s, 5m, 1h)
+	RetryTiers []time.Duration
+	// How long a commit may take, those left once a drain aborts included (default: 5s, set to the shutdown timeout)
+	CommitTimeout time.Duration
 }
 
 // getIntEnv parses an integer from environment variable with a default fallback.
@@ -29,6 +39,25 @@ func getIntEnv(key string, defaultVal int) int {
 	return defaultVal
 }
 
+// getDurationsEnv parses a comma separated list of durations from environment variable with a default fallback.
+// Returns the parsed values if all are valid and positive, otherwise returns the default.
+func getDurationsEnv(key string, defaultVal []time.Duration) []time.Duration {
+	v := os.Getenv(key)
+	if v == "" {
+		return defaultVal
+	}
+
+	var rs []time.Duration
+	for _, s := range strings.Split(v, ",") {
+		d, err := time.ParseDuration(strings.TrimSpace(s))
+		if err != nil || d <= 0 {
+			return defaultVal
+		}
+		rs = append(rs, d)
+	}
+	return rs
+}
+
 func NewConfig() Config {
 	workerCount := getIntEnv("KAFKA_CONSUMER_WORKERS", 10)
 	minBytes := getIntEnv("KAFKA_MIN_BYTES", 10*1024)      // 10 KB
@@ -36,6 +65,13 @@ func NewConfig() Config {
 	maxWait := getIntEnv("KAFKA_MAX_WAIT_MS", 1000)        // 1 second
 	channelBuffer := getIntEnv("KAFKA_CHANNEL_BUFFER", 0)  // override
 	clientID := os.Getenv("KAFKA_CLIENT_ID")
+	redisMaxLen := getIntEnv("REDIS_STREAM_MAX_LEN", 0)
+
+	// An unknown backend is kept as is and rejected by Validate
+	backend, err := ParseBackend(os.Getenv("BROKER_BACKEND"))
+	if err != nil {
+		backend = Backend(os.Getenv("BROKER_BACKEND"))
+	}
 
 	if channelBuffer <= 0 {
 		channelBuffer = workerCount * 2 // default rule
@@ -51,19 +87,33 @@ func NewConfig() Config {
 	}
 
 	return Config{
+		Backend:       backend,
 		Brokers:       brokers,
+		RedisAddr:     os.Getenv("REDIS_ADDR"),
+		RedisMaxLen:   int64(redisMaxLen),
+		NATSURL:       os.Getenv("NATS_URL"),
 		ClientID:      clientID,
 		WorkerCount:   workerCount,
 		MinBytes:      minBytes,
 		MaxBytes:      maxBytes,
 		MaxWait:       maxWait,
 		ChannelBuffer: channelBuffer,
+		RetryTiers:    getDurationsEnv("KAFKA_RETRY_TIERS", DefaultRetryTiers),
 	}
 }
 
 func (c Config) Validate() error {
-	if len(c.Brokers) == 0 {
+	backend, err := ParseBackend(string(c.Backend))
+	if err != nil {
+		return fmt.Errorf("invalid BROKER_BACKEND: %w", err)
+	}
+	switch {
+	case backend == BackendKafka && len(c.Brokers) == 0:
 		return errors.New("missing KAFKA_BROKERS")
+	case backend == BackendRedis && c.RedisAddr == "":
+		return errors.New("missing REDIS_ADDR")
+	case backend == BackendNATS && c.NATSURL == "":
+		return errors.New("missing NATS_URL")
 	}
 	if c.WorkerCount <= 0 {
 		return errors.New("WorkerCount must be > 0")
@@ -0,0 +1,539 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	kafkago "github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/require"
+)
+
+// headerWarmUp marks the messages published until a consumer receives one, as some backends join their
+// group in the background
+const headerWarmUp = "conformance_warm_up"
+
+// conformanceTimeout bounds every wait of the suite
+const conformanceTimeout = 20 * time.Second
+
+// conformanceRetryTiers are short retry delays, for the suite to go through every retry topic
+var conformanceRetryTiers = []time.Duration{10 * time.Millisecond, 20 * time.Millisecond}
+
+// conformanceBackend opens a broker and creates the topics it needs before they are used
+type conformanceBackend struct {
+	open         func(t *testing.T) Broker
+	createTopics func(t *testing.T, topics ...string)
+}
+
+// conformanceBackends returns the backends to check: memory always, the others when their server is set with
+// the same environment variables as the services
+func conformanceBackends() map[string]conformanceBackend {
+	noTopics := func(*testing.T, ...string) {}
+
+	backends := map[string]conformanceBackend{
+		"memory": {
+			open: func(t *testing.T) Broker {
+				return NewMemoryBroker(Config{WorkerCount: 2, RetryTiers: conformanceRetryTiers})
+			},
+			createTopics: noTopics,
+		},
+	}
+
+	if addr := os.Getenv("REDIS_ADDR"); addr != "" {
+		backends["redis"] = conformanceBackend{
+			open: func(t *testing.T) Broker {
+				b, err := Open(Config{
+					Backend:     BackendRedis,
+					RedisAddr:   addr,
+					WorkerCount: 2,
+					MaxWait:     100,
+					RetryTiers:  conformanceRetryTiers,
+				})
+				require.NoError(t, err)
+				return b
+			},
+			createTopics: noTopics,
+		}
+	}
+	if url := os.Getenv("NATS_URL"); url != "" {
+		backends["nats"] = conformanceBackend{
+			open: func(t *testing.T) Broker {
+				b, err := Open(Config{
+					Backend:     BackendNATS,
+					NATSURL:     url,
+					WorkerCount: 2,
+					MaxWait:     100,
+					RetryTiers:  conformanceRetryTiers,
+				})
+				require.NoError(t, err)
+				return b
+			},
+			createTopics: noTopics,
+		}
+	}
+	if brokers := os.Getenv("KAFKA_BROKERS"); brokers != "" {
+		cfg := Config{
+			Backend:     BackendKafka,
+			Brokers:     strings.Split(brokers, ","),
+			WorkerCount: 2,
+			MaxWait:     100,
+			RetryTiers:  conformanceRetryTiers,
+		}
+		backends["kafka"] = conformanceBackend{
+			open: func(t *testing.T) Broker {
+				b, err := Open(cfg)
+				require.NoError(t, err)
+				return b
+			},
+			createTopics: func(t *testing.T, topics ...string) {
+				conn, err := kafkago.Dial("tcp", cfg.Brokers[0])
+				require.NoError(t, err)
+				defer conn.Close()
+
+				configs := make([]kafkago.TopicConfig, len(topics))
+				for i, topic := range topics {
+					configs[i] = kafkago.TopicConfig{Topic: topic, NumPartitions: 1, ReplicationFactor: 1}
+				}
+				require.NoError(t, conn.CreateTopics(configs...))
+			},
+		}
+	}
+
+	return backends
+}
+
+// TestConformance checks that every backend delivers messages and applies the retry and DLQ policy the same way
+func TestConformance(t *testing.T) {
+	errFailed := errors.New("handler failed")
+
+	tcs := map[string]struct {
+		failures    int  // calls failing before success, -1 for all of them
+		retryable   bool // whether the failures are retryable
+		wantRetries []int
+		wantDLQ     bool
+	}{
+		"success - delivered once": {
+			wantRetries: []int{0},
+		},
+		"success - retryable error retried through the retry topics until handled": {
+			failures:    2,
+			retryable:   true,
+			wantRetries: []int{0, 1, 2},
+		},
+		"error - retry topics exhausted, sent to DLQ": {
+			failures:    -1,
+			retryable:   true,
+			wantRetries: []int{0, 1, 2},
+			wantDLQ:     true,
+		},
+		"error - non-retryable error sent to DLQ without retry": {
+			failures:    -1,
+			wantRetries: []int{0},
+			wantDLQ:     true,
+		},
+	}
+
+	for backend, b := range conformanceBackends() {
+		t.Run(backend, func(t *testing.T) {
+			for s, tc := range tcs {
+				t.Run(s, func(t *testing.T) {
+					// Given
+					h := newConformanceHarness(t, b)
+
+					var (
+						mu       sync.Mutex
+						received []kafkago.Message
+					)
+					handled := make(chan struct{}, 1)
+					h.consume(h.topic, "conformance", func(ctx context.Context, msg kafkago.Message) *KafkaError {
+						mu.Lock()
+						defer mu.Unlock()
+
+						received = append(received, msg)
+						if tc.failures < 0 || len(received) <= tc.failures {
+							return NewKafkaError(errFailed, tc.retryable)
+						}
+						handled <- struct{}{}
+						return nil
+					})
+					dlqAcked := h.consume(DLQTopic(h.topic), "conformance-dlq", func(context.Context, kafkago.Message) *KafkaError {
+						return nil
+					})
+
+					// When
+					require.NoError(t, h.producer.Publish(context.Background(), Message{
+						Topic:   h.topic,
+						Key:     []byte("abc123"),
+						Value:   []byte(`{"short_code":"abc123"}`),
+						Headers: []Header{{Key: HeaderEventID, Value: []byte("42")}},
+					}))
+
+					// Then
+					var dlqMsg kafkago.Message
+					if tc.wantDLQ {
+						dlqMsg = h.receive(dlqAcked)
+					} else {
+						select {
+						case <-handled:
+						case <-time.After(conformanceTimeout):
+							t.Fatal("message not handled")
+						}
+					}
+					h.requireNone(dlqAcked)
+
+					mu.Lock()
+					defer mu.Unlock()
+
+					var retries []int
+					for _, msg := range received {
+						require.Equal(t, h.topic, msg.Topic)
+						require.Equal(t, "abc123", string(msg.Key))
+						require.JSONEq(t, `{"short_code":"abc123"}`, string(msg.Value))
+						require.Equal(t, "42", NewMessageCarrier(&msg).Get(HeaderEventID))
+						retries = append(retries, retryCount(msg))
+					}
+					require.Equal(t, tc.wantRetries, retries)
+
+					if !tc.wantDLQ {
+						return
+					}
+
+					require.Equal(t, DLQTopic(h.topic), dlqMsg.Topic)
+					require.Equal(t, "abc123", string(dlqMsg.Key))
+
+					var dlq DLQMessage
+					require.NoError(t, json.Unmarshal(dlqMsg.Value, &dlq))
+					require.Equal(t, h.topic, dlq.Topic)
+					require.Equal(t, "abc123", dlq.Key)
+					require.JSONEq(t, `{"short_code":"abc123"}`, string(dlq.Payload))
+					require.Equal(t, errFailed.Error(), dlq.Error)
+					require.Equal(t, len(tc.wantRetries), dlq.Attempts)
+					require.Equal(t, "42", dlq.Headers[HeaderEventID])
+					require.NotContains(t, dlq.Headers, HeaderRetryCount)
+				})
+			}
+
+			t.Run("success - batch delivered once per message", func(t *testing.T) {
+				// Given
+				h := newConformanceHarness(t, b)
+				acked := h.consume(h.topic, "conformance", func(context.Context, kafkago.Message) *KafkaError {
+					return nil
+				})
+
+				// When
+				msgs := []Message{
+					{Topic: h.topic, Key: []byte("a"), Value: []byte(`"1"`)},
+					{Topic: h.topic, Key: []byte("b"), Value: []byte(`"2"`)},
+					{Topic: h.topic, Key: []byte("a"), Value: []byte(`"3"`)},
+				}
+				errs := h.producer.PublishBatch(context.Background(), msgs)
+
+				// Then
+				require.Equal(t, []error{nil, nil, nil}, errs)
+
+				got := map[string]string{}
+				for range msgs {
+					msg := h.receive(acked)
+					got[string(msg.Value)] = string(msg.Key)
+				}
+				require.Equal(t, map[string]string{`"1"`: "a", `"2"`: "b", `"3"`: "a"}, got)
+				h.requireNone(acked)
+			})
+
+			t.Run("success - messages acknowledged in fetch order per partition", func(t *testing.T) {
+				// Given
+				h := newConformanceHarness(t, b)
+				acked := h.consume(h.topic, "conformance", func(_ context.Context, msg kafkago.Message) *KafkaError {
+					if string(msg.Value) == "1" {
+						// Completed last, the messages fetched after it wait for it
+						time.Sleep(100 * time.Millisecond)
+					}
+					return nil
+				})
+
+				// When
+				var msgs []Message
+				for i := 1; i <= 6; i++ {
+					msgs = append(msgs, Message{Topic: h.topic, Key: []byte(strconv.Itoa(i)), Value: []byte(strconv.Itoa(i))})
+				}
+				for _, msg := range msgs {
+					require.NoError(t, h.producer.Publish(context.Background(), msg))
+				}
+
+				// Then
+				last := map[int]int{}
+				for range msgs {
+					msg := h.receive(acked)
+					n, err := strconv.Atoi(string(msg.Value))
+					require.NoError(t, err)
+					require.Greater(t, n, last[msg.Partition], "acknowledged out of order in partition %d", msg.Partition)
+					last[msg.Partition] = n
+				}
+				h.requireNone(acked)
+			})
+
+			t.Run("success - drain lets the messages in flight finish and acknowledges them", func(t *testing.T) {
+				// Given
+				h := newConformanceHarness(t, b)
+				started, release := make(chan struct{}), make(chan struct{})
+				handlerErr := make(chan error, 1)
+				acked := h.consume(h.topic, "conformance", func(ctx context.Context, _ kafkago.Message) *KafkaError {
+					close(started)
+					<-release
+					handlerErr <- ctx.Err()
+					return nil
+				})
+				require.NoError(t, h.producer.Publish(context.Background(), Message{Topic: h.topic, Value: []byte(`"1"`)}))
+				<-started
+
+				// When
+				ctx, cancel := context.WithTimeout(context.Background(), conformanceTimeout)
+				defer cancel()
+				drained := make(chan error, 1)
+				go func() { drained <- h.drain(ctx) }()
+
+				// Then
+				select {
+				case err := <-drained:
+					t.Fatalf("drained with a message in flight: %v", err)
+				case <-time.After(100 * time.Millisecond):
+				}
+				close(release)
+
+				require.NoError(t, <-drained)
+				require.NoError(t, <-handlerErr)
+				require.Equal(t, `"1"`, string(h.receive(acked).Value))
+			})
+
+			t.Run("error - drain deadline cuts off the messages in flight, left unacknowledged", func(t *testing.T) {
+				// Given
+				h := newConformanceHarness(t, b)
+				started := make(chan struct{})
+				acked := h.consume(h.topic, "conformance", func(ctx context.Context, _ kafkago.Message) *KafkaError {
+					close(started)
+					<-ctx.Done()
+					return NewKafkaError(ctx.Err(), true)
+				})
+				require.NoError(t, h.producer.Publish(context.Background(), Message{Topic: h.topic, Value: []byte(`"1"`)}))
+				<-started
+
+				// When
+				ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+				defer cancel()
+				err := h.drain(ctx)
+
+				// Then
+				require.ErrorIs(t, err, context.DeadlineExceeded)
+				h.requireNone(acked)
+			})
+
+			t.Run("success - acknowledged messages not delivered again to the group", func(t *testing.T) {
+				// Given
+				h := newConformanceHarness(t, b)
+				handled := func(context.Context, kafkago.Message) *KafkaError { return nil }
+
+				first := h.consume(h.topic, "conformance", handled)
+				require.NoError(t, h.producer.Publish(context.Background(), Message{Topic: h.topic, Value: []byte(`"1"`)}))
+				require.Equal(t, `"1"`, string(h.receive(first).Value))
+				h.stop()
+
+				// When
+				second := h.consume(h.topic, "conformance", handled)
+				require.NoError(t, h.producer.Publish(context.Background(), Message{Topic: h.topic, Value: []byte(`"2"`)}))
+
+				// Then
+				require.Equal(t, `"2"`, string(h.receive(second).Value))
+				h.requireNone(second)
+			})
+
+			t.Run("success - scan reads every message from the first", func(t *testing.T) {
+				// Given
+				h := newConformanceHarness(t, b)
+				msgs := []Message{
+					{Topic: h.topic, Key: []byte("a"), Value: []byte(`"1"`), Headers: []Header{{Key: HeaderEventID, Value: []byte("1")}}},
+					{Topic: h.topic, Key: []byte("b"), Value: []byte(`"2"`)},
+					{Topic: h.topic, Value: []byte(`"3"`)},
+				}
+				require.Equal(t, []error{nil, nil, nil}, h.producer.PublishBatch(context.Background(), msgs))
+
+				// When
+				var got []kafkago.Message
+				err := h.broker.Scan(context.Background(), h.topic, func(msg kafkago.Message) error {
+					got = append(got, msg)
+					return nil
+				})
+
+				// Then
+				require.NoError(t, err)
+				require.Len(t, got, len(msgs))
+				for i, msg := range got {
+					require.Equal(t, h.topic, msg.Topic)
+					require.Equal(t, string(msgs[i].Key), string(msg.Key))
+					require.Equal(t, string(msgs[i].Value), string(msg.Value))
+				}
+				require.Equal(t, "1", NewMessageCarrier(&got[0]).Get(HeaderEventID))
+
+				require.NoError(t, h.broker.Scan(context.Background(), h.topic+".unknown", func(kafkago.Message) error {
+					t.Fatal("unexpected message")
+					return nil
+				}))
+			})
+		})
+	}
+}
+
+// conformanceHarness runs the consumers of a test on topics of its own
+type conformanceHarness struct {
+	t        *testing.T
+	backend  conformanceBackend
+	broker   Broker
+	producer Producer
+	topic    string
+	stops    []func()
+	drains   []func(ctx context.Context) error
+}
+
+func newConformanceHarness(t *testing.T, b conformanceBackend) *conformanceHarness {
+	topic := "conformance." + strconv.FormatInt(time.Now().UnixNano(), 10)
+
+	// Consumers create their retry and DLQ topics on start
+	b.createTopics(t, topic, DLQTopic(topic))
+
+	h := &conformanceHarness{t: t, backend: b, broker: b.open(t), topic: topic}
+	h.producer = h.broker.NewProducer()
+	t.Cleanup(func() {
+		h.stop()
+		_ = h.producer.Close()
+		_ = h.broker.Close()
+	})
+
+	return h
+}
+
+// consume starts a consumer of topic which reports the messages it acknowledges, once each of its topics, retry
+// topics included, receives messages
+func (h *conformanceHarness) consume(topic, group string, fn HandlerFunc) <-chan kafkago.Message {
+	handler := HandlerFunc(func(ctx context.Context, msg kafkago.Message) *KafkaError {
+		if NewMessageCarrier(&msg).Get(headerWarmUp) != "" {
+			return nil
+		}
+		return fn(ctx, msg)
+	})
+
+	tiers := h.broker.NewConsumer(topic, group, handler, h.producer).(tieredConsumer)
+	acked := make(chan kafkago.Message, 1000)
+	ready := make([]chan struct{}, len(tiers))
+	for i, c := range tiers {
+		ready[i] = make(chan struct{})
+		c.source = &ackObserver{source: c.source, acked: acked, ready: ready[i]}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	require.NoError(h.t, tiers.Start(ctx))
+	h.stops = append(h.stops, func() {
+		_ = tiers.Close()
+		cancel()
+	})
+	h.drains = append(h.drains, func(ctx context.Context) error {
+		cancel()
+		return tiers.Drain(ctx)
+	})
+
+	timeout := time.After(conformanceTimeout)
+	for i, c := range tiers {
+		readTopic := topic
+		if c.attempt > 0 {
+			readTopic = RetryTopic(topic, c.retryTiers[c.attempt-1])
+		}
+
+		for warm := false; !warm; {
+			require.NoError(h.t, h.producer.Publish(ctx, Message{
+				Topic:   readTopic,
+				Headers: []Header{{Key: headerWarmUp, Value: []byte("1")}},
+			}))
+			select {
+			case <-ready[i]:
+				warm = true
+			case <-time.After(200 * time.Millisecond):
+			case <-timeout:
+				h.t.Fatalf("consumer of %s not ready", readTopic)
+			}
+		}
+	}
+
+	return acked
+}
+
+// receive returns the next message acknowledged
+func (h *conformanceHarness) receive(acked <-chan kafkago.Message) kafkago.Message {
+	select {
+	case msg := <-acked:
+		return msg
+	case <-time.After(conformanceTimeout):
+		h.t.Fatal("no message acknowledged")
+		return kafkago.Message{}
+	}
+}
+
+// requireNone checks that no other message is acknowledged for a while
+func (h *conformanceHarness) requireNone(acked <-chan kafkago.Message) {
+	select {
+	case msg := <-acked:
+		h.t.Fatalf("unexpected message %s", msg.Value)
+	case <-time.After(500 * time.Millisecond):
+	}
+}
+
+// stop closes the consumers started so far
+func (h *conformanceHarness) stop() {
+	for _, stop := range h.stops {
+		stop()
+	}
+	h.stops = nil
+	h.drains = nil
+}
+
+// drain drains the consumers started so far the way services shut down: their context is canceled first
+func (h *conformanceHarness) drain(ctx context.Context) error {
+	var errs []error
+	for _, drain := range h.drains {
+		errs = append(errs, drain(ctx))
+	}
+	return errors.Join(errs...)
+}
+
+// ackObserver reports the messages acknowledged through a source, once acknowledged. Warm-up messages are not
+// reported, the first one closes ready instead.
+type ackObserver struct {
+	source
+	acked     chan<- kafkago.Message
+	ready     chan struct{}
+	readyOnce sync.Once
+}
+
+func (o *ackObserver) fetch(ctx context.Context) (delivery, error) {
+	d, err := o.source.fetch(ctx)
+	if err != nil {
+		return d, err
+	}
+
+	ack := d.ack
+	d.ack = func(ctx context.Context) error {
+		if err := ack(ctx); err != nil {
+			return err
+		}
+		if NewMessageCarrier(&d.msg).Get(headerWarmUp) != "" {
+			o.readyOnce.Do(func() { close(o.ready) })
+		} else {
+			o.acked <- d.msg
+		}
+		return nil
+	}
+	return d, nil
+}
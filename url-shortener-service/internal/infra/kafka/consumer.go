@@ -3,47 +3,60 @@ package kafka
 import (
 	"context"
 	"errors"
+	"fmt"
 	"io"
+	"sync"
 	"time"
 
-	"github.com/cenkalti/backoff/v4"
 	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/monitoring"
 	kafkago "github.com/segmentio/kafka-go"
 )
 
 const (
-	MaxRetries         = 4
 	DefaultWorkerCount = 10
 
 	// ChannelBufferFactor = 2 means:
-	// bufferSize = workerCount * 2
+	// bufferSize = workerCount * 2, split between the queues of the workers
 	// → ensures workers always have pending messages
 	//   and prevents worker starvation when fetcher is briefly blocked.
 	ChannelBufferFactor = 2
+
+	// DefaultCommitTimeout bounds each commit, those of the messages handled while a drain aborts included,
+	// as long as the default shutdown timeout
+	DefaultCommitTimeout = 5 * time.Second
 )
 
-// Consumer defines the minimal interface required to consume messages from Kafka.
+// Consumer defines the minimal interface required to consume messages from the broker.
 type Consumer interface {
 	// Start begins consuming messages in a separate goroutine.
 	Start(ctx context.Context) error
+	// Drain stops fetching and waits for the messages fetched to be handled and acknowledged, until ctx is done:
+	// the messages still in flight are then cut off, and delivered again to the group.
+	Drain(ctx context.Context) error
 	// Close releases any resources held by the consumer.
 	Close() error
 }
 
-// NewConsumer creates a new Kafka consumer for a single topic and consumer group.
-func NewConsumer(cfg Config, topic, groupID string, handler MessageHandler, dlqProducer Producer) Consumer {
-	workerCount := cfg.WorkerCount
-	if workerCount <= 0 {
-		workerCount = DefaultWorkerCount
+// NewConsumer creates a new Kafka consumer for a single topic and consumer group, along with the consumers
+// of its retry topics. The retry and DLQ topics are created on Start when missing.
+func NewConsumer(cfg Config, topic, groupID string, handler MessageHandler, producer Producer) Consumer {
+	// Created by the consumer of topic, started first, before any message may be published to them
+	outlets := []string{DLQTopic(topic)}
+	for _, delay := range cfg.RetryTiers {
+		outlets = append(outlets, RetryTopic(topic, delay))
 	}
 
-	// Channel buffer = workerCount * 2 by default
-	// Can be overridden via cfg.CommitBuffer if needed.
-	channelBuffer := workerCount * ChannelBufferFactor
-	if cfg.ChannelBuffer > 0 {
-		channelBuffer = cfg.ChannelBuffer
-	}
+	return newTieredConsumer(cfg, topic, groupID, func(src string, _ time.Duration) source {
+		rs := newReaderSource(cfg, src, groupID)
+		if src == topic {
+			rs.brokers, rs.outlets = cfg.Brokers, outlets
+		}
+		return rs
+	}, handler, producer)
+}
 
+// newReaderSource creates the kafka-go reader of topic for a consumer group
+func newReaderSource(cfg Config, topic, groupID string) readerSource {
 	// Kafka reader performance tuning
 	minBytes := cfg.MinBytes
 	if minBytes <= 0 {
@@ -55,25 +68,14 @@ func NewConsumer(cfg Config, topic, groupID string, handler MessageHandler, dlqP
 		maxBytes = 10e6 // 10MB
 	}
 
-	maxWait := cfg.MaxWait
-	if maxWait <= 0 {
-		maxWait = 1000 // 1s
-	}
-
-	return &readerConsumer{
-		handler:       handler,
-		dlqProducer:   dlqProducer,
-		topic:         topic,
-		groupID:       groupID,
-		workerCount:   workerCount,
-		channelBuffer: channelBuffer,
+	return readerSource{
 		reader: kafkago.NewReader(kafkago.ReaderConfig{
 			Brokers:  cfg.Brokers,
 			GroupID:  groupID,
 			Topic:    topic,
 			MinBytes: minBytes,
 			MaxBytes: maxBytes,
-			MaxWait:  time.Duration(maxWait) * time.Millisecond,
+			MaxWait:  maxWait(cfg),
 			// avoid reprocessing old messages on first startup
 			StartOffset: kafkago.LastOffset,
 			// prevent long hangs on broker issues
@@ -83,40 +85,228 @@ func NewConsumer(cfg Config, topic, groupID string, handler MessageHandler, dlqP
 	}
 }
 
-type readerConsumer struct {
-	topic         string
-	groupID       string
-	reader        *kafkago.Reader
+// newTieredConsumer creates the consumer of topic and those of its retry topics, one per tier of cfg.RetryTiers.
+// newSource creates the source of a topic for the group; hold is how long its messages may wait, fetched but
+// not acknowledged, for their retry delay.
+func newTieredConsumer(
+	cfg Config,
+	topic, groupID string,
+	newSource func(topic string, hold time.Duration) source,
+	handler MessageHandler,
+	producer Producer,
+) Consumer {
+	rs := make(tieredConsumer, 0, len(cfg.RetryTiers)+1)
+	rs = append(rs, newConsumer(cfg, topic, groupID, 0, newSource(topic, 0), handler, producer))
+	for i, delay := range cfg.RetryTiers {
+		rs = append(rs, newConsumer(cfg, topic, groupID, i+1, newSource(RetryTopic(topic, delay), delay), handler, producer))
+	}
+
+	return rs
+}
+
+// newConsumer creates the consumer of topic, or of its retry topic for attempt > 0, for a consumer group,
+// fetching from the source of a backend
+func newConsumer(
+	cfg Config,
+	topic, groupID string,
+	attempt int,
+	src source,
+	handler MessageHandler,
+	producer Producer,
+) *consumer {
+	workerCount := cfg.WorkerCount
+	if workerCount <= 0 {
+		workerCount = DefaultWorkerCount
+	}
+
+	// Channel buffer = workerCount * 2 by default
+	// Can be overridden via cfg.CommitBuffer if needed.
+	channelBuffer := workerCount * ChannelBufferFactor
+	if cfg.ChannelBuffer > 0 {
+		channelBuffer = cfg.ChannelBuffer
+	}
+
+	commitTimeout := cfg.CommitTimeout
+	if commitTimeout <= 0 {
+		commitTimeout = DefaultCommitTimeout
+	}
+
+	return &consumer{
+		handler:       handler,
+		producer:      producer,
+		topic:         topic,
+		groupID:       groupID,
+		attempt:       attempt,
+		retryTiers:    cfg.RetryTiers,
+		workerCount:   workerCount,
+		channelBuffer: channelBuffer,
+		source:        src,
+		commits:       newCommitTracker(commitTimeout),
+		stopping:      make(chan struct{}),
+		aborted:       make(chan struct{}),
+		closed:        make(chan struct{}),
+	}
+}
+
+// maxWait is how long a fetch waits for messages before polling the broker again
+func maxWait(cfg Config) time.Duration {
+	if cfg.MaxWait <= 0 {
+		return time.Second
+	}
+	return time.Duration(cfg.MaxWait) * time.Millisecond
+}
+
+// source is the backend side of a consumer: it fetches the messages of a topic for a consumer group
+type source interface {
+	// subscribe joins the consumer group, creating it when needed, before the first fetch
+	subscribe(ctx context.Context) error
+	// fetch blocks until the next message of the group. It returns the error of ctx once done, and io.EOF once closed.
+	fetch(ctx context.Context) (delivery, error)
+	// Close stops fetching, the messages fetched but not acknowledged are delivered again to the group.
+	Close() error
+}
+
+// delivery is a fetched message, in kafka-go form whatever the backend, and how to acknowledge it
+type delivery struct {
+	msg kafkago.Message
+	// ack tells the broker the message is handled, so that it is not delivered to the group again
+	ack func(ctx context.Context) error
+	// cumulative tells that ack also acknowledges the messages fetched before on the partition, as Kafka commits do
+	cumulative bool
+}
+
+// tieredConsumer runs the consumer of a topic and those of its retry topics
+type tieredConsumer []*consumer
+
+// Start implements Consumer
+func (t tieredConsumer) Start(ctx context.Context) error {
+	for _, c := range t {
+		if err := c.Start(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Drain implements Consumer, draining the consumers of the topic and its retry topics together
+func (t tieredConsumer) Drain(ctx context.Context) error {
+	errs := make([]error, len(t))
+	var wg sync.WaitGroup
+	for i, c := range t {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = c.Drain(ctx)
+		}()
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+// Close implements Consumer
+func (t tieredConsumer) Close() error {
+	var errs []error
+	for _, c := range t {
+		errs = append(errs, c.Close())
+	}
+	return errors.Join(errs...)
+}
+
+// consumer runs the processing shared by every backend: worker pool, retry topics and DLQ.
+//
+// Messages are sharded between the workers by key, so that those of a key are handled one at a time in fetch
+// order, and acknowledged through a commitTracker, so that no commit passes a message still in flight.
+type consumer struct {
+	topic   string // the topic consumed, its retry topic is read when attempt > 0
+	groupID string
+	// attempt is the number of failed attempts of the messages read: 0 for the topic, n for its n-th retry topic
+	attempt       int
+	retryTiers    []time.Duration
+	source        source
 	handler       MessageHandler
-	dlqProducer   Producer
+	producer      Producer // publishes the retries and the DLQ messages
 	workerCount   int
-	channelBuffer int
+	channelBuffer int // total size of the worker queues
+	commits       *commitTracker
+	workers       sync.WaitGroup
+	stopOnce      sync.Once
+	stopping      chan struct{} // closed to stop fetching
+	abortOnce     sync.Once
+	aborted       chan struct{} // closed to cut off the messages in flight
+	closeOnce     sync.Once
+	closed        chan struct{}
 }
 
 // Start begins the consume loop in a background goroutine.
-func (c *readerConsumer) Start(ctx context.Context) error {
+func (c *consumer) Start(ctx context.Context) error {
 	log := monitoring.Log(ctx).
 		Field("topic", c.topic).
 		Field("group_id", c.groupID).
+		Field("attempt", c.attempt).
 		Field("workers", c.workerCount).
 		Field("channel_buffer", c.channelBuffer)
 
-	// shared queue for all workers
-	msgChan := make(chan kafkago.Message, c.channelBuffer)
+	if err := c.source.subscribe(ctx); err != nil {
+		log.Error().Err(err).Msg("[KafkaConsumer] subscribe failed")
+		return err
+	}
+
+	// Fetching stops once ctx is done or Drain is called. Handling goes on after ctx is done, until the fetched
+	// messages are handled or the consumer aborted, so that they are not cut off mid-way nor their commits failed.
+	fetchCtx, stopFetch := context.WithCancel(ctx)
+	workCtx, abort := context.WithCancel(context.WithoutCancel(ctx))
+	workersDone := make(chan struct{})
+	go func() {
+		defer stopFetch()
+		select {
+		case <-c.stopping:
+		case <-fetchCtx.Done():
+		}
+	}()
+	go func() {
+		defer abort()
+		select {
+		case <-c.aborted:
+		case <-workersDone:
+		}
+	}()
+
+	// one queue per worker, see shard
+	workerBuffer := c.channelBuffer / c.workerCount
+	if workerBuffer < 1 {
+		workerBuffer = 1
+	}
+	msgChans := make([]chan *trackedDelivery, c.workerCount)
 
 	// start worker pool
-	for i := 0; i < c.workerCount; i++ {
-		go c.worker(ctx, msgChan, i)
+	c.workers.Add(c.workerCount)
+	for i := range msgChans {
+		msgChans[i] = make(chan *trackedDelivery, workerBuffer)
+		go func() {
+			defer c.workers.Done()
+			c.worker(workCtx, msgChans[i], i)
+		}()
 	}
+	go func() {
+		c.workers.Wait()
+		close(workersDone)
+	}()
 
 	// fetcher goroutine: continuously fetch messages from Kafka
 	go func() {
 		log.Info().Msg("[KafkaConsumer] fetcher started")
 		defer log.Info().Msg("[KafkaConsumer] fetcher stopped")
-		defer close(msgChan) // closing the channel gracefully shuts down workers
+		defer func() {
+			// closing the channels gracefully shuts down workers
+			for _, ch := range msgChans {
+				close(ch)
+			}
+		}()
 
+		var unkeyed uint64
 		for {
-			msg, err := c.reader.FetchMessage(ctx)
+			d, err := c.source.fetch(fetchCtx)
 			if err != nil {
 				if errors.Is(err, context.Canceled) {
 					log.Info().Msg("[KafkaConsumer] context canceled, stopping fetcher")
@@ -133,16 +323,28 @@ func (c *readerConsumer) Start(ctx context.Context) error {
 			}
 
 			log.Info().
-				Str("topic", msg.Topic).
-				Int("partition", msg.Partition).
-				Int64("offset", msg.Offset).
+				Str("topic", d.msg.Topic).
+				Int("partition", d.msg.Partition).
+				Int64("offset", d.msg.Offset).
 				Msg("[KafkaConsumer] partition assigned")
 
-			// send message to workers (blocks if channel is full → natural backpressure)
+			// Retries wait for their delay, an error leaves the message to be delivered again
+			if c.attempt > 0 {
+				if err := waitRetryDelay(fetchCtx, c.closed, d.msg); err != nil {
+					log.Info().Err(err).Msg("[KafkaConsumer] stopped while waiting for a retry delay")
+					return
+				}
+			}
+
+			// send message to its worker (blocks if its queue is full → natural backpressure)
+			worker := shard(d.msg.Key, c.workerCount, unkeyed)
+			if len(d.msg.Key) == 0 {
+				unkeyed++
+			}
 			select {
-			case msgChan <- msg:
+			case msgChans[worker] <- c.commits.track(d):
 				// successfully queued
-			case <-ctx.Done():
+			case <-fetchCtx.Done():
 				log.Info().Msg("[KafkaConsumer] context canceled while sending to channel")
 				return
 			}
@@ -153,14 +355,15 @@ func (c *readerConsumer) Start(ctx context.Context) error {
 	return nil
 }
 
-// worker processes messages concurrently.
-// Workers read from the shared channel until:
+// worker processes messages concurrently with the other workers, those of its queue one at a time.
+// Workers read from their channel until:
 // - channel is closed, or
 // - context is canceled.
-func (c *readerConsumer) worker(ctx context.Context, msgChan <-chan kafkago.Message, workerID int) {
+func (c *consumer) worker(ctx context.Context, msgChan <-chan *trackedDelivery, workerID int) {
 	log := monitoring.Log(ctx).
 		Field("worker_id", workerID).
-		Field("topic", c.topic)
+		Field("topic", c.topic).
+		Field("attempt", c.attempt)
 
 	log.Info().Msg("[KafkaConsumer-Worker] started")
 	defer log.Info().Msg("[KafkaConsumer-Worker] stopped")
@@ -174,7 +377,7 @@ func (c *readerConsumer) worker(ctx context.Context, msgChan <-chan kafkago.Mess
 
 	for {
 		select {
-		case msg, ok := <-msgChan:
+		case d, ok := <-msgChan:
 			if !ok {
 				// channel closed → fetcher stopped → safe shutdown
 				log.Info().
@@ -184,60 +387,49 @@ func (c *readerConsumer) worker(ctx context.Context, msgChan <-chan kafkago.Mess
 				return
 			}
 
+			// Retries are handled as messages of the topic
+			msg := d.msg
+			msg.Topic = c.topic
+
 			// add per-message timeout to prevent long-running tasks from blocking the worker forever
 			msgCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
-			processErr := c.processMessage(msgCtx, msg)
+			msgCtx, span := startConsumeSpan(msgCtx, msg)
+			kerr := c.processMessage(msgCtx, msg)
+			var processErr error
+			if kerr != nil {
+				processErr = kerr.error
+			}
+			monitoring.End(span, &processErr)
 			cancel()
 
-			if processErr != nil {
-				errorCount++
-
-				log.Error().
-					Err(processErr).
+			if kerr != nil && ctx.Err() != nil {
+				// Cut off by the abort of a drain, not failed: left to be delivered again to the group
+				log.Warn().
+					Err(kerr.error).
 					Int("partition", msg.Partition).
 					Int64("offset", msg.Offset).
-					Msg("[KafkaConsumer-Worker] error occurred → sending to DLQ")
-
-				meta := monitoring.SpanMetadataFromContext(msgCtx)
-
-				dlqMsg := DLQMessage{
-					Topic:         c.topic,
-					Payload:       msg.Value,
-					Error:         processErr.Error(),
-					TraceID:       meta.TraceID,
-					SpanID:        meta.SpanID,
-					CorrelationID: meta.CorrelationID,
-					Partition:     msg.Partition,
-					Offset:        msg.Offset,
-					Timestamp:     time.Now(),
-				}
+					Msg("[KafkaConsumer-Worker] aborted, message left unacknowledged")
+				return
+			}
 
-				if err := publishDLQ(msgCtx, c.dlqProducer, dlqMsg, c.topic+".dlq"); err != nil {
-					log.Error().Err(err).Msg("[KafkaConsumer-Worker] DLQ publish failed")
-				}
+			if kerr != nil {
+				errorCount++
 
-				// Commit offset to avoid poison message loop
-				if err := c.reader.CommitMessages(ctx, msg); err != nil {
-					log.Error().
-						Err(err).
-						Int("partition", msg.Partition).
-						Int64("offset", msg.Offset).
-						Msg("[KafkaConsumer-Worker] commit failed after DLQ")
+				// msgCtx is done by now, the retry and DLQ publish keep its values only
+				pubCtx := context.WithoutCancel(msgCtx)
+				if !c.retry(pubCtx, msg, kerr) {
+					c.sendToDLQ(pubCtx, msg, kerr.error)
 				}
 
+				// Commit offset to avoid poison message loop, the message lives on in the retry topic or the DLQ
+				c.commits.complete(ctx, d)
+
 				continue
 			}
 
-			// Successful processing → commit offset
+			// Successful processing → commit offset, once those fetched before are
 			processedCount++
-
-			if err := c.reader.CommitMessages(ctx, msg); err != nil {
-				log.Error().
-					Err(err).
-					Int("partition", msg.Partition).
-					Int64("offset", msg.Offset).
-					Msg("[KafkaConsumer-Worker] commit failed")
-			}
+			c.commits.complete(ctx, d)
 
 		case <-statsTicker.C:
 			total := processedCount + errorCount
@@ -253,6 +445,7 @@ func (c *readerConsumer) worker(ctx context.Context, msgChan <-chan kafkago.Mess
 				Msg("[KafkaConsumer-Worker] stats")
 
 		case <-ctx.Done():
+			// aborted, the messages left in the queue are delivered again to the group
 			log.Info().
 				Int("processed", processedCount).
 				Int("errors", errorCount).
@@ -262,46 +455,138 @@ func (c *readerConsumer) worker(ctx context.Context, msgChan <-chan kafkago.Mess
 	}
 }
 
-// processMessage handles retry logic and returns the final error (if any).
-// Worker is responsible for DLQ logic and committing offsets.
-func (c *readerConsumer) processMessage(ctx context.Context, msg kafkago.Message) error {
-	log := monitoring.Log(ctx)
-
-	// First attempt
+// processMessage calls the handler once and returns its error (if any). Retries go through the retry topics
+// instead of blocking the worker, see retry.
+func (c *consumer) processMessage(ctx context.Context, msg kafkago.Message) *KafkaError {
 	err := c.handler.ConsumeMessage(ctx, msg)
-	if err == nil {
-		return nil
-	}
-
-	// Non-retryable → return immediately
-	if !err.isRetryable() {
-		log.Warn().
+	if err != nil && !err.isRetryable() {
+		monitoring.Log(ctx).Warn().
 			Err(err).
 			Int("partition", msg.Partition).
 			Int64("offset", msg.Offset).
 			Msg("[KafkaConsumer] non-retryable error")
-		return err.error
 	}
 
-	// Retryable → apply backoff policy
-	policy := backoff.WithMaxRetries(DefaultBackoffPolicy(), MaxRetries)
+	return err
+}
 
-	lastErr := retryKafka(ctx, policy, func(ctx context.Context) error {
-		return c.handler.ConsumeMessage(ctx, msg)
-	})
+// retry publishes a failed message to the retry topic of its next attempt. It returns false when the error is
+// not retryable, the retry tiers are exhausted or the publish failed: the message then goes to the DLQ.
+func (c *consumer) retry(ctx context.Context, msg kafkago.Message, cause *KafkaError) bool {
+	attempt := c.attempt + 1
+	if !cause.isRetryable() || attempt > len(c.retryTiers) {
+		return false
+	}
 
-	if lastErr != nil {
-		log.Warn().
-			Err(lastErr).
-			Int("partition", msg.Partition).
-			Int64("offset", msg.Offset).
-			Msg("[KafkaConsumer] retry exhausted")
+	log := monitoring.Log(ctx).
+		Field("attempt", attempt).
+		Field("delay", c.retryTiers[attempt-1].String())
+
+	if err := c.producer.Publish(ctx, retryMessage(msg, c.topic, c.retryTiers, attempt, cause.error)); err != nil {
+		log.Error().Err(err).Msg("[KafkaConsumer-Worker] retry publish failed → sending to DLQ")
+		return false
+	}
+
+	log.Warn().Err(cause.error).Msg("[KafkaConsumer-Worker] error occurred → retry scheduled")
+	return true
+}
+
+// sendToDLQ publishes a message which failed for good to the DLQ of the topic
+func (c *consumer) sendToDLQ(ctx context.Context, msg kafkago.Message, cause error) {
+	log := monitoring.Log(ctx)
+
+	log.Error().
+		Err(cause).
+		Int("partition", msg.Partition).
+		Int64("offset", msg.Offset).
+		Int("attempts", c.attempt+1).
+		Msg("[KafkaConsumer-Worker] error occurred → sending to DLQ")
+
+	meta := monitoring.SpanMetadataFromContext(ctx)
+
+	dlqMsg := DLQMessage{
+		Topic:         c.topic,
+		Key:           string(msg.Key),
+		Payload:       msg.Value,
+		Headers:       dlqHeaders(msg),
+		Error:         cause.Error(),
+		Attempts:      c.attempt + 1,
+		TraceID:       meta.TraceID,
+		SpanID:        meta.SpanID,
+		CorrelationID: meta.CorrelationID,
+		Partition:     msg.Partition,
+		Offset:        msg.Offset,
+		Timestamp:     time.Now(),
+	}
+
+	if err := publishDLQ(ctx, c.producer, dlqMsg, DLQTopic(c.topic)); err != nil {
+		log.Error().Err(err).Msg("[KafkaConsumer-Worker] DLQ publish failed")
+	}
+}
+
+// Drain stops fetching then waits for the workers to handle and acknowledge the messages of their queues. Once ctx
+// is done, the messages in flight are cut off and left to be delivered again to the group.
+func (c *consumer) Drain(ctx context.Context) error {
+	c.stopOnce.Do(func() { close(c.stopping) })
+
+	drained := make(chan struct{})
+	go func() {
+		c.workers.Wait()
+		close(drained)
+	}()
+
+	select {
+	case <-drained:
+		return nil
+	case <-ctx.Done():
+		c.abortOnce.Do(func() { close(c.aborted) })
+		return fmt.Errorf("kafka: drain %s of %s: %w", c.topic, c.groupID, ctx.Err())
+	}
+}
+
+// Close stops fetching, cuts off the messages in flight, then stops the source and frees associated resources.
+// Call Drain first to let them finish.
+func (c *consumer) Close() error {
+	c.stopOnce.Do(func() { close(c.stopping) })
+	c.abortOnce.Do(func() { close(c.aborted) })
+	c.closeOnce.Do(func() { close(c.closed) })
+	return c.source.Close()
+}
+
+// readerSource fetches from Kafka with a kafka-go reader, acknowledging by committing the offset
+type readerSource struct {
+	reader *kafkago.Reader
+	// brokers and outlets are set on the source of the consumed topic: the retry and DLQ topics it creates
+	brokers []string
+	outlets []string
+}
+
+// subscribe implements source, creating the outlets which do not exist yet. The reader joins the group
+// on its first fetch.
+func (s readerSource) subscribe(ctx context.Context) error {
+	if len(s.outlets) == 0 {
+		return nil
+	}
+	return ensureTopics(ctx, s.brokers, s.reader.Config().Topic, s.outlets)
+}
+
+// fetch implements source
+func (s readerSource) fetch(ctx context.Context) (delivery, error) {
+	msg, err := s.reader.FetchMessage(ctx)
+	if err != nil {
+		return delivery{}, err
 	}
 
-	return lastErr
+	return delivery{
+		msg: msg,
+		ack: func(ctx context.Context) error {
+			return s.reader.CommitMessages(ctx, msg)
+		},
+		cumulative: true,
+	}, nil
 }
 
-// Close closes the underlying Kafka reader and frees associated resources.
-func (c *readerConsumer) Close() error {
-	return c.reader.Close()
+// Close implements source, closing the reader
+func (s readerSource) Close() error {
+	return s.reader.Close()
 }
@@ -2,25 +2,37 @@ package kafka
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
 	"time"
 
 	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/monitoring"
+	kafkago "github.com/segmentio/kafka-go"
 )
 
+// HeaderReplayCount carries the number of times a message was replayed from the DLQ
+const HeaderReplayCount = "replay_count"
+
 // DLQMessage represents a failed Kafka message that is sent to a Dead Letter Queue.
 // It contains the original message payload, error details, and tracing metadata
 // for debugging and potential reprocessing.
 type DLQMessage struct {
-	Topic         string          `json:"topic"`          // Original topic where the message failed
-	Payload       json.RawMessage `json:"payload"`        // Original message payload
-	Error         string          `json:"error"`          // Error message that caused the failure
-	TraceID       string          `json:"trace_id"`       // OpenTelemetry trace ID for correlation
-	SpanID        string          `json:"span_id"`        // OpenTelemetry span ID for correlation
-	CorrelationID string          `json:"correlation_id"` // Correlation ID for request tracking
-	Partition     int             `json:"partition"`      // Kafka partition where message was consumed
-	Offset        int64           `json:"offset"`         // Kafka offset of the failed message
-	Timestamp     time.Time       `json:"timestamp"`      // When the message failed
+	Topic         string            `json:"topic"`             // Original topic where the message failed
+	Key           string            `json:"key,omitempty"`     // Original message key, kept on the DLQ message too
+	Payload       json.RawMessage   `json:"payload"`           // Original message payload
+	Headers       map[string]string `json:"headers,omitempty"` // Original message headers, retry headers aside
+	Error         string            `json:"error"`             // Error message that caused the failure
+	Attempts      int               `json:"attempts"`          // Number of attempts, the retries included
+	TraceID       string            `json:"trace_id"`          // OpenTelemetry trace ID for correlation
+	SpanID        string            `json:"span_id"`           // OpenTelemetry span ID for correlation
+	CorrelationID string            `json:"correlation_id"`    // Correlation ID for request tracking
+	Partition     int               `json:"partition"`         // Kafka partition where message was consumed
+	Offset        int64             `json:"offset"`            // Kafka offset of the failed message
+	Timestamp     time.Time         `json:"timestamp"`         // When the message failed
 }
 
 // publishDLQ sends a failed message to the Dead Letter Queue topic.
@@ -34,7 +46,7 @@ func publishDLQ(ctx context.Context, producer Producer, msg DLQMessage, targetTo
 		return err
 	}
 
-	if err := producer.Publish(ctx, targetTopic, raw); err != nil {
+	if err := producer.Publish(ctx, Message{Topic: targetTopic, Key: []byte(msg.Key), Value: raw}); err != nil {
 		log.Error().Err(err).Msg("[DLQ] publish failed")
 		return err
 	}
@@ -42,3 +54,75 @@ func publishDLQ(ctx context.Context, producer Producer, msg DLQMessage, targetTo
 	log.Info().Msg("[DLQ] message sent")
 	return nil
 }
+
+// DLQRecord is a DLQ message read back from its topic
+type DLQRecord struct {
+	DLQMessage
+	// ID identifies the record: a digest of its content, the same whenever and from wherever it is read
+	ID string
+	// DLQTopic is the topic the record was read from
+	DLQTopic string
+}
+
+// ReadDLQRecord decodes a message of a DLQ topic
+func ReadDLQRecord(msg kafkago.Message) (DLQRecord, error) {
+	var rs DLQRecord
+	if err := json.Unmarshal(msg.Value, &rs.DLQMessage); err != nil {
+		return DLQRecord{}, fmt.Errorf("invalid DLQ message at offset %d of %s: %w", msg.Offset, msg.Topic, err)
+	}
+
+	sum := sha256.Sum256(msg.Value)
+	rs.ID = hex.EncodeToString(sum[:8])
+	rs.DLQTopic = msg.Topic
+
+	return rs, nil
+}
+
+// EventID returns the outbox event ID of the original message, "" when unknown
+func (r DLQRecord) EventID() string {
+	return r.Headers[HeaderEventID]
+}
+
+// ReplayCount returns the number of times the original message had been replayed already
+func (r DLQRecord) ReplayCount() int {
+	n, _ := strconv.Atoi(r.Headers[HeaderReplayCount])
+	return n
+}
+
+// ReplayMessage rebuilds the original message for its topic, its replay count incremented
+func (r DLQRecord) ReplayMessage() Message {
+	rs := Message{Topic: r.Topic, Value: r.Payload}
+	if r.Key != "" {
+		rs.Key = []byte(r.Key)
+	}
+
+	keys := make([]string, 0, len(r.Headers))
+	for k := range r.Headers {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		rs.Headers = append(rs.Headers, Header{Key: k, Value: []byte(r.Headers[k])})
+	}
+	headersCarrier{headers: &rs.Headers}.Set(HeaderReplayCount, strconv.Itoa(r.ReplayCount()+1))
+
+	return rs
+}
+
+// dlqHeaders returns the headers of a failed message worth keeping on its DLQ message, to replay it as it was
+func dlqHeaders(msg kafkago.Message) map[string]string {
+	rs := map[string]string{}
+	for _, h := range msg.Headers {
+		switch h.Key {
+		case HeaderRetryCount, HeaderRetryNotBefore, HeaderRetryError:
+		default:
+			if _, ok := rs[h.Key]; !ok {
+				rs[h.Key] = string(h.Value)
+			}
+		}
+	}
+	if len(rs) == 0 {
+		return nil
+	}
+	return rs
+}
@@ -0,0 +1,96 @@
+package kafka
+
+import (
+	"encoding/json"
+	"testing"
+
+	kafkago "github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadDLQRecord(t *testing.T) {
+	tcs := map[string]struct {
+		value           string
+		wantErr         bool
+		wantEventID     string
+		wantReplayCount int
+	}{
+		"success - never replayed": {
+			value:       `{"topic":"links","key":"abc123","payload":{"short_code":"abc123"},"headers":{"event_id":"42"},"error":"boom"}`,
+			wantEventID: "42",
+		},
+		"success - replayed before": {
+			value:           `{"topic":"links","payload":{},"headers":{"event_id":"42","replay_count":"2"},"error":"boom"}`,
+			wantEventID:     "42",
+			wantReplayCount: 2,
+		},
+		"success - written before headers were kept": {
+			value: `{"topic":"links","payload":{},"error":"boom"}`,
+		},
+		"error - not a DLQ message": {
+			value:   `not json`,
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			msg := kafkago.Message{Topic: "links.dlq", Offset: 7, Value: []byte(tc.value)}
+
+			rs, err := ReadDLQRecord(msg)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, "links.dlq", rs.DLQTopic)
+			require.Equal(t, "links", rs.Topic)
+			require.Equal(t, tc.wantEventID, rs.EventID())
+			require.Equal(t, tc.wantReplayCount, rs.ReplayCount())
+			require.Len(t, rs.ID, 16)
+
+			again, err := ReadDLQRecord(kafkago.Message{Topic: "links.dlq", Offset: 9, Value: []byte(tc.value)})
+			require.NoError(t, err)
+			require.Equal(t, rs.ID, again.ID)
+		})
+	}
+}
+
+func TestDLQRecordReplayMessage(t *testing.T) {
+	// Given
+	rec := DLQRecord{DLQMessage: DLQMessage{
+		Topic:   "links",
+		Key:     "abc123",
+		Payload: json.RawMessage(`{"short_code":"abc123"}`),
+		Headers: map[string]string{HeaderEventID: "42", HeaderReplayCount: "1", "traceparent": "00-a-b-01"},
+	}}
+
+	// When
+	msg := rec.ReplayMessage()
+
+	// Then
+	require.Equal(t, Message{
+		Topic: "links",
+		Key:   []byte("abc123"),
+		Value: []byte(`{"short_code":"abc123"}`),
+		Headers: []Header{
+			{Key: HeaderEventID, Value: []byte("42")},
+			{Key: HeaderReplayCount, Value: []byte("2")},
+			{Key: "traceparent", Value: []byte("00-a-b-01")},
+		},
+	}, msg)
+}
+
+func TestDLQHeaders(t *testing.T) {
+	msg := kafkago.Message{Headers: []kafkago.Header{
+		{Key: HeaderEventID, Value: []byte("42")},
+		{Key: HeaderRetryCount, Value: []byte("3")},
+		{Key: HeaderRetryNotBefore, Value: []byte("1700000000000")},
+		{Key: HeaderRetryError, Value: []byte("boom")},
+		{Key: HeaderEventID, Value: []byte("43")},
+	}}
+
+	require.Equal(t, map[string]string{HeaderEventID: "42"}, dlqHeaders(msg))
+	require.Nil(t, dlqHeaders(kafkago.Message{}))
+}
@@ -0,0 +1,229 @@
+package kafka
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/monitoring"
+	kafkago "github.com/segmentio/kafka-go"
+)
+
+// memoryBroker keeps the messages of each topic in an append-only log, a single partition, read by the consumer
+// groups from their own position
+type memoryBroker struct {
+	cfg    Config
+	mu     sync.Mutex
+	topics map[string]*memoryTopic
+}
+
+// memoryTopic is the log of a topic and the groups reading it
+type memoryTopic struct {
+	log    []kafkago.Message
+	groups map[string]*memoryGroup
+	// appended is closed, and replaced, on each append to wake up the waiting fetches
+	appended chan struct{}
+}
+
+// memoryGroup is the position of a consumer group in a topic log
+type memoryGroup struct {
+	start   int          // offset of the first message after the creation of the group
+	next    int          // offset of the next message to deliver
+	acked   map[int]bool // offsets acknowledged
+	members int          // consumers currently subscribed
+}
+
+// NewMemoryBroker returns an in-memory broker. Messages are lost with the process; producers and consumers
+// must be created from the same broker.
+func NewMemoryBroker(cfg Config) Broker {
+	return &memoryBroker{cfg: cfg, topics: map[string]*memoryTopic{}}
+}
+
+// NewProducer implements Broker
+func (b *memoryBroker) NewProducer() Producer {
+	return memoryProducer{broker: b}
+}
+
+// NewConsumer implements Broker
+func (b *memoryBroker) NewConsumer(topic, groupID string, handler MessageHandler, producer Producer) Consumer {
+	return newTieredConsumer(b.cfg, topic, groupID, func(topic string, _ time.Duration) source {
+		return &memorySource{
+			broker: b,
+			topic:  topic,
+			group:  groupID,
+			closed: make(chan struct{}),
+		}
+	}, handler, producer)
+}
+
+// Scan implements Broker
+func (b *memoryBroker) Scan(ctx context.Context, topic string, fn func(kafkago.Message) error) error {
+	b.mu.Lock()
+	log := append([]kafkago.Message(nil), b.topic(topic).log...)
+	b.mu.Unlock()
+
+	for _, msg := range log {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := fn(msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close implements Broker
+func (b *memoryBroker) Close() error {
+	return nil
+}
+
+// topic returns the log of a topic, creating it on first use. Callers hold the lock.
+func (b *memoryBroker) topic(name string) *memoryTopic {
+	t, ok := b.topics[name]
+	if !ok {
+		t = &memoryTopic{groups: map[string]*memoryGroup{}, appended: make(chan struct{})}
+		b.topics[name] = t
+	}
+	return t
+}
+
+// append adds a message to the log of its topic
+func (b *memoryBroker) append(msg Message) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	t := b.topic(msg.Topic)
+	km := toKafkaMessage(msg)
+	km.Offset = int64(len(t.log))
+	t.log = append(t.log, km)
+
+	close(t.appended)
+	t.appended = make(chan struct{})
+}
+
+// memoryProducer appends to the logs of a memoryBroker
+type memoryProducer struct {
+	broker *memoryBroker
+}
+
+// Publish implements Producer
+func (p memoryProducer) Publish(ctx context.Context, msg Message) error {
+	var err error
+	ctx, span := StartPublishSpan(ctx, &msg)
+	defer monitoring.End(span, &err)
+
+	if err = ctx.Err(); err != nil {
+		return err
+	}
+	p.broker.append(msg)
+
+	return nil
+}
+
+// PublishBatch implements Producer
+func (p memoryProducer) PublishBatch(ctx context.Context, msgs []Message) []error {
+	errs := make([]error, len(msgs))
+	for i, msg := range msgs {
+		if errs[i] = ctx.Err(); errs[i] == nil {
+			p.broker.append(msg)
+		}
+	}
+	return errs
+}
+
+// Close implements Producer
+func (memoryProducer) Close() error {
+	return nil
+}
+
+// memorySource reads a topic log for a consumer group
+type memorySource struct {
+	broker    *memoryBroker
+	topic     string
+	group     string
+	closeOnce sync.Once
+	closed    chan struct{}
+	joined    bool // whether subscribe counted the source as a member of the group
+}
+
+// subscribe implements source. A new group starts after the last message, like Kafka groups without
+// committed offset. The first member of a group resumes from the first message not acknowledged.
+func (s *memorySource) subscribe(context.Context) error {
+	s.broker.mu.Lock()
+	defer s.broker.mu.Unlock()
+
+	t := s.broker.topic(s.topic)
+	g, ok := t.groups[s.group]
+	if !ok {
+		g = &memoryGroup{start: len(t.log), next: len(t.log), acked: map[int]bool{}}
+		t.groups[s.group] = g
+	}
+	if g.members == 0 {
+		g.next = g.start
+		for g.next < len(t.log) && g.acked[g.next] {
+			g.next++
+		}
+	}
+	g.members++
+	s.joined = true
+
+	return nil
+}
+
+// fetch implements source
+func (s *memorySource) fetch(ctx context.Context) (delivery, error) {
+	for {
+		select {
+		case <-s.closed:
+			return delivery{}, io.EOF
+		default:
+		}
+
+		s.broker.mu.Lock()
+		t := s.broker.topic(s.topic)
+		g := t.groups[s.group]
+		if g.next < len(t.log) {
+			offset := g.next
+			g.next++
+			msg := t.log[offset]
+			s.broker.mu.Unlock()
+
+			return delivery{msg: msg, ack: func(context.Context) error { return s.ack(offset) }}, nil
+		}
+		appended := t.appended
+		s.broker.mu.Unlock()
+
+		select {
+		case <-appended:
+		case <-s.closed:
+			return delivery{}, io.EOF
+		case <-ctx.Done():
+			return delivery{}, ctx.Err()
+		}
+	}
+}
+
+// ack marks the message at offset as handled by the group
+func (s *memorySource) ack(offset int) error {
+	s.broker.mu.Lock()
+	defer s.broker.mu.Unlock()
+
+	s.broker.topic(s.topic).groups[s.group].acked[offset] = true
+	return nil
+}
+
+// Close implements source, leaving the group
+func (s *memorySource) Close() error {
+	s.closeOnce.Do(func() {
+		close(s.closed)
+
+		s.broker.mu.Lock()
+		defer s.broker.mu.Unlock()
+		if s.joined {
+			s.broker.topic(s.topic).groups[s.group].members--
+		}
+	})
+	return nil
+}
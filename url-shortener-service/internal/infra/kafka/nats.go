@@ -0,0 +1,338 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/monitoring"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	kafkago "github.com/segmentio/kafka-go"
+)
+
+const (
+	// natsHeaderKey carries the message key, NATS messages having none
+	natsHeaderKey = "Msg-Key"
+
+	// natsAckWait is how long a message stays unacknowledged before JetStream delivers it again to the group,
+	// the consumer being presumed dead. It exceeds the processing time of a message; consumers of retry topics
+	// add the delay their messages wait for.
+	natsAckWait = 5 * time.Minute
+)
+
+// natsNames replaces the characters a topic may contain but not a stream or consumer name
+var natsNames = strings.NewReplacer(".", "_", "*", "_", ">", "_", " ", "_", "/", "_", "\\", "_")
+
+// natsBroker publishes each topic to the subject of the same name, stored in a JetStream stream of its own
+// and read through durable consumers, one per consumer group
+type natsBroker struct {
+	cfg  Config
+	conn *nats.Conn
+	js   jetstream.JetStream
+
+	// streams holds the topics whose stream was created by this broker
+	streams sync.Map
+}
+
+// openNATSBroker connects to the NATS servers of the config
+func openNATSBroker(cfg Config) (Broker, error) {
+	conn, err := nats.Connect(cfg.NATSURL, nats.Name(cfg.ClientID))
+	if err != nil {
+		return nil, fmt.Errorf("nats: %w", err)
+	}
+
+	b, err := NewNATSBroker(cfg, conn)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return b, nil
+}
+
+// NewNATSBroker returns a broker on the JetStream of conn, which Close closes
+func NewNATSBroker(cfg Config, conn *nats.Conn) (Broker, error) {
+	js, err := jetstream.New(conn)
+	if err != nil {
+		return nil, fmt.Errorf("nats: %w", err)
+	}
+	return &natsBroker{cfg: cfg, conn: conn, js: js}, nil
+}
+
+// NewProducer implements Broker
+func (b *natsBroker) NewProducer() Producer {
+	return natsProducer{broker: b}
+}
+
+// NewConsumer implements Broker
+func (b *natsBroker) NewConsumer(topic, groupID string, handler MessageHandler, producer Producer) Consumer {
+	return newTieredConsumer(b.cfg, topic, groupID, func(topic string, hold time.Duration) source {
+		return &natsSource{
+			broker:  b,
+			topic:   topic,
+			group:   groupID,
+			count:   max(b.cfg.ChannelBuffer, 1),
+			block:   maxWait(b.cfg),
+			ackWait: natsAckWait + hold,
+			closed:  make(chan struct{}),
+		}
+	}, handler, producer)
+}
+
+// Scan implements Broker, getting the messages of the stream by sequence
+func (b *natsBroker) Scan(ctx context.Context, topic string, fn func(kafkago.Message) error) error {
+	stream, err := b.js.Stream(ctx, natsNames.Replace(topic))
+	if errors.Is(err, jetstream.ErrStreamNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("nats: stream of %s: %w", topic, err)
+	}
+
+	info, err := stream.Info(ctx)
+	if err != nil {
+		return fmt.Errorf("nats: stream of %s: %w", topic, err)
+	}
+	if info.State.Msgs == 0 {
+		return nil
+	}
+
+	for seq := info.State.FirstSeq; seq <= info.State.LastSeq; seq++ {
+		m, err := stream.GetMsg(ctx, seq)
+		if errors.Is(err, jetstream.ErrMsgNotFound) {
+			continue // deleted
+		}
+		if err != nil {
+			return fmt.Errorf("nats: get %d of %s: %w", seq, topic, err)
+		}
+
+		msg := fromNATS(m.Subject, m.Data, m.Header)
+		msg.Offset = int64(m.Sequence)
+		msg.Time = m.Time
+		if err = fn(msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close implements Broker
+func (b *natsBroker) Close() error {
+	b.conn.Close()
+	return nil
+}
+
+// ensureStream creates, or updates, the stream of a topic once per broker
+func (b *natsBroker) ensureStream(ctx context.Context, topic string) (string, error) {
+	name := natsNames.Replace(topic)
+	if _, ok := b.streams.Load(topic); ok {
+		return name, nil
+	}
+
+	_, err := b.js.CreateOrUpdateStream(ctx, jetstream.StreamConfig{
+		Name:     name,
+		Subjects: []string{topic},
+		Storage:  jetstream.FileStorage,
+	})
+	if err != nil {
+		return "", fmt.Errorf("nats: create stream of %s: %w", topic, err)
+	}
+	b.streams.Store(topic, true)
+
+	return name, nil
+}
+
+// natsProducer publishes to JetStream
+type natsProducer struct {
+	broker *natsBroker
+}
+
+// Publish implements Producer
+func (p natsProducer) Publish(ctx context.Context, msg Message) error {
+	var err error
+	ctx, span := StartPublishSpan(ctx, &msg)
+	defer monitoring.End(span, &err)
+
+	if _, err = p.broker.ensureStream(ctx, msg.Topic); err != nil {
+		return err
+	}
+
+	if _, err = p.broker.js.PublishMsg(ctx, toNATSMessage(msg)); err != nil {
+		monitoring.Log(ctx).Error().
+			Str("topic", msg.Topic).
+			Str("key", string(msg.Key)).
+			Err(err).
+			Msg("[natsProducer.Publish] nats publish failed")
+
+		err = fmt.Errorf("nats: failed to publish message: %w", err)
+		return err
+	}
+
+	return nil
+}
+
+// PublishBatch implements Producer, publishing asynchronously then waiting for every acknowledgement
+func (p natsProducer) PublishBatch(ctx context.Context, msgs []Message) []error {
+	errs := make([]error, len(msgs))
+	futures := make([]jetstream.PubAckFuture, len(msgs))
+
+	for i, msg := range msgs {
+		if _, errs[i] = p.broker.ensureStream(ctx, msg.Topic); errs[i] != nil {
+			continue
+		}
+		if futures[i], errs[i] = p.broker.js.PublishMsgAsync(toNATSMessage(msg)); errs[i] != nil {
+			errs[i] = fmt.Errorf("nats: failed to publish message: %w", errs[i])
+		}
+	}
+
+	for i, f := range futures {
+		if f == nil {
+			continue
+		}
+		select {
+		case <-f.Ok():
+		case err := <-f.Err():
+			errs[i] = fmt.Errorf("nats: failed to publish message: %w", err)
+		case <-ctx.Done():
+			errs[i] = fmt.Errorf("nats: failed to publish message: %w", ctx.Err())
+		}
+	}
+
+	return errs
+}
+
+// Close implements Producer, the connection belongs to the broker
+func (natsProducer) Close() error {
+	return nil
+}
+
+// toNATSMessage converts a message to its NATS form, the key carried as a header
+func toNATSMessage(msg Message) *nats.Msg {
+	rs := &nats.Msg{Subject: msg.Topic, Data: msg.Value, Header: nats.Header{}}
+	if len(msg.Key) > 0 {
+		rs.Header.Set(natsHeaderKey, string(msg.Key))
+	}
+	for _, h := range msg.Headers {
+		rs.Header.Add(h.Key, string(h.Value))
+	}
+
+	return rs
+}
+
+// natsSource reads a topic through the durable consumer of a group
+type natsSource struct {
+	broker *natsBroker
+	topic  string
+	group  string
+	count  int
+	block  time.Duration
+	// ackWait is how long a fetched message stays unacknowledged before it is delivered again
+	ackWait  time.Duration
+	consumer jetstream.Consumer
+	buf      []jetstream.Msg
+
+	closeOnce sync.Once
+	closed    chan struct{}
+}
+
+// subscribe implements source. A new group starts after the last message, like Kafka groups without
+// committed offset.
+func (s *natsSource) subscribe(ctx context.Context) error {
+	stream, err := s.broker.ensureStream(ctx, s.topic)
+	if err != nil {
+		return err
+	}
+
+	s.consumer, err = s.broker.js.CreateOrUpdateConsumer(ctx, stream, jetstream.ConsumerConfig{
+		Durable:       natsNames.Replace(s.group),
+		FilterSubject: s.topic,
+		DeliverPolicy: jetstream.DeliverNewPolicy,
+		AckPolicy:     jetstream.AckExplicitPolicy,
+		AckWait:       s.ackWait,
+		MaxDeliver:    -1,
+	})
+	if err != nil {
+		return fmt.Errorf("nats: create consumer %s of %s: %w", s.group, s.topic, err)
+	}
+
+	return nil
+}
+
+// fetch implements source
+func (s *natsSource) fetch(ctx context.Context) (delivery, error) {
+	for len(s.buf) == 0 {
+		select {
+		case <-s.closed:
+			return delivery{}, io.EOF
+		case <-ctx.Done():
+			return delivery{}, ctx.Err()
+		default:
+		}
+
+		batch, err := s.consumer.Fetch(s.count, jetstream.FetchMaxWait(s.block))
+		if err != nil {
+			return delivery{}, fmt.Errorf("nats: fetch %s: %w", s.topic, err)
+		}
+		for m := range batch.Messages() {
+			s.buf = append(s.buf, m)
+		}
+		if err = batch.Error(); err != nil && !errors.Is(err, nats.ErrTimeout) && len(s.buf) == 0 {
+			return delivery{}, fmt.Errorf("nats: fetch %s: %w", s.topic, err)
+		}
+	}
+
+	m := s.buf[0]
+	s.buf = s.buf[1:]
+
+	return delivery{
+		msg: toKafkaMessageFromNATS(m),
+		ack: func(ctx context.Context) error {
+			return m.DoubleAck(ctx)
+		},
+	}, nil
+}
+
+// Close implements source. Messages fetched but not acknowledged are delivered again after ackWait.
+func (s *natsSource) Close() error {
+	s.closeOnce.Do(func() { close(s.closed) })
+	return nil
+}
+
+// toKafkaMessageFromNATS converts a JetStream message to its kafka-go form, the offset being the stream sequence
+func toKafkaMessageFromNATS(m jetstream.Msg) kafkago.Message {
+	msg := fromNATS(m.Subject(), m.Data(), m.Headers())
+	if md, err := m.Metadata(); err == nil {
+		msg.Offset = int64(md.Sequence.Stream)
+		msg.Time = md.Timestamp
+	}
+	return msg
+}
+
+// fromNATS converts the content of a NATS message to its kafka-go form. NATS headers are unordered, they are
+// sorted by name.
+func fromNATS(subject string, data []byte, headers nats.Header) kafkago.Message {
+	msg := kafkago.Message{Topic: subject, Value: data}
+	if k := headers.Get(natsHeaderKey); k != "" {
+		msg.Key = []byte(k)
+	}
+
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		if name != natsHeaderKey {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, v := range headers[name] {
+			msg.Headers = append(msg.Headers, kafkago.Header{Key: name, Value: []byte(v)})
+		}
+	}
+
+	return msg
+}
@@ -2,6 +2,7 @@ package kafka
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -9,10 +10,37 @@ import (
 	kafkago "github.com/segmentio/kafka-go"
 )
 
-// Producer defines the minimal interface required to publish messages to Kafka.
+const (
+	// HeaderEventID carries the outbox event ID, for deduplication without decoding the payload
+	HeaderEventID = "event_id"
+	// HeaderCorrelationID carries the correlation ID of the request which caused the message
+	HeaderCorrelationID = "correlation_id"
+)
+
+// Message is a record to publish
+type Message struct {
+	Topic string
+	// Key routes the message: messages sharing a key land on the same partition and are consumed in publish order.
+	// Messages without a key are spread over partitions.
+	Key     []byte
+	Value   []byte
+	Headers []Header
+}
+
+// Header is a key/value pair attached to a message
+type Header struct {
+	Key   string
+	Value []byte
+}
+
+// Producer defines the minimal interface required to publish messages to the broker.
 type Producer interface {
-	// Publish sends a message with the given payload to the specified topic.
-	Publish(ctx context.Context, topic string, payload []byte) error
+	// Publish sends a message to its topic.
+	Publish(ctx context.Context, msg Message) error
+	// PublishBatch sends messages in as few broker round-trips as possible. It returns the outcome of each message,
+	// by position: nil for the published ones. Messages are written as they are, callers start the PRODUCER span
+	// of each one with StartPublishSpan.
+	PublishBatch(ctx context.Context, msgs []Message) []error
 	// Close releases any resources held by the producer.
 	Close() error
 }
@@ -30,8 +58,9 @@ func NewProducer(cfg Config) Producer {
 			// Target Kafka brokers
 			Addr: kafkago.TCP(cfg.Brokers...),
 
-			// Distribute load based on the broker with the least accumulated bytes.
-			Balancer: &kafkago.LeastBytes{},
+			// Route messages by key hash so that the messages of a key keep their order.
+			// Messages without key are distributed round-robin.
+			Balancer: &kafkago.Hash{},
 			// Wait for all in-sync replicas to acknowledge the write.
 			RequiredAcks: kafkago.RequireAll,
 			// Use synchronous writes to keep error handling simple and predictable.
@@ -68,41 +97,114 @@ func NewProducer(cfg Config) Producer {
 	}
 }
 
-// Publish sends a single message to the specified topic using the underlying Kafka writer.
+// Publish sends a single message to its topic using the underlying Kafka writer.
 // It relies on kafka-go's internal batching and retry mechanisms.
-func (p *writerProducer) Publish(ctx context.Context, topic string, payload []byte) error {
+func (p *writerProducer) Publish(ctx context.Context, msg Message) error {
+	var err error
+	ctx, span := StartPublishSpan(ctx, &msg)
+	defer monitoring.End(span, &err)
+
 	start := time.Now()
 	log := monitoring.Log(ctx)
 
-	msg := kafkago.Message{
-		Topic: topic,
-		Value: payload,
-		Time:  time.Now(),
-	}
-
-	err := p.writer.WriteMessages(ctx, msg)
+	km := toKafkaMessage(msg)
+	err = p.writer.WriteMessages(ctx, km)
 	latency := time.Since(start)
 
 	if err != nil {
 		log.Error().
-			Str("topic", topic).
+			Str("topic", msg.Topic).
+			Str("key", string(msg.Key)).
 			Dur("latency", latency).
-			Int("msg_size", len(payload)).
+			Int("msg_size", len(msg.Value)).
 			Err(err).
 			Msg("[writerProducer.Publish] kafka publish failed")
 
-		return fmt.Errorf("kafka: failed to publish message: %w", err)
+		err = fmt.Errorf("kafka: failed to publish message: %w", err)
+		return err
 	}
 
 	log.Info().
-		Str("topic", topic).
+		Str("topic", msg.Topic).
+		Str("key", string(msg.Key)).
 		Dur("latency", latency).
-		Int("msg_size", len(payload)).
+		Int("msg_size", len(msg.Value)).
 		Msg("[writerProducer.Publish] kafka publish success")
 
 	return nil
 }
 
+// PublishBatch sends the messages with a single write, which kafka-go splits by partition and batches.
+// A failed write of the whole batch, e.g. on a canceled context, fails every message.
+func (p *writerProducer) PublishBatch(ctx context.Context, msgs []Message) []error {
+	if len(msgs) == 0 {
+		return make([]error, 0)
+	}
+
+	start := time.Now()
+	log := monitoring.Log(ctx).Field("batch_size", len(msgs))
+
+	kms := make([]kafkago.Message, len(msgs))
+	for i, msg := range msgs {
+		kms[i] = toKafkaMessage(msg)
+	}
+
+	errs := batchErrors(len(msgs), p.writer.WriteMessages(ctx, kms...))
+
+	var failed int
+	for _, e := range errs {
+		if e != nil {
+			failed++
+		}
+	}
+
+	log.Info().
+		Int("published", len(msgs)-failed).
+		Int("failed", failed).
+		Dur("latency", time.Since(start)).
+		Msg("[writerProducer.PublishBatch] kafka batch published")
+
+	return errs
+}
+
+// batchErrors maps the error of writing n messages at once to the outcome of each message, by position
+func batchErrors(n int, err error) []error {
+	errs := make([]error, n)
+
+	var writeErrs kafkago.WriteErrors
+	switch {
+	case err == nil:
+	case errors.As(err, &writeErrs) && len(writeErrs) == n:
+		// Partial failure, the errors are reported by position
+		for i, werr := range writeErrs {
+			if werr != nil {
+				errs[i] = fmt.Errorf("kafka: failed to publish message: %w", werr)
+			}
+		}
+	default:
+		for i := range errs {
+			errs[i] = fmt.Errorf("kafka: failed to publish message: %w", err)
+		}
+	}
+
+	return errs
+}
+
+// toKafkaMessage converts a Message to its kafka-go form
+func toKafkaMessage(msg Message) kafkago.Message {
+	rs := kafkago.Message{
+		Topic: msg.Topic,
+		Key:   msg.Key,
+		Value: msg.Value,
+		Time:  time.Now(),
+	}
+	for _, h := range msg.Headers {
+		rs.Headers = append(rs.Headers, kafkago.Header{Key: h.Key, Value: h.Value})
+	}
+
+	return rs
+}
+
 // Close closes the underlying Kafka writer and frees associated resources.
 func (p *writerProducer) Close() error {
 	return p.writer.Close()
@@ -0,0 +1,52 @@
+package kafka
+
+import (
+	"context"
+	"testing"
+
+	kafkago "github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchErrors(t *testing.T) {
+	tcs := map[string]struct {
+		n        int
+		err      error
+		wantErrs []error
+	}{
+		"success - every message published": {
+			n:        2,
+			wantErrs: []error{nil, nil},
+		},
+		"error - partial failure reported by position": {
+			n:        3,
+			err:      kafkago.WriteErrors{nil, kafkago.LeaderNotAvailable, nil},
+			wantErrs: []error{nil, kafkago.LeaderNotAvailable, nil},
+		},
+		"error - whole write failed": {
+			n:        2,
+			err:      context.Canceled,
+			wantErrs: []error{context.Canceled, context.Canceled},
+		},
+		"error - write errors not matching the batch fail every message": {
+			n:        2,
+			err:      kafkago.WriteErrors{kafkago.LeaderNotAvailable},
+			wantErrs: []error{kafkago.LeaderNotAvailable, kafkago.LeaderNotAvailable},
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			errs := batchErrors(tc.n, tc.err)
+
+			require.Len(t, errs, len(tc.wantErrs))
+			for i, want := range tc.wantErrs {
+				if want == nil {
+					require.NoError(t, errs[i], "message %d", i)
+					continue
+				}
+				require.ErrorContains(t, errs[i], want.Error(), "message %d", i)
+			}
+		})
+	}
+}
@@ -0,0 +1,345 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/monitoring"
+	"github.com/redis/go-redis/extra/redisotel/v9"
+	"github.com/redis/go-redis/v9"
+	kafkago "github.com/segmentio/kafka-go"
+)
+
+const (
+	// redisFieldKey, redisFieldValue and redisFieldHeaders are the fields of a stream entry
+	redisFieldKey     = "key"
+	redisFieldValue   = "value"
+	redisFieldHeaders = "headers"
+
+	// redisClaimIdle is how long an entry stays pending with a consumer before another consumer of the group
+	// takes it over, the consumer being presumed dead. It exceeds the processing time of a message; consumers of
+	// retry topics add the delay their entries wait for.
+	redisClaimIdle = 5 * time.Minute
+
+	// redisScanPage is the number of entries read at a time by Scan
+	redisScanPage = 100
+)
+
+// redisBroker publishes each topic to the Redis stream of the same name, read through consumer groups
+type redisBroker struct {
+	cfg    Config
+	client *redis.Client
+}
+
+// openRedisBroker connects to the Redis of the config
+func openRedisBroker(cfg Config) (Broker, error) {
+	client := redis.NewClient(&redis.Options{Addr: cfg.RedisAddr})
+
+	if err := redisotel.InstrumentTracing(client); err != nil {
+		return nil, err
+	}
+	if err := client.Ping(context.Background()).Err(); err != nil {
+		return nil, fmt.Errorf("redis: %w", err)
+	}
+
+	return NewRedisBroker(cfg, client), nil
+}
+
+// NewRedisBroker returns a broker on Redis Streams using client, which Close closes
+func NewRedisBroker(cfg Config, client *redis.Client) Broker {
+	return redisBroker{cfg: cfg, client: client}
+}
+
+// NewProducer implements Broker
+func (b redisBroker) NewProducer() Producer {
+	return redisProducer{client: b.client, maxLen: b.cfg.RedisMaxLen}
+}
+
+// NewConsumer implements Broker
+func (b redisBroker) NewConsumer(topic, groupID string, handler MessageHandler, producer Producer) Consumer {
+	// Stable across restarts, for a run to read again the entries left pending by the previous one
+	hostname, _ := os.Hostname()
+	name := strings.Trim(b.cfg.ClientID+"-"+hostname, "-")
+
+	return newTieredConsumer(b.cfg, topic, groupID, func(topic string, hold time.Duration) source {
+		return &redisSource{
+			client:      b.client,
+			stream:      topic,
+			group:       groupID,
+			consumer:    name,
+			count:       int64(max(b.cfg.ChannelBuffer, 1)),
+			block:       maxWait(b.cfg),
+			claimIdle:   redisClaimIdle + hold,
+			pendingFrom: "0",
+			closed:      make(chan struct{}),
+		}
+	}, handler, producer)
+}
+
+// Scan implements Broker, reading the stream with XRANGE a page at a time
+func (b redisBroker) Scan(ctx context.Context, topic string, fn func(kafkago.Message) error) error {
+	last, err := b.client.XRevRangeN(ctx, topic, "+", "-", 1).Result()
+	if err != nil {
+		return fmt.Errorf("redis: read %s: %w", topic, err)
+	}
+	if len(last) == 0 {
+		return nil
+	}
+
+	s := redisSource{stream: topic}
+	for from := "-"; ; {
+		entries, err := b.client.XRangeN(ctx, topic, from, last[0].ID, redisScanPage).Result()
+		if err != nil {
+			return fmt.Errorf("redis: read %s: %w", topic, err)
+		}
+		for _, entry := range entries {
+			msg, err := s.toKafkaMessage(entry)
+			if err != nil {
+				return err
+			}
+			if err = fn(msg); err != nil {
+				return err
+			}
+		}
+		if len(entries) < redisScanPage {
+			return nil
+		}
+		from = "(" + entries[len(entries)-1].ID
+	}
+}
+
+// Close implements Broker
+func (b redisBroker) Close() error {
+	return b.client.Close()
+}
+
+// redisProducer appends messages to streams with XADD
+type redisProducer struct {
+	client *redis.Client
+	maxLen int64
+}
+
+// Publish implements Producer
+func (p redisProducer) Publish(ctx context.Context, msg Message) error {
+	var err error
+	ctx, span := StartPublishSpan(ctx, &msg)
+	defer monitoring.End(span, &err)
+
+	args, err := p.addArgs(msg)
+	if err != nil {
+		return err
+	}
+
+	if err = p.client.XAdd(ctx, args).Err(); err != nil {
+		monitoring.Log(ctx).Error().
+			Str("topic", msg.Topic).
+			Str("key", string(msg.Key)).
+			Err(err).
+			Msg("[redisProducer.Publish] redis publish failed")
+
+		err = fmt.Errorf("redis: failed to publish message: %w", err)
+		return err
+	}
+
+	return nil
+}
+
+// PublishBatch implements Producer, sending the messages in a single pipeline
+func (p redisProducer) PublishBatch(ctx context.Context, msgs []Message) []error {
+	errs := make([]error, len(msgs))
+	if len(msgs) == 0 {
+		return errs
+	}
+
+	cmds := make([]*redis.StringCmd, len(msgs))
+	_, _ = p.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, msg := range msgs {
+			args, err := p.addArgs(msg)
+			if err != nil {
+				errs[i] = err
+				continue
+			}
+			cmds[i] = pipe.XAdd(ctx, args)
+		}
+		return nil
+	})
+
+	for i, cmd := range cmds {
+		if cmd != nil && cmd.Err() != nil {
+			errs[i] = fmt.Errorf("redis: failed to publish message: %w", cmd.Err())
+		}
+	}
+
+	return errs
+}
+
+// addArgs converts a message to a stream entry, the headers encoded as a JSON list to keep their order
+func (p redisProducer) addArgs(msg Message) (*redis.XAddArgs, error) {
+	headers, err := json.Marshal(msg.Headers)
+	if err != nil {
+		return nil, err
+	}
+
+	return &redis.XAddArgs{
+		Stream: msg.Topic,
+		MaxLen: p.maxLen,
+		Approx: p.maxLen > 0,
+		Values: []any{redisFieldKey, msg.Key, redisFieldValue, msg.Value, redisFieldHeaders, headers},
+	}, nil
+}
+
+// Close implements Producer, the client belongs to the broker
+func (redisProducer) Close() error {
+	return nil
+}
+
+// redisSource reads a stream through a consumer group
+type redisSource struct {
+	client   *redis.Client
+	stream   string
+	group    string
+	consumer string
+	count    int64
+	block    time.Duration
+	// claimIdle is how long an entry stays pending with a consumer before another one takes it over
+	claimIdle time.Duration
+
+	// pendingFrom is the ID after which the entries left pending with this consumer by a previous run are read
+	// again, empty once they all are
+	pendingFrom string
+	buf         []redis.XMessage
+
+	closeOnce sync.Once
+	closed    chan struct{}
+}
+
+// subscribe implements source. A new group starts after the last entry, like Kafka groups without
+// committed offset.
+func (s *redisSource) subscribe(ctx context.Context) error {
+	err := s.client.XGroupCreateMkStream(ctx, s.stream, s.group, "$").Err()
+	if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		return fmt.Errorf("redis: create group %s of %s: %w", s.group, s.stream, err)
+	}
+	return nil
+}
+
+// fetch implements source. Besides new entries, it reads again the entries this consumer fetched without
+// acknowledging them, then takes over those of consumers idle for claimIdle.
+func (s *redisSource) fetch(ctx context.Context) (delivery, error) {
+	for len(s.buf) == 0 {
+		select {
+		case <-s.closed:
+			return delivery{}, io.EOF
+		case <-ctx.Done():
+			return delivery{}, ctx.Err()
+		default:
+		}
+
+		var err error
+		if s.pendingFrom != "" {
+			if s.buf, err = s.read(ctx, s.pendingFrom, -1); err == nil {
+				s.pendingFrom = ""
+				if len(s.buf) > 0 {
+					s.pendingFrom = s.buf[len(s.buf)-1].ID
+				}
+			}
+		} else if s.buf, err = s.read(ctx, ">", s.block); err == nil && len(s.buf) == 0 {
+			s.buf, _, err = s.client.XAutoClaim(ctx, &redis.XAutoClaimArgs{
+				Stream:   s.stream,
+				Group:    s.group,
+				Consumer: s.consumer,
+				MinIdle:  s.claimIdle,
+				Start:    "0-0",
+				Count:    s.count,
+			}).Result()
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return delivery{}, ctx.Err()
+			}
+			return delivery{}, fmt.Errorf("redis: read %s: %w", s.stream, err)
+		}
+	}
+
+	entry := s.buf[0]
+	s.buf = s.buf[1:]
+
+	msg, err := s.toKafkaMessage(entry)
+	if err != nil {
+		// Undecodable entries would stay pending forever, they are acknowledged and dropped
+		monitoring.Log(ctx).Error().Err(err).Str("id", entry.ID).Msg("[redisSource] invalid entry dropped")
+		_ = s.client.XAck(ctx, s.stream, s.group, entry.ID).Err()
+		return s.fetch(ctx)
+	}
+
+	return delivery{
+		msg: msg,
+		ack: func(ctx context.Context) error {
+			return s.client.XAck(ctx, s.stream, s.group, entry.ID).Err()
+		},
+	}, nil
+}
+
+// read reads the entries of the group from id, ">" for new ones, blocking up to block when positive
+func (s *redisSource) read(ctx context.Context, id string, block time.Duration) ([]redis.XMessage, error) {
+	streams, err := s.client.XReadGroup(ctx, &redis.XReadGroupArgs{
+		Group:    s.group,
+		Consumer: s.consumer,
+		Streams:  []string{s.stream, id},
+		Count:    s.count,
+		Block:    block,
+	}).Result()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil || len(streams) == 0 {
+		return nil, err
+	}
+
+	return streams[0].Messages, nil
+}
+
+// toKafkaMessage converts a stream entry to its kafka-go form, the entry time taken from its ID
+func (s *redisSource) toKafkaMessage(entry redis.XMessage) (kafkago.Message, error) {
+	msg := kafkago.Message{Topic: s.stream}
+
+	ms, _, _ := strings.Cut(entry.ID, "-")
+	if n, err := strconv.ParseInt(ms, 10, 64); err == nil {
+		msg.Time = time.UnixMilli(n)
+	}
+
+	if v, ok := entry.Values[redisFieldKey].(string); ok && v != "" {
+		msg.Key = []byte(v)
+	}
+	v, ok := entry.Values[redisFieldValue].(string)
+	if !ok {
+		return kafkago.Message{}, fmt.Errorf("redis: entry %s of %s has no value", entry.ID, s.stream)
+	}
+	msg.Value = []byte(v)
+
+	if raw, ok := entry.Values[redisFieldHeaders].(string); ok && raw != "" {
+		var headers []Header
+		if err := json.Unmarshal([]byte(raw), &headers); err != nil {
+			return kafkago.Message{}, fmt.Errorf("redis: entry %s of %s: headers: %w", entry.ID, s.stream, err)
+		}
+		for _, h := range headers {
+			msg.Headers = append(msg.Headers, kafkago.Header{Key: h.Key, Value: h.Value})
+		}
+	}
+
+	return msg, nil
+}
+
+// Close implements source. Entries fetched but not acknowledged stay pending, read again by the next run
+// of this consumer or taken over by another one.
+func (s *redisSource) Close() error {
+	s.closeOnce.Do(func() { close(s.closed) })
+	return nil
+}
@@ -0,0 +1,93 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	kafkago "github.com/segmentio/kafka-go"
+)
+
+const (
+	// HeaderRetryCount carries the number of failed attempts of a message republished to a retry topic
+	HeaderRetryCount = "retry_count"
+	// HeaderRetryNotBefore carries the time, in Unix milliseconds, before which a retry must not be processed
+	HeaderRetryNotBefore = "retry_not_before"
+	// HeaderRetryError carries the error of the last failed attempt
+	HeaderRetryError = "retry_error"
+)
+
+// DefaultRetryTiers are the delays of the retry topics a message goes through before the DLQ
+var DefaultRetryTiers = []time.Duration{30 * time.Second, 5 * time.Minute, time.Hour}
+
+// RetryTopic names the retry topic of topic for a delay, e.g. <topic>.retry.30s or <topic>.retry.5m
+func RetryTopic(topic string, delay time.Duration) string {
+	var suffix string
+	switch {
+	case delay%time.Hour == 0:
+		suffix = strconv.FormatInt(int64(delay/time.Hour), 10) + "h"
+	case delay%time.Minute == 0:
+		suffix = strconv.FormatInt(int64(delay/time.Minute), 10) + "m"
+	case delay%time.Second == 0:
+		suffix = strconv.FormatInt(int64(delay/time.Second), 10) + "s"
+	default:
+		suffix = strconv.FormatInt(delay.Milliseconds(), 10) + "ms"
+	}
+	return topic + ".retry." + suffix
+}
+
+// DLQTopic names the dead letter topic of topic, where messages land once their retries are exhausted
+func DLQTopic(topic string) string {
+	return topic + ".dlq"
+}
+
+// retryMessage builds the message republishing msg to the retry topic of the attempt, a 1-based index in tiers.
+// The headers of msg are kept, the retry headers replaced.
+func retryMessage(msg kafkago.Message, baseTopic string, tiers []time.Duration, attempt int, cause error) Message {
+	delay := tiers[attempt-1]
+
+	rs := Message{Topic: RetryTopic(baseTopic, delay), Key: msg.Key, Value: msg.Value}
+	for _, h := range msg.Headers {
+		rs.Headers = append(rs.Headers, Header{Key: h.Key, Value: h.Value})
+	}
+
+	c := headersCarrier{headers: &rs.Headers}
+	c.Set(HeaderRetryCount, strconv.Itoa(attempt))
+	c.Set(HeaderRetryNotBefore, strconv.FormatInt(time.Now().Add(delay).UnixMilli(), 10))
+	c.Set(HeaderRetryError, cause.Error())
+
+	return rs
+}
+
+// retryCount returns the number of failed attempts of a message, 0 for messages never retried
+func retryCount(msg kafkago.Message) int {
+	n, _ := strconv.Atoi(NewMessageCarrier(&msg).Get(HeaderRetryCount))
+	return n
+}
+
+// waitRetryDelay blocks until a retry may be processed. Retries of a tier share its delay, so waiting for the
+// first one keeps the others, published later, waiting too.
+func waitRetryDelay(ctx context.Context, done <-chan struct{}, msg kafkago.Message) error {
+	ms, err := strconv.ParseInt(NewMessageCarrier(&msg).Get(HeaderRetryNotBefore), 10, 64)
+	if err != nil {
+		return nil
+	}
+
+	wait := time.Until(time.UnixMilli(ms))
+	if wait <= 0 {
+		return nil
+	}
+
+	t := time.NewTimer(wait)
+	defer t.Stop()
+
+	select {
+	case <-t.C:
+		return nil
+	case <-done:
+		return fmt.Errorf("consumer closed while waiting %s for a retry", wait)
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,100 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	kafkago "github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryTopic(t *testing.T) {
+	tcs := map[string]struct {
+		delay time.Duration
+		want  string
+	}{
+		"seconds":      {delay: 30 * time.Second, want: "urlshortener.metadata.requested.v1.retry.30s"},
+		"minutes":      {delay: 5 * time.Minute, want: "urlshortener.metadata.requested.v1.retry.5m"},
+		"hours":        {delay: time.Hour, want: "urlshortener.metadata.requested.v1.retry.1h"},
+		"milliseconds": {delay: 1500 * time.Millisecond, want: "urlshortener.metadata.requested.v1.retry.1500ms"},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.want, RetryTopic("urlshortener.metadata.requested.v1", tc.delay))
+		})
+	}
+}
+
+func TestRetryMessage(t *testing.T) {
+	// Given
+	tiers := []time.Duration{30 * time.Second, 5 * time.Minute}
+	msg := kafkago.Message{
+		Topic: "links.retry.30s",
+		Key:   []byte("abc123"),
+		Value: []byte(`{"short_code":"abc123"}`),
+		Headers: []kafkago.Header{
+			{Key: HeaderEventID, Value: []byte("42")},
+			{Key: HeaderRetryCount, Value: []byte("1")},
+			{Key: HeaderRetryError, Value: []byte("timeout")},
+		},
+	}
+
+	// When
+	before := time.Now()
+	rs := retryMessage(msg, "links", tiers, 2, errors.New("connection refused"))
+
+	// Then
+	require.Equal(t, "links.retry.5m", rs.Topic)
+	require.Equal(t, msg.Key, rs.Key)
+	require.Equal(t, msg.Value, rs.Value)
+
+	km := toKafkaMessage(rs)
+	c := NewMessageCarrier(&km)
+	require.Equal(t, "42", c.Get(HeaderEventID))
+	require.Equal(t, 2, retryCount(km))
+	require.Equal(t, "connection refused", c.Get(HeaderRetryError))
+	require.Len(t, rs.Headers, 4)
+
+	notBefore, err := strconv.ParseInt(c.Get(HeaderRetryNotBefore), 10, 64)
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, notBefore, before.Add(5*time.Minute).UnixMilli())
+	require.LessOrEqual(t, notBefore, time.Now().Add(5*time.Minute).UnixMilli())
+}
+
+func TestWaitRetryDelay(t *testing.T) {
+	retryAt := func(at time.Time) kafkago.Message {
+		return kafkago.Message{Headers: []kafkago.Header{
+			{Key: HeaderRetryNotBefore, Value: []byte(strconv.FormatInt(at.UnixMilli(), 10))},
+		}}
+	}
+
+	t.Run("success - no retry header", func(t *testing.T) {
+		require.NoError(t, waitRetryDelay(context.Background(), nil, kafkago.Message{}))
+	})
+
+	t.Run("success - delay elapsed", func(t *testing.T) {
+		require.NoError(t, waitRetryDelay(context.Background(), nil, retryAt(time.Now().Add(-time.Second))))
+	})
+
+	t.Run("success - waits for the delay", func(t *testing.T) {
+		start := time.Now()
+		require.NoError(t, waitRetryDelay(context.Background(), nil, retryAt(start.Add(50*time.Millisecond))))
+		require.GreaterOrEqual(t, time.Since(start), 40*time.Millisecond)
+	})
+
+	t.Run("error - consumer closed while waiting", func(t *testing.T) {
+		done := make(chan struct{})
+		close(done)
+		require.Error(t, waitRetryDelay(context.Background(), done, retryAt(time.Now().Add(time.Hour))))
+	})
+
+	t.Run("error - context canceled while waiting", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		require.ErrorIs(t, waitRetryDelay(ctx, nil, retryAt(time.Now().Add(time.Hour))), context.Canceled)
+	})
+}
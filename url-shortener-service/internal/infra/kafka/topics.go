@@ -0,0 +1,57 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	kafkago "github.com/segmentio/kafka-go"
+)
+
+// ensureTopics creates those of topics missing from the cluster, with as many partitions as base so that keyed
+// messages are spread the same way, and the default replication factor of the brokers. Clusters which do not
+// auto-create topics would otherwise fail every publish to them. Topics created meanwhile, e.g. by another
+// replica of the consumer, are fine.
+func ensureTopics(ctx context.Context, brokers []string, base string, topics []string) error {
+	client := &kafkago.Client{Addr: kafkago.TCP(brokers...)}
+
+	meta, err := client.Metadata(ctx, &kafkago.MetadataRequest{Topics: append([]string{base}, topics...)})
+	if err != nil {
+		return fmt.Errorf("kafka: read metadata of %s: %w", base, err)
+	}
+
+	partitions := 1
+	existing := map[string]bool{}
+	for _, t := range meta.Topics {
+		if t.Error != nil {
+			continue
+		}
+		existing[t.Name] = true
+		if t.Name == base && len(t.Partitions) > 0 {
+			partitions = len(t.Partitions)
+		}
+	}
+
+	var missing []kafkago.TopicConfig
+	for _, topic := range topics {
+		if !existing[topic] {
+			missing = append(missing, kafkago.TopicConfig{Topic: topic, NumPartitions: partitions, ReplicationFactor: -1})
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	rs, err := client.CreateTopics(ctx, &kafkago.CreateTopicsRequest{Topics: missing})
+	if err != nil {
+		return fmt.Errorf("kafka: create topics of %s: %w", base, err)
+	}
+
+	var errs []error
+	for topic, err := range rs.Errors {
+		if err != nil && !errors.Is(err, kafkago.TopicAlreadyExists) {
+			errs = append(errs, fmt.Errorf("kafka: create topic %s: %w", topic, err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,46 @@
+package kafka
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	kafkago "github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnsureTopics(t *testing.T) {
+	if os.Getenv("KAFKA_BROKERS") == "" {
+		t.Skip("KAFKA_BROKERS not set")
+	}
+	brokers := strings.Split(os.Getenv("KAFKA_BROKERS"), ",")
+	ctx := context.Background()
+
+	// Given: a topic of 3 partitions, one of its outlets existing already
+	base := "ensure." + strconv.FormatInt(time.Now().UnixNano(), 10)
+	outlets := []string{DLQTopic(base), RetryTopic(base, 30*time.Second), RetryTopic(base, 5*time.Minute)}
+
+	conn, err := kafkago.Dial("tcp", brokers[0])
+	require.NoError(t, err)
+	defer conn.Close()
+	require.NoError(t, conn.CreateTopics(
+		kafkago.TopicConfig{Topic: base, NumPartitions: 3, ReplicationFactor: 1},
+		kafkago.TopicConfig{Topic: outlets[0], NumPartitions: 1, ReplicationFactor: 1},
+	))
+
+	// When: ensured twice, as by two replicas
+	require.NoError(t, ensureTopics(ctx, brokers, base, outlets))
+	require.NoError(t, ensureTopics(ctx, brokers, base, outlets))
+
+	// Then: the missing ones are created like base, the existing one left alone
+	partitions, err := conn.ReadPartitions(outlets...)
+	require.NoError(t, err)
+	counts := map[string]int{}
+	for _, p := range partitions {
+		counts[p.Topic]++
+	}
+	require.Equal(t, map[string]int{outlets[0]: 1, outlets[1]: 3, outlets[2]: 3}, counts)
+}
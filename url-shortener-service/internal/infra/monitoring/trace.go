@@ -9,8 +9,8 @@ import (
 
 // Start begins a new span using the global tracer.
 // Use defer End(span, &err) after calling it.
-func Start(ctx context.Context, name string) (context.Context, trace.Span) {
-	return globalTracer.Start(ctx, name)
+func Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return globalTracer.Start(ctx, name, opts...)
 }
 
 // End finishes a span and records any error if provided.
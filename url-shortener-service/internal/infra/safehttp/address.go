@@ -0,0 +1,77 @@
+package safehttp
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+)
+
+// ErrBlockedAddress means the destination resolved to an address of a non-public network
+var ErrBlockedAddress = errors.New("destination address is not allowed")
+
+// blockedPrefixes are the ranges which must never be reached with user-provided URLs
+var blockedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),         // "this" network
+	netip.MustParsePrefix("10.0.0.0/8"),        // private
+	netip.MustParsePrefix("100.64.0.0/10"),     // carrier-grade NAT
+	netip.MustParsePrefix("127.0.0.0/8"),       // loopback
+	netip.MustParsePrefix("169.254.0.0/16"),    // link-local, cloud metadata services (169.254.169.254)
+	netip.MustParsePrefix("172.16.0.0/12"),     // private
+	netip.MustParsePrefix("192.0.0.0/24"),      // IETF protocol assignments
+	netip.MustParsePrefix("192.0.2.0/24"),      // documentation
+	netip.MustParsePrefix("192.168.0.0/16"),    // private
+	netip.MustParsePrefix("198.18.0.0/15"),     // benchmarking
+	netip.MustParsePrefix("198.51.100.0/24"),   // documentation
+	netip.MustParsePrefix("203.0.113.0/24"),    // documentation
+	netip.MustParsePrefix("224.0.0.0/4"),       // multicast
+	netip.MustParsePrefix("240.0.0.0/4"),       // reserved, broadcast
+	netip.MustParsePrefix("::/128"),            // unspecified
+	netip.MustParsePrefix("::1/128"),           // loopback
+	netip.MustParsePrefix("64:ff9b::/96"),      // NAT64, embeds IPv4 addresses
+	netip.MustParsePrefix("100::/64"),          // discard
+	netip.MustParsePrefix("2001:db8::/32"),     // documentation
+	netip.MustParsePrefix("fc00::/7"),          // unique local
+	netip.MustParsePrefix("fe80::/10"),         // link-local
+	netip.MustParsePrefix("ff00::/8"),          // multicast
+	netip.MustParsePrefix("fd00:ec2::254/128"), // AWS metadata service over IPv6
+}
+
+// checkAddress rejects a resolved "ip:port" address unless it is public or explicitly allowed
+func checkAddress(address string, allowed []netip.Prefix) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	ip, err := netip.ParseAddr(host)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrBlockedAddress, host)
+	}
+	// IPv4-mapped IPv6 addresses (::ffff:127.0.0.1) are checked as the IPv4 address they carry
+	ip = ip.Unmap().WithZone("")
+
+	for _, p := range allowed {
+		if p.Contains(ip) {
+			return nil
+		}
+	}
+
+	if IsBlocked(ip) {
+		return fmt.Errorf("%w: %s", ErrBlockedAddress, ip)
+	}
+
+	return nil
+}
+
+// IsBlocked reports whether ip belongs to a private, loopback, link-local or otherwise non-public range.
+func IsBlocked(ip netip.Addr) bool {
+	ip = ip.Unmap()
+	for _, p := range blockedPrefixes {
+		if p.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
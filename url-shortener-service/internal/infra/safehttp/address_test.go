@@ -0,0 +1,78 @@
+package safehttp
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsBlocked(t *testing.T) {
+	tcs := map[string]struct {
+		ip   string
+		want bool
+	}{
+		"public ipv4":            {ip: "93.184.216.34"},
+		"public ipv6":            {ip: "2606:2800:220:1:248:1893:25c8:1946"},
+		"loopback":               {ip: "127.0.0.1", want: true},
+		"loopback range":         {ip: "127.10.0.1", want: true},
+		"private 10/8":           {ip: "10.1.2.3", want: true},
+		"private 172.16/12":      {ip: "172.20.0.5", want: true},
+		"private 192.168/16":     {ip: "192.168.1.1", want: true},
+		"cloud metadata":         {ip: "169.254.169.254", want: true},
+		"carrier-grade nat":      {ip: "100.64.0.1", want: true},
+		"unspecified":            {ip: "0.0.0.0", want: true},
+		"ipv6 loopback":          {ip: "::1", want: true},
+		"ipv6 unique local":      {ip: "fd12:3456::1", want: true},
+		"ipv6 link-local":        {ip: "fe80::1", want: true},
+		"ipv4-mapped loopback":   {ip: "::ffff:127.0.0.1", want: true},
+		"ipv4-mapped public":     {ip: "::ffff:93.184.216.34"},
+		"nat64 embedded private": {ip: "64:ff9b::a00:1", want: true},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.want, IsBlocked(netip.MustParseAddr(tc.ip)))
+		})
+	}
+}
+
+func TestCheckAddress(t *testing.T) {
+	tcs := map[string]struct {
+		address string
+		allowed []netip.Prefix
+		wantErr bool
+	}{
+		"public address": {
+			address: "93.184.216.34:443",
+		},
+		"blocked address": {
+			address: "127.0.0.1:80",
+			wantErr: true,
+		},
+		"blocked address with zone": {
+			address: "[fe80::1%eth0]:80",
+			wantErr: true,
+		},
+		"blocked address allowed by override": {
+			address: "127.0.0.1:80",
+			allowed: []netip.Prefix{netip.MustParsePrefix("127.0.0.0/8")},
+		},
+		"override does not allow other ranges": {
+			address: "10.0.0.1:80",
+			allowed: []netip.Prefix{netip.MustParsePrefix("127.0.0.0/8")},
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			err := checkAddress(tc.address, tc.allowed)
+			if tc.wantErr {
+				require.ErrorIs(t, err, ErrBlockedAddress)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package safehttp
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+)
+
+var (
+	// ErrTooManyRedirects means the destination redirected more than the allowed number of hops
+	ErrTooManyRedirects = errors.New("too many redirects")
+	// ErrUnsupportedScheme means a redirect pointed to a scheme other than http or https
+	ErrUnsupportedScheme = errors.New("unsupported scheme")
+	// ErrBodyTooLarge means the response body exceeded the allowed number of bytes
+	ErrBodyTooLarge = errors.New("response body too large")
+)
+
+const (
+	defaultTimeout        = 10 * time.Second
+	defaultDialTimeout    = 3 * time.Second
+	defaultMaxRedirects   = 5
+	defaultMaxBodyBytes   = 10 * 1024 * 1024
+	defaultMaxHeaderBytes = 64 * 1024
+)
+
+// options holds the limits of a client
+type options struct {
+	timeout        time.Duration
+	maxRedirects   int
+	maxBodyBytes   int64
+	allowed        []netip.Prefix
+	transportHooks []func(*http.Transport)
+}
+
+// Option enables tweaking the client
+type Option func(*options)
+
+// WithTimeout overrides the overall time limit of a request, redirects and body read included
+func WithTimeout(d time.Duration) Option {
+	return func(o *options) {
+		o.timeout = d
+	}
+}
+
+// WithMaxRedirects overrides the number of redirects a fetch stops at, like net/http stops at the 10th:
+// n-1 hops are followed, the nth fails with ErrTooManyRedirects
+func WithMaxRedirects(n int) Option {
+	return func(o *options) {
+		o.maxRedirects = n
+	}
+}
+
+// WithMaxBodyBytes overrides the number of response body bytes readable before ErrBodyTooLarge
+func WithMaxBodyBytes(n int64) Option {
+	return func(o *options) {
+		o.maxBodyBytes = n
+	}
+}
+
+// WithAllowedPrefixes lets the client connect to otherwise blocked ranges, e.g. loopback for test servers
+func WithAllowedPrefixes(prefixes ...netip.Prefix) Option {
+	return func(o *options) {
+		o.allowed = append(o.allowed, prefixes...)
+	}
+}
+
+// WithTransport tweaks the underlying transport once the address guard is in place, e.g. TLS settings in tests
+func WithTransport(fn func(*http.Transport)) Option {
+	return func(o *options) {
+		o.transportHooks = append(o.transportHooks, fn)
+	}
+}
+
+// NewClient returns an HTTP client for fetching untrusted URLs. It refuses to connect to private, loopback,
+// link-local and metadata addresses once DNS is resolved, so neither a hostname nor a redirect hop can
+// reach the internal network, and it bounds the time and bytes spent on a response.
+func NewClient(opts ...Option) *http.Client {
+	o := options{
+		timeout:      defaultTimeout,
+		maxRedirects: defaultMaxRedirects,
+		maxBodyBytes: defaultMaxBodyBytes,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	dialer := &net.Dialer{
+		Timeout: defaultDialTimeout,
+		// Control runs on the resolved address of every connection, redirects included,
+		// which also defeats DNS rebinding between a check and the actual connection
+		Control: func(network, address string, _ syscall.RawConn) error {
+			return checkAddress(address, o.allowed)
+		},
+	}
+
+	transport := &http.Transport{
+		Proxy:                  nil, // a proxy would connect on our behalf, bypassing the guard
+		DialContext:            dialer.DialContext,
+		ForceAttemptHTTP2:      true,
+		MaxIdleConns:           100,
+		IdleConnTimeout:        90 * time.Second,
+		TLSHandshakeTimeout:    defaultDialTimeout,
+		ResponseHeaderTimeout:  o.timeout,
+		ExpectContinueTimeout:  time.Second,
+		MaxResponseHeaderBytes: defaultMaxHeaderBytes,
+	}
+	for _, hook := range o.transportHooks {
+		hook(transport)
+	}
+
+	return &http.Client{
+		Timeout:   o.timeout,
+		Transport: limitedTransport{next: transport, maxBodyBytes: o.maxBodyBytes},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= o.maxRedirects {
+				return ErrTooManyRedirects
+			}
+			if req.URL.Scheme != "http" && req.URL.Scheme != "https" {
+				return fmt.Errorf("%w: %s", ErrUnsupportedScheme, req.URL.Scheme)
+			}
+			return nil
+		},
+	}
+}
+
+// limitedTransport caps the size of response bodies
+type limitedTransport struct {
+	next         http.RoundTripper
+	maxBodyBytes int64
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (t limitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Large documents stay allowed as long as the caller only reads their beginning
+	resp.Body = &limitedBody{ReadCloser: resp.Body, remaining: t.maxBodyBytes}
+
+	return resp, nil
+}
+
+// limitedBody fails reads past the byte limit instead of silently truncating the body
+type limitedBody struct {
+	io.ReadCloser
+	remaining int64
+}
+
+// Read implements io.Reader
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.remaining < 0 {
+		return 0, ErrBodyTooLarge
+	}
+	// Read one byte past the limit to tell an exact fit from an overflow
+	if int64(len(p)) > b.remaining+1 {
+		p = p[:b.remaining+1]
+	}
+
+	n, err := b.ReadCloser.Read(p)
+	b.remaining -= int64(n)
+	if b.remaining < 0 {
+		return n + int(b.remaining), ErrBodyTooLarge
+	}
+
+	return n, err
+}
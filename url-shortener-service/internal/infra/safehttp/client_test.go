@@ -0,0 +1,129 @@
+package safehttp
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var loopback = netip.MustParsePrefix("127.0.0.0/8")
+
+func TestClient(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if hops, ok := strings.CutPrefix(r.URL.Path, "/hops/"); ok {
+			n, _ := strconv.Atoi(hops)
+			if n == 0 {
+				_, _ = w.Write([]byte("hello"))
+				return
+			}
+			http.Redirect(w, r, "/hops/"+strconv.Itoa(n-1), http.StatusFound)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/ok":
+			_, _ = w.Write([]byte("hello"))
+		case "/large":
+			_, _ = w.Write([]byte(strings.Repeat("a", 64)))
+		case "/to-metadata":
+			http.Redirect(w, r, "http://169.254.169.254/latest/meta-data/", http.StatusFound)
+		case "/to-file":
+			http.Redirect(w, r, "file:///etc/passwd", http.StatusFound)
+		case "/loop":
+			http.Redirect(w, r, "/loop", http.StatusFound)
+		}
+	}))
+	defer srv.Close()
+
+	tcs := map[string]struct {
+		path     string
+		opts     []Option
+		wantBody string
+		wantErr  error
+	}{
+		"success - allowed by override": {
+			path:     "/ok",
+			opts:     []Option{WithAllowedPrefixes(loopback)},
+			wantBody: "hello",
+		},
+		"success - body within limit": {
+			path:     "/large",
+			opts:     []Option{WithAllowedPrefixes(loopback), WithMaxBodyBytes(64)},
+			wantBody: strings.Repeat("a", 64),
+		},
+		"success - redirects below the limit": {
+			path:     "/hops/4",
+			opts:     []Option{WithAllowedPrefixes(loopback)},
+			wantBody: "hello",
+		},
+		"fail - redirects at the limit": {
+			path:    "/hops/5",
+			opts:    []Option{WithAllowedPrefixes(loopback)},
+			wantErr: ErrTooManyRedirects,
+		},
+		"success - redirects below the overridden limit": {
+			path:     "/hops/2",
+			opts:     []Option{WithAllowedPrefixes(loopback), WithMaxRedirects(3)},
+			wantBody: "hello",
+		},
+		"fail - redirects at the overridden limit": {
+			path:    "/hops/3",
+			opts:    []Option{WithAllowedPrefixes(loopback), WithMaxRedirects(3)},
+			wantErr: ErrTooManyRedirects,
+		},
+		"fail - loopback blocked by default": {
+			path:    "/ok",
+			wantErr: ErrBlockedAddress,
+		},
+		"fail - redirect to metadata service": {
+			path:    "/to-metadata",
+			opts:    []Option{WithAllowedPrefixes(loopback)},
+			wantErr: ErrBlockedAddress,
+		},
+		"fail - redirect to unsupported scheme": {
+			path:    "/to-file",
+			opts:    []Option{WithAllowedPrefixes(loopback)},
+			wantErr: ErrUnsupportedScheme,
+		},
+		"fail - redirect loop": {
+			path:    "/loop",
+			opts:    []Option{WithAllowedPrefixes(loopback), WithMaxRedirects(3)},
+			wantErr: ErrTooManyRedirects,
+		},
+		"fail - body over limit": {
+			path:    "/large",
+			opts:    []Option{WithAllowedPrefixes(loopback), WithMaxBodyBytes(63)},
+			wantErr: ErrBodyTooLarge,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, srv.URL+tc.path, nil)
+			require.NoError(t, err)
+
+			body, err := func() ([]byte, error) {
+				resp, err := NewClient(tc.opts...).Do(req)
+				if err != nil {
+					return nil, err
+				}
+				defer resp.Body.Close()
+				return io.ReadAll(resp.Body)
+			}()
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.wantBody, string(body))
+		})
+	}
+}
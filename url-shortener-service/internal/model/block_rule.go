@@ -0,0 +1,32 @@
+package model
+
+// BlockRuleKind represents how a block rule matches a destination URL
+type BlockRuleKind string
+
+const (
+	// BlockRuleKindDomain blocks a domain and all its subdomains
+	BlockRuleKindDomain BlockRuleKind = "DOMAIN"
+	// BlockRuleKindRegex blocks the URLs matching a regular expression
+	BlockRuleKindRegex BlockRuleKind = "REGEX"
+	// BlockRuleKindShortener blocks the domain of another URL shortener, to prevent redirect chains
+	BlockRuleKindShortener BlockRuleKind = "SHORTENER"
+	// BlockRuleKindHashPrefix blocks the URLs whose Safe Browsing expression hash starts with a hex-encoded prefix
+	BlockRuleKindHashPrefix BlockRuleKind = "HASH_PREFIX"
+)
+
+// String converts to string value
+func (k BlockRuleKind) String() string {
+	return string(k)
+}
+
+// IsValid checks if the block rule kind is valid
+func (k BlockRuleKind) IsValid() bool {
+	return k == BlockRuleKindDomain || k == BlockRuleKindRegex || k == BlockRuleKindShortener || k == BlockRuleKindHashPrefix
+}
+
+// BlockRule represents a destination blocklist entry
+type BlockRule struct {
+	Kind    BlockRuleKind
+	Pattern string
+	Reason  string
+}
@@ -0,0 +1,70 @@
+package model
+
+import "time"
+
+// CrawlStatus represents the metadata crawl status of `short_url`
+type CrawlStatus string
+
+const (
+	// CrawlStatusPending means metadata has not been crawled yet
+	CrawlStatusPending CrawlStatus = "PENDING"
+	// CrawlStatusCrawled means the last crawl attempt succeeded
+	CrawlStatusCrawled CrawlStatus = "CRAWLED"
+	// CrawlStatusFailed means the last crawl attempt failed
+	CrawlStatusFailed CrawlStatus = "FAILED"
+	// CrawlStatusSkipped means the link was not eligible for crawling
+	CrawlStatusSkipped CrawlStatus = "SKIPPED"
+)
+
+// String converts to string value
+func (stt CrawlStatus) String() string {
+	return string(stt)
+}
+
+// CrawlErrorCode classifies why a crawl attempt failed
+type CrawlErrorCode string
+
+const (
+	// CrawlErrorTimeout means the destination did not answer in time
+	CrawlErrorTimeout CrawlErrorCode = "TIMEOUT"
+	// CrawlErrorNonHTML means the destination served a document which cannot be previewed
+	CrawlErrorNonHTML CrawlErrorCode = "NON_HTML"
+	// CrawlErrorHTTP4xx means the destination answered with a 4xx status
+	CrawlErrorHTTP4xx CrawlErrorCode = "HTTP_4XX"
+	// CrawlErrorHTTP5xx means the destination answered with a 5xx status
+	CrawlErrorHTTP5xx CrawlErrorCode = "HTTP_5XX"
+	// CrawlErrorTLS means the TLS handshake or certificate verification failed
+	CrawlErrorTLS CrawlErrorCode = "TLS"
+	// CrawlErrorDNS means the destination host could not be resolved
+	CrawlErrorDNS CrawlErrorCode = "DNS"
+	// CrawlErrorBlocked means the destination resolved to a private or otherwise non-public address
+	CrawlErrorBlocked CrawlErrorCode = "BLOCKED"
+	// CrawlErrorUnknown means any other failure
+	CrawlErrorUnknown CrawlErrorCode = "UNKNOWN"
+)
+
+// String converts to string value
+func (c CrawlErrorCode) String() string {
+	return string(c)
+}
+
+// CrawlState represents the metadata crawl tracking of `short_url`
+type CrawlState struct {
+	Status        CrawlStatus
+	Attempts      int
+	LastCrawledAt time.Time
+	LastErrorCode CrawlErrorCode
+	RequestedAt   time.Time // last time a crawl was enqueued
+	ETag          string    // validators of the last crawled response, used for conditional re-crawls
+	LastModified  string
+}
+
+// CrawlDomainStats represents crawl health of the links pointing to a domain
+type CrawlDomainStats struct {
+	Domain        string
+	Pending       int64
+	Crawled       int64
+	Failed        int64
+	Skipped       int64
+	LastCrawledAt time.Time
+}
@@ -0,0 +1,17 @@
+package model
+
+import "time"
+
+// DLQReplay is the audit record of a DLQ message replayed to its topic
+type DLQReplay struct {
+	DLQID       string // digest of the DLQ message, a message is replayed once
+	DLQTopic    string
+	Topic       string // topic the message was replayed to
+	Key         string
+	EventID     string // outbox event ID of the message, empty when unknown
+	Error       string // error which sent the message to the DLQ
+	ReplayCount int    // replay_count header of the replayed message
+	ReplayedBy  string
+	Reason      string
+	ReplayedAt  time.Time
+}
@@ -0,0 +1,25 @@
+package model
+
+// The data of each topic, as carried by Payload.Data. The .v1 topics predate typed data and encode every
+// field as a JSON string, integers included, so that their consumers keep decoding them.
+
+// MetadataRequestedV1 is the data of TopicMetadataRequestedV1 events
+type MetadataRequestedV1 struct {
+	ShortCode   string `json:"short_code"`
+	OriginalURL string `json:"original_url"`
+}
+
+// MetadataCrawledV1 is the data of TopicMetadataCrawledV1 events
+type MetadataCrawledV1 struct {
+	ShortCode   string `json:"short_code"`
+	OriginalURL string `json:"original_url"`
+}
+
+// LinkBrokenV1 is the data of TopicLinkBrokenV1 events
+type LinkBrokenV1 struct {
+	ShortCode           string `json:"short_code"`
+	OriginalURL         string `json:"original_url"`
+	HTTPStatus          int    `json:"http_status,string"`          // status of the last check, 0 when unreachable
+	ConsecutiveFailures int    `json:"consecutive_failures,string"` // failed checks in a row
+	LastOKAt            string `json:"last_ok_at"`                  // RFC 3339, empty when the destination never answered OK
+}
@@ -0,0 +1,49 @@
+package model
+
+import "time"
+
+// LinkHealthStatus represents whether the destination of `short_url` answers
+type LinkHealthStatus string
+
+const (
+	// LinkHealthUnknown means the destination has not been checked yet
+	LinkHealthUnknown LinkHealthStatus = "UNKNOWN"
+	// LinkHealthReachable means the destination answered with a success or redirect status
+	LinkHealthReachable LinkHealthStatus = "REACHABLE"
+	// LinkHealthUnreachable means the destination did not answer or answered with an error status
+	LinkHealthUnreachable LinkHealthStatus = "UNREACHABLE"
+	// LinkHealthBroken means the destination kept failing checks, see LinkHealth.ConsecutiveFailures
+	LinkHealthBroken LinkHealthStatus = "BROKEN"
+)
+
+// String converts to string value
+func (stt LinkHealthStatus) String() string {
+	return string(stt)
+}
+
+// IsValid checks if link health status is valid
+func (stt LinkHealthStatus) IsValid() bool {
+	return stt == LinkHealthUnknown || stt == LinkHealthReachable || stt == LinkHealthUnreachable || stt == LinkHealthBroken
+}
+
+// LinkHealth represents the reachability of the destination of `short_url`, as last observed by the crawler
+// or the link health worker
+type LinkHealth struct {
+	Status              LinkHealthStatus
+	HTTPStatus          int      // 0 when the destination did not answer
+	RedirectChain       []string // URLs followed after the original one, empty when it answered directly
+	ConsecutiveFailures int
+	CheckedAt           time.Time
+	LastOKAt            time.Time
+}
+
+// HealthCheck represents the outcome of a single reachability check of a destination
+type HealthCheck struct {
+	HTTPStatus    int // 0 when the destination did not answer
+	RedirectChain []string
+}
+
+// Reachable tells whether the destination answered with a success or redirect status
+func (c HealthCheck) Reachable() bool {
+	return c.HTTPStatus >= 200 && c.HTTPStatus < 400
+}
@@ -25,6 +25,8 @@ const (
 	TopicMetadataRequestedV1 Topic = "urlshortener.metadata.requested.v1"
 	// TopicMetadataCrawledV1 is the Kafka topic for metadata crawled events.
 	TopicMetadataCrawledV1 Topic = "urlshortener.metadata.crawled.v1"
+	// TopicLinkBrokenV1 is the Kafka topic for link broken events, emitted once a destination keeps failing health checks.
+	TopicLinkBrokenV1 Topic = "urlshortener.link.broken.v1"
 )
 
 // String returns the string representation of the outgoing event status.
@@ -37,27 +39,32 @@ func (t Topic) String() string { return string(t) }
 
 // OutgoingEvent represents the domain-level event pushed into the outgoing event.
 type OutgoingEvent struct {
-	ID            int64
-	RetryCount    int
-	LastError     string
-	CorrelationID string
-	TraceID       string
-	SpanID        string
-	Topic         Topic
-	Payload       Payload
-	Status        OutgoingEventStatus
-	CreatedAt     time.Time
-	UpdatedAt     time.Time
+	ID             int64
+	RetryCount     int
+	LastError      string
+	CorrelationID  string
+	TraceID        string
+	SpanID         string
+	Topic          Topic
+	Key            string // message key, events of a key are published to the same partition in order
+	Payload        Payload
+	Status         OutgoingEventStatus
+	LockedBy       string    // producer instance holding the lease, empty when unclaimed
+	LockedUntil    time.Time // lease expiry, zero when unclaimed
+	NextAttemptAt  time.Time // not published before, pushed back after each failure
+	AcknowledgedAt time.Time // when a FAILED event was acknowledged, zero until then
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
 }
 
 // Payload defines the structure stored inside the JSONB payload column.
 type Payload struct {
-	EventID       int64             `json:"event_id"` // Unique event identifier for idempotency
-	CorrelationID string            `json:"correlation_id"`
-	TraceID       string            `json:"trace_id"`
-	SpanID        string            `json:"span_id"`
-	OccurredAt    time.Time         `json:"occurred_at"` // Timestamp when the event occurred
-	Data          map[string]string `json:"data"`        // Actual business payload (generic)
+	EventID       int64           `json:"event_id"` // Unique event identifier for idempotency
+	CorrelationID string          `json:"correlation_id"`
+	TraceID       string          `json:"trace_id"`
+	SpanID        string          `json:"span_id"`
+	OccurredAt    time.Time       `json:"occurred_at"` // Timestamp when the event occurred
+	Data          json.RawMessage `json:"data"`        // Event data, of the type registered for the topic in eventschema
 }
 
 // MarshalPayload marshals Payload field to byte array
@@ -68,3 +75,24 @@ func (m OutgoingEvent) MarshalPayload() ([]byte, error) {
 	}
 	return b, nil
 }
+
+// OutgoingEventFilter selects outbox events, zero fields match every event
+type OutgoingEventFilter struct {
+	IDs           []int64
+	Status        OutgoingEventStatus
+	Topic         Topic
+	CreatedBefore time.Time
+}
+
+// OutgoingEventCount is the number of outbox events of a status and topic
+type OutgoingEventCount struct {
+	Status OutgoingEventStatus
+	Topic  Topic
+	Count  int64
+}
+
+// OutgoingEventStats summarizes the outbox
+type OutgoingEventStats struct {
+	Counts          []OutgoingEventCount
+	OldestPendingAt time.Time // creation of the oldest PENDING event, zero when none
+}
@@ -0,0 +1,11 @@
+package model
+
+import "time"
+
+// ProcessedEvent records that a consumer group handled an event, for the next deliveries of the event to be skipped
+type ProcessedEvent struct {
+	ConsumerGroup string
+	EventID       int64
+	Topic         Topic
+	ProcessedAt   time.Time
+}
@@ -30,6 +30,11 @@ type ShortUrl struct {
 	OriginalURL string
 	Status      ShortUrlStatus
 	Metadata    UrlMetadata
+	Crawl       CrawlState
+	Health      LinkHealth
+	ClickCount  int64
+	FlaggedAt   time.Time // set when the destination matches the blocklist
+	FlagReason  string
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
 }
@@ -1,11 +1,49 @@
 package model
 
+// MetadataKind discriminates the kind of document a link points to, so previews can render appropriately
+type MetadataKind string
+
+const (
+	// MetadataKindHTML means the link points to an HTML page
+	MetadataKindHTML MetadataKind = "html"
+	// MetadataKindPDF means the link points to a PDF document
+	MetadataKindPDF MetadataKind = "pdf"
+	// MetadataKindImage means the link points to an image
+	MetadataKindImage MetadataKind = "image"
+	// MetadataKindVideo means the link points to a video file
+	MetadataKindVideo MetadataKind = "video"
+	// MetadataKindAudio means the link points to an audio file
+	MetadataKindAudio MetadataKind = "audio"
+)
+
+// String converts to string value
+func (k MetadataKind) String() string {
+	return string(k)
+}
+
 type UrlMetadata struct {
 	FinalURL    string `json:"final_url"`
 	Title       string `json:"title"`
 	Description string `json:"description"`
 	Image       string `json:"image"`
 	Favicon     string `json:"favicon"`
+	// Extractor is the name of the metadata extractor which produced the result
+	Extractor string `json:"extractor,omitempty"`
+	// Kind is the kind of document the link points to
+	Kind MetadataKind `json:"kind,omitempty"`
+	// ContentType is the media type served by the destination, without parameters
+	ContentType string `json:"content_type,omitempty"`
+	// ContentLength is the size in bytes announced by the destination, 0 when unknown
+	ContentLength int64 `json:"content_length,omitempty"`
+	// Width and Height are the dimensions in pixels of an image
+	Width  int `json:"width,omitempty"`
+	Height int `json:"height,omitempty"`
+	// Duration is the length in seconds of a media file, 0 when not cheaply available
+	Duration float64 `json:"duration,omitempty"`
+	// OriginalImage and OriginalFavicon are the destination URLs of Image and Favicon when those
+	// are served from the local thumbnail cache
+	OriginalImage   string `json:"original_image,omitempty"`
+	OriginalFavicon string `json:"original_favicon,omitempty"`
 }
 
 func (u UrlMetadata) IsNotEmpty() bool {
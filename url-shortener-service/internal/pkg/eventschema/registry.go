@@ -0,0 +1,133 @@
+package eventschema
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/pkg/jsonschema"
+)
+
+var (
+	// ErrUnknownTopic means no data type is registered for the topic
+	ErrUnknownTopic = errors.New("unknown event topic")
+	// ErrTypeMismatch means the data is not of the type registered for the topic
+	ErrTypeMismatch = errors.New("event data type mismatch")
+)
+
+// Event is the registration of a topic: the type of its data, the schema version and the JSON Schema
+// generated from the type
+type Event struct {
+	Topic   model.Topic
+	Version int
+	Type    reflect.Type
+	Schema  *jsonschema.Schema
+}
+
+// events registers every topic produced through the outbox. A change to the data of a topic must stay
+// compatible with its schema, see TestCompatibility; a breaking change needs a new topic version.
+var events = register(
+	newEvent[model.MetadataRequestedV1](model.TopicMetadataRequestedV1, 1),
+	newEvent[model.MetadataCrawledV1](model.TopicMetadataCrawledV1, 1),
+	newEvent[model.LinkBrokenV1](model.TopicLinkBrokenV1, 1),
+)
+
+// newEvent registers T as the data type of topic
+func newEvent[T any](topic model.Topic, version int) Event {
+	t := reflect.TypeFor[T]()
+
+	s, err := jsonschema.Generate(t)
+	if err != nil {
+		panic(fmt.Sprintf("eventschema: %s: %v", topic, err))
+	}
+	s.ID = topic.String()
+	s.Title = t.Name()
+
+	return Event{Topic: topic, Version: version, Type: t, Schema: s}
+}
+
+// register indexes events by topic
+func register(evts ...Event) map[model.Topic]Event {
+	rs := make(map[model.Topic]Event, len(evts))
+	for _, e := range evts {
+		if _, ok := rs[e.Topic]; ok {
+			panic(fmt.Sprintf("eventschema: %s registered twice", e.Topic))
+		}
+		rs[e.Topic] = e
+	}
+	return rs
+}
+
+// Lookup returns the registration of a topic
+func Lookup(topic model.Topic) (Event, error) {
+	e, ok := events[topic]
+	if !ok {
+		return Event{}, fmt.Errorf("%w: %s", ErrUnknownTopic, topic)
+	}
+	return e, nil
+}
+
+// Events returns every registration, ordered by topic
+func Events() []Event {
+	rs := make([]Event, 0, len(events))
+	for _, e := range events {
+		rs = append(rs, e)
+	}
+	sort.Slice(rs, func(a, b int) bool { return rs[a].Topic < rs[b].Topic })
+	return rs
+}
+
+// Encode marshals the data of an event of topic, which must be of the registered type, and validates it
+// against the schema of the topic
+func Encode(topic model.Topic, data any) (json.RawMessage, error) {
+	e, err := Lookup(topic)
+	if err != nil {
+		return nil, err
+	}
+	if t := reflect.TypeOf(data); t != e.Type {
+		return nil, fmt.Errorf("%w: %s expects %s, got %s", ErrTypeMismatch, topic, e.Type, t)
+	}
+
+	b, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	if err = e.Schema.Validate(b); err != nil {
+		return nil, fmt.Errorf("%s: %w", topic, err)
+	}
+
+	return b, nil
+}
+
+// Decode validates the data of an event of topic against the schema of the topic and unmarshals it into dst,
+// a pointer to the registered type
+func Decode(topic model.Topic, data json.RawMessage, dst any) error {
+	e, err := Lookup(topic)
+	if err != nil {
+		return err
+	}
+	if t := reflect.TypeOf(dst); t == nil || t.Kind() != reflect.Pointer || t.Elem() != e.Type {
+		return fmt.Errorf("%w: %s expects *%s, got %s", ErrTypeMismatch, topic, e.Type, t)
+	}
+
+	if err = e.Schema.Validate(data); err != nil {
+		return fmt.Errorf("%s: %w", topic, err)
+	}
+
+	return json.Unmarshal(data, dst)
+}
+
+// Validate checks the data of an event of topic against the schema of the topic
+func Validate(topic model.Topic, data json.RawMessage) error {
+	e, err := Lookup(topic)
+	if err != nil {
+		return err
+	}
+	if err = e.Schema.Validate(data); err != nil {
+		return fmt.Errorf("%s: %w", topic, err)
+	}
+	return nil
+}
@@ -0,0 +1,175 @@
+package eventschema
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/pkg/jsonschema"
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "write the schemas of compatible changes and new topics to schemas/")
+
+// TestCompatibility fails when the data type of a topic changed in a way which breaks its consumers,
+// against the schema published in schemas/. Compatible changes are published with -update.
+func TestCompatibility(t *testing.T) {
+	for _, e := range Events() {
+		t.Run(e.Topic.String(), func(t *testing.T) {
+			path := filepath.Join("schemas", e.Topic.String()+".json")
+
+			current, err := json.MarshalIndent(e.Schema, "", "  ")
+			require.NoError(t, err)
+			current = append(current, '\n')
+
+			published, err := os.ReadFile(path)
+			if errors.Is(err, os.ErrNotExist) {
+				require.True(t, *update, "no schema published for %s, run go test -update", e.Topic)
+				require.NoError(t, os.WriteFile(path, current, 0o644))
+				return
+			}
+			require.NoError(t, err)
+
+			prev, err := jsonschema.Parse(published)
+			require.NoError(t, err)
+			require.NoError(t, jsonschema.CheckCompatible(prev, e.Schema),
+				"breaking change to %s, publish the data under a new topic version instead", e.Topic)
+
+			if *update {
+				require.NoError(t, os.WriteFile(path, current, 0o644))
+				return
+			}
+			require.Equal(t, string(published), string(current), "schema of %s changed, run go test -update", e.Topic)
+		})
+	}
+}
+
+// TestLegacyData checks that data written as string maps, before topics were typed, is still decoded
+func TestLegacyData(t *testing.T) {
+	tcs := map[string]struct {
+		topic model.Topic
+		given map[string]string
+		dst   any
+		want  any
+	}{
+		"metadata requested": {
+			topic: model.TopicMetadataRequestedV1,
+			given: map[string]string{"short_code": "abc123", "original_url": "https://example.com"},
+			dst:   &model.MetadataRequestedV1{},
+			want:  model.MetadataRequestedV1{ShortCode: "abc123", OriginalURL: "https://example.com"},
+		},
+		"metadata crawled": {
+			topic: model.TopicMetadataCrawledV1,
+			given: map[string]string{"short_code": "abc123", "original_url": "https://example.com"},
+			dst:   &model.MetadataCrawledV1{},
+			want:  model.MetadataCrawledV1{ShortCode: "abc123", OriginalURL: "https://example.com"},
+		},
+		"link broken": {
+			topic: model.TopicLinkBrokenV1,
+			given: map[string]string{
+				"short_code":           "abc123",
+				"original_url":         "https://example.com",
+				"http_status":          "404",
+				"consecutive_failures": "3",
+				"last_ok_at":           "",
+			},
+			dst: &model.LinkBrokenV1{},
+			want: model.LinkBrokenV1{
+				ShortCode:           "abc123",
+				OriginalURL:         "https://example.com",
+				HTTPStatus:          404,
+				ConsecutiveFailures: 3,
+			},
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			b, err := json.Marshal(tc.given)
+			require.NoError(t, err)
+
+			require.NoError(t, Decode(tc.topic, b, tc.dst))
+			require.Equal(t, tc.want, reflect.ValueOf(tc.dst).Elem().Interface())
+
+			// And typed data is written the way it used to be
+			encoded, err := Encode(tc.topic, tc.want)
+			require.NoError(t, err)
+			require.JSONEq(t, string(b), string(encoded))
+		})
+	}
+}
+
+func TestEncodeDecode(t *testing.T) {
+	tcs := map[string]struct {
+		run     func() error
+		wantErr error
+	}{
+		"success": {
+			run: func() error {
+				b, err := Encode(model.TopicMetadataRequestedV1, model.MetadataRequestedV1{ShortCode: "abc123"})
+				if err != nil {
+					return err
+				}
+				var data model.MetadataRequestedV1
+				return Decode(model.TopicMetadataRequestedV1, b, &data)
+			},
+		},
+		"fail - encode unknown topic": {
+			run: func() error {
+				_, err := Encode("evt.unknown.v1", model.MetadataRequestedV1{})
+				return err
+			},
+			wantErr: ErrUnknownTopic,
+		},
+		"fail - encode type of another topic": {
+			run: func() error {
+				_, err := Encode(model.TopicLinkBrokenV1, model.MetadataRequestedV1{})
+				return err
+			},
+			wantErr: ErrTypeMismatch,
+		},
+		"fail - decode into type of another topic": {
+			run: func() error {
+				var data model.LinkBrokenV1
+				return Decode(model.TopicMetadataRequestedV1, json.RawMessage(`{"short_code":"a","original_url":"b"}`), &data)
+			},
+			wantErr: ErrTypeMismatch,
+		},
+		"fail - decode missing property": {
+			run: func() error {
+				var data model.MetadataRequestedV1
+				return Decode(model.TopicMetadataRequestedV1, json.RawMessage(`{"short_code":"a"}`), &data)
+			},
+			wantErr: jsonschema.ErrInvalid,
+		},
+		"fail - validate malformed integer": {
+			run: func() error {
+				return Validate(model.TopicLinkBrokenV1, json.RawMessage(
+					`{"short_code":"a","original_url":"b","http_status":"none","consecutive_failures":"3","last_ok_at":""}`))
+			},
+			wantErr: jsonschema.ErrInvalid,
+		},
+		"fail - validate null data": {
+			run: func() error {
+				return Validate(model.TopicMetadataCrawledV1, nil)
+			},
+			wantErr: jsonschema.ErrInvalid,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			err := tc.run()
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
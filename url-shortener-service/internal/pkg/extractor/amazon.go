@@ -0,0 +1,74 @@
+package extractor
+
+import (
+	"bytes"
+	"context"
+	"strings"
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+	"golang.org/x/net/html"
+)
+
+// NameAmazon is the name of the Amazon extractor
+const NameAmazon = "amazon"
+
+// Amazon reads product pages, which carry no og:* tags; the product title and the main image
+// only exist in the page body.
+type Amazon struct{}
+
+// Name implements MetadataExtractor
+func (Amazon) Name() string { return NameAmazon }
+
+// Extract implements MetadataExtractor
+func (Amazon) Extract(_ context.Context, p Page) (model.UrlMetadata, error) {
+	h := parseHeadMetadata(p.Body)
+	md := buildMetadata(h, p.URL)
+
+	title, image := parseAmazonProduct(p.Body)
+	md.Title = firstNonEmpty(title, strings.TrimPrefix(md.Title, "Amazon.com: "))
+	md.Image = firstNonEmpty(resolveURL(image, p.URL), md.Image)
+	return md, nil
+}
+
+// parseAmazonProduct extracts the product title and main image from:
+//
+//	<span id="productTitle">...</span>
+//	<img id="landingImage" data-old-hires="..." src="...">
+func parseAmazonProduct(body []byte) (title, image string) {
+	z := html.NewTokenizer(bytes.NewReader(body))
+	var inTitle bool
+
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken || (title != "" && image != "") {
+			return title, image
+		}
+
+		tok := z.Token()
+		switch tt {
+		case html.StartTagToken, html.SelfClosingTagToken:
+			attrs := map[string]string{}
+			for _, a := range tok.Attr {
+				attrs[strings.ToLower(a.Key)] = a.Val
+			}
+
+			switch attrs["id"] {
+			case "productTitle":
+				inTitle = tok.Type == html.StartTagToken
+			case "landingImage", "imgBlkFront":
+				if image == "" {
+					image = firstNonEmpty(attrs["data-old-hires"], attrs["src"])
+				}
+			}
+
+		case html.TextToken:
+			if inTitle {
+				title = strings.TrimSpace(tok.Data)
+				inTitle = title == ""
+			}
+
+		case html.EndTagToken:
+			inTitle = false
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package extractor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAmazon_Extract(t *testing.T) {
+	tcs := map[string]struct {
+		body string
+		want model.UrlMetadata
+	}{
+		"product page": {
+			body: string(loadFixture(t, nil, "amazon_product.html")),
+			want: model.UrlMetadata{
+				FinalURL:    "https://www.amazon.com/dp/0134190440",
+				Title:       "The Go Programming Language (Addison-Wesley Professional Computing Series)",
+				Description: "Amazon.com: The Go Programming Language (Addison-Wesley Professional Computing Series): 9780134190440: Donovan, Alan, Kernighan, Brian: Books",
+				Image:       "https://m.media-amazon.com/images/I/71nWy2yn8dL._SL1500_.jpg",
+			},
+		},
+		"truncated page falls back to head": {
+			body: `<head><title>Amazon.com: Some Product</title></head><body><div id="dp">`,
+			want: model.UrlMetadata{
+				FinalURL: "https://www.amazon.com/dp/0134190440",
+				Title:    "Some Product",
+			},
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			actual, err := Amazon{}.Extract(context.Background(), Page{
+				URL:  mustParseURL(t, "https://www.amazon.com/dp/0134190440"),
+				Body: []byte(tc.body),
+			})
+			require.NoError(t, err)
+			require.True(t, cmp.Equal(tc.want, actual), "diff: %v", cmp.Diff(tc.want, actual))
+		})
+	}
+}
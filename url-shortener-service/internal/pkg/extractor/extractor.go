@@ -0,0 +1,169 @@
+package extractor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/monitoring"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+)
+
+// ErrUnsupportedContentType means the fetched document is of a kind no extractor can preview
+var ErrUnsupportedContentType = errors.New("unsupported content type")
+
+// Page is a fetched document handed to an extractor.
+type Page struct {
+	// URL is the final URL of the page after redirects
+	URL *url.URL
+	// Body is the (possibly truncated) document
+	Body []byte
+	// ContentType is the media type served by the destination, without parameters.
+	// An empty content type is handled as HTML.
+	ContentType string
+	// ContentLength is the size in bytes announced by the destination, 0 when unknown
+	ContentLength int64
+}
+
+// KindOf returns the kind of document served with the given media type,
+// or an empty kind when the document cannot be previewed.
+func KindOf(mediaType string) model.MetadataKind {
+	switch {
+	case mediaType == "", mediaType == "text/html", mediaType == "application/xhtml+xml":
+		return model.MetadataKindHTML
+	case mediaType == "application/pdf":
+		return model.MetadataKindPDF
+	case strings.HasPrefix(mediaType, "image/"):
+		return model.MetadataKindImage
+	case strings.HasPrefix(mediaType, "video/"):
+		return model.MetadataKindVideo
+	case strings.HasPrefix(mediaType, "audio/"):
+		return model.MetadataKindAudio
+	default:
+		return ""
+	}
+}
+
+// MetadataExtractor extracts link preview metadata from a fetched page.
+type MetadataExtractor interface {
+	// Name identifies the extractor, it is recorded on the produced metadata
+	Name() string
+	// Extract builds metadata for the given page
+	Extract(ctx context.Context, p Page) (model.UrlMetadata, error)
+}
+
+type entry struct {
+	patterns  []string
+	extractor MetadataExtractor
+}
+
+// Registry selects a MetadataExtractor by host pattern for HTML pages, falling back to the generic head parser,
+// and by document kind for other documents.
+type Registry struct {
+	entries  []entry
+	fallback MetadataExtractor
+	files    map[model.MetadataKind]MetadataExtractor
+}
+
+// NewRegistry creates an empty Registry using the generic head parser as fallback.
+// Non-HTML documents are always handled by the built-in PDF, image and media extractors.
+func NewRegistry() *Registry {
+	return &Registry{
+		fallback: Generic{},
+		files: map[model.MetadataKind]MetadataExtractor{
+			model.MetadataKindPDF:   PDF{},
+			model.MetadataKindImage: Image{},
+			model.MetadataKindVideo: Media{},
+			model.MetadataKindAudio: Media{},
+		},
+	}
+}
+
+// NewDefaultRegistry creates a Registry with all the built-in site-specific extractors registered.
+// The client is used by extractors that need extra requests such as oEmbed lookups.
+func NewDefaultRegistry(client *http.Client) *Registry {
+	r := NewRegistry()
+	r.Register(NewYouTube(client), "youtube.com", "youtu.be")
+	r.Register(NewTwitter(client), "twitter.com", "x.com")
+	r.Register(GitHub{}, "github.com")
+	r.Register(Amazon{}, "amazon.com", "amazon.co.uk", "amazon.de", "amazon.fr", "amazon.co.jp", "amazon.ca", "amazon.in")
+	return r
+}
+
+// Register adds an extractor for the given host patterns.
+// A pattern matches the host itself and any of its subdomains, e.g. "youtube.com" matches "m.youtube.com".
+// Extractors registered first win when several patterns match.
+func (r *Registry) Register(e MetadataExtractor, hostPatterns ...string) {
+	r.entries = append(r.entries, entry{patterns: hostPatterns, extractor: e})
+}
+
+// Select returns the extractor registered for the host, or the generic one when none matches.
+func (r *Registry) Select(host string) MetadataExtractor {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	for _, e := range r.entries {
+		for _, p := range e.patterns {
+			if matchHost(p, host) {
+				return e.extractor
+			}
+		}
+	}
+	return r.fallback
+}
+
+// Extract runs the extractor matching the document kind and, for HTML pages, the page host.
+// When a site-specific extractor fails or finds nothing useful, the generic parser is used instead.
+// The name of the extractor which produced the result, the document kind, content type and
+// content length are recorded on the metadata.
+func (r *Registry) Extract(ctx context.Context, p Page) (model.UrlMetadata, error) {
+	kind := KindOf(p.ContentType)
+
+	var md model.UrlMetadata
+	var err error
+	switch kind {
+	case "":
+		return model.UrlMetadata{}, fmt.Errorf("%w: %s", ErrUnsupportedContentType, p.ContentType)
+	case model.MetadataKindHTML:
+		md, err = r.extractHTML(ctx, p)
+	default:
+		e := r.files[kind]
+		md, err = e.Extract(ctx, p)
+		md.Extractor = e.Name()
+	}
+	if err != nil {
+		return model.UrlMetadata{}, err
+	}
+
+	md.Kind = kind
+	md.ContentType = p.ContentType
+	md.ContentLength = p.ContentLength
+	return md, nil
+}
+
+func (r *Registry) extractHTML(ctx context.Context, p Page) (model.UrlMetadata, error) {
+	e := r.Select(p.URL.Hostname())
+
+	md, err := e.Extract(ctx, p)
+	if e != r.fallback && (err != nil || md.Title == "") {
+		monitoring.Log(ctx).
+			Field("extractor", e.Name()).
+			Field("url", p.URL.String()).
+			Warn().Err(err).Msg("[Registry.Extract] site extractor gave no result, falling back to generic")
+
+		e = r.fallback
+		md, err = e.Extract(ctx, p)
+	}
+	if err != nil {
+		return model.UrlMetadata{}, err
+	}
+
+	md.Extractor = e.Name()
+	return md, nil
+}
+
+func matchHost(pattern, host string) bool {
+	pattern = strings.ToLower(pattern)
+	return host == pattern || strings.HasSuffix(host, "."+pattern)
+}
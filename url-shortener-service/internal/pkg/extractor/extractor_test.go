@@ -0,0 +1,179 @@
+package extractor
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeExtractor struct {
+	name string
+	md   model.UrlMetadata
+	err  error
+}
+
+func (f fakeExtractor) Name() string { return f.name }
+
+func (f fakeExtractor) Extract(context.Context, Page) (model.UrlMetadata, error) { return f.md, f.err }
+
+func TestRegistry_Select(t *testing.T) {
+	r := NewDefaultRegistry(http.DefaultClient)
+
+	tcs := map[string]struct {
+		host string
+		want string
+	}{
+		"youtube":                {host: "www.youtube.com", want: NameYouTube},
+		"youtube short link":     {host: "youtu.be", want: NameYouTube},
+		"youtube mobile":         {host: "m.youtube.com", want: NameYouTube},
+		"x":                      {host: "x.com", want: NameTwitter},
+		"twitter":                {host: "twitter.com", want: NameTwitter},
+		"github":                 {host: "github.com", want: NameGitHub},
+		"github upper case":      {host: "GitHub.com", want: NameGitHub},
+		"amazon":                 {host: "www.amazon.com", want: NameAmazon},
+		"amazon regional":        {host: "www.amazon.co.uk", want: NameAmazon},
+		"unknown host":           {host: "example.com", want: NameGeneric},
+		"suffix is not a domain": {host: "notyoutube.com", want: NameGeneric},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.want, r.Select(tc.host).Name())
+		})
+	}
+}
+
+func TestRegistry_Extract(t *testing.T) {
+	page := Page{
+		URL:  mustParseURL(t, "https://site.example.com/page"),
+		Body: []byte(`<html><head><title>Generic Title</title></head></html>`),
+	}
+
+	tcs := map[string]struct {
+		site fakeExtractor
+		want model.UrlMetadata
+	}{
+		"site extractor result is used": {
+			site: fakeExtractor{name: "site", md: model.UrlMetadata{Title: "Site Title"}},
+			want: model.UrlMetadata{Title: "Site Title", Extractor: "site", Kind: model.MetadataKindHTML},
+		},
+		"fallback to generic on error": {
+			site: fakeExtractor{name: "site", err: errors.New("oembed down")},
+			want: model.UrlMetadata{FinalURL: "https://site.example.com/page", Title: "Generic Title", Extractor: NameGeneric, Kind: model.MetadataKindHTML},
+		},
+		"fallback to generic on empty result": {
+			site: fakeExtractor{name: "site"},
+			want: model.UrlMetadata{FinalURL: "https://site.example.com/page", Title: "Generic Title", Extractor: NameGeneric, Kind: model.MetadataKindHTML},
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			r := NewRegistry()
+			r.Register(tc.site, "example.com")
+
+			actual, err := r.Extract(context.Background(), page)
+			require.NoError(t, err)
+			require.True(t, cmp.Equal(tc.want, actual), "diff: %v", cmp.Diff(tc.want, actual))
+		})
+	}
+}
+
+func TestRegistry_Extract_Kind(t *testing.T) {
+	tcs := map[string]struct {
+		page    Page
+		want    model.UrlMetadata
+		wantErr error
+	}{
+		"html without content type": {
+			page: Page{URL: mustParseURL(t, "https://example.com"), Body: []byte(`<title>Home</title>`)},
+			want: model.UrlMetadata{FinalURL: "https://example.com", Title: "Home", Extractor: NameGeneric, Kind: model.MetadataKindHTML},
+		},
+		"pdf": {
+			page: Page{URL: mustParseURL(t, "https://example.com/a.pdf"), ContentType: "application/pdf", ContentLength: 2048},
+			want: model.UrlMetadata{
+				FinalURL:      "https://example.com/a.pdf",
+				Title:         "a.pdf",
+				Extractor:     NamePDF,
+				Kind:          model.MetadataKindPDF,
+				ContentType:   "application/pdf",
+				ContentLength: 2048,
+			},
+		},
+		"audio": {
+			page: Page{URL: mustParseURL(t, "https://example.com/a.mp3"), ContentType: "audio/mpeg"},
+			want: model.UrlMetadata{
+				FinalURL:    "https://example.com/a.mp3",
+				Title:       "a.mp3",
+				Extractor:   NameMedia,
+				Kind:        model.MetadataKindAudio,
+				ContentType: "audio/mpeg",
+			},
+		},
+		"unsupported content type": {
+			page:    Page{URL: mustParseURL(t, "https://example.com/a.zip"), ContentType: "application/zip"},
+			wantErr: ErrUnsupportedContentType,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			actual, err := NewDefaultRegistry(http.DefaultClient).Extract(context.Background(), tc.page)
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.True(t, cmp.Equal(tc.want, actual), "diff: %v", cmp.Diff(tc.want, actual))
+		})
+	}
+}
+
+// newFixtureServer serves testdata files by path. Occurrences of {{server}} in the files are
+// replaced by the server URL, so recorded discovery links point back to the test server.
+func newFixtureServer(t *testing.T, routes map[string]string) *httptest.Server {
+	t.Helper()
+
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		file, ok := routes[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write(loadFixture(t, srv, file))
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func loadFixture(t *testing.T, srv *httptest.Server, file string) []byte {
+	t.Helper()
+
+	b, err := os.ReadFile("testdata/" + file)
+	require.NoError(t, err)
+
+	if srv != nil {
+		b = []byte(strings.ReplaceAll(string(b), "{{server}}", srv.URL))
+	}
+	return b
+}
+
+func mustParseURL(t *testing.T, rawURL string) *url.URL {
+	t.Helper()
+
+	u, err := url.Parse(rawURL)
+	require.NoError(t, err)
+	return u
+}
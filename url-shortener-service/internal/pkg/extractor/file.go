@@ -0,0 +1,15 @@
+package extractor
+
+import (
+	"net/url"
+	"path"
+)
+
+// fileName returns the last path segment of u, used as title of documents without one
+func fileName(u *url.URL) string {
+	name := path.Base(u.Path)
+	if name == "/" || name == "." {
+		return ""
+	}
+	return name
+}
@@ -0,0 +1,180 @@
+package extractor
+
+import (
+	"bytes"
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+	"golang.org/x/net/html"
+)
+
+// NameGeneric is the name of the generic <head> parser
+const NameGeneric = "generic"
+
+// Generic builds metadata from the standard <title>, <meta> and og:* tags of the page head.
+type Generic struct{}
+
+// Name implements MetadataExtractor
+func (Generic) Name() string { return NameGeneric }
+
+// Extract implements MetadataExtractor
+func (Generic) Extract(_ context.Context, p Page) (model.UrlMetadata, error) {
+	return buildMetadata(parseHeadMetadata(p.Body), p.URL), nil
+}
+
+// headMeta is internal model storing extracted <head> metadata
+type headMeta struct {
+	Title       string
+	Description string
+	OgTitle     string
+	OgDesc      string
+	OgImage     string
+	Favicon     string
+	OEmbedURL   string
+}
+
+func parseHeadMetadata(body []byte) headMeta {
+	z := html.NewTokenizer(bytes.NewReader(body))
+	h := headMeta{}
+	var inTitle bool
+
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			break
+		}
+
+		tok := z.Token()
+		switch tt {
+
+		case html.StartTagToken, html.SelfClosingTagToken:
+			if tok.Data == "title" {
+				inTitle = true
+			}
+
+			if tok.Data == "meta" {
+				parseMetaTag(tok, &h)
+			}
+
+			if tok.Data == "link" {
+				parseLinkTag(tok, &h)
+			}
+
+		case html.TextToken:
+			// Capture <title>text</title>
+			if inTitle && h.Title == "" {
+				h.Title = strings.TrimSpace(tok.Data)
+			}
+
+		case html.EndTagToken:
+			if tok.Data == "title" {
+				inTitle = false
+			}
+			// Stop parsing once </head> is reached; body is irrelevant
+			if tok.Data == "head" {
+				return h
+			}
+		}
+	}
+
+	return h
+}
+
+func buildMetadata(h headMeta, base *url.URL) model.UrlMetadata {
+	return model.UrlMetadata{
+		FinalURL:    base.String(),
+		Title:       firstNonEmpty(h.OgTitle, h.Title),
+		Description: firstNonEmpty(h.OgDesc, h.Description),
+		Image:       resolveURL(h.OgImage, base),
+		Favicon:     resolveURL(h.Favicon, base),
+	}
+}
+
+// Extract <meta> tags such as:
+//
+//	<meta property="og:title" content="...">
+//	<meta name="description" content="...">
+func parseMetaTag(tok html.Token, h *headMeta) {
+	var name, prop, content string
+	for _, a := range tok.Attr {
+		switch strings.ToLower(a.Key) {
+		case "name":
+			name = strings.ToLower(a.Val)
+		case "property":
+			prop = strings.ToLower(a.Val)
+		case "content":
+			content = a.Val
+		}
+	}
+
+	if prop == "og:title" {
+		h.OgTitle = content
+	}
+	if prop == "og:description" {
+		h.OgDesc = content
+	}
+	if prop == "og:image" {
+		h.OgImage = content
+	}
+	if name == "description" {
+		h.Description = content
+	}
+}
+
+// Extract favicon and oEmbed discovery links from:
+//
+//	<link rel="icon" href="...">
+//	<link rel="shortcut icon" href="...">
+//	<link rel="alternate" type="application/json+oembed" href="...">
+func parseLinkTag(tok html.Token, h *headMeta) {
+	var rel, typ, href string
+	for _, a := range tok.Attr {
+		switch strings.ToLower(a.Key) {
+		case "rel":
+			rel = a.Val
+		case "type":
+			typ = strings.ToLower(a.Val)
+		case "href":
+			href = a.Val
+		}
+	}
+
+	if rel == "icon" || rel == "shortcut icon" {
+		h.Favicon = href
+	}
+	if rel == "alternate" && typ == "application/json+oembed" {
+		h.OEmbedURL = href
+	}
+}
+
+// Utility selecting the first non-empty string
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if strings.TrimSpace(v) != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// Resolve relative URLs against base:
+//
+//	"/image.jpg" → "https://example.com/image.jpg"
+func resolveURL(resource string, base *url.URL) string {
+	if resource == "" {
+		return ""
+	}
+
+	u, err := url.Parse(resource)
+	if err != nil {
+		return resource
+	}
+
+	if u.IsAbs() {
+		return u.String()
+	}
+
+	return base.ResolveReference(u).String()
+}
@@ -0,0 +1,299 @@
+package extractor
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFirstNonEmpty(t *testing.T) {
+	tcs := map[string]struct {
+		values []string
+		want   string
+	}{
+		"first value is non-empty": {
+			values: []string{"first", "second", "third"},
+			want:   "first",
+		},
+		"first value is empty, second is not": {
+			values: []string{"", "second", "third"},
+			want:   "second",
+		},
+		"all empty": {
+			values: []string{"", "", ""},
+			want:   "",
+		},
+		"only whitespace values": {
+			values: []string{"  ", "\t", "\n"},
+			want:   "",
+		},
+		"mixed empty and whitespace": {
+			values: []string{"", "  ", "valid"},
+			want:   "valid",
+		},
+		"no values": {
+			values: []string{},
+			want:   "",
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			actual := firstNonEmpty(tc.values...)
+			require.Equal(t, tc.want, actual)
+		})
+	}
+}
+
+func TestResolveURL(t *testing.T) {
+	tcs := map[string]struct {
+		resource string
+		baseURL  string
+		want     string
+	}{
+		"absolute URL": {
+			resource: "https://cdn.example.com/image.jpg",
+			baseURL:  "https://example.com",
+			want:     "https://cdn.example.com/image.jpg",
+		},
+		"relative path": {
+			resource: "/image.jpg",
+			baseURL:  "https://example.com",
+			want:     "https://example.com/image.jpg",
+		},
+		"relative path with subdirectory": {
+			resource: "/assets/image.jpg",
+			baseURL:  "https://example.com/page",
+			want:     "https://example.com/assets/image.jpg",
+		},
+		"relative without leading slash": {
+			resource: "image.jpg",
+			baseURL:  "https://example.com/page/",
+			want:     "https://example.com/page/image.jpg",
+		},
+		"empty resource": {
+			resource: "",
+			baseURL:  "https://example.com",
+			want:     "",
+		},
+		"protocol-relative URL": {
+			resource: "//cdn.example.com/image.jpg",
+			baseURL:  "https://example.com",
+			want:     "https://cdn.example.com/image.jpg",
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			// Parse base URL
+			base, err := parseURL(tc.baseURL)
+			require.NoError(t, err)
+
+			actual := resolveURL(tc.resource, base)
+			require.Equal(t, tc.want, actual)
+		})
+	}
+}
+
+// Helper function for tests
+func parseURL(rawURL string) (*url.URL, error) {
+	return url.Parse(rawURL)
+}
+
+func TestParseHeadMetadata(t *testing.T) {
+	tcs := map[string]struct {
+		htmlBody string
+		want     headMeta
+	}{
+		"complete metadata": {
+			htmlBody: `
+<!DOCTYPE html>
+<html>
+<head>
+	<title>Example Title</title>
+	<meta name="description" content="Example description">
+	<meta property="og:title" content="OG Title">
+	<meta property="og:description" content="OG Description">
+	<meta property="og:image" content="https://example.com/image.jpg">
+	<link rel="icon" href="/favicon.ico">
+</head>
+<body>Content</body>
+</html>`,
+			want: headMeta{
+				Title:       "Example Title",
+				Description: "Example description",
+				OgTitle:     "OG Title",
+				OgDesc:      "OG Description",
+				OgImage:     "https://example.com/image.jpg",
+				Favicon:     "/favicon.ico",
+			},
+		},
+		"minimal metadata": {
+			htmlBody: `
+<!DOCTYPE html>
+<html>
+<head>
+	<title>Simple Title</title>
+</head>
+<body>Content</body>
+</html>`,
+			want: headMeta{
+				Title:       "Simple Title",
+				Description: "",
+				OgTitle:     "",
+				OgDesc:      "",
+				OgImage:     "",
+				Favicon:     "",
+			},
+		},
+		"og tags only": {
+			htmlBody: `
+<!DOCTYPE html>
+<html>
+<head>
+	<meta property="og:title" content="Only OG">
+	<meta property="og:description" content="Only OG Desc">
+</head>
+<body>Content</body>
+</html>`,
+			want: headMeta{
+				Title:       "",
+				Description: "",
+				OgTitle:     "Only OG",
+				OgDesc:      "Only OG Desc",
+				OgImage:     "",
+				Favicon:     "",
+			},
+		},
+		"shortcut icon": {
+			htmlBody: `
+<!DOCTYPE html>
+<html>
+<head>
+	<link rel="shortcut icon" href="/favicon.png">
+</head>
+<body>Content</body>
+</html>`,
+			want: headMeta{
+				Title:       "",
+				Description: "",
+				OgTitle:     "",
+				OgDesc:      "",
+				OgImage:     "",
+				Favicon:     "/favicon.png",
+			},
+		},
+		"oembed discovery link": {
+			htmlBody: `
+<!DOCTYPE html>
+<html>
+<head>
+	<link rel="alternate" type="application/json+oembed" href="https://example.com/oembed?url=x">
+	<link rel="alternate" type="text/xml+oembed" href="https://example.com/oembed?url=x&format=xml">
+</head>
+<body>Content</body>
+</html>`,
+			want: headMeta{
+				OEmbedURL: "https://example.com/oembed?url=x",
+			},
+		},
+		"empty html": {
+			htmlBody: ``,
+			want: headMeta{
+				Title:       "",
+				Description: "",
+				OgTitle:     "",
+				OgDesc:      "",
+				OgImage:     "",
+				Favicon:     "",
+			},
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			actual := parseHeadMetadata([]byte(tc.htmlBody))
+			require.True(t,
+				cmp.Equal(tc.want, actual),
+				"diff: %v",
+				cmp.Diff(tc.want, actual),
+			)
+		})
+	}
+}
+
+func TestBuildMetadata(t *testing.T) {
+	tcs := map[string]struct {
+		head    headMeta
+		baseURL string
+		want    model.UrlMetadata
+	}{
+		"prefers og tags over regular tags": {
+			head: headMeta{
+				Title:       "Regular Title",
+				Description: "Regular Description",
+				OgTitle:     "OG Title",
+				OgDesc:      "OG Description",
+				OgImage:     "/image.jpg",
+				Favicon:     "/favicon.ico",
+			},
+			baseURL: "https://example.com",
+			want: model.UrlMetadata{
+				FinalURL:    "https://example.com",
+				Title:       "OG Title",
+				Description: "OG Description",
+				Image:       "https://example.com/image.jpg",
+				Favicon:     "https://example.com/favicon.ico",
+			},
+		},
+		"fallback to regular tags when og tags empty": {
+			head: headMeta{
+				Title:       "Regular Title",
+				Description: "Regular Description",
+				OgTitle:     "",
+				OgDesc:      "",
+				OgImage:     "",
+				Favicon:     "/favicon.ico",
+			},
+			baseURL: "https://example.com",
+			want: model.UrlMetadata{
+				FinalURL:    "https://example.com",
+				Title:       "Regular Title",
+				Description: "Regular Description",
+				Image:       "",
+				Favicon:     "https://example.com/favicon.ico",
+			},
+		},
+		"absolute URLs preserved": {
+			head: headMeta{
+				OgImage: "https://cdn.example.com/image.jpg",
+				Favicon: "https://cdn.example.com/favicon.ico",
+			},
+			baseURL: "https://example.com",
+			want: model.UrlMetadata{
+				FinalURL:    "https://example.com",
+				Title:       "",
+				Description: "",
+				Image:       "https://cdn.example.com/image.jpg",
+				Favicon:     "https://cdn.example.com/favicon.ico",
+			},
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			base, err := parseURL(tc.baseURL)
+			require.NoError(t, err)
+
+			actual := buildMetadata(tc.head, base)
+			require.True(t,
+				cmp.Equal(tc.want, actual),
+				"diff: %v",
+				cmp.Diff(tc.want, actual),
+			)
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package extractor
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+)
+
+// NameGitHub is the name of the GitHub extractor
+const NameGitHub = "github"
+
+// gitHubBoilerplate matches the text GitHub appends to (or uses as) the description of repositories:
+//
+//	"... - Contribute to owner/repo development by creating an account on GitHub."
+var gitHubBoilerplate = regexp.MustCompile(`(?:\s+-\s+)?Contribute to \S+ development by creating an account on GitHub\.$`)
+
+// GitHub cleans up the og:* markup of GitHub pages, which is prefixed and padded with site boilerplate.
+type GitHub struct{}
+
+// Name implements MetadataExtractor
+func (GitHub) Name() string { return NameGitHub }
+
+// Extract implements MetadataExtractor
+func (GitHub) Extract(_ context.Context, p Page) (model.UrlMetadata, error) {
+	h := parseHeadMetadata(p.Body)
+	md := buildMetadata(h, p.URL)
+
+	// "GitHub - owner/repo: description" → "owner/repo: description"
+	md.Title = strings.TrimPrefix(md.Title, "GitHub - ")
+	md.Description = strings.TrimSpace(gitHubBoilerplate.ReplaceAllString(md.Description, ""))
+	return md, nil
+}
@@ -0,0 +1,49 @@
+package extractor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitHub_Extract(t *testing.T) {
+	tcs := map[string]struct {
+		body string
+		want model.UrlMetadata
+	}{
+		"repository page": {
+			body: string(loadFixture(t, nil, "github_repo.html")),
+			want: model.UrlMetadata{
+				FinalURL:    "https://github.com/golang/go",
+				Title:       "golang/go: The Go programming language",
+				Description: "The Go programming language.",
+				Image:       "https://opengraph.githubassets.com/0b3f6a5e3f/golang/go",
+				Favicon:     "https://github.githubassets.com/favicons/favicon.svg",
+			},
+		},
+		"repository without description": {
+			body: `<head>
+<meta property="og:title" content="GitHub - octocat/empty">
+<meta property="og:description" content="Contribute to octocat/empty development by creating an account on GitHub.">
+</head>`,
+			want: model.UrlMetadata{
+				FinalURL: "https://github.com/golang/go",
+				Title:    "octocat/empty",
+			},
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			actual, err := GitHub{}.Extract(context.Background(), Page{
+				URL:  mustParseURL(t, "https://github.com/golang/go"),
+				Body: []byte(tc.body),
+			})
+			require.NoError(t, err)
+			require.True(t, cmp.Equal(tc.want, actual), "diff: %v", cmp.Diff(tc.want, actual))
+		})
+	}
+}
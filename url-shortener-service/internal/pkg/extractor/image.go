@@ -0,0 +1,38 @@
+package extractor
+
+import (
+	"bytes"
+	"context"
+	"image"
+	_ "image/gif"  // register GIF decoding
+	_ "image/jpeg" // register JPEG decoding
+	_ "image/png"  // register PNG decoding
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+)
+
+// NameImage is the name of the image extractor
+const NameImage = "image"
+
+// Image extracts the dimensions of an image. The image URL itself is used as preview image.
+type Image struct{}
+
+// Name implements MetadataExtractor
+func (Image) Name() string { return NameImage }
+
+// Extract implements MetadataExtractor
+func (Image) Extract(_ context.Context, p Page) (model.UrlMetadata, error) {
+	md := model.UrlMetadata{
+		FinalURL: p.URL.String(),
+		Title:    fileName(p.URL),
+		Image:    p.URL.String(),
+	}
+
+	// Dimensions are best-effort: formats without a registered decoder (webp, svg, ...) have none
+	if cfg, _, err := image.DecodeConfig(bytes.NewReader(p.Body)); err == nil {
+		md.Width = cfg.Width
+		md.Height = cfg.Height
+	}
+
+	return md, nil
+}
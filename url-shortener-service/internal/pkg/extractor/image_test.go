@@ -0,0 +1,53 @@
+package extractor
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/png"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImage_Extract(t *testing.T) {
+	var pngBody bytes.Buffer
+	require.NoError(t, png.Encode(&pngBody, image.NewRGBA(image.Rect(0, 0, 640, 480))))
+
+	tcs := map[string]struct {
+		url  string
+		body []byte
+		want model.UrlMetadata
+	}{
+		"png with dimensions": {
+			url:  "https://cdn.example.com/img/cat.png",
+			body: pngBody.Bytes(),
+			want: model.UrlMetadata{
+				FinalURL: "https://cdn.example.com/img/cat.png",
+				Title:    "cat.png",
+				Image:    "https://cdn.example.com/img/cat.png",
+				Width:    640,
+				Height:   480,
+			},
+		},
+		"undecodable format has no dimensions": {
+			url:  "https://cdn.example.com/logo.svg",
+			body: []byte(`<svg xmlns="http://www.w3.org/2000/svg"></svg>`),
+			want: model.UrlMetadata{
+				FinalURL: "https://cdn.example.com/logo.svg",
+				Title:    "logo.svg",
+				Image:    "https://cdn.example.com/logo.svg",
+			},
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			actual, err := Image{}.Extract(context.Background(), Page{URL: mustParseURL(t, tc.url), Body: tc.body})
+			require.NoError(t, err)
+			require.True(t, cmp.Equal(tc.want, actual), "diff: %v", cmp.Diff(tc.want, actual))
+		})
+	}
+}
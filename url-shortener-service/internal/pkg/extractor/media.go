@@ -0,0 +1,140 @@
+package extractor
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+)
+
+// NameMedia is the name of the audio and video extractor
+const NameMedia = "media"
+
+// Media extracts the duration of audio and video files when it is cheaply available from the fetched bytes:
+// MP4/QuickTime files with the movie header first, and WAV files.
+type Media struct{}
+
+// Name implements MetadataExtractor
+func (Media) Name() string { return NameMedia }
+
+// Extract implements MetadataExtractor
+func (Media) Extract(_ context.Context, p Page) (model.UrlMetadata, error) {
+	return model.UrlMetadata{
+		FinalURL: p.URL.String(),
+		Title:    fileName(p.URL),
+		Duration: mediaDuration(p.Body),
+	}, nil
+}
+
+// mediaDuration sniffs the container format from its magic bytes and returns the duration in seconds, 0 if unknown
+func mediaDuration(b []byte) float64 {
+	switch {
+	case len(b) >= 12 && string(b[4:8]) == "ftyp":
+		return mp4Duration(b)
+	case len(b) >= 12 && string(b[0:4]) == "RIFF" && string(b[8:12]) == "WAVE":
+		return wavDuration(b)
+	default:
+		return 0
+	}
+}
+
+// mp4Duration reads the duration from the moov/mvhd box. It is only found when the file is
+// optimized for streaming, i.e. moov comes before the media data.
+func mp4Duration(b []byte) float64 {
+	moov := findMP4Box(b, "moov")
+	if moov == nil {
+		return 0
+	}
+
+	mvhd := findMP4Box(moov, "mvhd")
+	if len(mvhd) < 4 {
+		return 0
+	}
+
+	var timescale uint32
+	var duration uint64
+	switch mvhd[0] { // version
+	case 0:
+		if len(mvhd) < 20 {
+			return 0
+		}
+		timescale = binary.BigEndian.Uint32(mvhd[12:16])
+		duration = uint64(binary.BigEndian.Uint32(mvhd[16:20]))
+	case 1:
+		if len(mvhd) < 32 {
+			return 0
+		}
+		timescale = binary.BigEndian.Uint32(mvhd[20:24])
+		duration = binary.BigEndian.Uint64(mvhd[24:32])
+	default:
+		return 0
+	}
+
+	if timescale == 0 {
+		return 0
+	}
+	return float64(duration) / float64(timescale)
+}
+
+// findMP4Box returns the payload of the first box of the given type among the boxes of b
+func findMP4Box(b []byte, boxType string) []byte {
+	for len(b) >= 8 {
+		size := uint64(binary.BigEndian.Uint32(b[0:4]))
+		header := uint64(8)
+		switch size {
+		case 0: // box extends to the end of the file
+			size = uint64(len(b))
+		case 1: // 64-bit size follows the type
+			if len(b) < 16 {
+				return nil
+			}
+			size = binary.BigEndian.Uint64(b[8:16])
+			header = 16
+		}
+		if size < header {
+			return nil
+		}
+
+		if string(b[4:8]) == boxType {
+			if size > uint64(len(b)) {
+				return b[header:] // truncated by the fetch limit
+			}
+			return b[header:size]
+		}
+
+		if size > uint64(len(b)) {
+			return nil
+		}
+		b = b[size:]
+	}
+	return nil
+}
+
+// wavDuration computes the duration from the fmt byte rate and the data chunk size
+func wavDuration(b []byte) float64 {
+	var byteRate, dataSize uint32
+	for b = b[12:]; len(b) >= 8; {
+		id := b[0:4]
+		size := binary.LittleEndian.Uint32(b[4:8])
+		payload := b[8:]
+
+		switch {
+		case bytes.Equal(id, []byte("fmt ")) && len(payload) >= 12:
+			byteRate = binary.LittleEndian.Uint32(payload[8:12])
+		case bytes.Equal(id, []byte("data")):
+			dataSize = size
+		}
+
+		if byteRate != 0 && dataSize != 0 {
+			return float64(dataSize) / float64(byteRate)
+		}
+
+		next := uint64(size) + uint64(size&1) // chunks are padded to an even size
+		if next > uint64(len(payload)) {
+			return 0
+		}
+		b = payload[next:]
+	}
+	return 0
+}
@@ -0,0 +1,94 @@
+package extractor
+
+import (
+	"context"
+	"encoding/binary"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMedia_Extract(t *testing.T) {
+	tcs := map[string]struct {
+		url  string
+		body []byte
+		want model.UrlMetadata
+	}{
+		"mp4 with movie header first": {
+			url: "https://cdn.example.com/clip.mp4",
+			body: concat(
+				mp4Box("ftyp", []byte("isom\x00\x00\x02\x00isomiso2")),
+				mp4Box("moov", mp4Box("mvhd", mvhdV0(1000, 90500))),
+				mp4Box("mdat", make([]byte, 64)),
+			),
+			want: model.UrlMetadata{FinalURL: "https://cdn.example.com/clip.mp4", Title: "clip.mp4", Duration: 90.5},
+		},
+		"mp4 with movie header after media data": {
+			url: "https://cdn.example.com/clip.mp4",
+			body: concat(
+				mp4Box("ftyp", []byte("isom\x00\x00\x02\x00isomiso2")),
+				mp4Box("mdat", make([]byte, 64)),
+			),
+			want: model.UrlMetadata{FinalURL: "https://cdn.example.com/clip.mp4", Title: "clip.mp4"},
+		},
+		"wav": {
+			url:  "https://cdn.example.com/sound.wav",
+			body: wav(16000, 48000),
+			want: model.UrlMetadata{FinalURL: "https://cdn.example.com/sound.wav", Title: "sound.wav", Duration: 3},
+		},
+		"unknown container": {
+			url:  "https://cdn.example.com/song.mp3",
+			body: []byte("ID3\x04\x00\x00\x00\x00\x00\x00"),
+			want: model.UrlMetadata{FinalURL: "https://cdn.example.com/song.mp3", Title: "song.mp3"},
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			actual, err := Media{}.Extract(context.Background(), Page{URL: mustParseURL(t, tc.url), Body: tc.body})
+			require.NoError(t, err)
+			require.True(t, cmp.Equal(tc.want, actual), "diff: %v", cmp.Diff(tc.want, actual))
+		})
+	}
+}
+
+func mp4Box(boxType string, payload []byte) []byte {
+	b := make([]byte, 8, 8+len(payload))
+	binary.BigEndian.PutUint32(b, uint32(8+len(payload)))
+	copy(b[4:], boxType)
+	return append(b, payload...)
+}
+
+func mvhdV0(timescale, duration uint32) []byte {
+	b := make([]byte, 100)
+	binary.BigEndian.PutUint32(b[12:], timescale)
+	binary.BigEndian.PutUint32(b[16:], duration)
+	return b
+}
+
+func wav(byteRate, dataSize uint32) []byte {
+	fmtChunk := make([]byte, 16)
+	binary.LittleEndian.PutUint16(fmtChunk[0:], 1) // PCM
+	binary.LittleEndian.PutUint16(fmtChunk[2:], 1) // mono
+	binary.LittleEndian.PutUint32(fmtChunk[4:], byteRate/2)
+	binary.LittleEndian.PutUint32(fmtChunk[8:], byteRate)
+
+	chunk := func(id string, size uint32, payload []byte) []byte {
+		b := make([]byte, 8, 8+len(payload))
+		copy(b, id)
+		binary.LittleEndian.PutUint32(b[4:], size)
+		return append(b, payload...)
+	}
+
+	return concat([]byte("RIFF\x00\x00\x00\x00WAVE"), chunk("fmt ", 16, fmtChunk), chunk("data", dataSize, make([]byte, 32)))
+}
+
+func concat(parts ...[]byte) []byte {
+	var b []byte
+	for _, p := range parts {
+		b = append(b, p...)
+	}
+	return b
+}
@@ -0,0 +1,60 @@
+package extractor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	pkgerrors "github.com/pkg/errors"
+)
+
+// oEmbedReadLimit caps the size of an oEmbed response body
+const oEmbedReadLimit = 64 * 1024
+
+// oEmbed is the subset of the oEmbed response (https://oembed.com) we care about.
+type oEmbed struct {
+	Type         string `json:"type"`
+	Title        string `json:"title"`
+	AuthorName   string `json:"author_name"`
+	AuthorURL    string `json:"author_url"`
+	ProviderName string `json:"provider_name"`
+	ThumbnailURL string `json:"thumbnail_url"`
+	HTML         string `json:"html"`
+}
+
+// oEmbedEndpoint builds the oEmbed request URL for pageURL on a provider endpoint.
+func oEmbedEndpoint(endpoint string, pageURL *url.URL) string {
+	q := url.Values{}
+	q.Set("url", pageURL.String())
+	q.Set("format", "json")
+	return endpoint + "?" + q.Encode()
+}
+
+// fetchOEmbed retrieves and decodes an oEmbed response.
+func fetchOEmbed(ctx context.Context, client *http.Client, endpoint string) (oEmbed, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return oEmbed{}, pkgerrors.WithStack(err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return oEmbed{}, pkgerrors.WithStack(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return oEmbed{}, fmt.Errorf("unexpected oEmbed status code %d from %s", resp.StatusCode, endpoint)
+	}
+
+	var o oEmbed
+	if err = json.NewDecoder(io.LimitReader(resp.Body, oEmbedReadLimit)).Decode(&o); err != nil {
+		return oEmbed{}, pkgerrors.WithStack(err)
+	}
+
+	return o, nil
+}
@@ -0,0 +1,177 @@
+package extractor
+
+import (
+	"bytes"
+	"context"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+)
+
+// NamePDF is the name of the PDF document extractor
+const NamePDF = "pdf"
+
+var (
+	// pdfInfoRef matches the reference to the document information dictionary in the trailer
+	pdfInfoRef = regexp.MustCompile(`/Info\s+(\d+)\s+(\d+)\s+R`)
+	// xmpTitle matches the dc:title of an uncompressed XMP metadata stream
+	xmpTitle = regexp.MustCompile(`(?s)<dc:title>.*?<rdf:li[^>]*>([^<]*)</rdf:li>`)
+)
+
+// PDF extracts the title of a PDF document from its information dictionary or XMP metadata.
+// Only the fetched part of the document is inspected, so the file name is used when none is found.
+type PDF struct{}
+
+// Name implements MetadataExtractor
+func (PDF) Name() string { return NamePDF }
+
+// Extract implements MetadataExtractor
+func (PDF) Extract(_ context.Context, p Page) (model.UrlMetadata, error) {
+	return model.UrlMetadata{
+		FinalURL: p.URL.String(),
+		Title:    firstNonEmpty(pdfInfoTitle(p.Body), pdfXMPTitle(p.Body), fileName(p.URL)),
+	}, nil
+}
+
+// pdfInfoTitle reads /Title from the information dictionary object referenced by the trailer.
+// Searching the whole body for /Title would also match outline (bookmark) entries.
+func pdfInfoTitle(body []byte) string {
+	m := pdfInfoRef.FindSubmatch(body)
+	if m == nil {
+		return ""
+	}
+
+	obj := regexp.MustCompile(`(?:^|\s)` + string(m[1]) + `\s+` + string(m[2]) + `\s+obj\b`).FindIndex(body)
+	if obj == nil {
+		return ""
+	}
+
+	dict := body[obj[1]:]
+	if end := bytes.Index(dict, []byte("endobj")); end >= 0 {
+		dict = dict[:end]
+	}
+
+	i := bytes.Index(dict, []byte("/Title"))
+	if i < 0 {
+		return ""
+	}
+
+	return strings.TrimSpace(decodePDFString(parsePDFString(bytes.TrimLeft(dict[i+len("/Title"):], " \t\r\n"))))
+}
+
+func pdfXMPTitle(body []byte) string {
+	m := xmpTitle.FindSubmatch(body)
+	if m == nil {
+		return ""
+	}
+	return strings.TrimSpace(html.UnescapeString(string(m[1])))
+}
+
+// parsePDFString returns the raw bytes of the literal "(...)" or hexadecimal "<...>" string starting b
+func parsePDFString(b []byte) []byte {
+	if len(b) == 0 {
+		return nil
+	}
+
+	switch b[0] {
+	case '(':
+		var out []byte
+		depth := 0
+		for i := 1; i < len(b); i++ {
+			c := b[i]
+			switch c {
+			case '\\':
+				i++
+				if i >= len(b) {
+					return out
+				}
+				switch e := b[i]; e {
+				case 'n':
+					out = append(out, '\n')
+				case 'r':
+					out = append(out, '\r')
+				case 't':
+					out = append(out, '\t')
+				case 'b':
+					out = append(out, '\b')
+				case 'f':
+					out = append(out, '\f')
+				case '\r', '\n':
+					// line continuation
+				default:
+					if e >= '0' && e <= '7' {
+						j := i
+						for j < len(b) && j < i+3 && b[j] >= '0' && b[j] <= '7' {
+							j++
+						}
+						v, _ := strconv.ParseUint(string(b[i:j]), 8, 8)
+						out = append(out, byte(v))
+						i = j - 1
+						continue
+					}
+					out = append(out, e)
+				}
+			case '(':
+				depth++
+				out = append(out, c)
+			case ')':
+				if depth == 0 {
+					return out
+				}
+				depth--
+				out = append(out, c)
+			default:
+				out = append(out, c)
+			}
+		}
+		return out
+	case '<':
+		end := bytes.IndexByte(b, '>')
+		if end < 0 {
+			return nil
+		}
+		hex := bytes.Map(func(r rune) rune {
+			if strings.ContainsRune(" \t\r\n", r) {
+				return -1
+			}
+			return r
+		}, b[1:end])
+		if len(hex)%2 == 1 {
+			hex = append(hex, '0')
+		}
+		out := make([]byte, 0, len(hex)/2)
+		for i := 0; i < len(hex); i += 2 {
+			v, err := strconv.ParseUint(string(hex[i:i+2]), 16, 8)
+			if err != nil {
+				return nil
+			}
+			out = append(out, byte(v))
+		}
+		return out
+	default:
+		return nil
+	}
+}
+
+// decodePDFString decodes a PDF text string: UTF-16BE when it starts with a byte order mark,
+// PDFDocEncoding (handled as Latin-1) otherwise
+func decodePDFString(b []byte) string {
+	if len(b) >= 2 && b[0] == 0xFE && b[1] == 0xFF {
+		b = b[2:]
+		u := make([]uint16, 0, len(b)/2)
+		for i := 0; i+1 < len(b); i += 2 {
+			u = append(u, uint16(b[i])<<8|uint16(b[i+1]))
+		}
+		return string(utf16.Decode(u))
+	}
+
+	r := make([]rune, len(b))
+	for i, c := range b {
+		r[i] = rune(c)
+	}
+	return string(r)
+}
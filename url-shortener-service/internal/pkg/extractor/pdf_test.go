@@ -0,0 +1,48 @@
+package extractor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPDF_Extract(t *testing.T) {
+	tcs := map[string]struct {
+		url  string
+		body []byte
+		want model.UrlMetadata
+	}{
+		"title from information dictionary, not outline": {
+			url:  "https://example.com/files/report.pdf",
+			body: loadFixture(t, nil, "report.pdf"),
+			want: model.UrlMetadata{FinalURL: "https://example.com/files/report.pdf", Title: "Annual Report (2025)"},
+		},
+		"utf-16 hexadecimal title": {
+			url:  "https://example.com/doc.pdf",
+			body: []byte("%PDF-1.7\n7 0 obj\n<< /Title <FEFF0048006900EA0075> >>\nendobj\ntrailer\n<< /Info 7 0 R >>\n"),
+			want: model.UrlMetadata{FinalURL: "https://example.com/doc.pdf", Title: "Hiêu"},
+		},
+		"title from xmp metadata": {
+			url: "https://example.com/doc.pdf",
+			body: []byte(`%PDF-1.7 <x:xmpmeta><rdf:RDF><rdf:Description><dc:title><rdf:Alt>` +
+				`<rdf:li xml:lang="x-default">Design &amp; Review</rdf:li></rdf:Alt></dc:title></rdf:Description></rdf:RDF></x:xmpmeta>`),
+			want: model.UrlMetadata{FinalURL: "https://example.com/doc.pdf", Title: "Design & Review"},
+		},
+		"fallback to file name": {
+			url:  "https://example.com/files/manual.pdf",
+			body: []byte("%PDF-1.7\n1 0 obj\n<< /Type /Catalog >>\nendobj\n"),
+			want: model.UrlMetadata{FinalURL: "https://example.com/files/manual.pdf", Title: "manual.pdf"},
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			actual, err := PDF{}.Extract(context.Background(), Page{URL: mustParseURL(t, tc.url), Body: tc.body})
+			require.NoError(t, err)
+			require.True(t, cmp.Equal(tc.want, actual), "diff: %v", cmp.Diff(tc.want, actual))
+		})
+	}
+}
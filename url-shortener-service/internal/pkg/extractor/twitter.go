@@ -0,0 +1,86 @@
+package extractor
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+	"golang.org/x/net/html"
+)
+
+const (
+	// NameTwitter is the name of the Twitter/X extractor
+	NameTwitter = "twitter"
+
+	twitterOEmbedEndpoint = "https://publish.twitter.com/oembed"
+)
+
+// Twitter uses the publish oEmbed API, since twitter.com / x.com pages are rendered client side
+// and carry no useful markup.
+type Twitter struct {
+	client   *http.Client
+	endpoint string
+}
+
+// NewTwitter creates a Twitter/X extractor using the given client for oEmbed requests.
+func NewTwitter(client *http.Client) Twitter {
+	return Twitter{client: client, endpoint: twitterOEmbedEndpoint}
+}
+
+// Name implements MetadataExtractor
+func (Twitter) Name() string { return NameTwitter }
+
+// Extract implements MetadataExtractor
+func (t Twitter) Extract(ctx context.Context, p Page) (model.UrlMetadata, error) {
+	h := parseHeadMetadata(p.Body)
+
+	endpoint := resolveURL(h.OEmbedURL, p.URL)
+	if endpoint == "" {
+		endpoint = oEmbedEndpoint(t.endpoint, p.URL)
+	}
+
+	o, err := fetchOEmbed(ctx, t.client, endpoint)
+	if err != nil {
+		return model.UrlMetadata{}, err
+	}
+
+	md := buildMetadata(h, p.URL)
+	if o.AuthorName != "" {
+		md.Title = o.AuthorName + " on X"
+	}
+	md.Description = firstNonEmpty(postText(o.HTML), md.Description)
+	return md, nil
+}
+
+// postText extracts the post text from the oEmbed blockquote, i.e. the content of its first <p>.
+func postText(embed string) string {
+	z := html.NewTokenizer(strings.NewReader(embed))
+	var (
+		inP bool
+		sb  strings.Builder
+	)
+
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			return strings.TrimSpace(sb.String())
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, _ := z.TagName()
+			switch {
+			case string(name) == "p":
+				inP = true
+			case string(name) == "br" && inP:
+				sb.WriteString("\n")
+			}
+		case html.EndTagToken:
+			if name, _ := z.TagName(); string(name) == "p" && inP {
+				return strings.TrimSpace(sb.String())
+			}
+		case html.TextToken:
+			if inP {
+				sb.Write(z.Text())
+			}
+		}
+	}
+}
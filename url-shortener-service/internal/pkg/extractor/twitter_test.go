@@ -0,0 +1,79 @@
+package extractor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTwitter_Extract(t *testing.T) {
+	tcs := map[string]struct {
+		routes  map[string]string
+		want    model.UrlMetadata
+		wantErr bool
+	}{
+		"success": {
+			routes: map[string]string{"/oembed": "twitter_oembed.json"},
+			want: model.UrlMetadata{
+				FinalURL:    "https://x.com/golang/status/1699842463386763684",
+				Title:       "Go on X",
+				Description: "Go 1.21.1 and Go 1.20.8 are released!\n\n🔐 Security: Includes security fixes for cmd/go and html/template.\n\n📢 Announcement: https://t.co/abcdefghij",
+				Favicon:     "https://abs.twimg.com/favicons/twitter.3.ico",
+			},
+		},
+		"oembed unavailable": {
+			routes:  map[string]string{},
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			srv := newFixtureServer(t, tc.routes)
+
+			x := NewTwitter(srv.Client())
+			x.endpoint = srv.URL + "/oembed"
+
+			actual, err := x.Extract(context.Background(), Page{
+				URL:  mustParseURL(t, "https://x.com/golang/status/1699842463386763684"),
+				Body: loadFixture(t, srv, "twitter_status.html"),
+			})
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.True(t, cmp.Equal(tc.want, actual), "diff: %v", cmp.Diff(tc.want, actual))
+		})
+	}
+}
+
+func TestPostText(t *testing.T) {
+	tcs := map[string]struct {
+		embed string
+		want  string
+	}{
+		"text with line breaks and links": {
+			embed: `<blockquote><p>Hello<br>world <a href="https://t.co/x">https://t.co/x</a></p>&mdash; Go</blockquote>`,
+			want:  "Hello\nworld https://t.co/x",
+		},
+		"no paragraph": {
+			embed: `<blockquote>&mdash; Go</blockquote>`,
+			want:  "",
+		},
+		"empty": {
+			embed: "",
+			want:  "",
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.want, postText(tc.embed))
+		})
+	}
+}
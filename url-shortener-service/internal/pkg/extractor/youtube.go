@@ -0,0 +1,54 @@
+package extractor
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+)
+
+const (
+	// NameYouTube is the name of the YouTube extractor
+	NameYouTube = "youtube"
+
+	youTubeOEmbedEndpoint = "https://www.youtube.com/oembed"
+)
+
+// YouTube uses oEmbed to get the real video title and thumbnail, which are missing or generic
+// in the HTML served to non-browser clients.
+type YouTube struct {
+	client   *http.Client
+	endpoint string
+}
+
+// NewYouTube creates a YouTube extractor using the given client for oEmbed requests.
+func NewYouTube(client *http.Client) YouTube {
+	return YouTube{client: client, endpoint: youTubeOEmbedEndpoint}
+}
+
+// Name implements MetadataExtractor
+func (YouTube) Name() string { return NameYouTube }
+
+// Extract implements MetadataExtractor
+func (y YouTube) Extract(ctx context.Context, p Page) (model.UrlMetadata, error) {
+	h := parseHeadMetadata(p.Body)
+
+	// Prefer the discovered endpoint, it already carries the canonical video URL
+	endpoint := resolveURL(h.OEmbedURL, p.URL)
+	if endpoint == "" {
+		endpoint = oEmbedEndpoint(y.endpoint, p.URL)
+	}
+
+	o, err := fetchOEmbed(ctx, y.client, endpoint)
+	if err != nil {
+		return model.UrlMetadata{}, err
+	}
+
+	md := buildMetadata(h, p.URL)
+	md.Title = firstNonEmpty(o.Title, md.Title)
+	md.Image = firstNonEmpty(o.ThumbnailURL, md.Image)
+	if md.Description == "" && o.AuthorName != "" {
+		md.Description = "Video by " + o.AuthorName
+	}
+	return md, nil
+}
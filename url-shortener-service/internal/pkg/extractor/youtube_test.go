@@ -0,0 +1,71 @@
+package extractor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestYouTube_Extract(t *testing.T) {
+	tcs := map[string]struct {
+		routes  map[string]string
+		page    string
+		want    model.UrlMetadata
+		wantErr bool
+	}{
+		"oembed discovered from page": {
+			routes: map[string]string{"/oembed": "youtube_oembed.json"},
+			page:   "youtube_watch.html",
+			want: model.UrlMetadata{
+				FinalURL:    "https://www.youtube.com/watch?v=dQw4w9WgXcQ",
+				Title:       "Rick Astley - Never Gonna Give You Up (Official Video) (4K Remaster)",
+				Description: "Enjoy the videos and music you love, upload original content, and share it all with friends, family, and the world on YouTube.",
+				Image:       "https://i.ytimg.com/vi/dQw4w9WgXcQ/hqdefault.jpg",
+				Favicon:     "https://www.youtube.com/s/desktop/2b5e8a44/img/logos/favicon_32x32.png",
+			},
+		},
+		"oembed endpoint used without discovery link": {
+			routes: map[string]string{"/oembed": "youtube_oembed.json"},
+			want: model.UrlMetadata{
+				FinalURL:    "https://www.youtube.com/watch?v=dQw4w9WgXcQ",
+				Title:       "Rick Astley - Never Gonna Give You Up (Official Video) (4K Remaster)",
+				Description: "Video by Rick Astley",
+				Image:       "https://i.ytimg.com/vi/dQw4w9WgXcQ/hqdefault.jpg",
+			},
+		},
+		"oembed unavailable": {
+			routes:  map[string]string{},
+			page:    "youtube_watch.html",
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			srv := newFixtureServer(t, tc.routes)
+
+			y := NewYouTube(srv.Client())
+			y.endpoint = srv.URL + "/oembed"
+
+			var body []byte
+			if tc.page != "" {
+				body = loadFixture(t, srv, tc.page)
+			}
+
+			actual, err := y.Extract(context.Background(), Page{
+				URL:  mustParseURL(t, "https://www.youtube.com/watch?v=dQw4w9WgXcQ"),
+				Body: body,
+			})
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.True(t, cmp.Equal(tc.want, actual), "diff: %v", cmp.Diff(tc.want, actual))
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package jsonschema
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// ErrIncompatible means a schema change breaks the readers or the data of the previous schema
+var ErrIncompatible = errors.New("incompatible schema change")
+
+// CompatibilityError lists the breaking changes between two schemas
+type CompatibilityError struct {
+	Changes []string // one per breaking change, prefixed by the JSON pointer of the property
+}
+
+// Error implements error
+func (e CompatibilityError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrIncompatible, strings.Join(e.Changes, "; "))
+}
+
+// Unwrap makes errors.Is(err, ErrIncompatible) hold
+func (e CompatibilityError) Unwrap() error {
+	return ErrIncompatible
+}
+
+// CheckCompatible returns a CompatibilityError when next cannot replace prev on a topic. Both directions must hold,
+// as consumers of prev read data written with next, and consumers of next read data written with prev which
+// is still in the topic:
+//   - the required properties stay the same
+//   - a property keeps its type, format and pattern
+//   - optional properties may be added or removed
+func CheckCompatible(prev, next *Schema) error {
+	var changes []string
+	checkCompatible("", prev, next, &changes)
+	if len(changes) > 0 {
+		return CompatibilityError{Changes: changes}
+	}
+	return nil
+}
+
+// checkCompatible appends the breaking changes found at path to changes
+func checkCompatible(path string, prev, next *Schema, changes *[]string) {
+	at := func(format string, args ...any) {
+		p := path
+		if p == "" {
+			p = "/"
+		}
+		*changes = append(*changes, p+": "+fmt.Sprintf(format, args...))
+	}
+
+	if prev.Type != next.Type {
+		at("type changed from %s to %s", prev.Type, next.Type)
+		return
+	}
+	if prev.Format != next.Format {
+		at("format changed from %q to %q", prev.Format, next.Format)
+	}
+	if prev.Pattern != next.Pattern {
+		at("pattern changed from %q to %q", prev.Pattern, next.Pattern)
+	}
+	if allowsAdditional(prev) && !allowsAdditional(next) {
+		at("unknown properties no longer allowed")
+	}
+
+	for _, name := range prev.Required {
+		if !slices.Contains(next.Required, name) {
+			at("property %q no longer required", name)
+		}
+	}
+	for _, name := range next.Required {
+		if !slices.Contains(prev.Required, name) {
+			at("property %q newly required", name)
+		}
+	}
+
+	names := make([]string, 0, len(prev.Properties))
+	for name := range prev.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if p, ok := next.Properties[name]; ok {
+			checkCompatible(path+"/"+name, prev.Properties[name], p, changes)
+		}
+	}
+
+	if prev.Items != nil && next.Items != nil {
+		checkCompatible(path+"/items", prev.Items, next.Items, changes)
+	}
+}
+
+// allowsAdditional reports whether an object schema accepts unknown properties, the JSON Schema default
+func allowsAdditional(s *Schema) bool {
+	return s.AdditionalProperties == nil || *s.AdditionalProperties
+}
@@ -0,0 +1,85 @@
+package jsonschema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckCompatible(t *testing.T) {
+	allowed, denied := true, false
+	prev := &Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"short_code": {Type: "string"},
+			"status":     {Type: "string", Pattern: integerPattern},
+			"note":       {Type: "string"},
+		},
+		Required:             []string{"short_code", "status"},
+		AdditionalProperties: &allowed,
+	}
+
+	tcs := map[string]struct {
+		next        func(s *Schema)
+		wantChanges []string
+	}{
+		"success - unchanged": {
+			next: func(s *Schema) {},
+		},
+		"success - optional property added": {
+			next: func(s *Schema) { s.Properties["reason"] = &Schema{Type: "string"} },
+		},
+		"success - optional property removed": {
+			next: func(s *Schema) { delete(s.Properties, "note") },
+		},
+		"fail - required property removed": {
+			next: func(s *Schema) {
+				delete(s.Properties, "status")
+				s.Required = []string{"short_code"}
+			},
+			wantChanges: []string{`/: property "status" no longer required`},
+		},
+		"fail - property newly required": {
+			next: func(s *Schema) {
+				s.Properties["reason"] = &Schema{Type: "string"}
+				s.Required = []string{"reason", "short_code", "status"}
+			},
+			wantChanges: []string{`/: property "reason" newly required`},
+		},
+		"fail - type changed": {
+			next:        func(s *Schema) { s.Properties["status"] = &Schema{Type: "integer"} },
+			wantChanges: []string{"/status: type changed from string to integer"},
+		},
+		"fail - pattern changed": {
+			next:        func(s *Schema) { s.Properties["status"] = &Schema{Type: "string"} },
+			wantChanges: []string{`/status: pattern changed from "^-?[0-9]+$" to ""`},
+		},
+		"fail - unknown properties denied": {
+			next:        func(s *Schema) { s.AdditionalProperties = &denied },
+			wantChanges: []string{"/: unknown properties no longer allowed"},
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			next := *prev
+			next.Properties = map[string]*Schema{}
+			for k, v := range prev.Properties {
+				next.Properties[k] = v
+			}
+			next.Required = append([]string(nil), prev.Required...)
+			tc.next(&next)
+
+			err := CheckCompatible(prev, &next)
+			if tc.wantChanges == nil {
+				require.NoError(t, err)
+				return
+			}
+
+			require.ErrorIs(t, err, ErrIncompatible)
+			var cerr CompatibilityError
+			require.ErrorAs(t, err, &cerr)
+			require.Equal(t, tc.wantChanges, cerr.Changes)
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Draft is the JSON Schema dialect of the generated schemas
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema is the subset of JSON Schema needed to describe event data: objects of scalar, array and object properties
+type Schema struct {
+	Schema               string             `json:"$schema,omitempty"`
+	ID                   string             `json:"$id,omitempty"`
+	Title                string             `json:"title,omitempty"`
+	Type                 string             `json:"type"`
+	Format               string             `json:"format,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *bool              `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+}
+
+// integerPattern is the pattern of integers encoded as JSON strings, with the ",string" tag option
+const integerPattern = `^-?[0-9]+$`
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// Generate returns the schema of the JSON encoding of t, a struct type. Fields follow encoding/json:
+// the json tag names them, fields without omitempty are required and unknown properties are allowed,
+// so that consumers keep accepting data which gained fields.
+func Generate(t reflect.Type) (*Schema, error) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("jsonschema: %s is not a struct", t)
+	}
+
+	s, err := generate(t, false)
+	if err != nil {
+		return nil, err
+	}
+	s.Schema = Draft
+
+	return s, nil
+}
+
+// generate returns the schema of t, encoded as a JSON string when quoted
+func generate(t reflect.Type, quoted bool) (*Schema, error) {
+	if t == timeType {
+		return &Schema{Type: "string", Format: "date-time"}, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return generate(t.Elem(), quoted)
+	case reflect.String:
+		return &Schema{Type: "string"}, nil
+	case reflect.Bool:
+		if quoted {
+			return &Schema{Type: "string", Pattern: "^(true|false)$"}, nil
+		}
+		return &Schema{Type: "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if quoted {
+			return &Schema{Type: "string", Pattern: integerPattern}, nil
+		}
+		return &Schema{Type: "integer"}, nil
+	case reflect.Float32, reflect.Float64:
+		if quoted {
+			return nil, fmt.Errorf("jsonschema: quoted %s is not supported", t)
+		}
+		return &Schema{Type: "number"}, nil
+	case reflect.Slice, reflect.Array:
+		items, err := generate(t.Elem(), false)
+		if err != nil {
+			return nil, err
+		}
+		return &Schema{Type: "array", Items: items}, nil
+	case reflect.Struct:
+		return generateObject(t)
+	default:
+		return nil, fmt.Errorf("jsonschema: %s is not supported", t)
+	}
+}
+
+// generateObject returns the schema of the exported fields of a struct
+func generateObject(t reflect.Type) (*Schema, error) {
+	allowed := true
+	s := &Schema{Type: "object", Properties: map[string]*Schema{}, AdditionalProperties: &allowed}
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+
+		name, opts, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" && opts == "" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+
+		prop, err := generate(f.Type, hasOption(opts, "string"))
+		if err != nil {
+			return nil, fmt.Errorf("%s.%s: %w", t.Name(), f.Name, err)
+		}
+		s.Properties[name] = prop
+
+		if !hasOption(opts, "omitempty") && !hasOption(opts, "omitzero") {
+			s.Required = append(s.Required, name)
+		}
+	}
+	sort.Strings(s.Required)
+
+	return s, nil
+}
+
+// hasOption reports whether the comma separated json tag options contain opt
+func hasOption(opts, opt string) bool {
+	for _, o := range strings.Split(opts, ",") {
+		if o == opt {
+			return true
+		}
+	}
+	return false
+}
+
+// Parse decodes a schema, as written by json.Marshal
+func Parse(b []byte) (*Schema, error) {
+	var s Schema
+	if err := json.Unmarshal(b, &s); err != nil {
+		return nil, fmt.Errorf("jsonschema: %w", err)
+	}
+	return &s, nil
+}
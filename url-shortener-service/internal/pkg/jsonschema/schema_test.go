@@ -0,0 +1,90 @@
+package jsonschema
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testEvent struct {
+	Name     string    `json:"name"`
+	Count    int       `json:"count,string"`
+	Score    float64   `json:"score,omitempty"`
+	Active   bool      `json:"active"`
+	At       time.Time `json:"at"`
+	Tags     []string  `json:"tags,omitempty"`
+	Nested   *testSub  `json:"nested,omitempty"`
+	Skipped  string    `json:"-"`
+	internal string
+}
+
+type testSub struct {
+	ID int64 `json:"id"`
+}
+
+func TestGenerate(t *testing.T) {
+	allowed := true
+
+	tcs := map[string]struct {
+		given   reflect.Type
+		want    *Schema
+		wantErr bool
+	}{
+		"success - struct": {
+			given: reflect.TypeFor[testEvent](),
+			want: &Schema{
+				Schema: Draft,
+				Type:   "object",
+				Properties: map[string]*Schema{
+					"name":   {Type: "string"},
+					"count":  {Type: "string", Pattern: integerPattern},
+					"score":  {Type: "number"},
+					"active": {Type: "boolean"},
+					"at":     {Type: "string", Format: "date-time"},
+					"tags":   {Type: "array", Items: &Schema{Type: "string"}},
+					"nested": {
+						Type:                 "object",
+						Properties:           map[string]*Schema{"id": {Type: "integer"}},
+						Required:             []string{"id"},
+						AdditionalProperties: &allowed,
+					},
+				},
+				Required:             []string{"active", "at", "count", "name"},
+				AdditionalProperties: &allowed,
+			},
+		},
+		"success - pointer to struct": {
+			given: reflect.TypeFor[*testSub](),
+			want: &Schema{
+				Schema:               Draft,
+				Type:                 "object",
+				Properties:           map[string]*Schema{"id": {Type: "integer"}},
+				Required:             []string{"id"},
+				AdditionalProperties: &allowed,
+			},
+		},
+		"fail - not a struct": {
+			given:   reflect.TypeFor[string](),
+			wantErr: true,
+		},
+		"fail - unsupported field": {
+			given:   reflect.TypeFor[struct{ M map[string]string }](),
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			actual, err := Generate(tc.given)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.want, actual)
+		})
+	}
+}
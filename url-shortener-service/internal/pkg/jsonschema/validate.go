@@ -0,0 +1,150 @@
+package jsonschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// ErrInvalid means data does not match its schema
+var ErrInvalid = errors.New("invalid data")
+
+// ValidationError lists where data does not match its schema
+type ValidationError struct {
+	Problems []string // one per mismatch, prefixed by the JSON pointer of the value
+}
+
+// Error implements error
+func (e ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrInvalid, strings.Join(e.Problems, "; "))
+}
+
+// Unwrap makes errors.Is(err, ErrInvalid) hold
+func (e ValidationError) Unwrap() error {
+	return ErrInvalid
+}
+
+// Validate checks that the JSON document b matches the schema, returning a ValidationError when it does not
+func (s *Schema) Validate(b []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return ValidationError{Problems: []string{"/: " + err.Error()}}
+	}
+
+	var problems []string
+	s.validate("", v, &problems)
+	if len(problems) > 0 {
+		return ValidationError{Problems: problems}
+	}
+
+	return nil
+}
+
+// validate appends the mismatches of v, found at path, to problems
+func (s *Schema) validate(path string, v any, problems *[]string) {
+	fail := func(format string, args ...any) {
+		p := path
+		if p == "" {
+			p = "/"
+		}
+		*problems = append(*problems, p+": "+fmt.Sprintf(format, args...))
+	}
+
+	switch s.Type {
+	case "object":
+		obj, ok := v.(map[string]any)
+		if !ok {
+			fail("expected object, got %s", typeOf(v))
+			return
+		}
+		for _, name := range s.Required {
+			if _, ok := obj[name]; !ok {
+				fail("missing required property %q", name)
+			}
+		}
+		for name, val := range obj {
+			prop, ok := s.Properties[name]
+			if !ok {
+				if s.AdditionalProperties != nil && !*s.AdditionalProperties {
+					fail("unknown property %q", name)
+				}
+				continue
+			}
+			prop.validate(path+"/"+name, val, problems)
+		}
+	case "array":
+		arr, ok := v.([]any)
+		if !ok {
+			fail("expected array, got %s", typeOf(v))
+			return
+		}
+		if s.Items != nil {
+			for i, item := range arr {
+				s.Items.validate(fmt.Sprintf("%s/%d", path, i), item, problems)
+			}
+		}
+	case "string":
+		str, ok := v.(string)
+		if !ok {
+			fail("expected string, got %s", typeOf(v))
+			return
+		}
+		if s.Pattern != "" {
+			re, err := regexp.Compile(s.Pattern)
+			if err != nil {
+				fail("invalid pattern %q: %v", s.Pattern, err)
+			} else if !re.MatchString(str) {
+				fail("%q does not match %q", str, s.Pattern)
+			}
+		}
+		if s.Format == "date-time" {
+			if _, err := time.Parse(time.RFC3339Nano, str); err != nil {
+				fail("%q is not a date-time", str)
+			}
+		}
+	case "integer":
+		n, ok := v.(json.Number)
+		if !ok {
+			fail("expected integer, got %s", typeOf(v))
+			return
+		}
+		if _, err := n.Int64(); err != nil {
+			fail("%s is not an integer", n)
+		}
+	case "number":
+		if _, ok := v.(json.Number); !ok {
+			fail("expected number, got %s", typeOf(v))
+		}
+	case "boolean":
+		if _, ok := v.(bool); !ok {
+			fail("expected boolean, got %s", typeOf(v))
+		}
+	}
+}
+
+// typeOf names the JSON type of a decoded value
+func typeOf(v any) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	case string:
+		return "string"
+	case json.Number:
+		return "number"
+	case bool:
+		return "boolean"
+	default:
+		return fmt.Sprintf("%T", v)
+	}
+}
@@ -0,0 +1,69 @@
+package jsonschema
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	s, err := Generate(reflect.TypeFor[testEvent]())
+	require.NoError(t, err)
+
+	tcs := map[string]struct {
+		given        string
+		wantProblems []string
+	}{
+		"success": {
+			given: `{"name":"a","count":"3","active":true,"at":"2025-01-02T03:04:05Z","tags":["x"],"nested":{"id":1}}`,
+		},
+		"success - unknown property allowed": {
+			given: `{"name":"a","count":"-3","active":false,"at":"2025-01-02T03:04:05.123+07:00","extra":1}`,
+		},
+		"fail - missing required": {
+			given:        `{"name":"a","active":true,"at":"2025-01-02T03:04:05Z"}`,
+			wantProblems: []string{`/: missing required property "count"`},
+		},
+		"fail - wrong types": {
+			given: `{"name":1,"count":3,"active":"yes","at":"2025-01-02T03:04:05Z","nested":{"id":1.5}}`,
+			wantProblems: []string{
+				"/active: expected boolean, got string",
+				"/count: expected string, got number",
+				"/name: expected string, got number",
+				"/nested/id: 1.5 is not an integer",
+			},
+		},
+		"fail - pattern and format": {
+			given: `{"name":"a","count":"three","active":true,"at":"yesterday","tags":["x",2]}`,
+			wantProblems: []string{
+				`/at: "yesterday" is not a date-time`,
+				`/count: "three" does not match "^-?[0-9]+$"`,
+				"/tags/1: expected string, got number",
+			},
+		},
+		"fail - not an object": {
+			given:        `["a"]`,
+			wantProblems: []string{"/: expected object, got array"},
+		},
+		"fail - malformed": {
+			given:        `{"name":`,
+			wantProblems: []string{"/: unexpected EOF"},
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			err := s.Validate([]byte(tc.given))
+			if tc.wantProblems == nil {
+				require.NoError(t, err)
+				return
+			}
+
+			require.ErrorIs(t, err, ErrInvalid)
+			var verr ValidationError
+			require.ErrorAs(t, err, &verr)
+			require.ElementsMatch(t, tc.wantProblems, verr.Problems)
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package policy
+
+import (
+	"errors"
+	"os"
+	"strconv"
+	"time"
+)
+
+// Config represents the destination policy configuration.
+type Config struct {
+	BlocklistFile    string        // Optional blocklist file, see FileSource
+	SafeBrowsingFile string        // Optional Safe Browsing threat list update, see SafeBrowsingFileSource
+	ReloadInterval   time.Duration // How often the rules are reloaded
+}
+
+// NewConfig creates a new destination policy configuration from environment variables.
+func NewConfig() Config {
+	cfg := Config{
+		BlocklistFile:    os.Getenv("POLICY_BLOCKLIST_FILE"),
+		SafeBrowsingFile: os.Getenv("POLICY_SAFE_BROWSING_FILE"),
+		ReloadInterval:   time.Minute,
+	}
+
+	if sec, err := strconv.Atoi(os.Getenv("POLICY_RELOAD_INTERVAL_SECONDS")); err == nil {
+		cfg.ReloadInterval = time.Duration(sec) * time.Second
+	}
+
+	return cfg
+}
+
+// Validate checks that the destination policy configuration is usable.
+func (c Config) Validate() error {
+	if c.ReloadInterval <= 0 {
+		return errors.New("[policy.Config] env variable 'POLICY_RELOAD_INTERVAL_SECONDS' must be positive")
+	}
+
+	return nil
+}
+
+// Sources returns the file sources enabled by the configuration.
+func (c Config) Sources() []Source {
+	var sources []Source
+	if c.BlocklistFile != "" {
+		sources = append(sources, FileSource(c.BlocklistFile))
+	}
+	if c.SafeBrowsingFile != "" {
+		sources = append(sources, SafeBrowsingFileSource(c.SafeBrowsingFile))
+	}
+	return sources
+}
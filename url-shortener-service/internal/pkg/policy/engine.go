@@ -0,0 +1,78 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/monitoring"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+)
+
+// Engine checks destinations against the block rules of its sources, which are reloaded without restart.
+// It is safe for concurrent use.
+type Engine struct {
+	sources []Source
+	rules   atomic.Pointer[Ruleset]
+}
+
+// NewEngine creates an Engine over the given sources, the well-known URL shorteners included.
+// Until the first Reload, only the shorteners are blocked.
+func NewEngine(sources ...Source) *Engine {
+	e := &Engine{sources: append([]Source{shortenerSource{}}, sources...)}
+
+	defaults, _ := shortenerSource{}.Load(context.Background())
+	rs, _ := Compile(defaults)
+	e.rules.Store(rs)
+
+	return e
+}
+
+// Reload loads every source and swaps the rules in at once. On error the current rules are kept,
+// so a broken source never lifts the blocklist.
+func (e *Engine) Reload(ctx context.Context) error {
+	var rules []model.BlockRule
+	for _, s := range e.sources {
+		rs, err := s.Load(ctx)
+		if err != nil {
+			return fmt.Errorf("load block rules: %w", err)
+		}
+		rules = append(rules, rs...)
+	}
+
+	rs, err := Compile(rules)
+	if err != nil {
+		return err
+	}
+
+	e.rules.Store(rs)
+	return nil
+}
+
+// Watch reloads the rules every interval until ctx is done.
+func (e *Engine) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := e.Reload(ctx); err != nil {
+				monitoring.Log(ctx).Error().Err(err).Msg("[policy.Engine.Watch] reload err, keeping current rules")
+			}
+		}
+	}
+}
+
+// Check returns a BlockedError when rawURL matches a block rule.
+func (e *Engine) Check(rawURL string) error {
+	return e.rules.Load().Check(rawURL)
+}
+
+// Fingerprint identifies the rules currently in use
+func (e *Engine) Fingerprint() string {
+	return e.rules.Load().Fingerprint()
+}
@@ -0,0 +1,53 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEngine(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "blocklist.txt")
+	require.NoError(t, os.WriteFile(path, []byte("# reported phishing\ndomain phishing.example\n\n"), 0o644))
+
+	var dbRules []model.BlockRule
+	var dbErr error
+	e := NewEngine(FileSource(path), SourceFunc(func(context.Context) ([]model.BlockRule, error) {
+		return dbRules, dbErr
+	}))
+
+	// Shorteners are blocked before the first reload
+	require.ErrorIs(t, e.Check("https://bit.ly/abc"), ErrBlocked)
+	require.NoError(t, e.Check("https://phishing.example/"))
+
+	require.NoError(t, e.Reload(ctx))
+	require.ErrorIs(t, e.Check("https://phishing.example/"), ErrBlocked)
+	require.ErrorIs(t, e.Check("https://bit.ly/abc"), ErrBlocked)
+	loaded := e.Fingerprint()
+
+	// Hot reload picks up file and database changes
+	require.NoError(t, os.WriteFile(path, []byte("regex ^https://evil\\.example/\n"), 0o644))
+	dbRules = []model.BlockRule{{Kind: model.BlockRuleKindShortener, Pattern: "sho.rt"}}
+	require.NoError(t, e.Reload(ctx))
+	require.NoError(t, e.Check("https://phishing.example/"))
+	require.ErrorIs(t, e.Check("https://evil.example/"), ErrBlocked)
+	require.ErrorIs(t, e.Check("https://sho.rt/x"), ErrBlocked)
+	require.NotEqual(t, loaded, e.Fingerprint())
+
+	// A failing source keeps the current rules
+	dbErr = errors.New("database down")
+	require.Error(t, e.Reload(ctx))
+	require.ErrorIs(t, e.Check("https://sho.rt/x"), ErrBlocked)
+
+	// So does an invalid file
+	dbErr = nil
+	require.NoError(t, os.WriteFile(path, []byte("ip 10.0.0.1\n"), 0o644))
+	require.Error(t, e.Reload(ctx))
+	require.ErrorIs(t, e.Check("https://evil.example/"), ErrBlocked)
+}
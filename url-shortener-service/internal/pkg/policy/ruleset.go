@@ -0,0 +1,181 @@
+package policy
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+)
+
+// ErrBlocked means the destination matches a block rule
+var ErrBlocked = errors.New("destination blocked")
+
+// BlockedError tells which rule blocked a destination
+type BlockedError struct {
+	Rule model.BlockRule
+}
+
+// Error implements error
+func (e BlockedError) Error() string {
+	return fmt.Sprintf("destination blocked by %s rule %q", e.Rule.Kind, e.Rule.Pattern)
+}
+
+// Unwrap makes errors.Is(err, ErrBlocked) hold
+func (e BlockedError) Unwrap() error {
+	return ErrBlocked
+}
+
+// Reason describes the matched rule, as recorded on flagged links
+func (e BlockedError) Reason() string {
+	if e.Rule.Reason != "" {
+		return fmt.Sprintf("%s %s: %s", e.Rule.Kind, e.Rule.Pattern, e.Rule.Reason)
+	}
+	return fmt.Sprintf("%s %s", e.Rule.Kind, e.Rule.Pattern)
+}
+
+// regexRule is a compiled REGEX rule
+type regexRule struct {
+	re   *regexp.Regexp
+	rule model.BlockRule
+}
+
+// Ruleset is an immutable, compiled set of block rules
+type Ruleset struct {
+	domains      map[string]model.BlockRule // DOMAIN and SHORTENER rules by domain
+	regexes      []regexRule
+	hashPrefixes map[int]map[string]model.BlockRule // HASH_PREFIX rules by prefix length, keyed by raw prefix bytes
+	fingerprint  string
+}
+
+// Compile validates and indexes rules. Rules of unknown kind, invalid regular expressions
+// and malformed hash prefixes are rejected, so that a broken blocklist never silently allows everything.
+func Compile(rules []model.BlockRule) (*Ruleset, error) {
+	rs := &Ruleset{
+		domains:      map[string]model.BlockRule{},
+		hashPrefixes: map[int]map[string]model.BlockRule{},
+	}
+
+	keys := make([]string, 0, len(rules))
+	for _, r := range rules {
+		switch r.Kind {
+		case model.BlockRuleKindDomain, model.BlockRuleKindShortener:
+			domain := normalizeHost(r.Pattern)
+			if domain == "" {
+				return nil, fmt.Errorf("empty %s rule", r.Kind)
+			}
+			rs.domains[domain] = r
+		case model.BlockRuleKindRegex:
+			re, err := regexp.Compile(r.Pattern)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s rule %q: %w", r.Kind, r.Pattern, err)
+			}
+			rs.regexes = append(rs.regexes, regexRule{re: re, rule: r})
+		case model.BlockRuleKindHashPrefix:
+			prefix, err := hex.DecodeString(r.Pattern)
+			if err != nil || len(prefix) < 4 || len(prefix) > sha256.Size {
+				return nil, fmt.Errorf("invalid %s rule %q", r.Kind, r.Pattern)
+			}
+			if rs.hashPrefixes[len(prefix)] == nil {
+				rs.hashPrefixes[len(prefix)] = map[string]model.BlockRule{}
+			}
+			rs.hashPrefixes[len(prefix)][string(prefix)] = r
+		default:
+			return nil, fmt.Errorf("unknown block rule kind %q", r.Kind)
+		}
+		keys = append(keys, r.Kind.String()+"\x00"+r.Pattern)
+	}
+
+	// Fingerprint identifies the content of the ruleset whatever the order the rules were loaded in
+	sort.Strings(keys)
+	sum := sha256.Sum256([]byte(strings.Join(keys, "\n")))
+	rs.fingerprint = hex.EncodeToString(sum[:])
+
+	return rs, nil
+}
+
+// Fingerprint changes whenever a rule is added or removed
+func (rs *Ruleset) Fingerprint() string {
+	return rs.fingerprint
+}
+
+// Check returns a BlockedError when rawURL matches a rule. URLs which cannot be parsed are left
+// to the URL validation.
+func (rs *Ruleset) Check(rawURL string) error {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil {
+		return nil
+	}
+
+	// example.com rules match www.example.com and login.example.com too
+	host := normalizeHost(u.Hostname())
+	for h := host; h != ""; h = parentDomain(h) {
+		if r, ok := rs.domains[h]; ok {
+			return BlockedError{Rule: r}
+		}
+	}
+
+	// Patterns are written against one spelling of the URL, so that casing, credentials or an explicit
+	// default port cannot sneak a destination past them
+	normalized := normalizeURL(u)
+	for _, r := range rs.regexes {
+		if r.re.MatchString(normalized) {
+			return BlockedError{Rule: r.rule}
+		}
+	}
+
+	if len(rs.hashPrefixes) > 0 {
+		for _, expr := range expressions(rawURL) {
+			sum := sha256.Sum256([]byte(expr))
+			for size, prefixes := range rs.hashPrefixes {
+				if r, ok := prefixes[string(sum[:size])]; ok {
+					return BlockedError{Rule: r}
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// normalizeURL spells u the way REGEX rules match it: lowercased host without trailing dot,
+// and neither userinfo nor the default port of the scheme
+func normalizeURL(u *url.URL) string {
+	n := *u
+	n.User = nil
+
+	host, port := normalizeHost(u.Hostname()), u.Port()
+	if (n.Scheme == "http" && port == "80") || (n.Scheme == "https" && port == "443") {
+		port = ""
+	}
+	switch {
+	case port != "":
+		n.Host = net.JoinHostPort(host, port)
+	case strings.Contains(host, ":"):
+		n.Host = "[" + host + "]"
+	default:
+		n.Host = host
+	}
+
+	return n.String()
+}
+
+// normalizeHost lowercases a host name and strips the trailing dot of fully qualified names
+func normalizeHost(host string) string {
+	return strings.Trim(strings.ToLower(strings.TrimSpace(host)), ".")
+}
+
+// parentDomain returns the domain one level up, "" past the top-level domain
+func parentDomain(host string) string {
+	_, parent, ok := strings.Cut(host, ".")
+	if !ok {
+		return ""
+	}
+	return parent
+}
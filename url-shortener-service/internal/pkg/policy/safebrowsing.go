@@ -0,0 +1,248 @@
+package policy
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+)
+
+// ParseSafeBrowsingUpdate reads the hash prefixes of a threat list update, as answered by the Safe Browsing
+// Update API (threatListUpdates:fetch) with raw, uncompressed additions, and returns them as HASH_PREFIX rules.
+func ParseSafeBrowsingUpdate(r io.Reader) ([]model.BlockRule, error) {
+	var update struct {
+		ListUpdateResponses []struct {
+			ThreatType string `json:"threatType"`
+			Additions  []struct {
+				RawHashes struct {
+					PrefixSize int    `json:"prefixSize"`
+					RawHashes  string `json:"rawHashes"`
+				} `json:"rawHashes"`
+			} `json:"additions"`
+		} `json:"listUpdateResponses"`
+	}
+	if err := json.NewDecoder(r).Decode(&update); err != nil {
+		return nil, err
+	}
+
+	var rules []model.BlockRule
+	for _, list := range update.ListUpdateResponses {
+		for _, addition := range list.Additions {
+			size := addition.RawHashes.PrefixSize
+			if size < 4 || size > 32 {
+				return nil, fmt.Errorf("invalid hash prefix size %d", size)
+			}
+
+			raw, err := base64.StdEncoding.DecodeString(addition.RawHashes.RawHashes)
+			if err != nil {
+				return nil, err
+			}
+			if len(raw)%size != 0 {
+				return nil, fmt.Errorf("raw hashes length %d is not a multiple of prefix size %d", len(raw), size)
+			}
+
+			// Raw hashes are the concatenation of the sorted prefixes
+			for i := 0; i < len(raw); i += size {
+				rules = append(rules, model.BlockRule{
+					Kind:    model.BlockRuleKindHashPrefix,
+					Pattern: hex.EncodeToString(raw[i : i+size]),
+					Reason:  list.ThreatType,
+				})
+			}
+		}
+	}
+
+	return rules, nil
+}
+
+// expressions returns the host suffix / path prefix expressions of a URL, canonicalized as Safe Browsing does,
+// whose SHA-256 hashes are looked up in the hash prefix list.
+func expressions(rawURL string) []string {
+	host, path, query := canonicalize(rawURL)
+	if host == "" {
+		return nil
+	}
+
+	// Exact host, then up to 4 hosts formed from the last 5 components, the top-level domain excluded
+	hosts := []string{host}
+	if net.ParseIP(host) == nil {
+		parts := strings.Split(host, ".")
+		start := max(len(parts)-5, 1)
+		for i := start; i < len(parts)-1; i++ {
+			hosts = append(hosts, strings.Join(parts[i:], "."))
+		}
+	}
+
+	// Exact path with and without query, then up to 4 prefixes from the root, ending with a slash
+	var paths []string
+	if query != "" {
+		paths = append(paths, path+"?"+query)
+	}
+	paths = append(paths, path)
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	prefix := "/"
+	for i, added := 0, 0; added < 4; i++ {
+		if prefix != path {
+			paths = append(paths, prefix)
+			added++
+		}
+		if i >= len(segments)-1 {
+			break
+		}
+		prefix += segments[i] + "/"
+	}
+
+	seen := map[string]bool{}
+	var exprs []string
+	for _, h := range hosts {
+		for _, p := range paths {
+			if e := h + p; !seen[e] {
+				seen[e] = true
+				exprs = append(exprs, e)
+			}
+		}
+	}
+
+	return exprs
+}
+
+// canonicalize applies the Safe Browsing URL canonicalization and returns the host, path and query
+func canonicalize(rawURL string) (string, string, string) {
+	s := strings.NewReplacer("\t", "", "\r", "", "\n", "").Replace(strings.TrimSpace(rawURL))
+	s, _, _ = strings.Cut(s, "#")
+
+	// Repeatedly unescape, as URLs may be escaped several times to dodge lookups
+	for i := 0; i < 10; i++ {
+		unescaped, err := url.PathUnescape(s)
+		if err != nil || unescaped == s {
+			break
+		}
+		s = unescaped
+	}
+
+	if _, rest, ok := strings.Cut(s, "://"); ok {
+		s = rest
+	}
+
+	authority, rest := s, ""
+	if i := strings.IndexAny(s, "/?"); i >= 0 {
+		authority, rest = s[:i], s[i:]
+	}
+	if i := strings.LastIndex(authority, "@"); i >= 0 {
+		authority = authority[i+1:]
+	}
+	if h, _, err := net.SplitHostPort(authority); err == nil {
+		authority = h
+	}
+
+	host := asciiLower(strings.Trim(authority, "."))
+	for strings.Contains(host, "..") {
+		host = strings.ReplaceAll(host, "..", ".")
+	}
+	if ip := parseIPv4(host); ip != "" {
+		host = ip
+	}
+
+	path, query, _ := strings.Cut(rest, "?")
+	path = cleanPath(path)
+
+	return escape(host), escape(path), escape(query)
+}
+
+// cleanPath resolves "/./" and "/../" and collapses runs of slashes, keeping a trailing slash
+func cleanPath(path string) string {
+	var out []string
+	segments := strings.Split(path, "/")
+	for _, seg := range segments {
+		switch seg {
+		case "", ".":
+		case "..":
+			if len(out) > 0 {
+				out = out[:len(out)-1]
+			}
+		default:
+			out = append(out, seg)
+		}
+	}
+
+	cleaned := "/" + strings.Join(out, "/")
+	if len(out) > 0 {
+		if last := segments[len(segments)-1]; last == "" || last == "." || last == ".." {
+			cleaned += "/"
+		}
+	}
+	return cleaned
+}
+
+// parseIPv4 normalizes the decimal, octal, hexadecimal and shortened forms of an IPv4 host, "" when host is not one
+func parseIPv4(host string) string {
+	parts := strings.Split(host, ".")
+	if len(parts) > 4 {
+		return ""
+	}
+
+	values := make([]uint64, len(parts))
+	for i, p := range parts {
+		// Decimal, 0-prefixed octal and 0x-prefixed hexadecimal parts, as browsers accept them
+		base, digits := 10, p
+		switch {
+		case strings.HasPrefix(p, "0x"), strings.HasPrefix(p, "0X"):
+			base, digits = 16, p[2:]
+		case len(p) > 1 && p[0] == '0':
+			base, digits = 8, p[1:]
+		}
+		v, err := strconv.ParseUint(digits, base, 32)
+		if err != nil {
+			return ""
+		}
+		values[i] = v
+	}
+
+	// The last part fills the remaining bytes: 3232235777 and 192.168.257 both mean 192.168.1.1
+	var ip uint64
+	for i, v := range values[:len(values)-1] {
+		if v > 255 {
+			return ""
+		}
+		ip |= v << (8 * (3 - i))
+	}
+	last := values[len(values)-1]
+	if last >= 1<<(8*(5-len(values))) {
+		return ""
+	}
+	ip |= last
+
+	return fmt.Sprintf("%d.%d.%d.%d", ip>>24, ip>>16&0xff, ip>>8&0xff, ip&0xff)
+}
+
+// asciiLower lowercases ASCII letters only, leaving other bytes untouched even when not valid UTF-8
+func asciiLower(s string) string {
+	b := []byte(s)
+	for i, c := range b {
+		if 'A' <= c && c <= 'Z' {
+			b[i] = c + 'a' - 'A'
+		}
+	}
+	return string(b)
+}
+
+// escape percent-escapes control characters, non-ASCII bytes, "#" and "%"
+func escape(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c <= 32 || c >= 127 || c == '#' || c == '%' {
+			fmt.Fprintf(&b, "%%%02X", c)
+			continue
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
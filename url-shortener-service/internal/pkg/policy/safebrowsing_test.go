@@ -0,0 +1,133 @@
+package policy
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanonicalize(t *testing.T) {
+	// Examples from the Safe Browsing "URLs and Hashing" documentation
+	tcs := map[string]struct {
+		input string
+		want  string
+	}{
+		"escaped percent":           {input: "http://host/%25%32%35", want: "host/%25"},
+		"doubly escaped percent":    {input: "http://host/%25%32%35%25%32%35", want: "host/%25%25"},
+		"dot dot segment":           {input: "http://www.google.com/blah/..", want: "www.google.com/"},
+		"decimal ip":                {input: "http://3279880203/blah", want: "195.127.0.11/blah"},
+		"uppercase host":            {input: "http://www.GOOgle.com/", want: "www.google.com/"},
+		"trailing dots":             {input: "http://www.google.com.../", want: "www.google.com/"},
+		"tab cr lf":                 {input: "http://www.google.com/foo\tbar\rbaz\n2", want: "www.google.com/foobarbaz2"},
+		"question marks in query":   {input: "http://www.google.com/q?r?s", want: "www.google.com/q?r?s"},
+		"fragments":                 {input: "http://evil.com/foo#bar#baz", want: "evil.com/foo"},
+		"port":                      {input: "http://www.gotaport.com:1234/", want: "www.gotaport.com/"},
+		"slashes in path not query": {input: "http://host.com//twoslashes?more//slashes", want: "host.com/twoslashes?more//slashes"},
+		"control and non-ascii":     {input: "http://\x01\x80.com/", want: "%01%80.com/"},
+		"no trailing slash":         {input: "http://notrailingslash.com", want: "notrailingslash.com/"},
+		"escaped hash":              {input: "http://host.com/ab%23cd", want: "host.com/ab%23cd"},
+		"escaped ip and path": {
+			input: "http://%31%36%38%2e%31%38%38%2e%39%39%2e%32%36/%2E%73%65%63%75%72%65/%77%77%77%2E%65%62%61%79%2E%63%6F%6D/",
+			want:  "168.188.99.26/.secure/www.ebay.com/",
+		},
+		"octal and hex ip": {input: "http://0300.0xa8.0.1/", want: "192.168.0.1/"},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			host, path, query := canonicalize(tc.input)
+			actual := host + path
+			if query != "" {
+				actual += "?" + query
+			}
+			require.Equal(t, tc.want, actual)
+		})
+	}
+}
+
+func TestExpressions(t *testing.T) {
+	tcs := map[string]struct {
+		input string
+		want  []string
+	}{
+		"host suffixes and path prefixes": {
+			input: "http://a.b.c/1/2.html?param=1",
+			want: []string{
+				"a.b.c/1/2.html?param=1", "a.b.c/1/2.html", "a.b.c/", "a.b.c/1/",
+				"b.c/1/2.html?param=1", "b.c/1/2.html", "b.c/", "b.c/1/",
+			},
+		},
+		"long host keeps last 5 components": {
+			input: "http://a.b.c.d.e.f.g/1.html",
+			want: []string{
+				"a.b.c.d.e.f.g/1.html", "a.b.c.d.e.f.g/",
+				"c.d.e.f.g/1.html", "c.d.e.f.g/",
+				"d.e.f.g/1.html", "d.e.f.g/",
+				"e.f.g/1.html", "e.f.g/",
+				"f.g/1.html", "f.g/",
+			},
+		},
+		"ip host has no suffixes": {
+			input: "http://1.2.3.4/1/",
+			want:  []string{"1.2.3.4/1/", "1.2.3.4/"},
+		},
+		"long path keeps 4 prefixes": {
+			input: "https://example.com/a/b/c/d/e",
+			want: []string{
+				"example.com/a/b/c/d/e", "example.com/", "example.com/a/", "example.com/a/b/", "example.com/a/b/c/",
+			},
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			require.ElementsMatch(t, tc.want, expressions(tc.input))
+		})
+	}
+}
+
+func TestParseSafeBrowsingUpdate(t *testing.T) {
+	sum := sha256.Sum256([]byte("malware.example/"))
+	other := sha256.Sum256([]byte("phishing.example/"))
+	raw := base64.StdEncoding.EncodeToString(append(sum[:4], other[:4]...))
+
+	tcs := map[string]struct {
+		input   string
+		want    []model.BlockRule
+		wantErr bool
+	}{
+		"success": {
+			input: `{"listUpdateResponses":[{"threatType":"MALWARE","additions":[{"compressionType":"RAW","rawHashes":{"prefixSize":4,"rawHashes":"` + raw + `"}}]}]}`,
+			want: []model.BlockRule{
+				{Kind: model.BlockRuleKindHashPrefix, Pattern: hex.EncodeToString(sum[:4]), Reason: "MALWARE"},
+				{Kind: model.BlockRuleKindHashPrefix, Pattern: hex.EncodeToString(other[:4]), Reason: "MALWARE"},
+			},
+		},
+		"fail - truncated raw hashes": {
+			input:   `{"listUpdateResponses":[{"additions":[{"rawHashes":{"prefixSize":4,"rawHashes":"AAAAAAA="}}]}]}`,
+			wantErr: true,
+		},
+		"fail - invalid prefix size": {
+			input:   `{"listUpdateResponses":[{"additions":[{"rawHashes":{"prefixSize":2,"rawHashes":"AAAA"}}]}]}`,
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			actual, err := ParseSafeBrowsingUpdate(strings.NewReader(tc.input))
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.want, actual)
+		})
+	}
+}
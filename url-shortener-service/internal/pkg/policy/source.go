@@ -0,0 +1,91 @@
+package policy
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+)
+
+// Source provides block rules. Sources are loaded again on every reload of the engine.
+type Source interface {
+	Load(ctx context.Context) ([]model.BlockRule, error)
+}
+
+// SourceFunc adapts a function, e.g. a repository method, to a Source
+type SourceFunc func(ctx context.Context) ([]model.BlockRule, error)
+
+// Load implements Source
+func (f SourceFunc) Load(ctx context.Context) ([]model.BlockRule, error) {
+	return f(ctx)
+}
+
+// FileSource loads a blocklist file with one "<kind> <pattern>" rule per line, e.g. "domain phishing.example"
+// or "regex ^https?://[^/]+/wp-login\.php". Empty lines and lines starting with "#" are ignored.
+type FileSource string
+
+// Load implements Source
+func (f FileSource) Load(_ context.Context) ([]model.BlockRule, error) {
+	file, err := os.Open(string(f))
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var rules []model.BlockRule
+	scanner := bufio.NewScanner(file)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		kind, pattern, _ := strings.Cut(line, " ")
+		rule := model.BlockRule{
+			Kind:    model.BlockRuleKind(strings.ToUpper(kind)),
+			Pattern: strings.TrimSpace(pattern),
+		}
+		if !rule.Kind.IsValid() || rule.Pattern == "" {
+			return nil, fmt.Errorf("%s:%d: invalid rule %q", f, n, line)
+		}
+		rules = append(rules, rule)
+	}
+
+	return rules, scanner.Err()
+}
+
+// SafeBrowsingFileSource loads the hash prefixes of a Safe Browsing threat list update saved as JSON
+type SafeBrowsingFileSource string
+
+// Load implements Source
+func (f SafeBrowsingFileSource) Load(_ context.Context) ([]model.BlockRule, error) {
+	file, err := os.Open(string(f))
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return ParseSafeBrowsingUpdate(file)
+}
+
+// defaultShorteners are well-known URL shorteners: shortening their links would chain redirects
+// and hide the final destination from the blocklist
+var defaultShorteners = []string{
+	"bit.ly", "bitly.com", "buff.ly", "cutt.ly", "goo.gl", "is.gd", "lnkd.in", "ow.ly", "rb.gy",
+	"rebrand.ly", "s.id", "shorturl.at", "t.co", "t.ly", "tiny.cc", "tinyurl.com", "v.gd",
+}
+
+// shortenerSource provides the default shortener rules
+type shortenerSource struct{}
+
+// Load implements Source
+func (shortenerSource) Load(_ context.Context) ([]model.BlockRule, error) {
+	rules := make([]model.BlockRule, 0, len(defaultShorteners))
+	for _, d := range defaultShorteners {
+		rules = append(rules, model.BlockRule{Kind: model.BlockRuleKindShortener, Pattern: d})
+	}
+	return rules, nil
+}
@@ -0,0 +1,127 @@
+package retry
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+)
+
+// Config represents the retry configuration of outbox events.
+type Config struct {
+	Default       Policy // Policy of topics without override
+	TopicPolicies string // Optional JSON overrides by topic, see Schedule
+}
+
+// topicPolicy is the JSON form of a topic override, unset fields keep the default
+type topicPolicy struct {
+	MaxRetry       *int     `json:"max_retry"`
+	InitialDelayMS *int     `json:"initial_delay_ms"`
+	MaxDelayMS     *int     `json:"max_delay_ms"`
+	Multiplier     *float64 `json:"multiplier"`
+	Jitter         *float64 `json:"jitter"`
+}
+
+// NewConfig creates a new retry configuration from environment variables.
+func NewConfig() Config {
+	cfg := Config{
+		Default: Policy{
+			MaxRetry:     5,
+			InitialDelay: time.Second,
+			MaxDelay:     5 * time.Minute,
+			Multiplier:   2,
+			Jitter:       0.2,
+		},
+		TopicPolicies: os.Getenv("RETRY_TOPIC_POLICIES"),
+	}
+
+	if n, err := strconv.Atoi(os.Getenv("MAX_RETRY")); err == nil {
+		cfg.Default.MaxRetry = n
+	}
+	if ms, err := strconv.Atoi(os.Getenv("RETRY_INITIAL_DELAY_MS")); err == nil {
+		cfg.Default.InitialDelay = time.Duration(ms) * time.Millisecond
+	}
+	if ms, err := strconv.Atoi(os.Getenv("RETRY_MAX_DELAY_MS")); err == nil {
+		cfg.Default.MaxDelay = time.Duration(ms) * time.Millisecond
+	}
+	if f, err := strconv.ParseFloat(os.Getenv("RETRY_MULTIPLIER"), 64); err == nil {
+		cfg.Default.Multiplier = f
+	}
+	if f, err := strconv.ParseFloat(os.Getenv("RETRY_JITTER"), 64); err == nil {
+		cfg.Default.Jitter = f
+	}
+
+	return cfg
+}
+
+// Validate checks that the retry configuration is usable.
+func (c Config) Validate() error {
+	if err := validatePolicy(c.Default); err != nil {
+		return fmt.Errorf("[retry.Config] env variable %w", err)
+	}
+
+	if _, err := c.Schedule(); err != nil {
+		return fmt.Errorf("[retry.Config] env variable 'RETRY_TOPIC_POLICIES' %w", err)
+	}
+
+	return nil
+}
+
+// Schedule returns the default policy along with the topic overrides.
+func (c Config) Schedule() (Schedule, error) {
+	s := Schedule{Default: c.Default, Topics: map[string]Policy{}}
+	if c.TopicPolicies == "" {
+		return s, nil
+	}
+
+	var overrides map[string]topicPolicy
+	if err := json.Unmarshal([]byte(c.TopicPolicies), &overrides); err != nil {
+		return Schedule{}, fmt.Errorf("must be a JSON object of policies by topic: %w", err)
+	}
+
+	for topic, o := range overrides {
+		p := c.Default
+		if o.MaxRetry != nil {
+			p.MaxRetry = *o.MaxRetry
+		}
+		if o.InitialDelayMS != nil {
+			p.InitialDelay = time.Duration(*o.InitialDelayMS) * time.Millisecond
+		}
+		if o.MaxDelayMS != nil {
+			p.MaxDelay = time.Duration(*o.MaxDelayMS) * time.Millisecond
+		}
+		if o.Multiplier != nil {
+			p.Multiplier = *o.Multiplier
+		}
+		if o.Jitter != nil {
+			p.Jitter = *o.Jitter
+		}
+
+		if err := validatePolicy(p); err != nil {
+			return Schedule{}, fmt.Errorf("topic %q: %w", topic, err)
+		}
+		s.Topics[topic] = p
+	}
+
+	return s, nil
+}
+
+// validatePolicy names the setting of an unusable policy
+func validatePolicy(p Policy) error {
+	switch {
+	case p.MaxRetry < 0:
+		return errors.New("'MAX_RETRY' must not be negative")
+	case p.InitialDelay <= 0:
+		return errors.New("'RETRY_INITIAL_DELAY_MS' must be positive")
+	case p.MaxDelay < p.InitialDelay:
+		return errors.New("'RETRY_MAX_DELAY_MS' must not be less than the initial delay")
+	case p.Multiplier < 1:
+		return errors.New("'RETRY_MULTIPLIER' must be at least 1")
+	case p.Jitter < 0 || p.Jitter > 1:
+		return errors.New("'RETRY_JITTER' must be between 0 and 1")
+	}
+
+	return nil
+}
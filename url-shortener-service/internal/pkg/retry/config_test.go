@@ -0,0 +1,66 @@
+package retry
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigSchedule(t *testing.T) {
+	def := Policy{
+		MaxRetry:     5,
+		InitialDelay: time.Second,
+		MaxDelay:     5 * time.Minute,
+		Multiplier:   2,
+		Jitter:       0.2,
+	}
+
+	tcs := map[string]struct {
+		topicPolicies string
+		want          map[string]Policy
+		wantErr       string
+	}{
+		"success - no override": {
+			want: map[string]Policy{},
+		},
+		"success - override keeps unset fields": {
+			topicPolicies: `{"urlshortener.link.broken.v1":{"max_retry":10,"max_delay_ms":3600000}}`,
+			want: map[string]Policy{
+				"urlshortener.link.broken.v1": {
+					MaxRetry:     10,
+					InitialDelay: time.Second,
+					MaxDelay:     time.Hour,
+					Multiplier:   2,
+					Jitter:       0.2,
+				},
+			},
+		},
+		"fail - malformed JSON": {
+			topicPolicies: `{"urlshortener.link.broken.v1":`,
+			wantErr:       "must be a JSON object",
+		},
+		"fail - invalid override": {
+			topicPolicies: `{"urlshortener.link.broken.v1":{"jitter":2}}`,
+			wantErr:       `topic "urlshortener.link.broken.v1": 'RETRY_JITTER' must be between 0 and 1`,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			cfg := Config{Default: def, TopicPolicies: tc.topicPolicies}
+
+			actual, err := cfg.Schedule()
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+				require.Error(t, cfg.Validate())
+				return
+			}
+
+			require.NoError(t, err)
+			require.NoError(t, cfg.Validate())
+			require.Equal(t, def, actual.Default)
+			require.Equal(t, tc.want, actual.Topics)
+		})
+	}
+}
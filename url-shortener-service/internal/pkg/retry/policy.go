@@ -0,0 +1,49 @@
+package retry
+
+import (
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+// Policy is an exponential backoff schedule with jitter
+type Policy struct {
+	MaxRetry     int           // Retries allowed after the first attempt
+	InitialDelay time.Duration // Delay before the first retry
+	MaxDelay     time.Duration // Upper bound of any delay
+	Multiplier   float64       // Growth of the delay from one retry to the next
+	Jitter       float64       // Fraction of the delay randomized either way, spreading retries of events which failed together
+}
+
+// Exhausted reports whether retry, counted from 1, is past the allowed retries
+func (p Policy) Exhausted(retry int) bool {
+	return retry > p.MaxRetry
+}
+
+// Delay returns how long to wait before retry, counted from 1
+func (p Policy) Delay(retry int) time.Duration {
+	return p.delay(retry, rand.Float64())
+}
+
+// delay computes the delay with r, uniform in [0, 1), picking the jitter
+func (p Policy) delay(retry int, r float64) time.Duration {
+	d := float64(p.InitialDelay) * math.Pow(p.Multiplier, float64(max(retry-1, 0)))
+	d = math.Min(d, float64(p.MaxDelay))
+	d *= 1 + p.Jitter*(2*r-1)
+
+	return time.Duration(math.Min(d, float64(p.MaxDelay)))
+}
+
+// Schedule picks the retry policy of each topic
+type Schedule struct {
+	Default Policy
+	Topics  map[string]Policy // Overrides by topic
+}
+
+// For returns the policy of topic
+func (s Schedule) For(topic string) Policy {
+	if p, ok := s.Topics[topic]; ok {
+		return p
+	}
+	return s.Default
+}
@@ -0,0 +1,84 @@
+package retry
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolicyDelay(t *testing.T) {
+	p := Policy{
+		MaxRetry:     5,
+		InitialDelay: time.Second,
+		MaxDelay:     time.Minute,
+		Multiplier:   2,
+		Jitter:       0.5,
+	}
+
+	tcs := map[string]struct {
+		retry int
+		r     float64
+		want  time.Duration
+	}{
+		"first retry, no jitter drawn": {
+			retry: 1,
+			r:     0.5,
+			want:  time.Second,
+		},
+		"third retry doubles twice": {
+			retry: 3,
+			r:     0.5,
+			want:  4 * time.Second,
+		},
+		"jitter lower bound": {
+			retry: 3,
+			r:     0,
+			want:  2 * time.Second,
+		},
+		"jitter upper bound": {
+			retry: 3,
+			r:     1,
+			want:  6 * time.Second,
+		},
+		"capped at max delay": {
+			retry: 20,
+			r:     0.5,
+			want:  time.Minute,
+		},
+		"capped at max delay whatever the jitter": {
+			retry: 20,
+			r:     1,
+			want:  time.Minute,
+		},
+		"jitter below max delay once capped": {
+			retry: 20,
+			r:     0,
+			want:  30 * time.Second,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.want, p.delay(tc.retry, tc.r))
+		})
+	}
+}
+
+func TestPolicyExhausted(t *testing.T) {
+	p := Policy{MaxRetry: 2}
+
+	require.False(t, p.Exhausted(1))
+	require.False(t, p.Exhausted(2))
+	require.True(t, p.Exhausted(3))
+}
+
+func TestScheduleFor(t *testing.T) {
+	s := Schedule{
+		Default: Policy{MaxRetry: 5},
+		Topics:  map[string]Policy{"urlshortener.link.broken.v1": {MaxRetry: 10}},
+	}
+
+	require.Equal(t, 10, s.For("urlshortener.link.broken.v1").MaxRetry)
+	require.Equal(t, 5, s.For("urlshortener.metadata.requested.v1").MaxRetry)
+}
@@ -0,0 +1,90 @@
+package thumbnail
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif" // register GIF decoding
+	"image/jpeg"
+	"image/png"
+
+	_ "golang.org/x/image/bmp" // register BMP decoding
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp" // register WebP decoding
+)
+
+// Size is the bounding box a thumbnail is fitted in
+type Size struct {
+	Width  int
+	Height int
+}
+
+var (
+	// SizePreview is the standard size of preview card images, with the 1.91:1 ratio of og:image
+	SizePreview = Size{Width: 600, Height: 315}
+	// SizeFavicon is the standard size of favicons
+	SizeFavicon = Size{Width: 64, Height: 64}
+)
+
+// maxSourcePixels rejects images whose decoding would allocate too much memory (decompression bombs)
+const maxSourcePixels = 40_000_000
+
+// ErrTooLarge means the source image dimensions exceed the decoding limit
+var ErrTooLarge = errors.New("image dimensions too large")
+
+// Thumbnail is an encoded thumbnail
+type Thumbnail struct {
+	Data        []byte
+	ContentType string
+	Width       int
+	Height      int
+}
+
+// Make decodes the source image and fits it in size, keeping its aspect ratio. Images are never upscaled.
+// Opaque images are encoded as JPEG, images with transparency as PNG.
+func Make(src []byte, size Size) (Thumbnail, error) {
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(src))
+	if err != nil {
+		return Thumbnail{}, err
+	}
+	if cfg.Width*cfg.Height > maxSourcePixels {
+		return Thumbnail{}, fmt.Errorf("%w: %dx%d", ErrTooLarge, cfg.Width, cfg.Height)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(src))
+	if err != nil {
+		return Thumbnail{}, err
+	}
+
+	w, h := fit(img.Bounds().Dx(), img.Bounds().Dy(), size)
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, img.Bounds(), draw.Src, nil)
+
+	var buf bytes.Buffer
+	contentType := "image/png"
+	if dst.Opaque() {
+		contentType = "image/jpeg"
+		err = jpeg.Encode(&buf, dst, &jpeg.Options{Quality: 85})
+	} else {
+		err = png.Encode(&buf, dst)
+	}
+	if err != nil {
+		return Thumbnail{}, err
+	}
+
+	return Thumbnail{Data: buf.Bytes(), ContentType: contentType, Width: w, Height: h}, nil
+}
+
+// fit returns the largest dimensions fitting in size with the w:h aspect ratio, never larger than w×h
+func fit(w, h int, size Size) (int, int) {
+	if w <= size.Width && h <= size.Height {
+		return w, h
+	}
+
+	// scale by the most constraining side
+	if w*size.Height > h*size.Width {
+		return size.Width, max(1, h*size.Width/w)
+	}
+	return max(1, w*size.Height/h), size.Height
+}
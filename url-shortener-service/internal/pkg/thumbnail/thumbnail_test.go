@@ -0,0 +1,95 @@
+package thumbnail
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMake(t *testing.T) {
+	tcs := map[string]struct {
+		src             []byte
+		size            Size
+		wantWidth       int
+		wantHeight      int
+		wantContentType string
+		wantErr         error
+	}{
+		"landscape photo fitted in preview size": {
+			src:             encodePNG(t, 1200, 800, true),
+			size:            SizePreview,
+			wantWidth:       472,
+			wantHeight:      315,
+			wantContentType: "image/jpeg",
+		},
+		"wide banner fitted in preview size": {
+			src:             encodePNG(t, 2400, 600, true),
+			size:            SizePreview,
+			wantWidth:       600,
+			wantHeight:      150,
+			wantContentType: "image/jpeg",
+		},
+		"transparent favicon kept as png": {
+			src:             encodePNG(t, 256, 256, false),
+			size:            SizeFavicon,
+			wantWidth:       64,
+			wantHeight:      64,
+			wantContentType: "image/png",
+		},
+		"small image is not upscaled": {
+			src:             encodePNG(t, 32, 16, true),
+			size:            SizeFavicon,
+			wantWidth:       32,
+			wantHeight:      16,
+			wantContentType: "image/jpeg",
+		},
+		"not an image": {
+			src:     []byte("<html></html>"),
+			size:    SizePreview,
+			wantErr: image.ErrFormat,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			actual, err := Make(tc.src, tc.size)
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.wantWidth, actual.Width)
+			require.Equal(t, tc.wantHeight, actual.Height)
+			require.Equal(t, tc.wantContentType, actual.ContentType)
+
+			cfg, _, err := image.DecodeConfig(bytes.NewReader(actual.Data))
+			require.NoError(t, err)
+			require.Equal(t, tc.wantWidth, cfg.Width)
+			require.Equal(t, tc.wantHeight, cfg.Height)
+		})
+	}
+}
+
+func encodePNG(t *testing.T, w, h int, opaque bool) []byte {
+	t.Helper()
+
+	img := image.NewNRGBA(image.Rect(0, 0, w, h))
+	c := color.NRGBA{R: 200, G: 80, B: 40, A: 255}
+	if !opaque {
+		c.A = 128
+	}
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, c)
+		}
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
@@ -0,0 +1,31 @@
+package validator
+
+import (
+	"errors"
+	"os"
+)
+
+// Config represents the URL validation configuration.
+type Config struct {
+	Mode Mode // How far URLs are validated at shorten time, syntax only by default
+}
+
+// NewConfig creates a new URL validation configuration from environment variables.
+func NewConfig() Config {
+	cfg := Config{Mode: ModeSyntax}
+
+	if mode := os.Getenv("URL_VALIDATION_MODE"); mode != "" {
+		cfg.Mode = Mode(mode)
+	}
+
+	return cfg
+}
+
+// Validate checks that the URL validation configuration is usable.
+func (c Config) Validate() error {
+	if !c.Mode.IsValid() {
+		return errors.New("[validator.Config] env variable 'URL_VALIDATION_MODE' must be one of syntax, dns or full")
+	}
+
+	return nil
+}
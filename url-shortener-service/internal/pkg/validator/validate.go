@@ -1,49 +1,190 @@
 package validator
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"net"
 	"net/http"
+	"net/netip"
 	"net/url"
 	"time"
 
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/safehttp"
 	pkgerrors "github.com/pkg/errors"
 )
 
-// ValidateURL validates that a URL is well-formed, accessible, and uses a supported scheme.
-// It performs multiple checks: URL parsing, scheme validation (http/https), DNS resolution, and HTTP HEAD request.
-func ValidateURL(rawURL string) error {
+var (
+	// ErrUnsupportedScheme means the URL scheme is neither http nor https
+	ErrUnsupportedScheme = errors.New("unsupported scheme")
+	// ErrMissingHost means the URL has no host
+	ErrMissingHost = errors.New("missing host")
+	// ErrBlockedHost means the host is, or resolves to, a private or otherwise non-public address
+	ErrBlockedHost = errors.New("host is not allowed")
+	// ErrUnresolvableHost means the host has no DNS record
+	ErrUnresolvableHost = errors.New("host cannot be resolved")
+	// ErrUnreachable means the destination did not answer or answered with an error status
+	ErrUnreachable = errors.New("destination unreachable")
+)
+
+// Mode controls how far a URL is validated before being shortened
+type Mode string
+
+const (
+	// ModeSyntax only checks the URL is a well-formed http(s) URL. It never leaves the process.
+	ModeSyntax Mode = "syntax"
+	// ModeDNS also checks the host resolves to public addresses
+	ModeDNS Mode = "dns"
+	// ModeFull also checks the destination answers with a success or redirect status
+	ModeFull Mode = "full"
+)
+
+// String converts to string value
+func (m Mode) String() string {
+	return string(m)
+}
+
+// IsValid checks if the validation mode is valid
+func (m Mode) IsValid() bool {
+	return m == ModeSyntax || m == ModeDNS || m == ModeFull
+}
+
+const reachabilityTimeout = 3 * time.Second
+
+// Validator validates URLs submitted for shortening
+type Validator struct {
+	mode       Mode
+	allowed    []netip.Prefix
+	resolver   *net.Resolver
+	httpClient *http.Client
+}
+
+// Option enables tweaking the validator
+type Option func(*Validator)
+
+// WithAllowedPrefixes lets URLs point to otherwise blocked ranges, e.g. loopback for test servers
+func WithAllowedPrefixes(prefixes ...netip.Prefix) Option {
+	return func(v *Validator) {
+		v.allowed = append(v.allowed, prefixes...)
+	}
+}
+
+// New returns a Validator for the given mode. Unknown modes fall back to ModeSyntax.
+func New(mode Mode, opts ...Option) Validator {
+	if !mode.IsValid() {
+		mode = ModeSyntax
+	}
+
+	v := Validator{mode: mode, resolver: net.DefaultResolver}
+	for _, opt := range opts {
+		opt(&v)
+	}
+
+	// Checks reachability without ever connecting to the internal network
+	v.httpClient = safehttp.NewClient(
+		safehttp.WithTimeout(reachabilityTimeout),
+		safehttp.WithAllowedPrefixes(v.allowed...),
+	)
+	// Prevent following redirects: a redirect is an answer
+	v.httpClient.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+
+	return v
+}
+
+// ValidateURL validates that a URL is well-formed and uses a supported scheme, then depending on the mode
+// that its host resolves to public addresses and that the destination answers.
+func (v Validator) ValidateURL(ctx context.Context, rawURL string) error {
 	u, err := url.Parse(rawURL)
 	if err != nil {
-		return err
+		return pkgerrors.WithStack(err)
 	}
 
 	if u.Scheme != "http" && u.Scheme != "https" {
-		return errors.New("unsupported scheme")
+		return pkgerrors.WithStack(ErrUnsupportedScheme)
 	}
 
-	if _, err = net.LookupHost(u.Hostname()); err != nil {
-		return pkgerrors.WithStack(err)
+	host := u.Hostname()
+	if host == "" {
+		return pkgerrors.WithStack(ErrMissingHost)
 	}
 
-	client := &http.Client{
-		Timeout: 3 * time.Second,
-		CheckRedirect: func(req *http.Request, via []*http.Request) error {
-			// Prevent following redirects
-			return http.ErrUseLastResponse
-		},
+	// IP literals are checked whatever the mode, it costs nothing
+	if ip, err := netip.ParseAddr(host); err == nil && v.isBlocked(ip) {
+		return pkgerrors.WithStack(ErrBlockedHost)
 	}
 
-	resp, err := client.Head(rawURL)
+	switch v.mode {
+	case ModeDNS:
+		return v.checkDNS(ctx, host)
+	case ModeFull:
+		if err = v.checkDNS(ctx, host); err != nil {
+			return err
+		}
+		return v.checkReachable(ctx, rawURL)
+	default: // the zero Validator checks the syntax only, like ModeSyntax
+		return nil
+	}
+}
+
+// checkDNS checks the host resolves, and only to public addresses
+func (v Validator) checkDNS(ctx context.Context, host string) error {
+	addrs, err := v.resolver.LookupNetIP(ctx, "ip", host)
 	if err != nil {
-		return pkgerrors.WithStack(err)
+		return pkgerrors.WithStack(fmt.Errorf("%w: %w", ErrUnresolvableHost, err))
 	}
 
-	resp.Body.Close()
+	for _, ip := range addrs {
+		if v.isBlocked(ip) {
+			return pkgerrors.WithStack(ErrBlockedHost)
+		}
+	}
+
+	return nil
+}
+
+// checkReachable checks the destination answers with a success or redirect status. Sites refusing HEAD
+// are asked again with GET.
+func (v Validator) checkReachable(ctx context.Context, rawURL string) error {
+	statusCode, err := v.request(ctx, http.MethodHead, rawURL)
+	if err == nil && (statusCode == http.StatusMethodNotAllowed || statusCode == http.StatusNotImplemented) {
+		statusCode, err = v.request(ctx, http.MethodGet, rawURL)
+	}
+	if err != nil {
+		return pkgerrors.WithStack(fmt.Errorf("%w: %w", ErrUnreachable, err))
+	}
 
-	if resp.StatusCode < 200 && resp.StatusCode >= 300 {
-		return errors.New("invalid path")
+	if statusCode < 200 || statusCode >= 400 {
+		return pkgerrors.WithStack(fmt.Errorf("%w: status code %d", ErrUnreachable, statusCode))
 	}
 
 	return nil
 }
+
+// request sends a body-less request and returns the status code, the body is never read
+func (v Validator) request(ctx context.Context, method, rawURL string) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	resp.Body.Close()
+
+	return resp.StatusCode, nil
+}
+
+// isBlocked reports whether ip belongs to a non-public range which is not explicitly allowed
+func (v Validator) isBlocked(ip netip.Addr) bool {
+	ip = ip.Unmap()
+	for _, p := range v.allowed {
+		if p.Contains(ip) {
+			return false
+		}
+	}
+	return safehttp.IsBlocked(ip)
+}
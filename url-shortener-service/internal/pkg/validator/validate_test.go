@@ -0,0 +1,105 @@
+package validator
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateURL(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/no-head":
+			if r.Method == http.MethodHead {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				return
+			}
+		case "/moved":
+			http.Redirect(w, r, "/", http.StatusMovedPermanently)
+			return
+		case "/missing":
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+
+	tcs := map[string]struct {
+		mode    Mode
+		allowed []netip.Prefix
+		url     string
+		wantErr error
+	}{
+		"success - syntax only never resolves": {
+			mode: ModeSyntax,
+			url:  "https://does-not-exist.invalid/path",
+		},
+		"success - unknown mode falls back to syntax": {
+			mode: Mode("strict"),
+			url:  "https://does-not-exist.invalid/path",
+		},
+		"success - full reachable": {
+			mode:    ModeFull,
+			allowed: []netip.Prefix{netip.MustParsePrefix("127.0.0.0/8")},
+			url:     srv.URL + "/",
+		},
+		"success - full redirect is an answer": {
+			mode:    ModeFull,
+			allowed: []netip.Prefix{netip.MustParsePrefix("127.0.0.0/8")},
+			url:     srv.URL + "/moved",
+		},
+		"success - full falls back to GET when HEAD is refused": {
+			mode:    ModeFull,
+			allowed: []netip.Prefix{netip.MustParsePrefix("127.0.0.0/8")},
+			url:     srv.URL + "/no-head",
+		},
+		"fail - not a url": {
+			mode:    ModeSyntax,
+			url:     "not-a-url",
+			wantErr: ErrUnsupportedScheme,
+		},
+		"fail - unsupported scheme": {
+			mode:    ModeSyntax,
+			url:     "ftp://example.com/file",
+			wantErr: ErrUnsupportedScheme,
+		},
+		"fail - missing host": {
+			mode:    ModeSyntax,
+			url:     "https:///path",
+			wantErr: ErrMissingHost,
+		},
+		"fail - private ip literal": {
+			mode:    ModeSyntax,
+			url:     "http://169.254.169.254/latest/meta-data",
+			wantErr: ErrBlockedHost,
+		},
+		"fail - host resolving to loopback": {
+			mode:    ModeDNS,
+			url:     "http://localhost:8080",
+			wantErr: ErrBlockedHost,
+		},
+		"fail - full error status": {
+			mode:    ModeFull,
+			allowed: []netip.Prefix{netip.MustParsePrefix("127.0.0.0/8")},
+			url:     srv.URL + "/missing",
+			wantErr: ErrUnreachable,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			err := New(tc.mode, WithAllowedPrefixes(tc.allowed...)).ValidateURL(context.Background(), tc.url)
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package blockeddestination
+
+import (
+	"context"
+
+	"github.com/aarondl/sqlboiler/v4/queries/qm"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/monitoring"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/repository/orm"
+	pkgerrors "github.com/pkg/errors"
+)
+
+// List returns every blocklist entry, oldest first.
+func (i impl) List(ctx context.Context) ([]model.BlockRule, error) {
+	var err error
+	ctx, span := monitoring.Start(ctx, "BlockedDestinationRepository.List")
+	defer monitoring.End(span, &err)
+
+	os, err := orm.BlockedDestinations(qm.OrderBy(orm.BlockedDestinationColumns.ID)).All(ctx, i.db)
+	if err != nil {
+		return nil, pkgerrors.WithStack(err)
+	}
+
+	rs := make([]model.BlockRule, 0, len(os))
+	for _, o := range os {
+		rs = append(rs, model.BlockRule{
+			Kind:    model.BlockRuleKind(o.Kind),
+			Pattern: o.Pattern,
+			Reason:  o.Reason.String,
+		})
+	}
+
+	return rs, nil
+}
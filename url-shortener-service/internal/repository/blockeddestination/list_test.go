@@ -0,0 +1,44 @@
+package blockeddestination
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/pkg/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestList(t *testing.T) {
+	tcs := map[string]struct {
+		fixture string
+		want    []model.BlockRule
+	}{
+		"success": {
+			fixture: "testdata/blocked_destinations.sql",
+			want: []model.BlockRule{
+				{Kind: model.BlockRuleKindDomain, Pattern: "phishing.example", Reason: "reported phishing"},
+				{Kind: model.BlockRuleKindRegex, Pattern: `^https?://[^/]+/wp-login\.php`},
+				{Kind: model.BlockRuleKindShortener, Pattern: "sho.rt"},
+			},
+		},
+		"success - empty blocklist": {
+			want: []model.BlockRule{},
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			testutil.WithTxDB(t, func(tx *sql.Tx) {
+				if tc.fixture != "" {
+					testutil.LoadSQLFile(t, tx, tc.fixture)
+				}
+
+				actual, err := New(tx).List(context.Background())
+				require.NoError(t, err)
+				require.Equal(t, tc.want, actual)
+			})
+		})
+	}
+}
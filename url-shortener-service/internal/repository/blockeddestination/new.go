@@ -0,0 +1,25 @@
+package blockeddestination
+
+import (
+	"context"
+
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+)
+
+// Repository defines the interface for destination blocklist data access operations.
+// It provides the specification of the functionality provided by this package.
+type Repository interface {
+	List(context.Context) ([]model.BlockRule, error)
+}
+
+// impl is the implementation of the repository
+type impl struct {
+	db boil.ContextExecutor
+}
+
+// New creates and returns a new Repository instance with the provided database.
+// It returns a new instance of the repository for accessing the destination blocklist.
+func New(db boil.ContextExecutor) Repository {
+	return &impl{db: db}
+}
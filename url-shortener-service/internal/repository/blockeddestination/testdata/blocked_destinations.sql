@@ -0,0 +1,4 @@
+INSERT INTO blocked_destinations (id, kind, pattern, reason)
+VALUES (1, 'DOMAIN', 'phishing.example', 'reported phishing'),
+       (2, 'REGEX', '^https?://[^/]+/wp-login\.php', NULL),
+       (3, 'SHORTENER', 'sho.rt', NULL);
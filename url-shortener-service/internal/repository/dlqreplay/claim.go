@@ -0,0 +1,38 @@
+package dlqreplay
+
+import (
+	"context"
+
+	"github.com/aarondl/sqlboiler/v4/queries"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/monitoring"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+	pkgerrors "github.com/pkg/errors"
+)
+
+// claimQuery records a replay unless its DLQ message was replayed already
+const claimQuery = `
+INSERT INTO dlq_replays (dlq_id, dlq_topic, topic, message_key, event_id, error, replay_count, replayed_by, reason)
+VALUES ($1, $2, $3, NULLIF($4, ''), NULLIF($5, ''), $6, $7, $8, NULLIF($9, ''))
+ON CONFLICT (dlq_id) DO NOTHING`
+
+// Claim records the replay of a DLQ message before it is published. It returns false when the message
+// was replayed already, by this run or another one, in which case it must not be published again.
+func (i impl) Claim(ctx context.Context, r model.DLQReplay) (bool, error) {
+	var err error
+	ctx, span := monitoring.Start(ctx, "DLQReplayRepository.Claim")
+	defer monitoring.End(span, &err)
+
+	rs, err := queries.Raw(claimQuery,
+		r.DLQID, r.DLQTopic, r.Topic, r.Key, r.EventID, r.Error, r.ReplayCount, r.ReplayedBy, r.Reason,
+	).ExecContext(ctx, i.db)
+	if err != nil {
+		return false, pkgerrors.WithStack(err)
+	}
+
+	n, err := rs.RowsAffected()
+	if err != nil {
+		return false, pkgerrors.WithStack(err)
+	}
+
+	return n == 1, nil
+}
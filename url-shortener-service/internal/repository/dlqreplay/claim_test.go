@@ -0,0 +1,59 @@
+package dlqreplay
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/pkg/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClaim(t *testing.T) {
+	tcs := map[string]struct {
+		dlqID       string
+		wantClaimed bool
+	}{
+		"success - never replayed": {
+			dlqID:       "2c3d4e5f6a7b8c9d",
+			wantClaimed: true,
+		},
+		"success - replayed already": {
+			dlqID: "0a1b2c3d4e5f6a7b",
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			testutil.WithTxDB(t, func(tx *sql.Tx) {
+				testutil.LoadSQLFile(t, tx, "testdata/dlq_replays.sql")
+
+				ctx := context.Background()
+				replay := model.DLQReplay{
+					DLQID:       tc.dlqID,
+					DLQTopic:    "urlshortener.metadata.requested.v1.dlq",
+					Topic:       "urlshortener.metadata.requested.v1",
+					Key:         "xyz789",
+					Error:       "crawl timeout",
+					ReplayCount: 1,
+					ReplayedBy:  "carol",
+				}
+
+				claimed, err := New(tx).Claim(ctx, replay)
+				require.NoError(t, err)
+				require.Equal(t, tc.wantClaimed, claimed)
+
+				rs, err := New(tx).ListByDLQIDs(ctx, []string{tc.dlqID})
+				require.NoError(t, err)
+				require.Len(t, rs, 1)
+				if tc.wantClaimed {
+					require.Equal(t, "carol", rs[0].ReplayedBy)
+					require.Equal(t, "xyz789", rs[0].Key)
+				} else {
+					require.Equal(t, "alice", rs[0].ReplayedBy)
+				}
+			})
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package dlqreplay
+
+import (
+	"context"
+	"time"
+
+	"github.com/aarondl/null/v8"
+	"github.com/aarondl/sqlboiler/v4/queries"
+	"github.com/aarondl/sqlboiler/v4/types"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/monitoring"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+	pkgerrors "github.com/pkg/errors"
+)
+
+// listByDLQIDsQuery selects the replay records of DLQ messages
+const listByDLQIDsQuery = `
+SELECT dlq_id, dlq_topic, topic, message_key, event_id, error, replay_count, replayed_by, reason, replayed_at
+FROM dlq_replays
+WHERE dlq_id = ANY($1)
+ORDER BY replayed_at, id`
+
+// ListByDLQIDs returns the replay records of the given DLQ messages, oldest first.
+// Messages never replayed have none.
+func (i impl) ListByDLQIDs(ctx context.Context, dlqIDs []string) ([]model.DLQReplay, error) {
+	var err error
+	ctx, span := monitoring.Start(ctx, "DLQReplayRepository.ListByDLQIDs")
+	defer monitoring.End(span, &err)
+
+	if len(dlqIDs) == 0 {
+		return nil, nil
+	}
+
+	var rows []struct {
+		DLQID       string      `boil:"dlq_id"`
+		DLQTopic    string      `boil:"dlq_topic"`
+		Topic       string      `boil:"topic"`
+		MessageKey  null.String `boil:"message_key"`
+		EventID     null.String `boil:"event_id"`
+		Error       string      `boil:"error"`
+		ReplayCount int         `boil:"replay_count"`
+		ReplayedBy  string      `boil:"replayed_by"`
+		Reason      null.String `boil:"reason"`
+		ReplayedAt  time.Time   `boil:"replayed_at"`
+	}
+	if err = queries.Raw(listByDLQIDsQuery, types.StringArray(dlqIDs)).Bind(ctx, i.db, &rows); err != nil {
+		return nil, pkgerrors.WithStack(err)
+	}
+
+	rs := make([]model.DLQReplay, 0, len(rows))
+	for _, r := range rows {
+		rs = append(rs, model.DLQReplay{
+			DLQID:       r.DLQID,
+			DLQTopic:    r.DLQTopic,
+			Topic:       r.Topic,
+			Key:         r.MessageKey.String,
+			EventID:     r.EventID.String,
+			Error:       r.Error,
+			ReplayCount: r.ReplayCount,
+			ReplayedBy:  r.ReplayedBy,
+			Reason:      r.Reason.String,
+			ReplayedAt:  r.ReplayedAt,
+		})
+	}
+
+	return rs, nil
+}
@@ -0,0 +1,66 @@
+package dlqreplay
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/pkg/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListByDLQIDs(t *testing.T) {
+	tcs := map[string]struct {
+		dlqIDs []string
+		want   []model.DLQReplay
+	}{
+		"success": {
+			dlqIDs: []string{"1b2c3d4e5f6a7b8c", "0a1b2c3d4e5f6a7b", "ffffffffffffffff"},
+			want: []model.DLQReplay{
+				{
+					DLQID:       "0a1b2c3d4e5f6a7b",
+					DLQTopic:    "urlshortener.metadata.requested.v1.dlq",
+					Topic:       "urlshortener.metadata.requested.v1",
+					Key:         "abc123",
+					EventID:     "42",
+					Error:       "crawl timeout",
+					ReplayCount: 1,
+					ReplayedBy:  "alice",
+					Reason:      "crawler fixed",
+					ReplayedAt:  time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+				},
+				{
+					DLQID:       "1b2c3d4e5f6a7b8c",
+					DLQTopic:    "urlshortener.metadata.requested.v1.dlq",
+					Topic:       "urlshortener.metadata.requested.v1",
+					Error:       "invalid payload",
+					ReplayCount: 2,
+					ReplayedBy:  "bob",
+					ReplayedAt:  time.Date(2025, 1, 3, 3, 4, 5, 0, time.UTC),
+				},
+			},
+		},
+		"success - never replayed": {
+			dlqIDs: []string{"ffffffffffffffff"},
+			want:   []model.DLQReplay{},
+		},
+		"success - no ID": {},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			testutil.WithTxDB(t, func(tx *sql.Tx) {
+				testutil.LoadSQLFile(t, tx, "testdata/dlq_replays.sql")
+
+				actual, err := New(tx).ListByDLQIDs(context.Background(), tc.dlqIDs)
+				require.NoError(t, err)
+				for i := range actual {
+					actual[i].ReplayedAt = actual[i].ReplayedAt.UTC()
+				}
+				require.Equal(t, tc.want, actual)
+			})
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package dlqreplay
+
+import (
+	"context"
+
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+)
+
+// Repository defines the interface for the DLQ replay audit data access operations.
+// It provides the specification of the functionality provided by this package.
+type Repository interface {
+	Claim(context.Context, model.DLQReplay) (bool, error)
+	ListByDLQIDs(context.Context, []string) ([]model.DLQReplay, error)
+	Release(context.Context, string) error
+}
+
+// impl is the implementation of the repository
+type impl struct {
+	db boil.ContextExecutor
+}
+
+// New creates and returns a new Repository instance with the provided database.
+// It returns a new instance of the repository for accessing the DLQ replay audit.
+func New(db boil.ContextExecutor) Repository {
+	return &impl{db: db}
+}
@@ -0,0 +1,26 @@
+package dlqreplay
+
+import (
+	"context"
+
+	"github.com/aarondl/sqlboiler/v4/queries"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/monitoring"
+	pkgerrors "github.com/pkg/errors"
+)
+
+// releaseQuery deletes the replay record of a DLQ message
+const releaseQuery = `DELETE FROM dlq_replays WHERE dlq_id = $1`
+
+// Release deletes the replay record of a DLQ message whose publication failed after Claim,
+// so that a later run replays it.
+func (i impl) Release(ctx context.Context, dlqID string) error {
+	var err error
+	ctx, span := monitoring.Start(ctx, "DLQReplayRepository.Release")
+	defer monitoring.End(span, &err)
+
+	if _, err = queries.Raw(releaseQuery, dlqID).ExecContext(ctx, i.db); err != nil {
+		return pkgerrors.WithStack(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,39 @@
+package dlqreplay
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/pkg/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRelease(t *testing.T) {
+	tcs := map[string]struct {
+		dlqID string
+	}{
+		"success - replay record deleted": {
+			dlqID: "0a1b2c3d4e5f6a7b",
+		},
+		"success - unknown DLQ message": {
+			dlqID: "ffffffffffffffff",
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			testutil.WithTxDB(t, func(tx *sql.Tx) {
+				testutil.LoadSQLFile(t, tx, "testdata/dlq_replays.sql")
+
+				ctx := context.Background()
+				require.NoError(t, New(tx).Release(ctx, tc.dlqID))
+
+				rs, err := New(tx).ListByDLQIDs(ctx, []string{tc.dlqID, "1b2c3d4e5f6a7b8c"})
+				require.NoError(t, err)
+				require.Len(t, rs, 1)
+				require.Equal(t, "1b2c3d4e5f6a7b8c", rs[0].DLQID)
+			})
+		})
+	}
+}
@@ -0,0 +1,5 @@
+INSERT INTO dlq_replays (dlq_id, dlq_topic, topic, message_key, event_id, error, replay_count, replayed_by, reason, replayed_at)
+VALUES ('0a1b2c3d4e5f6a7b', 'urlshortener.metadata.requested.v1.dlq', 'urlshortener.metadata.requested.v1', 'abc123', '42',
+        'crawl timeout', 1, 'alice', 'crawler fixed', '2025-01-02 03:04:05+00'),
+       ('1b2c3d4e5f6a7b8c', 'urlshortener.metadata.requested.v1.dlq', 'urlshortener.metadata.requested.v1', NULL, NULL,
+        'invalid payload', 2, 'bob', NULL, '2025-01-03 03:04:05+00');
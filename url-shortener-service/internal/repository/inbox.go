@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+)
+
+// ErrEventProcessed means the event being handled was processed already by its consumer group, a concurrent
+// delivery having committed first. The transaction recording it is rolled back.
+var ErrEventProcessed = errors.New("event processed already")
+
+// inboxKey is the context key of the event being handled
+type inboxKey struct{}
+
+// inboxEvent is an event being handled by a consumer group
+type inboxEvent struct {
+	event    model.ProcessedEvent
+	recorded atomic.Bool
+}
+
+// WithInboxEvent returns a context handling an event for a consumer group. The first transaction run by DoInTx with
+// this context records the event as processed, along with the work of the handler, or fails with ErrEventProcessed.
+// Transactions whose outcome must not mark the event processed, as they commit before the work of the handler is
+// done, run with WithoutInboxEvent.
+func WithInboxEvent(ctx context.Context, e model.ProcessedEvent) context.Context {
+	return context.WithValue(ctx, inboxKey{}, &inboxEvent{event: e})
+}
+
+// WithoutInboxEvent returns a context whose transactions do not record the event being handled, if any
+func WithoutInboxEvent(ctx context.Context) context.Context {
+	return context.WithValue(ctx, inboxKey{}, (*inboxEvent)(nil))
+}
+
+// InboxEventRecorded tells whether a transaction recorded the event of the context as processed
+func InboxEventRecorded(ctx context.Context) bool {
+	e, _ := ctx.Value(inboxKey{}).(*inboxEvent)
+	return e != nil && e.recorded.Load()
+}
+
+// pendingInboxEvent returns the event of the context not recorded yet, nil when there is none
+func pendingInboxEvent(ctx context.Context) *inboxEvent {
+	e, _ := ctx.Value(inboxKey{}).(*inboxEvent)
+	if e == nil || e.recorded.Load() {
+		return nil
+	}
+	return e
+}
+
+// recordInboxEvent records the pending event of the context in the transaction of txRepo
+func recordInboxEvent(ctx context.Context, txRepo Registry, e *inboxEvent) error {
+	inserted, err := txRepo.ProcessedEvent().Insert(ctx, e.event)
+	if err != nil {
+		return err
+	}
+	if !inserted {
+		return ErrEventProcessed
+	}
+	return nil
+}
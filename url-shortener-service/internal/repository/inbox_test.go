@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/repository/processedevent"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordInboxEvent(t *testing.T) {
+	event := model.ProcessedEvent{ConsumerGroup: "metadata-requested", EventID: 123, Topic: model.TopicMetadataRequestedV1}
+
+	tcs := map[string]struct {
+		mockInserted  bool
+		mockInsertErr error
+		wantErr       error
+	}{
+		"success - event recorded": {
+			mockInserted: true,
+		},
+		"error - event processed already": {
+			wantErr: ErrEventProcessed,
+		},
+		"error - insert failed": {
+			mockInsertErr: errors.New("connection refused"),
+			wantErr:       errors.New("connection refused"),
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			// Given
+			ctx := WithInboxEvent(context.Background(), event)
+			inbox := pendingInboxEvent(ctx)
+			require.NotNil(t, inbox)
+
+			mockProcessed := processedevent.NewMockRepository(t)
+			mockProcessed.On("Insert", mock.Anything, event).Return(tc.mockInserted, tc.mockInsertErr)
+			mockReg := NewMockRegistry(t)
+			mockReg.On("ProcessedEvent").Return(mockProcessed)
+
+			// When
+			err := recordInboxEvent(ctx, mockReg, inbox)
+
+			// Then
+			if tc.wantErr != nil {
+				require.EqualError(t, err, tc.wantErr.Error())
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestInboxEventRecorded(t *testing.T) {
+	require.False(t, InboxEventRecorded(context.Background()))
+	require.Nil(t, pendingInboxEvent(context.Background()))
+
+	ctx := WithInboxEvent(context.Background(), model.ProcessedEvent{ConsumerGroup: "metadata-requested", EventID: 123})
+	require.False(t, InboxEventRecorded(ctx))
+
+	// What DoInTx does once the transaction recording the event committed
+	pendingInboxEvent(ctx).recorded.Store(true)
+
+	require.True(t, InboxEventRecorded(ctx))
+	require.Nil(t, pendingInboxEvent(ctx))
+}
+
+func TestWithoutInboxEvent(t *testing.T) {
+	require.Nil(t, pendingInboxEvent(WithoutInboxEvent(context.Background())))
+
+	// Given
+	ctx := WithInboxEvent(context.Background(), model.ProcessedEvent{ConsumerGroup: "metadata-requested", EventID: 123})
+
+	// When
+	sideCtx := WithoutInboxEvent(ctx)
+
+	// Then: the side transactions leave the event to the transaction of the work
+	require.Nil(t, pendingInboxEvent(sideCtx))
+	require.False(t, InboxEventRecorded(sideCtx))
+	require.NotNil(t, pendingInboxEvent(ctx))
+}
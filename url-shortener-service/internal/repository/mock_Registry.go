@@ -6,11 +6,14 @@ import (
 	context "context"
 
 	backoff "github.com/cenkalti/backoff/v4"
+	blockeddestination "github.com/kytruongdev/sturl/url-shortener-service/internal/repository/blockeddestination"
 
 	mock "github.com/stretchr/testify/mock"
 
 	outgoingevent "github.com/kytruongdev/sturl/url-shortener-service/internal/repository/outgoingevent"
 
+	processedevent "github.com/kytruongdev/sturl/url-shortener-service/internal/repository/processedevent"
+
 	shorturl "github.com/kytruongdev/sturl/url-shortener-service/internal/repository/shorturl"
 )
 
@@ -19,6 +22,26 @@ type MockRegistry struct {
 	mock.Mock
 }
 
+// BlockedDestination provides a mock function with no fields
+func (_m *MockRegistry) BlockedDestination() blockeddestination.Repository {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for BlockedDestination")
+	}
+
+	var r0 blockeddestination.Repository
+	if rf, ok := ret.Get(0).(func() blockeddestination.Repository); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(blockeddestination.Repository)
+		}
+	}
+
+	return r0
+}
+
 // DoInTx provides a mock function with given fields: ctx, backoffPolicy, fn
 func (_m *MockRegistry) DoInTx(ctx context.Context, backoffPolicy backoff.BackOff, fn func(context.Context, Registry) error) error {
 	ret := _m.Called(ctx, backoffPolicy, fn)
@@ -57,6 +80,26 @@ func (_m *MockRegistry) OutgoingEvent() outgoingevent.Repository {
 	return r0
 }
 
+// ProcessedEvent provides a mock function with no fields
+func (_m *MockRegistry) ProcessedEvent() processedevent.Repository {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ProcessedEvent")
+	}
+
+	var r0 processedevent.Repository
+	if rf, ok := ret.Get(0).(func() processedevent.Repository); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(processedevent.Repository)
+		}
+	}
+
+	return r0
+}
+
 // ShortUrl provides a mock function with no fields
 func (_m *MockRegistry) ShortUrl() shorturl.Repository {
 	ret := _m.Called()
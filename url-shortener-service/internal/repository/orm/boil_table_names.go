@@ -4,9 +4,11 @@
 package orm
 
 var TableNames = struct {
-	OutgoingEvents string
-	ShortUrls      string
+	BlockedDestinations string
+	OutgoingEvents      string
+	ShortUrls           string
 }{
-	OutgoingEvents: "outgoing_events",
-	ShortUrls:      "short_urls",
+	BlockedDestinations: "blocked_destinations",
+	OutgoingEvents:      "outgoing_events",
+	ShortUrls:           "short_urls",
 }
@@ -25,99 +25,101 @@ import (
 
 // OutgoingEvent is an object representing the database table.
 type OutgoingEvent struct {
-	ID            int64       `boil:"id" json:"id" toml:"id" yaml:"id"`
-	Payload       types.JSON  `boil:"payload" json:"payload" toml:"payload" yaml:"payload"`
-	Topic         string      `boil:"topic" json:"topic" toml:"topic" yaml:"topic"`
-	Status        string      `boil:"status" json:"status" toml:"status" yaml:"status"`
-	LastError     null.String `boil:"last_error" json:"last_error,omitempty" toml:"last_error" yaml:"last_error,omitempty"`
-	CorrelationID string      `boil:"correlation_id" json:"correlation_id" toml:"correlation_id" yaml:"correlation_id"`
-	TraceID       string      `boil:"trace_id" json:"trace_id" toml:"trace_id" yaml:"trace_id"`
-	SpanID        string      `boil:"span_id" json:"span_id" toml:"span_id" yaml:"span_id"`
-	RetryCount    int         `boil:"retry_count" json:"retry_count" toml:"retry_count" yaml:"retry_count"`
-	CreatedAt     time.Time   `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
-	UpdatedAt     time.Time   `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
+	ID             int64       `boil:"id" json:"id" toml:"id" yaml:"id"`
+	Payload        types.JSON  `boil:"payload" json:"payload" toml:"payload" yaml:"payload"`
+	Topic          string      `boil:"topic" json:"topic" toml:"topic" yaml:"topic"`
+	Status         string      `boil:"status" json:"status" toml:"status" yaml:"status"`
+	LastError      null.String `boil:"last_error" json:"last_error,omitempty" toml:"last_error" yaml:"last_error,omitempty"`
+	CorrelationID  string      `boil:"correlation_id" json:"correlation_id" toml:"correlation_id" yaml:"correlation_id"`
+	TraceID        string      `boil:"trace_id" json:"trace_id" toml:"trace_id" yaml:"trace_id"`
+	SpanID         string      `boil:"span_id" json:"span_id" toml:"span_id" yaml:"span_id"`
+	RetryCount     int         `boil:"retry_count" json:"retry_count" toml:"retry_count" yaml:"retry_count"`
+	CreatedAt      time.Time   `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	UpdatedAt      time.Time   `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
+	LockedBy       null.String `boil:"locked_by" json:"locked_by,omitempty" toml:"locked_by" yaml:"locked_by,omitempty"`
+	LockedUntil    null.Time   `boil:"locked_until" json:"locked_until,omitempty" toml:"locked_until" yaml:"locked_until,omitempty"`
+	NextAttemptAt  time.Time   `boil:"next_attempt_at" json:"next_attempt_at" toml:"next_attempt_at" yaml:"next_attempt_at"`
+	AcknowledgedAt null.Time   `boil:"acknowledged_at" json:"acknowledged_at,omitempty" toml:"acknowledged_at" yaml:"acknowledged_at,omitempty"`
+	MessageKey     null.String `boil:"message_key" json:"message_key,omitempty" toml:"message_key" yaml:"message_key,omitempty"`
 
 	R *outgoingEventR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L outgoingEventL  `boil:"-" json:"-" toml:"-" yaml:"-"`
 }
 
 var OutgoingEventColumns = struct {
-	ID            string
-	Payload       string
-	Topic         string
-	Status        string
-	LastError     string
-	CorrelationID string
-	TraceID       string
-	SpanID        string
-	RetryCount    string
-	CreatedAt     string
-	UpdatedAt     string
+	ID             string
+	Payload        string
+	Topic          string
+	Status         string
+	LastError      string
+	CorrelationID  string
+	TraceID        string
+	SpanID         string
+	RetryCount     string
+	CreatedAt      string
+	UpdatedAt      string
+	LockedBy       string
+	LockedUntil    string
+	NextAttemptAt  string
+	AcknowledgedAt string
+	MessageKey     string
 }{
-	ID:            "id",
-	Payload:       "payload",
-	Topic:         "topic",
-	Status:        "status",
-	LastError:     "last_error",
-	CorrelationID: "correlation_id",
-	TraceID:       "trace_id",
-	SpanID:        "span_id",
-	RetryCount:    "retry_count",
-	CreatedAt:     "created_at",
-	UpdatedAt:     "updated_at",
+	ID:             "id",
+	Payload:        "payload",
+	Topic:          "topic",
+	Status:         "status",
+	LastError:      "last_error",
+	CorrelationID:  "correlation_id",
+	TraceID:        "trace_id",
+	SpanID:         "span_id",
+	RetryCount:     "retry_count",
+	CreatedAt:      "created_at",
+	UpdatedAt:      "updated_at",
+	LockedBy:       "locked_by",
+	LockedUntil:    "locked_until",
+	NextAttemptAt:  "next_attempt_at",
+	AcknowledgedAt: "acknowledged_at",
+	MessageKey:     "message_key",
 }
 
 var OutgoingEventTableColumns = struct {
-	ID            string
-	Payload       string
-	Topic         string
-	Status        string
-	LastError     string
-	CorrelationID string
-	TraceID       string
-	SpanID        string
-	RetryCount    string
-	CreatedAt     string
-	UpdatedAt     string
+	ID             string
+	Payload        string
+	Topic          string
+	Status         string
+	LastError      string
+	CorrelationID  string
+	TraceID        string
+	SpanID         string
+	RetryCount     string
+	CreatedAt      string
+	UpdatedAt      string
+	LockedBy       string
+	LockedUntil    string
+	NextAttemptAt  string
+	AcknowledgedAt string
+	MessageKey     string
 }{
-	ID:            "outgoing_events.id",
-	Payload:       "outgoing_events.payload",
-	Topic:         "outgoing_events.topic",
-	Status:        "outgoing_events.status",
-	LastError:     "outgoing_events.last_error",
-	CorrelationID: "outgoing_events.correlation_id",
-	TraceID:       "outgoing_events.trace_id",
-	SpanID:        "outgoing_events.span_id",
-	RetryCount:    "outgoing_events.retry_count",
-	CreatedAt:     "outgoing_events.created_at",
-	UpdatedAt:     "outgoing_events.updated_at",
+	ID:             "outgoing_events.id",
+	Payload:        "outgoing_events.payload",
+	Topic:          "outgoing_events.topic",
+	Status:         "outgoing_events.status",
+	LastError:      "outgoing_events.last_error",
+	CorrelationID:  "outgoing_events.correlation_id",
+	TraceID:        "outgoing_events.trace_id",
+	SpanID:         "outgoing_events.span_id",
+	RetryCount:     "outgoing_events.retry_count",
+	CreatedAt:      "outgoing_events.created_at",
+	UpdatedAt:      "outgoing_events.updated_at",
+	LockedBy:       "outgoing_events.locked_by",
+	LockedUntil:    "outgoing_events.locked_until",
+	NextAttemptAt:  "outgoing_events.next_attempt_at",
+	AcknowledgedAt: "outgoing_events.acknowledged_at",
+	MessageKey:     "outgoing_events.message_key",
 }
 
 // Generated where
 
-type whereHelperint64 struct{ field string }
-
-func (w whereHelperint64) EQ(x int64) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
-func (w whereHelperint64) NEQ(x int64) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
-func (w whereHelperint64) LT(x int64) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
-func (w whereHelperint64) LTE(x int64) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
-func (w whereHelperint64) GT(x int64) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
-func (w whereHelperint64) GTE(x int64) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
-func (w whereHelperint64) IN(slice []int64) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
-}
-func (w whereHelperint64) NIN(slice []int64) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
-}
-
 type whereHelpertypes_JSON struct{ field string }
 
 func (w whereHelpertypes_JSON) EQ(x types.JSON) qm.QueryMod {
@@ -139,93 +141,6 @@ func (w whereHelpertypes_JSON) GTE(x types.JSON) qm.QueryMod {
 	return qmhelper.Where(w.field, qmhelper.GTE, x)
 }
 
-type whereHelperstring struct{ field string }
-
-func (w whereHelperstring) EQ(x string) qm.QueryMod      { return qmhelper.Where(w.field, qmhelper.EQ, x) }
-func (w whereHelperstring) NEQ(x string) qm.QueryMod     { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
-func (w whereHelperstring) LT(x string) qm.QueryMod      { return qmhelper.Where(w.field, qmhelper.LT, x) }
-func (w whereHelperstring) LTE(x string) qm.QueryMod     { return qmhelper.Where(w.field, qmhelper.LTE, x) }
-func (w whereHelperstring) GT(x string) qm.QueryMod      { return qmhelper.Where(w.field, qmhelper.GT, x) }
-func (w whereHelperstring) GTE(x string) qm.QueryMod     { return qmhelper.Where(w.field, qmhelper.GTE, x) }
-func (w whereHelperstring) LIKE(x string) qm.QueryMod    { return qm.Where(w.field+" LIKE ?", x) }
-func (w whereHelperstring) NLIKE(x string) qm.QueryMod   { return qm.Where(w.field+" NOT LIKE ?", x) }
-func (w whereHelperstring) ILIKE(x string) qm.QueryMod   { return qm.Where(w.field+" ILIKE ?", x) }
-func (w whereHelperstring) NILIKE(x string) qm.QueryMod  { return qm.Where(w.field+" NOT ILIKE ?", x) }
-func (w whereHelperstring) SIMILAR(x string) qm.QueryMod { return qm.Where(w.field+" SIMILAR TO ?", x) }
-func (w whereHelperstring) NSIMILAR(x string) qm.QueryMod {
-	return qm.Where(w.field+" NOT SIMILAR TO ?", x)
-}
-func (w whereHelperstring) IN(slice []string) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
-}
-func (w whereHelperstring) NIN(slice []string) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
-}
-
-type whereHelpernull_String struct{ field string }
-
-func (w whereHelpernull_String) EQ(x null.String) qm.QueryMod {
-	return qmhelper.WhereNullEQ(w.field, false, x)
-}
-func (w whereHelpernull_String) NEQ(x null.String) qm.QueryMod {
-	return qmhelper.WhereNullEQ(w.field, true, x)
-}
-func (w whereHelpernull_String) LT(x null.String) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LT, x)
-}
-func (w whereHelpernull_String) LTE(x null.String) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LTE, x)
-}
-func (w whereHelpernull_String) GT(x null.String) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GT, x)
-}
-func (w whereHelpernull_String) GTE(x null.String) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GTE, x)
-}
-func (w whereHelpernull_String) LIKE(x null.String) qm.QueryMod {
-	return qm.Where(w.field+" LIKE ?", x)
-}
-func (w whereHelpernull_String) NLIKE(x null.String) qm.QueryMod {
-	return qm.Where(w.field+" NOT LIKE ?", x)
-}
-func (w whereHelpernull_String) ILIKE(x null.String) qm.QueryMod {
-	return qm.Where(w.field+" ILIKE ?", x)
-}
-func (w whereHelpernull_String) NILIKE(x null.String) qm.QueryMod {
-	return qm.Where(w.field+" NOT ILIKE ?", x)
-}
-func (w whereHelpernull_String) SIMILAR(x null.String) qm.QueryMod {
-	return qm.Where(w.field+" SIMILAR TO ?", x)
-}
-func (w whereHelpernull_String) NSIMILAR(x null.String) qm.QueryMod {
-	return qm.Where(w.field+" NOT SIMILAR TO ?", x)
-}
-func (w whereHelpernull_String) IN(slice []string) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
-}
-func (w whereHelpernull_String) NIN(slice []string) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
-}
-
-func (w whereHelpernull_String) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
-func (w whereHelpernull_String) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
-
 type whereHelperint struct{ field string }
 
 func (w whereHelperint) EQ(x int) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
@@ -249,51 +164,64 @@ func (w whereHelperint) NIN(slice []int) qm.QueryMod {
 	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
 }
 
-type whereHelpertime_Time struct{ field string }
+type whereHelpernull_Time struct{ field string }
 
-func (w whereHelpertime_Time) EQ(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.EQ, x)
+func (w whereHelpernull_Time) EQ(x null.Time) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, false, x)
 }
-func (w whereHelpertime_Time) NEQ(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.NEQ, x)
+func (w whereHelpernull_Time) NEQ(x null.Time) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, true, x)
 }
-func (w whereHelpertime_Time) LT(x time.Time) qm.QueryMod {
+func (w whereHelpernull_Time) LT(x null.Time) qm.QueryMod {
 	return qmhelper.Where(w.field, qmhelper.LT, x)
 }
-func (w whereHelpertime_Time) LTE(x time.Time) qm.QueryMod {
+func (w whereHelpernull_Time) LTE(x null.Time) qm.QueryMod {
 	return qmhelper.Where(w.field, qmhelper.LTE, x)
 }
-func (w whereHelpertime_Time) GT(x time.Time) qm.QueryMod {
+func (w whereHelpernull_Time) GT(x null.Time) qm.QueryMod {
 	return qmhelper.Where(w.field, qmhelper.GT, x)
 }
-func (w whereHelpertime_Time) GTE(x time.Time) qm.QueryMod {
+func (w whereHelpernull_Time) GTE(x null.Time) qm.QueryMod {
 	return qmhelper.Where(w.field, qmhelper.GTE, x)
 }
 
+func (w whereHelpernull_Time) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
+func (w whereHelpernull_Time) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
+
 var OutgoingEventWhere = struct {
-	ID            whereHelperint64
-	Payload       whereHelpertypes_JSON
-	Topic         whereHelperstring
-	Status        whereHelperstring
-	LastError     whereHelpernull_String
-	CorrelationID whereHelperstring
-	TraceID       whereHelperstring
-	SpanID        whereHelperstring
-	RetryCount    whereHelperint
-	CreatedAt     whereHelpertime_Time
-	UpdatedAt     whereHelpertime_Time
+	ID             whereHelperint64
+	Payload        whereHelpertypes_JSON
+	Topic          whereHelperstring
+	Status         whereHelperstring
+	LastError      whereHelpernull_String
+	CorrelationID  whereHelperstring
+	TraceID        whereHelperstring
+	SpanID         whereHelperstring
+	RetryCount     whereHelperint
+	CreatedAt      whereHelpertime_Time
+	UpdatedAt      whereHelpertime_Time
+	LockedBy       whereHelpernull_String
+	LockedUntil    whereHelpernull_Time
+	NextAttemptAt  whereHelpertime_Time
+	AcknowledgedAt whereHelpernull_Time
+	MessageKey     whereHelpernull_String
 }{
-	ID:            whereHelperint64{field: "\"outgoing_events\".\"id\""},
-	Payload:       whereHelpertypes_JSON{field: "\"outgoing_events\".\"payload\""},
-	Topic:         whereHelperstring{field: "\"outgoing_events\".\"topic\""},
-	Status:        whereHelperstring{field: "\"outgoing_events\".\"status\""},
-	LastError:     whereHelpernull_String{field: "\"outgoing_events\".\"last_error\""},
-	CorrelationID: whereHelperstring{field: "\"outgoing_events\".\"correlation_id\""},
-	TraceID:       whereHelperstring{field: "\"outgoing_events\".\"trace_id\""},
-	SpanID:        whereHelperstring{field: "\"outgoing_events\".\"span_id\""},
-	RetryCount:    whereHelperint{field: "\"outgoing_events\".\"retry_count\""},
-	CreatedAt:     whereHelpertime_Time{field: "\"outgoing_events\".\"created_at\""},
-	UpdatedAt:     whereHelpertime_Time{field: "\"outgoing_events\".\"updated_at\""},
+	ID:             whereHelperint64{field: "\"outgoing_events\".\"id\""},
+	Payload:        whereHelpertypes_JSON{field: "\"outgoing_events\".\"payload\""},
+	Topic:          whereHelperstring{field: "\"outgoing_events\".\"topic\""},
+	Status:         whereHelperstring{field: "\"outgoing_events\".\"status\""},
+	LastError:      whereHelpernull_String{field: "\"outgoing_events\".\"last_error\""},
+	CorrelationID:  whereHelperstring{field: "\"outgoing_events\".\"correlation_id\""},
+	TraceID:        whereHelperstring{field: "\"outgoing_events\".\"trace_id\""},
+	SpanID:         whereHelperstring{field: "\"outgoing_events\".\"span_id\""},
+	RetryCount:     whereHelperint{field: "\"outgoing_events\".\"retry_count\""},
+	CreatedAt:      whereHelpertime_Time{field: "\"outgoing_events\".\"created_at\""},
+	UpdatedAt:      whereHelpertime_Time{field: "\"outgoing_events\".\"updated_at\""},
+	LockedBy:       whereHelpernull_String{field: "\"outgoing_events\".\"locked_by\""},
+	LockedUntil:    whereHelpernull_Time{field: "\"outgoing_events\".\"locked_until\""},
+	NextAttemptAt:  whereHelpertime_Time{field: "\"outgoing_events\".\"next_attempt_at\""},
+	AcknowledgedAt: whereHelpernull_Time{field: "\"outgoing_events\".\"acknowledged_at\""},
+	MessageKey:     whereHelpernull_String{field: "\"outgoing_events\".\"message_key\""},
 }
 
 // OutgoingEventRels is where relationship names are stored.
@@ -313,9 +241,9 @@ func (*outgoingEventR) NewStruct() *outgoingEventR {
 type outgoingEventL struct{}
 
 var (
-	outgoingEventAllColumns            = []string{"id", "payload", "topic", "status", "last_error", "correlation_id", "trace_id", "span_id", "retry_count", "created_at", "updated_at"}
+	outgoingEventAllColumns            = []string{"id", "payload", "topic", "status", "last_error", "correlation_id", "trace_id", "span_id", "retry_count", "created_at", "updated_at", "locked_by", "locked_until", "next_attempt_at", "acknowledged_at", "message_key"}
 	outgoingEventColumnsWithoutDefault = []string{"id", "payload", "topic", "correlation_id", "trace_id", "span_id"}
-	outgoingEventColumnsWithDefault    = []string{"status", "last_error", "retry_count", "created_at", "updated_at"}
+	outgoingEventColumnsWithDefault    = []string{"status", "last_error", "retry_count", "created_at", "updated_at", "locked_by", "locked_until", "next_attempt_at", "acknowledged_at", "message_key"}
 	outgoingEventPrimaryKeyColumns     = []string{"id"}
 	outgoingEventGeneratedColumns      = []string{}
 )
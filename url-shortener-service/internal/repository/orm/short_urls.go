@@ -24,47 +24,127 @@ import (
 
 // ShortURL is an object representing the database table.
 type ShortURL struct {
-	ShortCode   string    `boil:"short_code" json:"short_code" toml:"short_code" yaml:"short_code"`
-	OriginalURL string    `boil:"original_url" json:"original_url" toml:"original_url" yaml:"original_url"`
-	Status      string    `boil:"status" json:"status" toml:"status" yaml:"status"`
-	CreatedAt   time.Time `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
-	UpdatedAt   time.Time `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
-	Metadata    null.JSON `boil:"metadata" json:"metadata,omitempty" toml:"metadata" yaml:"metadata,omitempty"`
+	ShortCode                 string      `boil:"short_code" json:"short_code" toml:"short_code" yaml:"short_code"`
+	OriginalURL               string      `boil:"original_url" json:"original_url" toml:"original_url" yaml:"original_url"`
+	Status                    string      `boil:"status" json:"status" toml:"status" yaml:"status"`
+	CreatedAt                 time.Time   `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	UpdatedAt                 time.Time   `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
+	Metadata                  null.JSON   `boil:"metadata" json:"metadata,omitempty" toml:"metadata" yaml:"metadata,omitempty"`
+	CrawlStatus               string      `boil:"crawl_status" json:"crawl_status" toml:"crawl_status" yaml:"crawl_status"`
+	CrawlAttempts             int         `boil:"crawl_attempts" json:"crawl_attempts" toml:"crawl_attempts" yaml:"crawl_attempts"`
+	LastCrawledAt             null.Time   `boil:"last_crawled_at" json:"last_crawled_at,omitempty" toml:"last_crawled_at" yaml:"last_crawled_at,omitempty"`
+	LastCrawlErrorCode        null.String `boil:"last_crawl_error_code" json:"last_crawl_error_code,omitempty" toml:"last_crawl_error_code" yaml:"last_crawl_error_code,omitempty"`
+	ClickCount                int64       `boil:"click_count" json:"click_count" toml:"click_count" yaml:"click_count"`
+	CrawlRequestedAt          null.Time   `boil:"crawl_requested_at" json:"crawl_requested_at,omitempty" toml:"crawl_requested_at" yaml:"crawl_requested_at,omitempty"`
+	MetadataEtag              null.String `boil:"metadata_etag" json:"metadata_etag,omitempty" toml:"metadata_etag" yaml:"metadata_etag,omitempty"`
+	MetadataLastModified      null.String `boil:"metadata_last_modified" json:"metadata_last_modified,omitempty" toml:"metadata_last_modified" yaml:"metadata_last_modified,omitempty"`
+	FlaggedAt                 null.Time   `boil:"flagged_at" json:"flagged_at,omitempty" toml:"flagged_at" yaml:"flagged_at,omitempty"`
+	FlagReason                null.String `boil:"flag_reason" json:"flag_reason,omitempty" toml:"flag_reason" yaml:"flag_reason,omitempty"`
+	HealthStatus              string      `boil:"health_status" json:"health_status" toml:"health_status" yaml:"health_status"`
+	HealthHTTPStatus          null.Int    `boil:"health_http_status" json:"health_http_status,omitempty" toml:"health_http_status" yaml:"health_http_status,omitempty"`
+	HealthCheckedAt           null.Time   `boil:"health_checked_at" json:"health_checked_at,omitempty" toml:"health_checked_at" yaml:"health_checked_at,omitempty"`
+	LastOkAt                  null.Time   `boil:"last_ok_at" json:"last_ok_at,omitempty" toml:"last_ok_at" yaml:"last_ok_at,omitempty"`
+	HealthRedirectChain       null.JSON   `boil:"health_redirect_chain" json:"health_redirect_chain,omitempty" toml:"health_redirect_chain" yaml:"health_redirect_chain,omitempty"`
+	HealthConsecutiveFailures int         `boil:"health_consecutive_failures" json:"health_consecutive_failures" toml:"health_consecutive_failures" yaml:"health_consecutive_failures"`
 
 	R *shortURLR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L shortURLL  `boil:"-" json:"-" toml:"-" yaml:"-"`
 }
 
 var ShortURLColumns = struct {
-	ShortCode   string
-	OriginalURL string
-	Status      string
-	CreatedAt   string
-	UpdatedAt   string
-	Metadata    string
+	ShortCode                 string
+	OriginalURL               string
+	Status                    string
+	CreatedAt                 string
+	UpdatedAt                 string
+	Metadata                  string
+	CrawlStatus               string
+	CrawlAttempts             string
+	LastCrawledAt             string
+	LastCrawlErrorCode        string
+	ClickCount                string
+	CrawlRequestedAt          string
+	MetadataEtag              string
+	MetadataLastModified      string
+	FlaggedAt                 string
+	FlagReason                string
+	HealthStatus              string
+	HealthHTTPStatus          string
+	HealthCheckedAt           string
+	LastOkAt                  string
+	HealthRedirectChain       string
+	HealthConsecutiveFailures string
 }{
-	ShortCode:   "short_code",
-	OriginalURL: "original_url",
-	Status:      "status",
-	CreatedAt:   "created_at",
-	UpdatedAt:   "updated_at",
-	Metadata:    "metadata",
+	ShortCode:                 "short_code",
+	OriginalURL:               "original_url",
+	Status:                    "status",
+	CreatedAt:                 "created_at",
+	UpdatedAt:                 "updated_at",
+	Metadata:                  "metadata",
+	CrawlStatus:               "crawl_status",
+	CrawlAttempts:             "crawl_attempts",
+	LastCrawledAt:             "last_crawled_at",
+	LastCrawlErrorCode:        "last_crawl_error_code",
+	ClickCount:                "click_count",
+	CrawlRequestedAt:          "crawl_requested_at",
+	MetadataEtag:              "metadata_etag",
+	MetadataLastModified:      "metadata_last_modified",
+	FlaggedAt:                 "flagged_at",
+	FlagReason:                "flag_reason",
+	HealthStatus:              "health_status",
+	HealthHTTPStatus:          "health_http_status",
+	HealthCheckedAt:           "health_checked_at",
+	LastOkAt:                  "last_ok_at",
+	HealthRedirectChain:       "health_redirect_chain",
+	HealthConsecutiveFailures: "health_consecutive_failures",
 }
 
 var ShortURLTableColumns = struct {
-	ShortCode   string
-	OriginalURL string
-	Status      string
-	CreatedAt   string
-	UpdatedAt   string
-	Metadata    string
+	ShortCode                 string
+	OriginalURL               string
+	Status                    string
+	CreatedAt                 string
+	UpdatedAt                 string
+	Metadata                  string
+	CrawlStatus               string
+	CrawlAttempts             string
+	LastCrawledAt             string
+	LastCrawlErrorCode        string
+	ClickCount                string
+	CrawlRequestedAt          string
+	MetadataEtag              string
+	MetadataLastModified      string
+	FlaggedAt                 string
+	FlagReason                string
+	HealthStatus              string
+	HealthHTTPStatus          string
+	HealthCheckedAt           string
+	LastOkAt                  string
+	HealthRedirectChain       string
+	HealthConsecutiveFailures string
 }{
-	ShortCode:   "short_urls.short_code",
-	OriginalURL: "short_urls.original_url",
-	Status:      "short_urls.status",
-	CreatedAt:   "short_urls.created_at",
-	UpdatedAt:   "short_urls.updated_at",
-	Metadata:    "short_urls.metadata",
+	ShortCode:                 "short_urls.short_code",
+	OriginalURL:               "short_urls.original_url",
+	Status:                    "short_urls.status",
+	CreatedAt:                 "short_urls.created_at",
+	UpdatedAt:                 "short_urls.updated_at",
+	Metadata:                  "short_urls.metadata",
+	CrawlStatus:               "short_urls.crawl_status",
+	CrawlAttempts:             "short_urls.crawl_attempts",
+	LastCrawledAt:             "short_urls.last_crawled_at",
+	LastCrawlErrorCode:        "short_urls.last_crawl_error_code",
+	ClickCount:                "short_urls.click_count",
+	CrawlRequestedAt:          "short_urls.crawl_requested_at",
+	MetadataEtag:              "short_urls.metadata_etag",
+	MetadataLastModified:      "short_urls.metadata_last_modified",
+	FlaggedAt:                 "short_urls.flagged_at",
+	FlagReason:                "short_urls.flag_reason",
+	HealthStatus:              "short_urls.health_status",
+	HealthHTTPStatus:          "short_urls.health_http_status",
+	HealthCheckedAt:           "short_urls.health_checked_at",
+	LastOkAt:                  "short_urls.last_ok_at",
+	HealthRedirectChain:       "short_urls.health_redirect_chain",
+	HealthConsecutiveFailures: "short_urls.health_consecutive_failures",
 }
 
 // Generated where
@@ -93,20 +173,90 @@ func (w whereHelpernull_JSON) GTE(x null.JSON) qm.QueryMod {
 func (w whereHelpernull_JSON) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
 func (w whereHelpernull_JSON) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
 
+type whereHelpernull_Int struct{ field string }
+
+func (w whereHelpernull_Int) EQ(x null.Int) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, false, x)
+}
+func (w whereHelpernull_Int) NEQ(x null.Int) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, true, x)
+}
+func (w whereHelpernull_Int) LT(x null.Int) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpernull_Int) LTE(x null.Int) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpernull_Int) GT(x null.Int) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpernull_Int) GTE(x null.Int) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+func (w whereHelpernull_Int) IN(slice []int) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
+}
+func (w whereHelpernull_Int) NIN(slice []int) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
+}
+
+func (w whereHelpernull_Int) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
+func (w whereHelpernull_Int) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
+
 var ShortURLWhere = struct {
-	ShortCode   whereHelperstring
-	OriginalURL whereHelperstring
-	Status      whereHelperstring
-	CreatedAt   whereHelpertime_Time
-	UpdatedAt   whereHelpertime_Time
-	Metadata    whereHelpernull_JSON
+	ShortCode                 whereHelperstring
+	OriginalURL               whereHelperstring
+	Status                    whereHelperstring
+	CreatedAt                 whereHelpertime_Time
+	UpdatedAt                 whereHelpertime_Time
+	Metadata                  whereHelpernull_JSON
+	CrawlStatus               whereHelperstring
+	CrawlAttempts             whereHelperint
+	LastCrawledAt             whereHelpernull_Time
+	LastCrawlErrorCode        whereHelpernull_String
+	ClickCount                whereHelperint64
+	CrawlRequestedAt          whereHelpernull_Time
+	MetadataEtag              whereHelpernull_String
+	MetadataLastModified      whereHelpernull_String
+	FlaggedAt                 whereHelpernull_Time
+	FlagReason                whereHelpernull_String
+	HealthStatus              whereHelperstring
+	HealthHTTPStatus          whereHelpernull_Int
+	HealthCheckedAt           whereHelpernull_Time
+	LastOkAt                  whereHelpernull_Time
+	HealthRedirectChain       whereHelpernull_JSON
+	HealthConsecutiveFailures whereHelperint
 }{
-	ShortCode:   whereHelperstring{field: "\"short_urls\".\"short_code\""},
-	OriginalURL: whereHelperstring{field: "\"short_urls\".\"original_url\""},
-	Status:      whereHelperstring{field: "\"short_urls\".\"status\""},
-	CreatedAt:   whereHelpertime_Time{field: "\"short_urls\".\"created_at\""},
-	UpdatedAt:   whereHelpertime_Time{field: "\"short_urls\".\"updated_at\""},
-	Metadata:    whereHelpernull_JSON{field: "\"short_urls\".\"metadata\""},
+	ShortCode:                 whereHelperstring{field: "\"short_urls\".\"short_code\""},
+	OriginalURL:               whereHelperstring{field: "\"short_urls\".\"original_url\""},
+	Status:                    whereHelperstring{field: "\"short_urls\".\"status\""},
+	CreatedAt:                 whereHelpertime_Time{field: "\"short_urls\".\"created_at\""},
+	UpdatedAt:                 whereHelpertime_Time{field: "\"short_urls\".\"updated_at\""},
+	Metadata:                  whereHelpernull_JSON{field: "\"short_urls\".\"metadata\""},
+	CrawlStatus:               whereHelperstring{field: "\"short_urls\".\"crawl_status\""},
+	CrawlAttempts:             whereHelperint{field: "\"short_urls\".\"crawl_attempts\""},
+	LastCrawledAt:             whereHelpernull_Time{field: "\"short_urls\".\"last_crawled_at\""},
+	LastCrawlErrorCode:        whereHelpernull_String{field: "\"short_urls\".\"last_crawl_error_code\""},
+	ClickCount:                whereHelperint64{field: "\"short_urls\".\"click_count\""},
+	CrawlRequestedAt:          whereHelpernull_Time{field: "\"short_urls\".\"crawl_requested_at\""},
+	MetadataEtag:              whereHelpernull_String{field: "\"short_urls\".\"metadata_etag\""},
+	MetadataLastModified:      whereHelpernull_String{field: "\"short_urls\".\"metadata_last_modified\""},
+	FlaggedAt:                 whereHelpernull_Time{field: "\"short_urls\".\"flagged_at\""},
+	FlagReason:                whereHelpernull_String{field: "\"short_urls\".\"flag_reason\""},
+	HealthStatus:              whereHelperstring{field: "\"short_urls\".\"health_status\""},
+	HealthHTTPStatus:          whereHelpernull_Int{field: "\"short_urls\".\"health_http_status\""},
+	HealthCheckedAt:           whereHelpernull_Time{field: "\"short_urls\".\"health_checked_at\""},
+	LastOkAt:                  whereHelpernull_Time{field: "\"short_urls\".\"last_ok_at\""},
+	HealthRedirectChain:       whereHelpernull_JSON{field: "\"short_urls\".\"health_redirect_chain\""},
+	HealthConsecutiveFailures: whereHelperint{field: "\"short_urls\".\"health_consecutive_failures\""},
 }
 
 // ShortURLRels is where relationship names are stored.
@@ -126,9 +276,9 @@ func (*shortURLR) NewStruct() *shortURLR {
 type shortURLL struct{}
 
 var (
-	shortURLAllColumns            = []string{"short_code", "original_url", "status", "created_at", "updated_at", "metadata"}
+	shortURLAllColumns            = []string{"short_code", "original_url", "status", "created_at", "updated_at", "metadata", "crawl_status", "crawl_attempts", "last_crawled_at", "last_crawl_error_code", "click_count", "crawl_requested_at", "metadata_etag", "metadata_last_modified", "flagged_at", "flag_reason", "health_status", "health_http_status", "health_checked_at", "last_ok_at", "health_redirect_chain", "health_consecutive_failures"}
 	shortURLColumnsWithoutDefault = []string{"short_code", "original_url", "status"}
-	shortURLColumnsWithDefault    = []string{"created_at", "updated_at", "metadata"}
+	shortURLColumnsWithDefault    = []string{"created_at", "updated_at", "metadata", "crawl_status", "crawl_attempts", "last_crawled_at", "last_crawl_error_code", "click_count", "crawl_requested_at", "metadata_etag", "metadata_last_modified", "flagged_at", "flag_reason", "health_status", "health_http_status", "health_checked_at", "last_ok_at", "health_redirect_chain", "health_consecutive_failures"}
 	shortURLPrimaryKeyColumns     = []string{"short_code"}
 	shortURLGeneratedColumns      = []string{}
 )
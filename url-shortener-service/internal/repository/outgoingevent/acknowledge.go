@@ -0,0 +1,36 @@
+package outgoingevent
+
+import (
+	"context"
+	"time"
+
+	"github.com/aarondl/null/v8"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/monitoring"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/repository/orm"
+	pkgerrors "github.com/pkg/errors"
+)
+
+// Acknowledge marks FAILED events as looked at, which lets the retention job archive them.
+// Events of another status, or already acknowledged, are left untouched.
+// It returns the number of events acknowledged.
+func (i impl) Acknowledge(ctx context.Context, ids []int64) (int64, error) {
+	var err error
+	ctx, span := monitoring.Start(ctx, "OutgoingEventRepository.Acknowledge")
+	defer monitoring.End(span, &err)
+
+	now := time.Now()
+	n, err := orm.OutgoingEvents(
+		orm.OutgoingEventWhere.ID.IN(ids),
+		orm.OutgoingEventWhere.Status.EQ(model.OutgoingEventStatusFailed.String()),
+		orm.OutgoingEventWhere.AcknowledgedAt.IsNull(),
+	).UpdateAll(ctx, i.db, orm.M{
+		orm.OutgoingEventColumns.AcknowledgedAt: null.TimeFrom(now),
+		orm.OutgoingEventColumns.UpdatedAt:      now,
+	})
+	if err != nil {
+		return 0, pkgerrors.WithStack(err)
+	}
+
+	return n, nil
+}
@@ -0,0 +1,49 @@
+package outgoingevent
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/pkg/testutil"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/repository/orm"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAcknowledge(t *testing.T) {
+	tcs := map[string]struct {
+		ids       []int64
+		wantCount int64
+	}{
+		"success - failed event acknowledged": {
+			ids:       []int64{3},
+			wantCount: 1,
+		},
+		"success - other statuses and acknowledged events skipped": {
+			ids:       []int64{1, 3, 4, 5},
+			wantCount: 1,
+		},
+		"success - unknown id": {
+			ids: []int64{404},
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			testutil.WithTxDB(t, func(tx *sql.Tx) {
+				testutil.LoadSQLFile(t, tx, "testdata/outgoing_events_retention.sql")
+
+				ctx := context.Background()
+				n, err := New(tx).Acknowledge(ctx, tc.ids)
+				require.NoError(t, err)
+				require.Equal(t, tc.wantCount, n)
+
+				if tc.wantCount > 0 {
+					o, err := orm.FindOutgoingEvent(ctx, tx, 3)
+					require.NoError(t, err)
+					require.True(t, o.AcknowledgedAt.Valid)
+				}
+			})
+		})
+	}
+}
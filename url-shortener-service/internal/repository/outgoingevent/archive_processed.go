@@ -0,0 +1,54 @@
+package outgoingevent
+
+import (
+	"context"
+	"time"
+
+	"github.com/aarondl/sqlboiler/v4/queries"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/monitoring"
+	pkgerrors "github.com/pkg/errors"
+)
+
+// processedBatch selects, oldest first, a batch of events which need no more attention: published, or failed
+// and acknowledged, before $1. Rows claimed by a concurrent job are skipped.
+const processedBatch = `
+SELECT id
+FROM outgoing_events
+WHERE (status = 'PUBLISHED' AND updated_at < $1)
+   OR (status = 'FAILED' AND acknowledged_at < $1)
+ORDER BY id
+LIMIT $2
+FOR UPDATE SKIP LOCKED`
+
+// archiveProcessedQuery moves a batch to the archive in a single statement, so an event is never lost nor duplicated
+const archiveProcessedQuery = `
+WITH moved AS (
+    DELETE FROM outgoing_events
+    WHERE id IN (` + processedBatch + `)
+    RETURNING id, payload, topic, message_key, status, last_error, correlation_id, trace_id, span_id, retry_count,
+              acknowledged_at, created_at, updated_at
+)
+INSERT INTO outgoing_events_archive (id, payload, topic, message_key, status, last_error, correlation_id, trace_id, span_id,
+                                     retry_count, acknowledged_at, created_at, updated_at)
+SELECT * FROM moved`
+
+// ArchiveProcessed moves up to limit events published, or failed and acknowledged, before the given time
+// to outgoing_events_archive. FAILED events are kept until acknowledged. The archive partition of the
+// current month must exist, see EnsureArchivePartition. It returns the number of events archived.
+func (i impl) ArchiveProcessed(ctx context.Context, before time.Time, limit int) (int64, error) {
+	var err error
+	ctx, span := monitoring.Start(ctx, "OutgoingEventRepository.ArchiveProcessed")
+	defer monitoring.End(span, &err)
+
+	rs, err := queries.Raw(archiveProcessedQuery, before, limit).ExecContext(ctx, i.db)
+	if err != nil {
+		return 0, pkgerrors.WithStack(err)
+	}
+
+	n, err := rs.RowsAffected()
+	if err != nil {
+		return 0, pkgerrors.WithStack(err)
+	}
+
+	return n, nil
+}
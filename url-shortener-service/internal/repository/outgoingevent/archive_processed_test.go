@@ -0,0 +1,61 @@
+package outgoingevent
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/aarondl/sqlboiler/v4/queries"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/pkg/testutil"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/repository/orm"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArchiveProcessed(t *testing.T) {
+	tcs := map[string]struct {
+		limit       int
+		wantArchive []int64
+	}{
+		"success - old published and acknowledged failed events archived": {
+			limit:       10,
+			wantArchive: []int64{1, 4, 6},
+		},
+		"success - limit respected from the oldest": {
+			limit:       2,
+			wantArchive: []int64{1, 4},
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			testutil.WithTxDB(t, func(tx *sql.Tx) {
+				testutil.LoadSQLFile(t, tx, "testdata/outgoing_events_retention.sql")
+
+				ctx := context.Background()
+				repo := New(tx)
+				require.NoError(t, repo.EnsureArchivePartition(ctx, time.Now()))
+
+				n, err := repo.ArchiveProcessed(ctx, time.Now().Add(-7*24*time.Hour), tc.limit)
+				require.NoError(t, err)
+				require.Equal(t, int64(len(tc.wantArchive)), n)
+
+				var archived []struct {
+					ID int64 `boil:"id"`
+				}
+				require.NoError(t, queries.Raw(`SELECT id FROM outgoing_events_archive ORDER BY id`).Bind(ctx, tx, &archived))
+				var archivedIDs []int64
+				for _, a := range archived {
+					archivedIDs = append(archivedIDs, a.ID)
+				}
+				require.Equal(t, tc.wantArchive, archivedIDs)
+
+				for _, id := range tc.wantArchive {
+					exists, err := orm.OutgoingEventExists(ctx, tx, id)
+					require.NoError(t, err)
+					require.False(t, exists, "event %d should have left outgoing_events", id)
+				}
+			})
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package outgoingevent
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/aarondl/sqlboiler/v4/queries"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/monitoring"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/repository/orm"
+	pkgerrors "github.com/pkg/errors"
+)
+
+// claimPendingQuery leases the earliest due pending events that nobody holds, or whose holder let the lease expire.
+// SKIP LOCKED makes concurrent claims pick disjoint rows instead of waiting on each other. Ordering by next_attempt_at
+// walks idx_outgoing_events_pending_next_attempt instead of sorting every due event.
+// An event waits while an older one of its key is pending, backing off or held included: publishing it first
+// would reorder the events of the link on their partition.
+const claimPendingQuery = `
+UPDATE outgoing_events
+SET locked_by    = $1,
+    locked_until = NOW() + $2 * INTERVAL '1 millisecond',
+    updated_at   = NOW()
+WHERE id IN (
+    SELECT id
+    FROM outgoing_events
+    WHERE status = 'PENDING'
+      AND next_attempt_at <= NOW()
+      AND (locked_until IS NULL OR locked_until < NOW())
+      AND NOT EXISTS (
+          SELECT 1
+          FROM outgoing_events p
+          WHERE p.message_key = outgoing_events.message_key
+            AND p.id < outgoing_events.id
+            AND p.status = 'PENDING'
+      )
+    ORDER BY next_attempt_at, id
+    LIMIT $3
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *`
+
+// ClaimPending leases up to limit PENDING events due for an attempt to workerID for the lease duration, so that other workers
+// skip them until the lease is released or expires. Events leased by a worker which crashed are claimed again
+// once their lease expired, events with an older pending event of their key once it left PENDING.
+// The claimed events are returned ordered by ID.
+func (i impl) ClaimPending(ctx context.Context, workerID string, lease time.Duration, limit int) ([]model.OutgoingEvent, error) {
+	var err error
+	ctx, span := monitoring.Start(ctx, "OutgoingEventRepository.ClaimPending")
+	defer monitoring.End(span, &err)
+
+	var items orm.OutgoingEventSlice
+	if err = queries.Raw(claimPendingQuery, workerID, lease.Milliseconds(), limit).Bind(ctx, i.db, &items); err != nil {
+		return nil, pkgerrors.WithStack(err)
+	}
+
+	// RETURNING does not keep the order of the sub-select
+	sort.Slice(items, func(a, b int) bool { return items[a].ID < items[b].ID })
+
+	var rs []model.OutgoingEvent
+	for _, item := range items {
+		m, err := toOutgoingEventModel(item)
+		if err != nil {
+			return nil, pkgerrors.WithStack(err)
+		}
+
+		rs = append(rs, m)
+	}
+
+	return rs, nil
+}
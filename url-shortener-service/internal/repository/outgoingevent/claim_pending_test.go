@@ -0,0 +1,72 @@
+package outgoingevent
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/pkg/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClaimPending(t *testing.T) {
+	tcs := map[string]struct {
+		fixture string
+		limit   int
+		wantIDs []int64
+	}{
+		"success - claims due events with free or expired leases, skips held and backing off ones": {
+			fixture: "testdata/outgoing_events_leases.sql",
+			limit:   10,
+			wantIDs: []int64{1, 3, 5},
+		},
+		"success - limit respected from the earliest due": {
+			fixture: "testdata/outgoing_events_leases.sql",
+			limit:   2,
+			wantIDs: []int64{1, 5},
+		},
+		"success - events wait for the older pending event of their key, backing off or held": {
+			fixture: "testdata/outgoing_events_keys.sql",
+			limit:   10,
+			wantIDs: []int64{4, 5, 6},
+		},
+		"success - nothing to claim": {
+			limit: 10,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			testutil.WithTxDB(t, func(tx *sql.Tx) {
+				if tc.fixture != "" {
+					testutil.LoadSQLFile(t, tx, tc.fixture)
+				} else {
+					_, err := tx.Exec("TRUNCATE TABLE outgoing_events")
+					require.NoError(t, err)
+				}
+
+				ctx := context.Background()
+				repo := New(tx)
+
+				events, err := repo.ClaimPending(ctx, "worker-a", time.Minute, tc.limit)
+				require.NoError(t, err)
+
+				var ids []int64
+				for _, e := range events {
+					ids = append(ids, e.ID)
+					require.Equal(t, "worker-a", e.LockedBy)
+					require.WithinDuration(t, time.Now().Add(time.Minute), e.LockedUntil, 10*time.Second)
+				}
+				require.Equal(t, tc.wantIDs, ids)
+
+				// A second worker finds nothing left among the claimed events
+				again, err := repo.ClaimPending(ctx, "worker-d", time.Minute, tc.limit)
+				require.NoError(t, err)
+				for _, e := range again {
+					require.NotContains(t, tc.wantIDs, e.ID)
+				}
+			})
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package outgoingevent
+
+import (
+	"context"
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/monitoring"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/repository/orm"
+	pkgerrors "github.com/pkg/errors"
+)
+
+// Count returns the number of outbox events matching the filter
+func (i impl) Count(ctx context.Context, filter model.OutgoingEventFilter) (int64, error) {
+	var err error
+	ctx, span := monitoring.Start(ctx, "OutgoingEventRepository.Count")
+	defer monitoring.End(span, &err)
+
+	n, err := orm.OutgoingEvents(filterMods(filter)...).Count(ctx, i.db)
+	if err != nil {
+		return 0, pkgerrors.WithStack(err)
+	}
+
+	return n, nil
+}
@@ -0,0 +1,41 @@
+package outgoingevent
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/pkg/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCount(t *testing.T) {
+	tcs := map[string]struct {
+		filter model.OutgoingEventFilter
+		want   int64
+	}{
+		"success - no filter": {
+			want: 6,
+		},
+		"success - by status": {
+			filter: model.OutgoingEventFilter{Status: model.OutgoingEventStatusPublished},
+			want:   3,
+		},
+		"success - nothing matches": {
+			filter: model.OutgoingEventFilter{IDs: []int64{404}},
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			testutil.WithTxDB(t, func(tx *sql.Tx) {
+				testutil.LoadSQLFile(t, tx, "testdata/outgoing_events_retention.sql")
+
+				actual, err := New(tx).Count(context.Background(), tc.filter)
+				require.NoError(t, err)
+				require.Equal(t, tc.want, actual)
+			})
+		})
+	}
+}
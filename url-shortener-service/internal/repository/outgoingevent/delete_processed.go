@@ -0,0 +1,35 @@
+package outgoingevent
+
+import (
+	"context"
+	"time"
+
+	"github.com/aarondl/sqlboiler/v4/queries"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/monitoring"
+	pkgerrors "github.com/pkg/errors"
+)
+
+// deleteProcessedQuery deletes a batch without keeping a copy
+const deleteProcessedQuery = `
+DELETE FROM outgoing_events
+WHERE id IN (` + processedBatch + `)`
+
+// DeleteProcessed deletes up to limit events published, or failed and acknowledged, before the given time.
+// FAILED events are kept until acknowledged. It returns the number of events deleted.
+func (i impl) DeleteProcessed(ctx context.Context, before time.Time, limit int) (int64, error) {
+	var err error
+	ctx, span := monitoring.Start(ctx, "OutgoingEventRepository.DeleteProcessed")
+	defer monitoring.End(span, &err)
+
+	rs, err := queries.Raw(deleteProcessedQuery, before, limit).ExecContext(ctx, i.db)
+	if err != nil {
+		return 0, pkgerrors.WithStack(err)
+	}
+
+	n, err := rs.RowsAffected()
+	if err != nil {
+		return 0, pkgerrors.WithStack(err)
+	}
+
+	return n, nil
+}
@@ -0,0 +1,34 @@
+package outgoingevent
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/pkg/testutil"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/repository/orm"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeleteProcessed(t *testing.T) {
+	testutil.WithTxDB(t, func(tx *sql.Tx) {
+		testutil.LoadSQLFile(t, tx, "testdata/outgoing_events_retention.sql")
+
+		ctx := context.Background()
+		repo := New(tx)
+
+		n, err := repo.DeleteProcessed(ctx, time.Now().Add(-7*24*time.Hour), 10)
+		require.NoError(t, err)
+		require.Equal(t, int64(3), n)
+
+		// Recent published, unacknowledged failed and pending events are kept
+		remaining, err := orm.OutgoingEvents().All(ctx, tx)
+		require.NoError(t, err)
+		var ids []int64
+		for _, o := range remaining {
+			ids = append(ids, o.ID)
+		}
+		require.ElementsMatch(t, []int64{2, 3, 5}, ids)
+	})
+}
@@ -0,0 +1,54 @@
+package outgoingevent
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/aarondl/sqlboiler/v4/queries"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/monitoring"
+	pkgerrors "github.com/pkg/errors"
+)
+
+// listArchivePartitionsQuery lists the partitions attached to outgoing_events_archive
+const listArchivePartitionsQuery = `
+SELECT c.relname AS name
+FROM pg_inherits i
+JOIN pg_class c ON c.oid = i.inhrelid
+WHERE i.inhparent = 'outgoing_events_archive'::regclass`
+
+// DropArchivePartitionsBefore drops the monthly partitions of outgoing_events_archive entirely before the month
+// of the given time, along with the events they hold. It returns the names of the partitions dropped.
+func (i impl) DropArchivePartitionsBefore(ctx context.Context, month time.Time) ([]string, error) {
+	var err error
+	ctx, span := monitoring.Start(ctx, "OutgoingEventRepository.DropArchivePartitionsBefore")
+	defer monitoring.End(span, &err)
+
+	var partitions []struct {
+		Name string `boil:"name"`
+	}
+	if err = queries.Raw(listArchivePartitionsQuery).Bind(ctx, i.db, &partitions); err != nil {
+		return nil, pkgerrors.WithStack(err)
+	}
+
+	cutoff := monthStart(month)
+	var dropped []string
+	for _, p := range partitions {
+		// Partitions not named by the retention job are none of its business
+		suffix, ok := strings.CutPrefix(p.Name, archivePartitionPrefix)
+		if !ok {
+			continue
+		}
+		m, perr := time.Parse("200601", suffix)
+		if perr != nil || !m.Before(cutoff) {
+			continue
+		}
+
+		if _, err = queries.Raw(`DROP TABLE IF EXISTS `+p.Name).ExecContext(ctx, i.db); err != nil {
+			return dropped, pkgerrors.WithStack(err)
+		}
+		dropped = append(dropped, p.Name)
+	}
+
+	return dropped, nil
+}
@@ -0,0 +1,37 @@
+package outgoingevent
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/pkg/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDropArchivePartitionsBefore(t *testing.T) {
+	testutil.WithTxDB(t, func(tx *sql.Tx) {
+		ctx := context.Background()
+		repo := New(tx)
+
+		months := []time.Time{
+			time.Date(2001, time.January, 15, 0, 0, 0, 0, time.UTC),
+			time.Date(2001, time.February, 1, 0, 0, 0, 0, time.UTC),
+			time.Date(2001, time.March, 31, 23, 0, 0, 0, time.UTC),
+		}
+		for _, m := range months {
+			require.NoError(t, repo.EnsureArchivePartition(ctx, m))
+			// Creating it again is a no-op
+			require.NoError(t, repo.EnsureArchivePartition(ctx, m))
+		}
+
+		dropped, err := repo.DropArchivePartitionsBefore(ctx, time.Date(2001, time.March, 10, 0, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{"outgoing_events_archive_200101", "outgoing_events_archive_200102"}, dropped)
+
+		dropped, err = repo.DropArchivePartitionsBefore(ctx, time.Date(2001, time.March, 10, 0, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+		require.Empty(t, dropped)
+	})
+}
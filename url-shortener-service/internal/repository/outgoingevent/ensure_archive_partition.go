@@ -0,0 +1,42 @@
+package outgoingevent
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aarondl/sqlboiler/v4/queries"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/monitoring"
+	pkgerrors "github.com/pkg/errors"
+)
+
+// archivePartitionPrefix names the monthly partitions of outgoing_events_archive, suffixed with YYYYMM
+const archivePartitionPrefix = "outgoing_events_archive_"
+
+// EnsureArchivePartition creates the partition of outgoing_events_archive holding the events archived
+// during the month of the given time, unless it exists already.
+func (i impl) EnsureArchivePartition(ctx context.Context, month time.Time) error {
+	var err error
+	ctx, span := monitoring.Start(ctx, "OutgoingEventRepository.EnsureArchivePartition")
+	defer monitoring.End(span, &err)
+
+	from := monthStart(month)
+	to := from.AddDate(0, 1, 0)
+
+	// Identifiers and bounds cannot be bind parameters, they are formatted from the month only
+	q := fmt.Sprintf(
+		`CREATE TABLE IF NOT EXISTS %s%s PARTITION OF outgoing_events_archive FOR VALUES FROM ('%s') TO ('%s')`,
+		archivePartitionPrefix, from.Format("200601"), from.Format(time.RFC3339), to.Format(time.RFC3339),
+	)
+	if _, err = queries.Raw(q).ExecContext(ctx, i.db); err != nil {
+		return pkgerrors.WithStack(err)
+	}
+
+	return nil
+}
+
+// monthStart returns the first instant of the month of t, in UTC
+func monthStart(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,10 @@
+package outgoingevent
+
+import "errors"
+
+var (
+	// ErrNotFound means no outgoing_event record found
+	ErrNotFound = errors.New("outgoing_event record not found")
+	// ErrLeaseLost means the event is no longer claimed by the worker, its lease expired and another worker may have claimed it
+	ErrLeaseLost = errors.New("outgoing_event lease lost")
+)
@@ -0,0 +1,26 @@
+package outgoingevent
+
+import (
+	"github.com/aarondl/sqlboiler/v4/queries/qm"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/repository/orm"
+)
+
+// filterMods translates a filter into query mods, zero fields adding no condition
+func filterMods(f model.OutgoingEventFilter) []qm.QueryMod {
+	var mods []qm.QueryMod
+	if len(f.IDs) > 0 {
+		mods = append(mods, orm.OutgoingEventWhere.ID.IN(f.IDs))
+	}
+	if f.Status != "" {
+		mods = append(mods, orm.OutgoingEventWhere.Status.EQ(f.Status.String()))
+	}
+	if f.Topic != "" {
+		mods = append(mods, orm.OutgoingEventWhere.Topic.EQ(f.Topic.String()))
+	}
+	if !f.CreatedBefore.IsZero() {
+		mods = append(mods, orm.OutgoingEventWhere.CreatedAt.LT(f.CreatedBefore))
+	}
+
+	return mods
+}
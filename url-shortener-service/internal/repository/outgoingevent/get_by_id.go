@@ -0,0 +1,29 @@
+package outgoingevent
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/monitoring"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/repository/orm"
+	pkgerrors "github.com/pkg/errors"
+)
+
+// GetByID retrieves an outbox event by its ID
+func (i impl) GetByID(ctx context.Context, id int64) (model.OutgoingEvent, error) {
+	var err error
+	ctx, span := monitoring.Start(ctx, "OutgoingEventRepository.GetByID")
+	defer monitoring.End(span, &err)
+
+	o, err := orm.FindOutgoingEvent(ctx, i.db, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.OutgoingEvent{}, pkgerrors.WithStack(ErrNotFound)
+		}
+		return model.OutgoingEvent{}, pkgerrors.WithStack(err)
+	}
+
+	return toOutgoingEventModel(o)
+}
@@ -0,0 +1,50 @@
+package outgoingevent
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/pkg/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetByID(t *testing.T) {
+	tcs := map[string]struct {
+		id         int64
+		wantTopic  model.Topic
+		wantStatus model.OutgoingEventStatus
+		wantErr    error
+	}{
+		"success": {
+			id:         3,
+			wantTopic:  "evt.failed.3",
+			wantStatus: model.OutgoingEventStatusFailed,
+		},
+		"fail - not found": {
+			id:      404,
+			wantErr: ErrNotFound,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			testutil.WithTxDB(t, func(tx *sql.Tx) {
+				testutil.LoadSQLFile(t, tx, "testdata/outgoing_events_retention.sql")
+
+				actual, err := New(tx).GetByID(context.Background(), tc.id)
+				if tc.wantErr != nil {
+					require.ErrorIs(t, err, tc.wantErr)
+					return
+				}
+
+				require.NoError(t, err)
+				require.Equal(t, tc.id, actual.ID)
+				require.Equal(t, tc.wantTopic, actual.Topic)
+				require.Equal(t, tc.wantStatus, actual.Status)
+				require.Equal(t, tc.id, actual.Payload.EventID)
+			})
+		})
+	}
+}
@@ -3,6 +3,7 @@ package outgoingevent
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"testing"
 	"time"
 
@@ -36,10 +37,7 @@ func TestGetByStatus(t *testing.T) {
 					Payload: model.Payload{
 						EventID:    1,
 						OccurredAt: time.Now(),
-						Data: map[string]string{
-							"short_code":   "1",
-							"original_url": "foo.bar/1",
-						},
+						Data:       json.RawMessage(`{"short_code": "1", "original_url": "foo.bar/1"}`),
 					},
 				},
 				{
@@ -52,10 +50,7 @@ func TestGetByStatus(t *testing.T) {
 					Payload: model.Payload{
 						EventID:    2,
 						OccurredAt: time.Now(),
-						Data: map[string]string{
-							"short_code":   "2",
-							"original_url": "foo.bar/2",
-						},
+						Data:       json.RawMessage(`{"short_code": "2", "original_url": "foo.bar/2"}`),
 					},
 				},
 				{
@@ -68,10 +63,7 @@ func TestGetByStatus(t *testing.T) {
 					Payload: model.Payload{
 						EventID:    3,
 						OccurredAt: time.Now(),
-						Data: map[string]string{
-							"short_code":   "3",
-							"original_url": "foo.bar/3",
-						},
+						Data:       json.RawMessage(`{"short_code": "3", "original_url": "foo.bar/3"}`),
 					},
 				},
 			},
@@ -103,13 +95,22 @@ func TestGetByStatus(t *testing.T) {
 				require.True(t,
 					cmp.Equal(tc.want, got,
 						cmpopts.IgnoreFields(model.OutgoingEvent{},
-							"CreatedAt", "UpdatedAt", "Payload.OccurredAt")),
+							"CreatedAt", "UpdatedAt", "Payload.OccurredAt"), jsonEqual),
 					"diff: %v",
 					cmp.Diff(tc.want, got,
 						cmpopts.IgnoreFields(model.OutgoingEvent{},
-							"CreatedAt", "UpdatedAt", "Payload.OccurredAt")),
+							"CreatedAt", "UpdatedAt", "Payload.OccurredAt"), jsonEqual),
 				)
 			})
 		})
 	}
 }
+
+// jsonEqual compares JSON documents whatever their formatting, as jsonb re-encodes them
+var jsonEqual = cmp.Comparer(func(a, b json.RawMessage) bool {
+	var va, vb any
+	if json.Unmarshal(a, &va) != nil || json.Unmarshal(b, &vb) != nil {
+		return string(a) == string(b)
+	}
+	return cmp.Equal(va, vb)
+})
@@ -0,0 +1,48 @@
+package outgoingevent
+
+import (
+	"context"
+	"time"
+
+	"github.com/aarondl/null/v8"
+	"github.com/aarondl/sqlboiler/v4/queries"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/monitoring"
+	pkgerrors "github.com/pkg/errors"
+)
+
+// nextAttemptDelayQuery finds, in seconds from now, when the first PENDING event can be claimed: once due,
+// once the lease of its holder expired, and once no older event of its key is pending, as claimPendingQuery.
+// GREATEST ignores the lease of events nobody holds.
+const nextAttemptDelayQuery = `
+SELECT EXTRACT(EPOCH FROM MIN(GREATEST(next_attempt_at, locked_until)) - NOW()) AS seconds
+FROM outgoing_events
+WHERE status = 'PENDING'
+  AND NOT EXISTS (
+      SELECT 1
+      FROM outgoing_events p
+      WHERE p.message_key = outgoing_events.message_key
+        AND p.id < outgoing_events.id
+        AND p.status = 'PENDING'
+  )`
+
+// GetNextAttemptDelay returns how long until the next PENDING event can be claimed, 0 when one can be already.
+// The delay is measured on the database clock, the one ClaimPending compares to. It returns ErrNotFound
+// when no event is pending.
+func (i impl) GetNextAttemptDelay(ctx context.Context) (time.Duration, error) {
+	var err error
+	ctx, span := monitoring.Start(ctx, "OutgoingEventRepository.GetNextAttemptDelay")
+	defer monitoring.End(span, &err)
+
+	var next struct {
+		Seconds null.Float64 `boil:"seconds"`
+	}
+	if err = queries.Raw(nextAttemptDelayQuery).Bind(ctx, i.db, &next); err != nil {
+		return 0, pkgerrors.WithStack(err)
+	}
+
+	if !next.Seconds.Valid {
+		return 0, pkgerrors.WithStack(ErrNotFound)
+	}
+
+	return max(time.Duration(next.Seconds.Float64*float64(time.Second)), 0), nil
+}
@@ -0,0 +1,69 @@
+package outgoingevent
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/pkg/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetNextAttemptDelay(t *testing.T) {
+	tcs := map[string]struct {
+		fixture   string
+		query     string
+		wantDelay time.Duration
+		wantErr   error
+	}{
+		"success - event due already": {
+			fixture: "testdata/outgoing_events_leases.sql",
+			query:   "DELETE FROM outgoing_events WHERE id NOT IN (5, 6)",
+		},
+		"success - event backing off": {
+			fixture:   "testdata/outgoing_events_leases.sql",
+			query:     "DELETE FROM outgoing_events WHERE id <> 6",
+			wantDelay: 10 * time.Minute,
+		},
+		"success - backing off event claimable before a held one": {
+			fixture:   "testdata/outgoing_events_leases.sql",
+			query:     "DELETE FROM outgoing_events WHERE id NOT IN (2, 6)",
+			wantDelay: 10 * time.Minute,
+		},
+		"success - held event claimable once its lease expired": {
+			fixture:   "testdata/outgoing_events_leases.sql",
+			query:     "UPDATE outgoing_events SET status = 'PUBLISHED' WHERE id <> 2",
+			wantDelay: time.Hour,
+		},
+		"success - event behind an older one of its key waits for it": {
+			fixture:   "testdata/outgoing_events_keys.sql",
+			query:     "DELETE FROM outgoing_events WHERE id > 2",
+			wantDelay: 10 * time.Minute,
+		},
+		"fail - nothing pending": {
+			fixture: "testdata/outgoing_events_leases.sql",
+			query:   "UPDATE outgoing_events SET status = 'PUBLISHED'",
+			wantErr: ErrNotFound,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			testutil.WithTxDB(t, func(tx *sql.Tx) {
+				testutil.LoadSQLFile(t, tx, tc.fixture)
+				_, err := tx.Exec(tc.query)
+				require.NoError(t, err)
+
+				delay, err := New(tx).GetNextAttemptDelay(context.Background())
+				if tc.wantErr != nil {
+					require.ErrorIs(t, err, tc.wantErr)
+					return
+				}
+
+				require.NoError(t, err)
+				require.InDelta(t, tc.wantDelay, delay, float64(time.Second))
+			})
+		})
+	}
+}
@@ -2,6 +2,7 @@ package outgoingevent
 
 import (
 	"context"
+	"encoding/json"
 	"database/sql"
 	"testing"
 
@@ -114,9 +115,7 @@ func TestGetPendingEventsToRetry_WithMultipleEvents(t *testing.T) {
 				Status: model.OutgoingEventStatusPending,
 				Payload: model.Payload{
 					EventID: -100,
-					Data: map[string]string{
-						"test": "event1",
-					},
+					Data: json.RawMessage(`{"test": "event1"}`),
 				},
 			}
 			event2 := model.OutgoingEvent{
@@ -125,9 +124,7 @@ func TestGetPendingEventsToRetry_WithMultipleEvents(t *testing.T) {
 				Status: model.OutgoingEventStatusPending,
 				Payload: model.Payload{
 					EventID: -101,
-					Data: map[string]string{
-						"test": "event2",
-					},
+					Data: json.RawMessage(`{"test": "event2"}`),
 				},
 			}
 			event3 := model.OutgoingEvent{
@@ -136,9 +133,7 @@ func TestGetPendingEventsToRetry_WithMultipleEvents(t *testing.T) {
 				Status: model.OutgoingEventStatusPending,
 				Payload: model.Payload{
 					EventID: -102,
-					Data: map[string]string{
-						"test": "event3",
-					},
+					Data: json.RawMessage(`{"test": "event3"}`),
 				},
 			}
 
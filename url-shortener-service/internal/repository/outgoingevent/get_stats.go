@@ -0,0 +1,61 @@
+package outgoingevent
+
+import (
+	"context"
+
+	"github.com/aarondl/null/v8"
+	"github.com/aarondl/sqlboiler/v4/queries"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/monitoring"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+	pkgerrors "github.com/pkg/errors"
+)
+
+const (
+	// countByStatusAndTopicQuery counts the events of each status and topic
+	countByStatusAndTopicQuery = `
+SELECT status, topic, COUNT(*) AS count
+FROM outgoing_events
+GROUP BY status, topic
+ORDER BY status, topic`
+
+	// oldestPendingQuery finds the creation time of the oldest PENDING event
+	oldestPendingQuery = `
+SELECT MIN(created_at) AS oldest
+FROM outgoing_events
+WHERE status = 'PENDING'`
+)
+
+// GetStats counts the outbox events per status and topic, and finds the oldest PENDING one
+func (i impl) GetStats(ctx context.Context) (model.OutgoingEventStats, error) {
+	var err error
+	ctx, span := monitoring.Start(ctx, "OutgoingEventRepository.GetStats")
+	defer monitoring.End(span, &err)
+
+	var counts []struct {
+		Status string `boil:"status"`
+		Topic  string `boil:"topic"`
+		Count  int64  `boil:"count"`
+	}
+	if err = queries.Raw(countByStatusAndTopicQuery).Bind(ctx, i.db, &counts); err != nil {
+		return model.OutgoingEventStats{}, pkgerrors.WithStack(err)
+	}
+
+	var oldest struct {
+		Oldest null.Time `boil:"oldest"`
+	}
+	if err = queries.Raw(oldestPendingQuery).Bind(ctx, i.db, &oldest); err != nil {
+		return model.OutgoingEventStats{}, pkgerrors.WithStack(err)
+	}
+
+	// Time stays zero when there is no PENDING event
+	rs := model.OutgoingEventStats{OldestPendingAt: oldest.Oldest.Time}
+	for _, c := range counts {
+		rs.Counts = append(rs.Counts, model.OutgoingEventCount{
+			Status: model.OutgoingEventStatus(c.Status),
+			Topic:  model.Topic(c.Topic),
+			Count:  c.Count,
+		})
+	}
+
+	return rs, nil
+}
@@ -0,0 +1,45 @@
+package outgoingevent
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/pkg/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetStats(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		testutil.WithTxDB(t, func(tx *sql.Tx) {
+			testutil.LoadSQLFile(t, tx, "testdata/outgoing_events_retention.sql")
+
+			actual, err := New(tx).GetStats(context.Background())
+			require.NoError(t, err)
+
+			require.Equal(t, []model.OutgoingEventCount{
+				{Status: model.OutgoingEventStatusFailed, Topic: "evt.failed.3", Count: 1},
+				{Status: model.OutgoingEventStatusFailed, Topic: "evt.failed.4", Count: 1},
+				{Status: model.OutgoingEventStatusPending, Topic: "evt.pending.5", Count: 1},
+				{Status: model.OutgoingEventStatusPublished, Topic: "evt.published.1", Count: 1},
+				{Status: model.OutgoingEventStatusPublished, Topic: "evt.published.2", Count: 1},
+				{Status: model.OutgoingEventStatusPublished, Topic: "evt.published.6", Count: 1},
+			}, actual.Counts)
+			require.WithinDuration(t, time.Now().Add(-10*24*time.Hour), actual.OldestPendingAt, time.Minute)
+		})
+	})
+
+	t.Run("success - empty outbox", func(t *testing.T) {
+		testutil.WithTxDB(t, func(tx *sql.Tx) {
+			_, err := tx.Exec("TRUNCATE TABLE outgoing_events")
+			require.NoError(t, err)
+
+			actual, err := New(tx).GetStats(context.Background())
+			require.NoError(t, err)
+			require.Empty(t, actual.Counts)
+			require.True(t, actual.OldestPendingAt.IsZero())
+		})
+	})
+}
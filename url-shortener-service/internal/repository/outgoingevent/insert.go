@@ -3,6 +3,7 @@ package outgoingevent
 import (
 	"context"
 
+	"github.com/aarondl/null/v8"
 	"github.com/aarondl/sqlboiler/v4/boil"
 	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/monitoring"
 	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
@@ -19,6 +20,7 @@ func (i impl) Insert(ctx context.Context, m model.OutgoingEvent) (model.Outgoing
 	o := orm.OutgoingEvent{
 		ID:            m.ID,
 		Topic:         m.Topic.String(),
+		MessageKey:    null.NewString(m.Key, m.Key != ""),
 		Status:        m.Status.String(),
 		CorrelationID: m.CorrelationID,
 		TraceID:       m.TraceID,
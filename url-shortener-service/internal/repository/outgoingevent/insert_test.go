@@ -28,10 +28,7 @@ func TestInsert(t *testing.T) {
 				Status: model.OutgoingEventStatusPending,
 				Payload: model.Payload{
 					EventID: -10,
-					Data: map[string]string{
-						"short_code":   "abc123",
-						"original_url": "https://google.com",
-					},
+					Data:    json.RawMessage(`{"short_code": "abc123", "original_url": "https://google.com"}`),
 				},
 			},
 			want: model.OutgoingEvent{
@@ -40,13 +37,27 @@ func TestInsert(t *testing.T) {
 				Status: model.OutgoingEventStatusPending,
 				Payload: model.Payload{
 					EventID: -10,
-					Data: map[string]string{
-						"short_code":   "abc123",
-						"original_url": "https://google.com",
-					},
+					Data:    json.RawMessage(`{"short_code": "abc123", "original_url": "https://google.com"}`),
 				},
 			},
 		},
+		"success - with message key": {
+			given: model.OutgoingEvent{
+				ID:     -11,
+				Topic:  model.TopicMetadataRequestedV1,
+				Key:    "abc123",
+				Status: model.OutgoingEventStatusPending,
+				Payload: model.Payload{
+					EventID: -11,
+				},
+			},
+			want: model.OutgoingEvent{
+				ID:     -11,
+				Topic:  model.TopicMetadataRequestedV1,
+				Key:    "abc123",
+				Status: model.OutgoingEventStatusPending,
+			},
+		},
 		"fail - duplicate primary key": {
 			fixture: "testdata/outgoing_events.sql",
 			given: model.OutgoingEvent{
@@ -95,6 +106,10 @@ func TestInsert(t *testing.T) {
 					require.NoError(t, err)
 
 					require.Equal(t, tc.given.Payload.EventID, unmarshaled.EventID)
+
+					stored, err := repo.GetByID(ctx, tc.given.ID)
+					require.NoError(t, err)
+					require.Equal(t, tc.given.Key, stored.Key)
 				}
 			})
 		})
@@ -0,0 +1,39 @@
+package outgoingevent
+
+import (
+	"context"
+
+	"github.com/aarondl/sqlboiler/v4/queries/qm"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/monitoring"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/repository/orm"
+	pkgerrors "github.com/pkg/errors"
+)
+
+// List retrieves up to limit outbox events matching the filter, ordered by ID
+func (i impl) List(ctx context.Context, filter model.OutgoingEventFilter, limit int) ([]model.OutgoingEvent, error) {
+	var err error
+	ctx, span := monitoring.Start(ctx, "OutgoingEventRepository.List")
+	defer monitoring.End(span, &err)
+
+	mods := append(filterMods(filter),
+		qm.OrderBy(orm.OutgoingEventColumns.ID),
+		qm.Limit(limit))
+
+	items, err := orm.OutgoingEvents(mods...).All(ctx, i.db)
+	if err != nil {
+		return nil, pkgerrors.WithStack(err)
+	}
+
+	var rs []model.OutgoingEvent
+	for _, item := range items {
+		m, err := toOutgoingEventModel(item)
+		if err != nil {
+			return nil, pkgerrors.WithStack(err)
+		}
+
+		rs = append(rs, m)
+	}
+
+	return rs, nil
+}
@@ -0,0 +1,66 @@
+package outgoingevent
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/pkg/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestList(t *testing.T) {
+	tcs := map[string]struct {
+		filter  model.OutgoingEventFilter
+		limit   int
+		wantIDs []int64
+	}{
+		"success - no filter": {
+			limit:   10,
+			wantIDs: []int64{1, 2, 3, 4, 5, 6},
+		},
+		"success - by status": {
+			filter:  model.OutgoingEventFilter{Status: model.OutgoingEventStatusFailed},
+			limit:   10,
+			wantIDs: []int64{3, 4},
+		},
+		"success - by topic": {
+			filter:  model.OutgoingEventFilter{Topic: "evt.published.2"},
+			limit:   10,
+			wantIDs: []int64{2},
+		},
+		"success - by age": {
+			filter:  model.OutgoingEventFilter{Status: model.OutgoingEventStatusPublished, CreatedBefore: time.Now().Add(-5 * 24 * time.Hour)},
+			limit:   10,
+			wantIDs: []int64{1, 6},
+		},
+		"success - by ids with limit": {
+			filter:  model.OutgoingEventFilter{IDs: []int64{2, 4, 6}},
+			limit:   2,
+			wantIDs: []int64{2, 4},
+		},
+		"success - nothing matches": {
+			filter: model.OutgoingEventFilter{Topic: "evt.unknown"},
+			limit:  10,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			testutil.WithTxDB(t, func(tx *sql.Tx) {
+				testutil.LoadSQLFile(t, tx, "testdata/outgoing_events_retention.sql")
+
+				events, err := New(tx).List(context.Background(), tc.filter, tc.limit)
+				require.NoError(t, err)
+
+				var ids []int64
+				for _, e := range events {
+					ids = append(ids, e.ID)
+				}
+				require.Equal(t, tc.wantIDs, ids)
+			})
+		})
+	}
+}
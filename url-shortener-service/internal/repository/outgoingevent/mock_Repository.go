@@ -7,6 +7,8 @@ import (
 
 	model "github.com/kytruongdev/sturl/url-shortener-service/internal/model"
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // MockRepository is an autogenerated mock type for the Repository type
@@ -14,6 +16,224 @@ type MockRepository struct {
 	mock.Mock
 }
 
+// Acknowledge provides a mock function with given fields: _a0, _a1
+func (_m *MockRepository) Acknowledge(_a0 context.Context, _a1 []int64) (int64, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Acknowledge")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int64) (int64, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []int64) int64); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []int64) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ArchiveProcessed provides a mock function with given fields: _a0, _a1, _a2
+func (_m *MockRepository) ArchiveProcessed(_a0 context.Context, _a1 time.Time, _a2 int) (int64, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ArchiveProcessed")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) (int64, error)); ok {
+		return rf(_a0, _a1, _a2)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) int64); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, int) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ClaimPending provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *MockRepository) ClaimPending(_a0 context.Context, _a1 string, _a2 time.Duration, _a3 int) ([]model.OutgoingEvent, error) {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimPending")
+	}
+
+	var r0 []model.OutgoingEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration, int) ([]model.OutgoingEvent, error)); ok {
+		return rf(_a0, _a1, _a2, _a3)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration, int) []model.OutgoingEvent); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.OutgoingEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Duration, int) error); ok {
+		r1 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Count provides a mock function with given fields: _a0, _a1
+func (_m *MockRepository) Count(_a0 context.Context, _a1 model.OutgoingEventFilter) (int64, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Count")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.OutgoingEventFilter) (int64, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.OutgoingEventFilter) int64); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.OutgoingEventFilter) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteProcessed provides a mock function with given fields: _a0, _a1, _a2
+func (_m *MockRepository) DeleteProcessed(_a0 context.Context, _a1 time.Time, _a2 int) (int64, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteProcessed")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) (int64, error)); ok {
+		return rf(_a0, _a1, _a2)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) int64); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, int) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DropArchivePartitionsBefore provides a mock function with given fields: _a0, _a1
+func (_m *MockRepository) DropArchivePartitionsBefore(_a0 context.Context, _a1 time.Time) ([]string, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DropArchivePartitionsBefore")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]string, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []string); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EnsureArchivePartition provides a mock function with given fields: _a0, _a1
+func (_m *MockRepository) EnsureArchivePartition(_a0 context.Context, _a1 time.Time) error {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnsureArchivePartition")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetByID provides a mock function with given fields: _a0, _a1
+func (_m *MockRepository) GetByID(_a0 context.Context, _a1 int64) (model.OutgoingEvent, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 model.OutgoingEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (model.OutgoingEvent, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) model.OutgoingEvent); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(model.OutgoingEvent)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetByStatus provides a mock function with given fields: _a0, _a1, _a2
 func (_m *MockRepository) GetByStatus(_a0 context.Context, _a1 string, _a2 int) ([]model.OutgoingEvent, error) {
 	ret := _m.Called(_a0, _a1, _a2)
@@ -44,6 +264,34 @@ func (_m *MockRepository) GetByStatus(_a0 context.Context, _a1 string, _a2 int)
 	return r0, r1
 }
 
+// GetNextAttemptDelay provides a mock function with given fields: _a0
+func (_m *MockRepository) GetNextAttemptDelay(_a0 context.Context) (time.Duration, error) {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNextAttemptDelay")
+	}
+
+	var r0 time.Duration
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (time.Duration, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) time.Duration); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Get(0).(time.Duration)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetPendingEventsToRetry provides a mock function with given fields: _a0, _a1, _a2
 func (_m *MockRepository) GetPendingEventsToRetry(_a0 context.Context, _a1 string, _a2 int) ([]model.OutgoingEvent, error) {
 	ret := _m.Called(_a0, _a1, _a2)
@@ -74,6 +322,34 @@ func (_m *MockRepository) GetPendingEventsToRetry(_a0 context.Context, _a1 strin
 	return r0, r1
 }
 
+// GetStats provides a mock function with given fields: _a0
+func (_m *MockRepository) GetStats(_a0 context.Context) (model.OutgoingEventStats, error) {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStats")
+	}
+
+	var r0 model.OutgoingEventStats
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (model.OutgoingEventStats, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) model.OutgoingEventStats); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Get(0).(model.OutgoingEventStats)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Insert provides a mock function with given fields: _a0, _a1
 func (_m *MockRepository) Insert(_a0 context.Context, _a1 model.OutgoingEvent) (model.OutgoingEvent, error) {
 	ret := _m.Called(_a0, _a1)
@@ -102,6 +378,140 @@ func (_m *MockRepository) Insert(_a0 context.Context, _a1 model.OutgoingEvent) (
 	return r0, r1
 }
 
+// List provides a mock function with given fields: _a0, _a1, _a2
+func (_m *MockRepository) List(_a0 context.Context, _a1 model.OutgoingEventFilter, _a2 int) ([]model.OutgoingEvent, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []model.OutgoingEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.OutgoingEventFilter, int) ([]model.OutgoingEvent, error)); ok {
+		return rf(_a0, _a1, _a2)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.OutgoingEventFilter, int) []model.OutgoingEvent); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.OutgoingEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.OutgoingEventFilter, int) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Purge provides a mock function with given fields: _a0, _a1
+func (_m *MockRepository) Purge(_a0 context.Context, _a1 model.OutgoingEventFilter) (int64, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Purge")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.OutgoingEventFilter) (int64, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.OutgoingEventFilter) int64); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.OutgoingEventFilter) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReleaseClaim provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *MockRepository) ReleaseClaim(_a0 context.Context, _a1 model.OutgoingEvent, _a2 int64, _a3 string) error {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleaseClaim")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.OutgoingEvent, int64, string) error); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReleaseClaims provides a mock function with given fields: _a0, _a1, _a2
+func (_m *MockRepository) ReleaseClaims(_a0 context.Context, _a1 []model.OutgoingEvent, _a2 string) ([]int64, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleaseClaims")
+	}
+
+	var r0 []int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []model.OutgoingEvent, string) ([]int64, error)); ok {
+		return rf(_a0, _a1, _a2)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []model.OutgoingEvent, string) []int64); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int64)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []model.OutgoingEvent, string) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Requeue provides a mock function with given fields: _a0, _a1
+func (_m *MockRepository) Requeue(_a0 context.Context, _a1 model.OutgoingEventFilter) (int64, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Requeue")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.OutgoingEventFilter) (int64, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.OutgoingEventFilter) int64); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.OutgoingEventFilter) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Update provides a mock function with given fields: _a0, _a1, _a2
 func (_m *MockRepository) Update(_a0 context.Context, _a1 model.OutgoingEvent, _a2 int64) error {
 	ret := _m.Called(_a0, _a1, _a2)
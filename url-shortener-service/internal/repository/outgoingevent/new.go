@@ -2,17 +2,36 @@ package outgoingevent
 
 import (
 	"context"
+	"time"
 
 	"github.com/aarondl/sqlboiler/v4/boil"
 	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
 )
 
+// NotifyChannel is the Postgres channel notified whenever outgoing events are inserted
+const NotifyChannel = "outgoing_events"
+
 // Repository defines the interface for short URL data access operations.
 // It provides the specification of the functionality provided by this package.
 type Repository interface {
+	Acknowledge(context.Context, []int64) (int64, error)
+	ArchiveProcessed(context.Context, time.Time, int) (int64, error)
+	ClaimPending(context.Context, string, time.Duration, int) ([]model.OutgoingEvent, error)
+	Count(context.Context, model.OutgoingEventFilter) (int64, error)
+	DeleteProcessed(context.Context, time.Time, int) (int64, error)
+	DropArchivePartitionsBefore(context.Context, time.Time) ([]string, error)
+	EnsureArchivePartition(context.Context, time.Time) error
+	GetByID(context.Context, int64) (model.OutgoingEvent, error)
 	GetByStatus(context.Context, string, int) ([]model.OutgoingEvent, error)
+	GetNextAttemptDelay(context.Context) (time.Duration, error)
 	GetPendingEventsToRetry(context.Context, string, int) ([]model.OutgoingEvent, error)
+	GetStats(context.Context) (model.OutgoingEventStats, error)
 	Insert(context.Context, model.OutgoingEvent) (model.OutgoingEvent, error)
+	List(context.Context, model.OutgoingEventFilter, int) ([]model.OutgoingEvent, error)
+	Purge(context.Context, model.OutgoingEventFilter) (int64, error)
+	ReleaseClaim(context.Context, model.OutgoingEvent, int64, string) error
+	ReleaseClaims(context.Context, []model.OutgoingEvent, string) ([]int64, error)
+	Requeue(context.Context, model.OutgoingEventFilter) (int64, error)
 	Update(context.Context, model.OutgoingEvent, int64) error
 }
 
@@ -0,0 +1,25 @@
+package outgoingevent
+
+import (
+	"context"
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/monitoring"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/repository/orm"
+	pkgerrors "github.com/pkg/errors"
+)
+
+// Purge deletes the outbox events matching the filter, without archiving them.
+// It returns the number of events deleted.
+func (i impl) Purge(ctx context.Context, filter model.OutgoingEventFilter) (int64, error) {
+	var err error
+	ctx, span := monitoring.Start(ctx, "OutgoingEventRepository.Purge")
+	defer monitoring.End(span, &err)
+
+	n, err := orm.OutgoingEvents(filterMods(filter)...).DeleteAll(ctx, i.db)
+	if err != nil {
+		return 0, pkgerrors.WithStack(err)
+	}
+
+	return n, nil
+}
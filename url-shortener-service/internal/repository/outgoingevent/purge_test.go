@@ -0,0 +1,49 @@
+package outgoingevent
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/pkg/testutil"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/repository/orm"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPurge(t *testing.T) {
+	tcs := map[string]struct {
+		filter        model.OutgoingEventFilter
+		wantRemaining int64
+	}{
+		"success - by status": {
+			filter:        model.OutgoingEventFilter{Status: model.OutgoingEventStatusFailed},
+			wantRemaining: 4,
+		},
+		"success - by ids": {
+			filter:        model.OutgoingEventFilter{IDs: []int64{1, 2}},
+			wantRemaining: 4,
+		},
+		"success - nothing matches": {
+			filter:        model.OutgoingEventFilter{Topic: "evt.unknown"},
+			wantRemaining: 6,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			testutil.WithTxDB(t, func(tx *sql.Tx) {
+				testutil.LoadSQLFile(t, tx, "testdata/outgoing_events_retention.sql")
+
+				ctx := context.Background()
+				n, err := New(tx).Purge(ctx, tc.filter)
+				require.NoError(t, err)
+				require.Equal(t, 6-tc.wantRemaining, n)
+
+				remaining, err := orm.OutgoingEvents().Count(ctx, tx)
+				require.NoError(t, err)
+				require.Equal(t, tc.wantRemaining, remaining)
+			})
+		})
+	}
+}
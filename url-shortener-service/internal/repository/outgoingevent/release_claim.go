@@ -0,0 +1,58 @@
+package outgoingevent
+
+import (
+	"context"
+	"time"
+
+	"github.com/aarondl/null/v8"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/monitoring"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/repository/orm"
+	pkgerrors "github.com/pkg/errors"
+)
+
+// ReleaseClaim records the outcome of a publish attempt on an event claimed by workerID and releases its lease.
+// Like Update, only the fields set in the model are written. It returns ErrLeaseLost, leaving the event untouched,
+// when workerID no longer holds the lease.
+func (i impl) ReleaseClaim(ctx context.Context, m model.OutgoingEvent, id int64, workerID string) error {
+	var err error
+	ctx, span := monitoring.Start(ctx, "OutgoingEventRepository.ReleaseClaim")
+	defer monitoring.End(span, &err)
+
+	cols := orm.M{
+		orm.OutgoingEventColumns.LockedBy:    null.String{},
+		orm.OutgoingEventColumns.LockedUntil: null.Time{},
+		orm.OutgoingEventColumns.UpdatedAt:   time.Now(),
+	}
+
+	if m.Status != "" {
+		cols[orm.OutgoingEventColumns.Status] = m.Status.String()
+	}
+
+	if m.LastError != "" {
+		cols[orm.OutgoingEventColumns.LastError] = null.StringFrom(m.LastError)
+	}
+
+	if m.RetryCount > 0 {
+		cols[orm.OutgoingEventColumns.RetryCount] = m.RetryCount
+	}
+
+	if !m.NextAttemptAt.IsZero() {
+		cols[orm.OutgoingEventColumns.NextAttemptAt] = m.NextAttemptAt
+	}
+
+	rows, err := orm.OutgoingEvents(
+		orm.OutgoingEventWhere.ID.EQ(id),
+		orm.OutgoingEventWhere.LockedBy.EQ(null.StringFrom(workerID)),
+	).UpdateAll(ctx, i.db, cols)
+	if err != nil {
+		return pkgerrors.WithStack(err)
+	}
+
+	if rows == 0 {
+		err = ErrLeaseLost
+		return pkgerrors.WithStack(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+package outgoingevent
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/pkg/testutil"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/repository/orm"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReleaseClaim(t *testing.T) {
+	tcs := map[string]struct {
+		id             int64
+		workerID       string
+		update         model.OutgoingEvent
+		wantStatus     string
+		wantRetryCount int
+		wantLastError  string
+		wantNextDelay  time.Duration
+		wantErr        error
+	}{
+		"success - mark published and release lease": {
+			id:         2,
+			workerID:   "worker-b",
+			update:     model.OutgoingEvent{Status: model.OutgoingEventStatusPublished},
+			wantStatus: model.OutgoingEventStatusPublished.String(),
+		},
+		"success - record retry and release lease": {
+			id:             2,
+			workerID:       "worker-b",
+			update:         model.OutgoingEvent{RetryCount: 1, LastError: "broker unavailable", NextAttemptAt: time.Now().Add(time.Minute)},
+			wantStatus:     model.OutgoingEventStatusPending.String(),
+			wantRetryCount: 1,
+			wantLastError:  "broker unavailable",
+			wantNextDelay:  time.Minute,
+		},
+		"fail - held by another worker": {
+			id:       2,
+			workerID: "worker-a",
+			update:   model.OutgoingEvent{Status: model.OutgoingEventStatusPublished},
+			wantErr:  ErrLeaseLost,
+		},
+		"fail - not claimed": {
+			id:       1,
+			workerID: "worker-a",
+			update:   model.OutgoingEvent{Status: model.OutgoingEventStatusPublished},
+			wantErr:  ErrLeaseLost,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			testutil.WithTxDB(t, func(tx *sql.Tx) {
+				testutil.LoadSQLFile(t, tx, "testdata/outgoing_events_leases.sql")
+
+				ctx := context.Background()
+				repo := New(tx)
+
+				err := repo.ReleaseClaim(ctx, tc.update, tc.id, tc.workerID)
+				if tc.wantErr != nil {
+					require.ErrorIs(t, err, tc.wantErr)
+					return
+				}
+				require.NoError(t, err)
+
+				o, err := orm.FindOutgoingEvent(ctx, tx, tc.id)
+				require.NoError(t, err)
+				require.Equal(t, tc.wantStatus, o.Status)
+				require.Equal(t, tc.wantRetryCount, o.RetryCount)
+				require.Equal(t, tc.wantLastError, o.LastError.String)
+				require.False(t, o.LockedBy.Valid)
+				require.False(t, o.LockedUntil.Valid)
+				if tc.wantNextDelay > 0 {
+					require.WithinDuration(t, time.Now().Add(tc.wantNextDelay), o.NextAttemptAt, 10*time.Second)
+				}
+			})
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package outgoingevent
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/aarondl/sqlboiler/v4/queries"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/monitoring"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+	pkgerrors "github.com/pkg/errors"
+)
+
+// releaseClaimsQuery applies the outcome of every event of a batch in a single statement. Outcomes are passed as
+// a JSON array, absent fields keep the current value of the column, as ReleaseClaim does for zero fields.
+const releaseClaimsQuery = `
+UPDATE outgoing_events e
+SET status          = COALESCE(o.status, e.status),
+    last_error      = COALESCE(o.last_error, e.last_error),
+    retry_count     = COALESCE(o.retry_count, e.retry_count),
+    next_attempt_at = COALESCE(o.next_attempt_at, e.next_attempt_at),
+    locked_by       = NULL,
+    locked_until    = NULL,
+    updated_at      = NOW()
+FROM jsonb_to_recordset($1::jsonb) AS o(id BIGINT, status TEXT, last_error TEXT, retry_count INT, next_attempt_at TIMESTAMPTZ)
+WHERE e.id = o.id
+  AND e.locked_by = $2
+RETURNING e.id`
+
+// claimOutcome is the JSON form of an outcome in releaseClaimsQuery
+type claimOutcome struct {
+	ID            int64      `json:"id"`
+	Status        string     `json:"status,omitempty"`
+	LastError     string     `json:"last_error,omitempty"`
+	RetryCount    int        `json:"retry_count,omitempty"`
+	NextAttemptAt *time.Time `json:"next_attempt_at,omitempty"`
+}
+
+// ReleaseClaims is the bulk form of ReleaseClaim: it records the outcome of each event, identified by its ID,
+// and releases the leases workerID holds on them in one UPDATE. It returns the IDs of the released events,
+// the events missing from it were no longer leased to workerID and are left untouched.
+func (i impl) ReleaseClaims(ctx context.Context, outcomes []model.OutgoingEvent, workerID string) ([]int64, error) {
+	var err error
+	ctx, span := monitoring.Start(ctx, "OutgoingEventRepository.ReleaseClaims")
+	defer monitoring.End(span, &err)
+
+	if len(outcomes) == 0 {
+		return nil, nil
+	}
+
+	rows := make([]claimOutcome, 0, len(outcomes))
+	for _, m := range outcomes {
+		o := claimOutcome{
+			ID:         m.ID,
+			Status:     m.Status.String(),
+			LastError:  m.LastError,
+			RetryCount: m.RetryCount,
+		}
+		if !m.NextAttemptAt.IsZero() {
+			o.NextAttemptAt = &m.NextAttemptAt
+		}
+		rows = append(rows, o)
+	}
+
+	b, err := json.Marshal(rows)
+	if err != nil {
+		return nil, pkgerrors.WithStack(err)
+	}
+
+	var released []struct {
+		ID int64 `boil:"id"`
+	}
+	if err = queries.Raw(releaseClaimsQuery, string(b), workerID).Bind(ctx, i.db, &released); err != nil {
+		return nil, pkgerrors.WithStack(err)
+	}
+
+	ids := make([]int64, 0, len(released))
+	for _, r := range released {
+		ids = append(ids, r.ID)
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,82 @@
+package outgoingevent
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/pkg/testutil"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/repository/orm"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReleaseClaims(t *testing.T) {
+	tcs := map[string]struct {
+		workerID     string
+		outcomes     []model.OutgoingEvent
+		wantReleased []int64
+		wantStatus   map[int64]string
+	}{
+		"success - mark published and release lease": {
+			workerID: "worker-c",
+			outcomes: []model.OutgoingEvent{
+				{ID: 3, Status: model.OutgoingEventStatusPublished},
+			},
+			wantReleased: []int64{3},
+			wantStatus:   map[int64]string{3: model.OutgoingEventStatusPublished.String()},
+		},
+		"success - skip events leased to another worker": {
+			workerID: "worker-b",
+			outcomes: []model.OutgoingEvent{
+				{ID: 2, RetryCount: 1, LastError: "broker unavailable", NextAttemptAt: time.Now().Add(time.Minute)},
+				{ID: 3, Status: model.OutgoingEventStatusFailed, LastError: "broker unavailable"},
+			},
+			wantReleased: []int64{2},
+			wantStatus: map[int64]string{
+				2: model.OutgoingEventStatusPending.String(),
+				3: model.OutgoingEventStatusPending.String(),
+			},
+		},
+		"success - no outcomes": {
+			workerID:     "worker-b",
+			wantReleased: []int64{},
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			testutil.WithTxDB(t, func(tx *sql.Tx) {
+				testutil.LoadSQLFile(t, tx, "testdata/outgoing_events_leases.sql")
+
+				ctx := context.Background()
+				repo := New(tx)
+
+				released, err := repo.ReleaseClaims(ctx, tc.outcomes, tc.workerID)
+				require.NoError(t, err)
+				require.ElementsMatch(t, tc.wantReleased, released)
+
+				for id, status := range tc.wantStatus {
+					o, err := orm.FindOutgoingEvent(ctx, tx, id)
+					require.NoError(t, err)
+					require.Equal(t, status, o.Status)
+				}
+
+				if len(tc.outcomes) > 0 && tc.workerID == "worker-b" {
+					o, err := orm.FindOutgoingEvent(ctx, tx, 2)
+					require.NoError(t, err)
+					require.Equal(t, 1, o.RetryCount)
+					require.Equal(t, "broker unavailable", o.LastError.String)
+					require.False(t, o.LockedBy.Valid)
+					require.WithinDuration(t, time.Now().Add(time.Minute), o.NextAttemptAt, 10*time.Second)
+
+					// Still leased to worker-c
+					o, err = orm.FindOutgoingEvent(ctx, tx, 3)
+					require.NoError(t, err)
+					require.Equal(t, "worker-c", o.LockedBy.String)
+				}
+			})
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package outgoingevent
+
+import (
+	"context"
+	"time"
+
+	"github.com/aarondl/null/v8"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/monitoring"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/repository/orm"
+	pkgerrors "github.com/pkg/errors"
+)
+
+// Requeue turns the FAILED events matching the filter back to PENDING for an immediate attempt,
+// with a fresh retry count. The last error is kept until the next attempt. Events of another status
+// are left untouched whatever the filter. It returns the number of events requeued.
+func (i impl) Requeue(ctx context.Context, filter model.OutgoingEventFilter) (int64, error) {
+	var err error
+	ctx, span := monitoring.Start(ctx, "OutgoingEventRepository.Requeue")
+	defer monitoring.End(span, &err)
+
+	filter.Status = model.OutgoingEventStatusFailed
+
+	now := time.Now()
+	n, err := orm.OutgoingEvents(filterMods(filter)...).UpdateAll(ctx, i.db, orm.M{
+		orm.OutgoingEventColumns.Status:         model.OutgoingEventStatusPending.String(),
+		orm.OutgoingEventColumns.RetryCount:     0,
+		orm.OutgoingEventColumns.NextAttemptAt:  now,
+		orm.OutgoingEventColumns.LockedBy:       null.String{},
+		orm.OutgoingEventColumns.LockedUntil:    null.Time{},
+		orm.OutgoingEventColumns.AcknowledgedAt: null.Time{},
+		orm.OutgoingEventColumns.UpdatedAt:      now,
+	})
+	if err != nil {
+		return 0, pkgerrors.WithStack(err)
+	}
+
+	return n, nil
+}
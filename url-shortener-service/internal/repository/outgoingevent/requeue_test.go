@@ -0,0 +1,54 @@
+package outgoingevent
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/pkg/testutil"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/repository/orm"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequeue(t *testing.T) {
+	tcs := map[string]struct {
+		filter      model.OutgoingEventFilter
+		wantRequeue []int64
+	}{
+		"success - single event": {
+			filter:      model.OutgoingEventFilter{IDs: []int64{3}},
+			wantRequeue: []int64{3},
+		},
+		"success - all failed events": {
+			wantRequeue: []int64{3, 4},
+		},
+		"success - other statuses left untouched": {
+			filter: model.OutgoingEventFilter{IDs: []int64{1, 5}, Status: model.OutgoingEventStatusPublished},
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			testutil.WithTxDB(t, func(tx *sql.Tx) {
+				testutil.LoadSQLFile(t, tx, "testdata/outgoing_events_retention.sql")
+				_, err := tx.Exec(`UPDATE outgoing_events SET retry_count = 5, last_error = 'broker down' WHERE status = 'FAILED'`)
+				require.NoError(t, err)
+
+				ctx := context.Background()
+				n, err := New(tx).Requeue(ctx, tc.filter)
+				require.NoError(t, err)
+				require.Equal(t, int64(len(tc.wantRequeue)), n)
+
+				for _, id := range tc.wantRequeue {
+					o, err := orm.FindOutgoingEvent(ctx, tx, id)
+					require.NoError(t, err)
+					require.Equal(t, model.OutgoingEventStatusPending.String(), o.Status)
+					require.Zero(t, o.RetryCount)
+					require.Equal(t, "broker down", o.LastError.String)
+					require.False(t, o.AcknowledgedAt.Valid)
+				}
+			})
+		})
+	}
+}
@@ -0,0 +1,12 @@
+TRUNCATE TABLE outgoing_events RESTART IDENTITY;
+
+INSERT INTO outgoing_events (id, topic, message_key, correlation_id, trace_id, span_id, payload, status, locked_by, locked_until, next_attempt_at, created_at, updated_at)
+VALUES
+    (1, 'evt.backoff.1', 'abc', 'c1', 't1', 's1', '{"event_id":1}', 'PENDING',   NULL,       NULL,                      NOW() + INTERVAL '10 minute', NOW(), NOW()),
+    (2, 'evt.behind.2',  'abc', 'c2', 't2', 's2', '{"event_id":2}', 'PENDING',   NULL,       NULL,                      NOW(),                        NOW(), NOW()),
+    (3, 'evt.sent.3',    'xyz', 'c3', 't3', 's3', '{"event_id":3}', 'PUBLISHED', NULL,       NULL,                      NOW(),                        NOW(), NOW()),
+    (4, 'evt.pending.4', 'xyz', 'c4', 't4', 's4', '{"event_id":4}', 'PENDING',   NULL,       NULL,                      NOW(),                        NOW(), NOW()),
+    (5, 'evt.nokey.5',   NULL,  'c5', 't5', 's5', '{"event_id":5}', 'PENDING',   NULL,       NULL,                      NOW(),                        NOW(), NOW()),
+    (6, 'evt.nokey.6',   NULL,  'c6', 't6', 's6', '{"event_id":6}', 'PENDING',   NULL,       NULL,                      NOW(),                        NOW(), NOW()),
+    (7, 'evt.leased.7',  'def', 'c7', 't7', 's7', '{"event_id":7}', 'PENDING',   'worker-b', NOW() + INTERVAL '1 hour', NOW(),                        NOW(), NOW()),
+    (8, 'evt.behind.8',  'def', 'c8', 't8', 's8', '{"event_id":8}', 'PENDING',   NULL,       NULL,                      NOW(),                        NOW(), NOW());
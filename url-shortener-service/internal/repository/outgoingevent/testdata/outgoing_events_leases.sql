@@ -0,0 +1,10 @@
+TRUNCATE TABLE outgoing_events RESTART IDENTITY;
+
+INSERT INTO outgoing_events (id, topic, correlation_id, trace_id, span_id, payload, status, locked_by, locked_until, next_attempt_at, created_at, updated_at)
+VALUES
+    (1, 'evt.pending.1',   'c1', 't1', 's1', '{"event_id":1}', 'PENDING',   NULL,       NULL,                          NOW(),                        NOW(), NOW()),
+    (2, 'evt.leased.2',    'c2', 't2', 's2', '{"event_id":2}', 'PENDING',   'worker-b', NOW() + INTERVAL '1 hour',     NOW(),                        NOW(), NOW()),
+    (3, 'evt.abandoned.3', 'c3', 't3', 's3', '{"event_id":3}', 'PENDING',   'worker-c', NOW() - INTERVAL '1 minute',   NOW(),                        NOW(), NOW()),
+    (4, 'evt.sent.4',      'c4', 't4', 's4', '{"event_id":4}', 'PUBLISHED', NULL,       NULL,                          NOW(),                        NOW(), NOW()),
+    (5, 'evt.pending.5',   'c5', 't5', 's5', '{"event_id":5}', 'PENDING',   NULL,       NULL,                          NOW() - INTERVAL '1 second',  NOW(), NOW()),
+    (6, 'evt.backoff.6',   'c6', 't6', 's6', '{"event_id":6}', 'PENDING',   NULL,       NULL,                          NOW() + INTERVAL '10 minute', NOW(), NOW());
@@ -0,0 +1,10 @@
+TRUNCATE TABLE outgoing_events RESTART IDENTITY;
+
+INSERT INTO outgoing_events (id, topic, correlation_id, trace_id, span_id, payload, status, acknowledged_at, created_at, updated_at)
+VALUES
+    (1, 'evt.published.1', 'c1', 't1', 's1', '{"event_id":1}', 'PUBLISHED', NULL,                      NOW() - INTERVAL '10 day', NOW() - INTERVAL '10 day'),
+    (2, 'evt.published.2', 'c2', 't2', 's2', '{"event_id":2}', 'PUBLISHED', NULL,                      NOW() - INTERVAL '1 day',  NOW() - INTERVAL '1 day'),
+    (3, 'evt.failed.3',    'c3', 't3', 's3', '{"event_id":3}', 'FAILED',    NULL,                      NOW() - INTERVAL '10 day', NOW() - INTERVAL '10 day'),
+    (4, 'evt.failed.4',    'c4', 't4', 's4', '{"event_id":4}', 'FAILED',    NOW() - INTERVAL '10 day', NOW() - INTERVAL '12 day', NOW() - INTERVAL '10 day'),
+    (5, 'evt.pending.5',   'c5', 't5', 's5', '{"event_id":5}', 'PENDING',   NULL,                      NOW() - INTERVAL '10 day', NOW() - INTERVAL '10 day'),
+    (6, 'evt.published.6', 'c6', 't6', 's6', '{"event_id":6}', 'PUBLISHED', NULL,                      NOW() - INTERVAL '20 day', NOW() - INTERVAL '20 day');
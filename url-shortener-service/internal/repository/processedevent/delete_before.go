@@ -0,0 +1,43 @@
+package processedevent
+
+import (
+	"context"
+	"time"
+
+	"github.com/aarondl/sqlboiler/v4/queries"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/monitoring"
+	pkgerrors "github.com/pkg/errors"
+)
+
+// deleteBeforeQuery deletes, oldest first, a batch of events processed before $1. Rows locked by a concurrent
+// job are skipped.
+const deleteBeforeQuery = `
+DELETE FROM processed_events
+WHERE (consumer_group, event_id) IN (
+    SELECT consumer_group, event_id
+    FROM processed_events
+    WHERE processed_at < $1
+    ORDER BY processed_at
+    LIMIT $2
+    FOR UPDATE SKIP LOCKED
+)`
+
+// DeleteBefore deletes up to limit events processed before the given time. Their redeliveries are not expected
+// anymore: the outbox publishes duplicates and Kafka redelivers within minutes. It returns the number of events deleted.
+func (i impl) DeleteBefore(ctx context.Context, before time.Time, limit int) (int64, error) {
+	var err error
+	ctx, span := monitoring.Start(ctx, "ProcessedEventRepository.DeleteBefore")
+	defer monitoring.End(span, &err)
+
+	rs, err := queries.Raw(deleteBeforeQuery, before, limit).ExecContext(ctx, i.db)
+	if err != nil {
+		return 0, pkgerrors.WithStack(err)
+	}
+
+	n, err := rs.RowsAffected()
+	if err != nil {
+		return 0, pkgerrors.WithStack(err)
+	}
+
+	return n, nil
+}
@@ -0,0 +1,60 @@
+package processedevent
+
+import (
+	"context"
+	"database/sql"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/pkg/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeleteBefore(t *testing.T) {
+	tcs := map[string]struct {
+		limit     int
+		wantN     int64
+		wantAfter map[string][]int64
+	}{
+		"success - all expired events deleted": {
+			limit: 10,
+			wantN: 3,
+			wantAfter: map[string][]int64{
+				"metadata-requested": {3},
+				"metadata-crawled":   {3},
+			},
+		},
+		"success - oldest expired events deleted first": {
+			limit: 2,
+			wantN: 2,
+			wantAfter: map[string][]int64{
+				"metadata-requested": {3},
+				"metadata-crawled":   {2, 3},
+			},
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			testutil.WithTxDB(t, func(tx *sql.Tx) {
+				testutil.LoadSQLFile(t, tx, "testdata/processed_events_retention.sql")
+
+				ctx := context.Background()
+				repo := New(tx)
+
+				n, err := repo.DeleteBefore(ctx, time.Now().Add(-7*24*time.Hour), tc.limit)
+				require.NoError(t, err)
+				require.Equal(t, tc.wantN, n)
+
+				for group, ids := range tc.wantAfter {
+					for _, id := range []int64{1, 2, 3} {
+						found, err := repo.Exists(ctx, group, id)
+						require.NoError(t, err)
+						require.Equal(t, slices.Contains(ids, id), found, "%s %d", group, id)
+					}
+				}
+			})
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package processedevent
+
+import (
+	"context"
+
+	"github.com/aarondl/sqlboiler/v4/queries"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/monitoring"
+	pkgerrors "github.com/pkg/errors"
+)
+
+// existsQuery tells whether a group processed an event
+const existsQuery = `
+SELECT EXISTS (
+    SELECT 1 FROM processed_events WHERE consumer_group = $1 AND event_id = $2
+) AS found`
+
+// Exists tells whether the consumer group processed the event
+func (i impl) Exists(ctx context.Context, consumerGroup string, eventID int64) (bool, error) {
+	var err error
+	ctx, span := monitoring.Start(ctx, "ProcessedEventRepository.Exists")
+	defer monitoring.End(span, &err)
+
+	var rs struct {
+		Found bool `boil:"found"`
+	}
+	if err = queries.Raw(existsQuery, consumerGroup, eventID).Bind(ctx, i.db, &rs); err != nil {
+		return false, pkgerrors.WithStack(err)
+	}
+
+	return rs.Found, nil
+}
@@ -0,0 +1,44 @@
+package processedevent
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/pkg/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExists(t *testing.T) {
+	tcs := map[string]struct {
+		consumerGroup string
+		eventID       int64
+		want          bool
+	}{
+		"success - processed": {
+			consumerGroup: "metadata-requested",
+			eventID:       42,
+			want:          true,
+		},
+		"success - processed by another group only": {
+			consumerGroup: "metadata-requested",
+			eventID:       43,
+		},
+		"success - unknown event": {
+			consumerGroup: "metadata-requested",
+			eventID:       404,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			testutil.WithTxDB(t, func(tx *sql.Tx) {
+				testutil.LoadSQLFile(t, tx, "testdata/processed_events.sql")
+
+				found, err := New(tx).Exists(context.Background(), tc.consumerGroup, tc.eventID)
+				require.NoError(t, err)
+				require.Equal(t, tc.want, found)
+			})
+		})
+	}
+}
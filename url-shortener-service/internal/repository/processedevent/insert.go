@@ -0,0 +1,37 @@
+package processedevent
+
+import (
+	"context"
+
+	"github.com/aarondl/sqlboiler/v4/queries"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/monitoring"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+	pkgerrors "github.com/pkg/errors"
+)
+
+// insertQuery records an event as processed by a group, unless it is already
+const insertQuery = `
+INSERT INTO processed_events (consumer_group, event_id, topic)
+VALUES ($1, $2, $3)
+ON CONFLICT (consumer_group, event_id) DO NOTHING`
+
+// Insert records an event as processed by its consumer group. It returns false when the group processed it already.
+// Within a transaction, a concurrent insert of the same event waits for the other transaction to end, so that only
+// one of them records it.
+func (i impl) Insert(ctx context.Context, e model.ProcessedEvent) (bool, error) {
+	var err error
+	ctx, span := monitoring.Start(ctx, "ProcessedEventRepository.Insert")
+	defer monitoring.End(span, &err)
+
+	rs, err := queries.Raw(insertQuery, e.ConsumerGroup, e.EventID, e.Topic.String()).ExecContext(ctx, i.db)
+	if err != nil {
+		return false, pkgerrors.WithStack(err)
+	}
+
+	n, err := rs.RowsAffected()
+	if err != nil {
+		return false, pkgerrors.WithStack(err)
+	}
+
+	return n == 1, nil
+}
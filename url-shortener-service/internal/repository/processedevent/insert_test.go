@@ -0,0 +1,47 @@
+package processedevent
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/pkg/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInsert(t *testing.T) {
+	tcs := map[string]struct {
+		event        model.ProcessedEvent
+		wantInserted bool
+	}{
+		"success - event not processed yet": {
+			event:        model.ProcessedEvent{ConsumerGroup: "metadata-requested", EventID: 43, Topic: model.TopicMetadataRequestedV1},
+			wantInserted: true,
+		},
+		"success - event processed by another group": {
+			event:        model.ProcessedEvent{ConsumerGroup: "metadata-crawled", EventID: 42, Topic: model.TopicMetadataRequestedV1},
+			wantInserted: true,
+		},
+		"success - event processed already": {
+			event: model.ProcessedEvent{ConsumerGroup: "metadata-requested", EventID: 42, Topic: model.TopicMetadataRequestedV1},
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			testutil.WithTxDB(t, func(tx *sql.Tx) {
+				testutil.LoadSQLFile(t, tx, "testdata/processed_events.sql")
+
+				ctx := context.Background()
+				inserted, err := New(tx).Insert(ctx, tc.event)
+				require.NoError(t, err)
+				require.Equal(t, tc.wantInserted, inserted)
+
+				found, err := New(tx).Exists(ctx, tc.event.ConsumerGroup, tc.event.EventID)
+				require.NoError(t, err)
+				require.True(t, found)
+			})
+		})
+	}
+}
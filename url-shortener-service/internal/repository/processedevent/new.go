@@ -0,0 +1,28 @@
+package processedevent
+
+import (
+	"context"
+	"time"
+
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+)
+
+// Repository defines the interface for the consumer inbox data access operations.
+// It provides the specification of the functionality provided by this package.
+type Repository interface {
+	Exists(context.Context, string, int64) (bool, error)
+	Insert(context.Context, model.ProcessedEvent) (bool, error)
+	DeleteBefore(context.Context, time.Time, int) (int64, error)
+}
+
+// impl is the implementation of the repository
+type impl struct {
+	db boil.ContextExecutor
+}
+
+// New creates and returns a new Repository instance with the provided database.
+// It returns a new instance of the repository for accessing the consumer inbox.
+func New(db boil.ContextExecutor) Repository {
+	return &impl{db: db}
+}
@@ -0,0 +1,3 @@
+INSERT INTO processed_events (consumer_group, event_id, topic, processed_at)
+VALUES ('metadata-requested', 42, 'urlshortener.metadata.requested.v1', '2025-01-02 03:04:05+00'),
+       ('metadata-crawled', 43, 'urlshortener.metadata.crawled.v1', '2025-01-02 03:04:05+00');
@@ -0,0 +1,6 @@
+INSERT INTO processed_events (consumer_group, event_id, topic, processed_at)
+VALUES ('metadata-requested', 1, 'urlshortener.metadata.requested.v1', NOW() - INTERVAL '10 days'),
+       ('metadata-requested', 2, 'urlshortener.metadata.requested.v1', NOW() - INTERVAL '9 days'),
+       ('metadata-crawled', 2, 'urlshortener.metadata.crawled.v1', NOW() - INTERVAL '8 days'),
+       ('metadata-requested', 3, 'urlshortener.metadata.requested.v1', NOW() - INTERVAL '1 day'),
+       ('metadata-crawled', 3, 'urlshortener.metadata.crawled.v1', NOW());
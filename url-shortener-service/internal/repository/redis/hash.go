@@ -0,0 +1,28 @@
+package redis
+
+import (
+	"context"
+
+	pkgerrors "github.com/pkg/errors"
+	"github.com/redis/go-redis/v9"
+)
+
+// HIncrBy increments the integer value of a field of the hash at key, creating both when needed.
+func (i impl) HIncrBy(ctx context.Context, key, field string, incr int64) *redis.IntCmd {
+	return i.redis.HIncrBy(ctx, key, field, incr)
+}
+
+// HGetAllDel gets every field of the hash at key and deletes it in one transaction, so that increments made
+// meanwhile land in a new hash instead of being lost. A missing key gives an empty map.
+func (i impl) HGetAllDel(ctx context.Context, key string) (map[string]string, error) {
+	var fields *redis.MapStringStringCmd
+	if _, err := i.redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		fields = pipe.HGetAll(ctx, key)
+		pipe.Del(ctx, key)
+		return nil
+	}); err != nil {
+		return nil, pkgerrors.WithStack(err)
+	}
+
+	return fields.Val(), nil
+}
@@ -0,0 +1,59 @@
+package redis
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHIncrBy(t *testing.T) {
+	rdb := initRedisClientForTestingPurpose()
+	ctx := context.Background()
+	repo := &impl{redis: rdb}
+	require.NoError(t, rdb.Del(ctx, "hash:incr").Err())
+
+	require.Equal(t, int64(1), repo.HIncrBy(ctx, "hash:incr", "abc", 1).Val())
+	require.Equal(t, int64(3), repo.HIncrBy(ctx, "hash:incr", "abc", 2).Val())
+	require.Equal(t, int64(1), repo.HIncrBy(ctx, "hash:incr", "xyz", 1).Val())
+
+	got, err := rdb.HGetAll(ctx, "hash:incr").Result()
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"abc": "3", "xyz": "1"}, got)
+}
+
+func TestHGetAllDel(t *testing.T) {
+	rdb := initRedisClientForTestingPurpose()
+	ctx := context.Background()
+	repo := &impl{redis: rdb}
+
+	tcs := map[string]struct {
+		existing map[string]string
+		want     map[string]string
+	}{
+		"hash taken and deleted": {
+			existing: map[string]string{"abc": "3", "xyz": "1"},
+			want:     map[string]string{"abc": "3", "xyz": "1"},
+		},
+		"missing key": {
+			want: map[string]string{},
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			require.NoError(t, rdb.Del(ctx, "hash:take").Err())
+			if tc.existing != nil {
+				require.NoError(t, rdb.HSet(ctx, "hash:take", tc.existing).Err())
+			}
+
+			got, err := repo.HGetAllDel(ctx, "hash:take")
+			require.NoError(t, err)
+			require.Equal(t, tc.want, got)
+
+			exists, err := rdb.Exists(ctx, "hash:take").Result()
+			require.NoError(t, err)
+			require.Zero(t, exists)
+		})
+	}
+}
@@ -16,6 +16,33 @@ type MockRedisClient struct {
 	mock.Mock
 }
 
+// Del provides a mock function with given fields: ctx, keys
+func (_m *MockRedisClient) Del(ctx context.Context, keys ...string) *v9.IntCmd {
+	_va := make([]interface{}, len(keys))
+	for _i := range keys {
+		_va[_i] = keys[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Del")
+	}
+
+	var r0 *v9.IntCmd
+	if rf, ok := ret.Get(0).(func(context.Context, ...string) *v9.IntCmd); ok {
+		r0 = rf(ctx, keys...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v9.IntCmd)
+		}
+	}
+
+	return r0
+}
+
 // GetBool provides a mock function with given fields: ctx, key
 func (_m *MockRedisClient) GetBool(ctx context.Context, key string) (bool, error) {
 	ret := _m.Called(ctx, key)
@@ -158,6 +185,56 @@ func (_m *MockRedisClient) GetString(ctx context.Context, key string) (string, e
 	return r0, r1
 }
 
+// HGetAllDel provides a mock function with given fields: ctx, key
+func (_m *MockRedisClient) HGetAllDel(ctx context.Context, key string) (map[string]string, error) {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HGetAllDel")
+	}
+
+	var r0 map[string]string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (map[string]string, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) map[string]string); ok {
+		r0 = rf(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HIncrBy provides a mock function with given fields: ctx, key, field, incr
+func (_m *MockRedisClient) HIncrBy(ctx context.Context, key string, field string, incr int64) *v9.IntCmd {
+	ret := _m.Called(ctx, key, field, incr)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HIncrBy")
+	}
+
+	var r0 *v9.IntCmd
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int64) *v9.IntCmd); ok {
+		r0 = rf(ctx, key, field, incr)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v9.IntCmd)
+		}
+	}
+
+	return r0
+}
+
 // Ping provides a mock function with given fields: ctx
 func (_m *MockRedisClient) Ping(ctx context.Context) *v9.StatusCmd {
 	ret := _m.Called(ctx)
@@ -198,6 +275,26 @@ func (_m *MockRedisClient) Set(ctx context.Context, key string, value interface{
 	return r0
 }
 
+// SetNX provides a mock function with given fields: ctx, key, value, ttl
+func (_m *MockRedisClient) SetNX(ctx context.Context, key string, value interface{}, ttl time.Duration) *v9.BoolCmd {
+	ret := _m.Called(ctx, key, value, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetNX")
+	}
+
+	var r0 *v9.BoolCmd
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}, time.Duration) *v9.BoolCmd); ok {
+		r0 = rf(ctx, key, value, ttl)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v9.BoolCmd)
+		}
+	}
+
+	return r0
+}
+
 // NewMockRedisClient creates a new instance of MockRedisClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockRedisClient(t interface {
@@ -18,6 +18,10 @@ type RedisClient interface {
 	GetString(ctx context.Context, key string) (string, error)
 	GetBytes(ctx context.Context, key string) ([]byte, error)
 	Set(ctx context.Context, key string, value interface{}, ttl time.Duration) *redis.StatusCmd
+	SetNX(ctx context.Context, key string, value interface{}, ttl time.Duration) *redis.BoolCmd
+	Del(ctx context.Context, keys ...string) *redis.IntCmd
+	HIncrBy(ctx context.Context, key, field string, incr int64) *redis.IntCmd
+	HGetAllDel(ctx context.Context, key string) (map[string]string, error)
 	Ping(ctx context.Context) *redis.StatusCmd
 }
 type impl struct {
@@ -12,6 +12,17 @@ func (i impl) Set(ctx context.Context, key string, value interface{}, ttl time.D
 	return i.redis.Set(ctx, key, value, ttl)
 }
 
+// SetNX sets a key/value pair which expires in Redis only if the key does not exist yet.
+// The result is true when the key was set.
+func (i impl) SetNX(ctx context.Context, key string, value interface{}, ttl time.Duration) *redis.BoolCmd {
+	return i.redis.SetNX(ctx, key, value, ttl)
+}
+
+// Del deletes keys, missing ones being ignored. The result is the number of keys deleted.
+func (i impl) Del(ctx context.Context, keys ...string) *redis.IntCmd {
+	return i.redis.Del(ctx, keys...)
+}
+
 // Ping checks the connection to Redis by sending a PING command.
 func (i impl) Ping(ctx context.Context) *redis.StatusCmd {
 	return i.redis.Ping(ctx)
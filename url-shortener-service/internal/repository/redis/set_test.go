@@ -65,3 +65,64 @@ func TestSet(t *testing.T) {
 		})
 	}
 }
+
+func TestSetNX(t *testing.T) {
+	rdb := initRedisClientForTestingPurpose()
+	ctx := context.Background()
+	repo := &impl{redis: rdb}
+
+	type testCase struct {
+		existing    interface{}
+		value       interface{}
+		expectSet   bool
+		expectValue string
+	}
+
+	tcs := map[string]testCase{
+		"key does not exist": {
+			value:       "first",
+			expectSet:   true,
+			expectValue: "first",
+		},
+		"key already exists": {
+			existing:    "first",
+			value:       "second",
+			expectSet:   false,
+			expectValue: "first",
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			key := "setnx:" + name
+			require.NoError(t, rdb.Del(ctx, key).Err())
+			if tc.existing != nil {
+				require.NoError(t, rdb.Set(ctx, key, tc.existing, 5*time.Second).Err())
+			}
+
+			ok, err := repo.SetNX(ctx, key, tc.value, 5*time.Second).Result()
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectSet, ok)
+
+			got, err := rdb.Get(ctx, key).Result()
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectValue, got)
+		})
+	}
+}
+
+func TestDel(t *testing.T) {
+	rdb := initRedisClientForTestingPurpose()
+	ctx := context.Background()
+	repo := &impl{redis: rdb}
+	require.NoError(t, rdb.Set(ctx, "del:a", "a", 5*time.Second).Err())
+	require.NoError(t, rdb.Del(ctx, "del:missing").Err())
+
+	deleted, err := repo.Del(ctx, "del:a", "del:missing").Result()
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+
+	exists, err := rdb.Exists(ctx, "del:a").Result()
+	require.NoError(t, err)
+	assert.Zero(t, exists)
+}
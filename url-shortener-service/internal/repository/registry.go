@@ -9,7 +9,9 @@ import (
 	"github.com/cenkalti/backoff/v4"
 	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/db/pg"
 	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/monitoring"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/repository/blockeddestination"
 	"github.com/kytruongdev/sturl/url-shortener-service/internal/repository/outgoingevent"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/repository/processedevent"
 	redisRepo "github.com/kytruongdev/sturl/url-shortener-service/internal/repository/redis"
 	"github.com/kytruongdev/sturl/url-shortener-service/internal/repository/shorturl"
 )
@@ -19,6 +21,8 @@ import (
 type Registry interface {
 	ShortUrl() shorturl.Repository
 	OutgoingEvent() outgoingevent.Repository
+	BlockedDestination() blockeddestination.Repository
+	ProcessedEvent() processedevent.Repository
 	DoInTx(ctx context.Context, backoffPolicy backoff.BackOff, fn func(ctx context.Context, txRepo Registry) error) error
 }
 
@@ -29,6 +33,8 @@ type impl struct {
 	redisClient   redisRepo.RedisClient
 	shortUrl      shorturl.Repository
 	outgoingEvent outgoingevent.Repository
+	blocked       blockeddestination.Repository
+	processed     processedevent.Repository
 }
 
 // New creates a new non-transactional repository registry.
@@ -38,6 +44,8 @@ func New(db *sql.DB, redisClient redisRepo.RedisClient) Registry {
 		redisClient:   redisClient,
 		shortUrl:      shorturl.New(db, redisClient),
 		outgoingEvent: outgoingevent.New(db),
+		blocked:       blockeddestination.New(db),
+		processed:     processedevent.New(db),
 	}
 }
 
@@ -51,6 +59,16 @@ func (i impl) OutgoingEvent() outgoingevent.Repository {
 	return i.outgoingEvent
 }
 
+// BlockedDestination returns the blockeddestination repository.
+func (i impl) BlockedDestination() blockeddestination.Repository {
+	return i.blocked
+}
+
+// ProcessedEvent returns the processedevent repository.
+func (i impl) ProcessedEvent() processedevent.Repository {
+	return i.processed
+}
+
 // DoInTx runs the provided function within a database transaction,
 // automatically handling retries for transient errors (e.g., deadlocks,
 // serialization failures) using an exponential backoff strategy.
@@ -59,6 +77,9 @@ func (i impl) OutgoingEvent() outgoingevent.Repository {
 //
 // Inside 'fn', a new transactional Registry instance is passed,
 // where repository operations share the same *sql.Tx context.
+//
+// When ctx handles a consumer event not recorded yet, see WithInboxEvent, the transaction records it as processed
+// first, and fails with ErrEventProcessed if its consumer group processed it already.
 func (i impl) DoInTx(ctx context.Context, backoffPolicy backoff.BackOff, fn func(ctx context.Context, txRepo Registry) error) error {
 	if backoffPolicy == nil {
 		backoffPolicy = pg.ExponentialBackOff(3, time.Minute)
@@ -68,11 +89,29 @@ func (i impl) DoInTx(ctx context.Context, backoffPolicy backoff.BackOff, fn func
 	spanCtx, span := monitoring.Start(ctx, "Repository.DoInTx")
 	defer monitoring.End(span, &err)
 
-	return pg.TxWithBackoff(spanCtx, i.db, backoffPolicy, func(ctx context.Context, tx boil.ContextExecutor) error {
-		return fn(ctx, impl{
+	inbox := pendingInboxEvent(ctx)
+	if err = pg.TxWithBackoff(spanCtx, i.db, backoffPolicy, func(ctx context.Context, tx boil.ContextExecutor) error {
+		txRepo := impl{
 			tx:            tx,
 			shortUrl:      shorturl.New(tx, i.redisClient),
 			outgoingEvent: outgoingevent.New(tx),
-		})
-	})
+			blocked:       blockeddestination.New(tx),
+			processed:     processedevent.New(tx),
+		}
+
+		if inbox != nil {
+			if err := recordInboxEvent(ctx, txRepo, inbox); err != nil {
+				return err
+			}
+		}
+
+		return fn(ctx, txRepo)
+	}); err != nil {
+		return err
+	}
+
+	if inbox != nil {
+		inbox.recorded.Store(true)
+	}
+	return nil
 }
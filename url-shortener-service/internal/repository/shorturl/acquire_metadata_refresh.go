@@ -0,0 +1,27 @@
+package shorturl
+
+import (
+	"context"
+	"time"
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/monitoring"
+	pkgerrors "github.com/pkg/errors"
+)
+
+// cacheKeyMetadataRefresh is the Redis key prefix guarding on-demand metadata refreshes by short code.
+const cacheKeyMetadataRefresh = "metadata_refresh:"
+
+// AcquireMetadataRefresh reserves the on-demand metadata refresh of a short code for the given interval.
+// It returns false when a refresh was already acquired within the interval.
+func (i impl) AcquireMetadataRefresh(ctx context.Context, shortCode string, interval time.Duration) (bool, error) {
+	var err error
+	ctx, span := monitoring.Start(ctx, "ShortURLRepository.AcquireMetadataRefresh")
+	defer monitoring.End(span, &err)
+
+	ok, err := i.redisClient.SetNX(ctx, cacheKeyMetadataRefresh+shortCode, time.Now().UTC().Unix(), interval).Result()
+	if err != nil {
+		return false, pkgerrors.WithStack(err)
+	}
+
+	return ok, nil
+}
@@ -0,0 +1,52 @@
+package shorturl
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	redisRepo "github.com/kytruongdev/sturl/url-shortener-service/internal/repository/redis"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAcquireMetadataRefresh(t *testing.T) {
+	tcs := map[string]struct {
+		mockSetNX func() *redis.BoolCmd
+		want      bool
+		wantErr   error
+	}{
+		"success - acquired": {
+			mockSetNX: func() *redis.BoolCmd { return redis.NewBoolResult(true, nil) },
+			want:      true,
+		},
+		"success - already acquired": {
+			mockSetNX: func() *redis.BoolCmd { return redis.NewBoolResult(false, nil) },
+			want:      false,
+		},
+		"fail - redis error": {
+			mockSetNX: func() *redis.BoolCmd { return redis.NewBoolResult(false, errors.New("redis down")) },
+			wantErr:   errors.New("redis down"),
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			mockRedis := new(redisRepo.MockRedisClient)
+			mockRedis.On("SetNX", mock.Anything, cacheKeyMetadataRefresh+"gg123", mock.Anything, time.Minute).
+				Return(tc.mockSetNX())
+
+			repo := New(nil, mockRedis)
+			actual, err := repo.AcquireMetadataRefresh(context.Background(), "gg123", time.Minute)
+			if tc.wantErr != nil {
+				require.ErrorContains(t, err, tc.wantErr.Error())
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.want, actual)
+		})
+	}
+}
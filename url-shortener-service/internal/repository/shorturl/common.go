@@ -26,12 +26,39 @@ func toShortUrlModel(o orm.ShortURL) (model.ShortUrl, error) {
 		}
 	}
 
+	var redirectChain []string
+	if o.HealthRedirectChain.Valid {
+		if err := json.Unmarshal(o.HealthRedirectChain.JSON, &redirectChain); err != nil {
+			return model.ShortUrl{}, pkgerrors.WithStack(err)
+		}
+	}
+
 	return model.ShortUrl{
 		ShortCode:   o.ShortCode,
 		OriginalURL: o.OriginalURL,
 		Status:      model.ShortUrlStatus(o.Status),
 		Metadata:    metadata,
-		CreatedAt:   o.CreatedAt,
-		UpdatedAt:   o.UpdatedAt,
+		Crawl: model.CrawlState{
+			Status:        model.CrawlStatus(o.CrawlStatus),
+			Attempts:      o.CrawlAttempts,
+			LastCrawledAt: o.LastCrawledAt.Time,
+			LastErrorCode: model.CrawlErrorCode(o.LastCrawlErrorCode.String),
+			RequestedAt:   o.CrawlRequestedAt.Time,
+			ETag:          o.MetadataEtag.String,
+			LastModified:  o.MetadataLastModified.String,
+		},
+		Health: model.LinkHealth{
+			Status:              model.LinkHealthStatus(o.HealthStatus),
+			HTTPStatus:          o.HealthHTTPStatus.Int,
+			RedirectChain:       redirectChain,
+			ConsecutiveFailures: o.HealthConsecutiveFailures,
+			CheckedAt:           o.HealthCheckedAt.Time,
+			LastOKAt:            o.LastOkAt.Time,
+		},
+		ClickCount: o.ClickCount,
+		FlaggedAt:  o.FlaggedAt.Time,
+		FlagReason: o.FlagReason.String,
+		CreatedAt:  o.CreatedAt,
+		UpdatedAt:  o.UpdatedAt,
 	}, nil
 }
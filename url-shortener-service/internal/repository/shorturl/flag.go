@@ -0,0 +1,44 @@
+package shorturl
+
+import (
+	"context"
+
+	"github.com/aarondl/sqlboiler/v4/queries"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/monitoring"
+	pkgerrors "github.com/pkg/errors"
+)
+
+const flagQuery = `
+UPDATE short_urls
+SET flagged_at  = NOW(),
+    flag_reason = $2,
+    updated_at  = NOW()
+WHERE short_code = $1`
+
+// Flag marks the link as pointing to a destination blocked by the destination policy, and evicts it from
+// the cache so that redirects stop right away instead of when the cached copy expires.
+func (i impl) Flag(ctx context.Context, shortCode, reason string) error {
+	var err error
+	ctx, span := monitoring.Start(ctx, "ShortURLRepository.Flag")
+	defer monitoring.End(span, &err)
+
+	rs, err := queries.Raw(flagQuery, shortCode, reason).ExecContext(ctx, i.db)
+	if err != nil {
+		return pkgerrors.WithStack(err)
+	}
+
+	affected, err := rs.RowsAffected()
+	if err != nil {
+		return pkgerrors.WithStack(err)
+	}
+
+	if affected == 0 {
+		return pkgerrors.WithStack(ErrNotFound)
+	}
+
+	if err = i.redisClient.Del(ctx, cacheKeyShortURL+shortCode).Err(); err != nil {
+		return pkgerrors.WithStack(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,75 @@
+package shorturl
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/pkg/testutil"
+	redisRepo "github.com/kytruongdev/sturl/url-shortener-service/internal/repository/redis"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlag(t *testing.T) {
+	errRedis := errors.New("redis down")
+
+	tcs := map[string]struct {
+		fixture   string
+		shortCode string
+		reason    string
+		delErr    error
+		wantErr   error
+	}{
+		"success": {
+			fixture:   "testdata/policy_sweep.sql",
+			shortCode: "bbb",
+			reason:    "domain: phishing.example",
+		},
+		"fail - cache eviction failed": {
+			fixture:   "testdata/policy_sweep.sql",
+			shortCode: "bbb",
+			reason:    "domain: phishing.example",
+			delErr:    errRedis,
+			wantErr:   errRedis,
+		},
+		"fail - short code not found": {
+			fixture:   "testdata/policy_sweep.sql",
+			shortCode: "notfound",
+			reason:    "domain: phishing.example",
+			wantErr:   ErrNotFound,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			testutil.WithTxDB(t, func(tx *sql.Tx) {
+				ctx := context.Background()
+				testutil.LoadSQLFile(t, tx, tc.fixture)
+
+				mockRedis := new(redisRepo.MockRedisClient)
+				mockRedis.On("GetBytes", mock.Anything, mock.Anything).Return(nil, sql.ErrNoRows)
+				mockRedis.On("Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				mockRedis.On("Del", mock.Anything, cacheKeyShortURL+tc.shortCode).Return(redis.NewIntResult(1, tc.delErr))
+
+				repo := New(tx, mockRedis)
+				err := repo.Flag(ctx, tc.shortCode, tc.reason)
+				if tc.wantErr != nil {
+					require.ErrorIs(t, err, tc.wantErr)
+					return
+				}
+
+				require.NoError(t, err)
+				mockRedis.AssertCalled(t, "Del", mock.Anything, cacheKeyShortURL+tc.shortCode)
+
+				updated, err := repo.GetByShortCode(ctx, tc.shortCode)
+				require.NoError(t, err)
+				require.WithinDuration(t, time.Now(), updated.FlaggedAt, time.Minute)
+				require.Equal(t, tc.reason, updated.FlagReason)
+			})
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package shorturl
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/aarondl/sqlboiler/v4/queries"
+	"github.com/aarondl/sqlboiler/v4/types"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/monitoring"
+	pkgerrors "github.com/pkg/errors"
+)
+
+// flushClickCountsQuery adds the counted clicks to their links in one statement
+const flushClickCountsQuery = `
+UPDATE short_urls s
+SET click_count = s.click_count + c.clicks
+FROM UNNEST($1::TEXT[], $2::BIGINT[]) AS c(short_code, clicks)
+WHERE s.short_code = c.short_code`
+
+// FlushClickCounts adds the clicks counted in Redis by IncrementClickCount to short_urls, and returns how many
+// links were updated. The counts are taken off Redis first, so that the clicks made meanwhile are left to the
+// next flush; they are put back when the update fails.
+func (i impl) FlushClickCounts(ctx context.Context) (int64, error) {
+	var err error
+	ctx, span := monitoring.Start(ctx, "ShortURLRepository.FlushClickCounts")
+	defer monitoring.End(span, &err)
+
+	counts, err := i.redisClient.HGetAllDel(ctx, cacheKeyClickCounts)
+	if err != nil {
+		return 0, pkgerrors.WithStack(err)
+	}
+
+	codes := make([]string, 0, len(counts))
+	clicks := make([]int64, 0, len(counts))
+	for code, v := range counts {
+		n, parseErr := strconv.ParseInt(v, 10, 64)
+		if parseErr != nil || n <= 0 {
+			continue
+		}
+		codes = append(codes, code)
+		clicks = append(clicks, n)
+	}
+	if len(codes) == 0 {
+		return 0, nil
+	}
+
+	rs, err := queries.Raw(flushClickCountsQuery, types.StringArray(codes), types.Int64Array(clicks)).ExecContext(ctx, i.db)
+	if err != nil {
+		// Best-effort: a failed restore loses these clicks, which only rank metadata re-crawls
+		for idx, code := range codes {
+			_ = i.redisClient.HIncrBy(ctx, cacheKeyClickCounts, code, clicks[idx]).Err()
+		}
+		return 0, pkgerrors.WithStack(err)
+	}
+
+	updated, err := rs.RowsAffected()
+	if err != nil {
+		return 0, pkgerrors.WithStack(err)
+	}
+
+	return updated, nil
+}
@@ -0,0 +1,66 @@
+package shorturl
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/pkg/testutil"
+	redisRepo "github.com/kytruongdev/sturl/url-shortener-service/internal/repository/redis"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlushClickCounts(t *testing.T) {
+	tcs := map[string]struct {
+		counts      map[string]string
+		countsErr   error
+		wantUpdated int64
+		wantClicks  map[string]int64
+		wantErr     error
+	}{
+		"success": {
+			counts:      map[string]string{"hot": "3", "warm": "1", "notfound": "7", "invalid": "x"},
+			wantUpdated: 2,
+			wantClicks:  map[string]int64{"hot": 123, "warm": 41, "new": 5},
+		},
+		"success - nothing counted": {
+			counts:     map[string]string{},
+			wantClicks: map[string]int64{"hot": 120},
+		},
+		"fail - redis error": {
+			countsErr: errors.New("redis down"),
+			wantErr:   errors.New("redis down"),
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			testutil.WithTxDB(t, func(tx *sql.Tx) {
+				ctx := context.Background()
+				testutil.LoadSQLFile(t, tx, "testdata/recrawl.sql")
+
+				mockRedis := new(redisRepo.MockRedisClient)
+				mockRedis.On("HGetAllDel", mock.Anything, cacheKeyClickCounts).Return(tc.counts, tc.countsErr)
+				mockRedis.On("GetBytes", mock.Anything, mock.Anything).Return(nil, sql.ErrNoRows)
+				mockRedis.On("Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+				repo := New(tx, mockRedis)
+				updated, err := repo.FlushClickCounts(ctx)
+				if tc.wantErr != nil {
+					require.ErrorContains(t, err, tc.wantErr.Error())
+					return
+				}
+
+				require.NoError(t, err)
+				require.Equal(t, tc.wantUpdated, updated)
+				for code, want := range tc.wantClicks {
+					m, err := repo.GetByShortCode(ctx, code)
+					require.NoError(t, err)
+					require.Equal(t, want, m.ClickCount, code)
+				}
+			})
+		})
+	}
+}
@@ -61,6 +61,7 @@ func TestGetByOriginalURL(t *testing.T) {
 				ShortCode:   "gg123",
 				OriginalURL: "https://google.com",
 				Status:      "ACTIVE",
+				Crawl:       model.CrawlState{Status: model.CrawlStatusPending},
 			},
 		},
 		"success - even set to cache fails": {
@@ -78,6 +79,7 @@ func TestGetByOriginalURL(t *testing.T) {
 				ShortCode:   "gg123",
 				OriginalURL: "https://google.com",
 				Status:      "ACTIVE",
+				Crawl:       model.CrawlState{Status: model.CrawlStatusPending},
 			},
 		},
 		"not found in both cache and database": {
@@ -60,6 +60,7 @@ func TestGetByShortCode(t *testing.T) {
 				ShortCode:   "gg123",
 				OriginalURL: "https://google.com",
 				Status:      "ACTIVE",
+				Crawl:       model.CrawlState{Status: model.CrawlStatusPending},
 			},
 		},
 		"success - even set to cache fails": {
@@ -77,6 +78,7 @@ func TestGetByShortCode(t *testing.T) {
 				ShortCode:   "gg123",
 				OriginalURL: "https://google.com",
 				Status:      "ACTIVE",
+				Crawl:       model.CrawlState{Status: model.CrawlStatusPending},
 			},
 		},
 		"not found in both cache and database": {
@@ -0,0 +1,64 @@
+package shorturl
+
+import (
+	"context"
+
+	"github.com/aarondl/null/v8"
+	"github.com/aarondl/sqlboiler/v4/queries"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/monitoring"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+	pkgerrors "github.com/pkg/errors"
+)
+
+// crawlStatsByDomainQuery aggregates crawl statuses per destination host, the host being
+// extracted from original_url (scheme, userinfo and port stripped). An empty $1 means all domains.
+const crawlStatsByDomainQuery = `
+SELECT domain,
+       COUNT(*) FILTER (WHERE crawl_status = 'PENDING') AS pending,
+       COUNT(*) FILTER (WHERE crawl_status = 'CRAWLED') AS crawled,
+       COUNT(*) FILTER (WHERE crawl_status = 'FAILED')  AS failed,
+       COUNT(*) FILTER (WHERE crawl_status = 'SKIPPED') AS skipped,
+       MAX(last_crawled_at)                             AS last_crawled_at
+FROM (SELECT LOWER(SUBSTRING(original_url FROM '^[^:]+://(?:[^@/]*@)?([^/:?#]+)')) AS domain,
+             crawl_status,
+             last_crawled_at
+      FROM short_urls) s
+WHERE $1 = '' OR domain = LOWER($1)
+GROUP BY domain
+ORDER BY failed DESC, domain`
+
+type crawlDomainStatsRow struct {
+	Domain        null.String `boil:"domain"`
+	Pending       int64       `boil:"pending"`
+	Crawled       int64       `boil:"crawled"`
+	Failed        int64       `boil:"failed"`
+	Skipped       int64       `boil:"skipped"`
+	LastCrawledAt null.Time   `boil:"last_crawled_at"`
+}
+
+// GetCrawlStatsByDomain returns crawl status counts per destination domain, most failing domains first.
+// When domain is empty, stats of all domains are returned.
+func (i impl) GetCrawlStatsByDomain(ctx context.Context, domain string) ([]model.CrawlDomainStats, error) {
+	var err error
+	ctx, span := monitoring.Start(ctx, "ShortURLRepository.GetCrawlStatsByDomain")
+	defer monitoring.End(span, &err)
+
+	var rows []crawlDomainStatsRow
+	if err = queries.Raw(crawlStatsByDomainQuery, domain).Bind(ctx, i.db, &rows); err != nil {
+		return nil, pkgerrors.WithStack(err)
+	}
+
+	rs := make([]model.CrawlDomainStats, 0, len(rows))
+	for _, r := range rows {
+		rs = append(rs, model.CrawlDomainStats{
+			Domain:        r.Domain.String,
+			Pending:       r.Pending,
+			Crawled:       r.Crawled,
+			Failed:        r.Failed,
+			Skipped:       r.Skipped,
+			LastCrawledAt: r.LastCrawledAt.Time,
+		})
+	}
+
+	return rs, nil
+}
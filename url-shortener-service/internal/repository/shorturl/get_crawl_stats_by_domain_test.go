@@ -0,0 +1,62 @@
+package shorturl
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/pkg/testutil"
+	redisRepo "github.com/kytruongdev/sturl/url-shortener-service/internal/repository/redis"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetCrawlStatsByDomain(t *testing.T) {
+	tcs := map[string]struct {
+		fixture string
+		domain  string
+		want    []model.CrawlDomainStats
+	}{
+		"success - all domains, most failing first": {
+			fixture: "testdata/crawl_status.sql",
+			want: []model.CrawlDomainStats{
+				{Domain: "github.com", Failed: 1, Skipped: 1, LastCrawledAt: time.Date(2025, 10, 19, 10, 0, 0, 0, time.UTC)},
+				{Domain: "www.google.com", Failed: 1, LastCrawledAt: time.Date(2025, 10, 21, 10, 0, 0, 0, time.UTC)},
+				{Domain: "example.com", Pending: 1},
+				{Domain: "google.com", Crawled: 1, LastCrawledAt: time.Date(2025, 10, 20, 10, 0, 0, 0, time.UTC)},
+			},
+		},
+		"success - single domain, case insensitive": {
+			fixture: "testdata/crawl_status.sql",
+			domain:  "GitHub.com",
+			want: []model.CrawlDomainStats{
+				{Domain: "github.com", Failed: 1, Skipped: 1, LastCrawledAt: time.Date(2025, 10, 19, 10, 0, 0, 0, time.UTC)},
+			},
+		},
+		"success - unknown domain": {
+			fixture: "testdata/crawl_status.sql",
+			domain:  "unknown.org",
+			want:    []model.CrawlDomainStats{},
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			testutil.WithTxDB(t, func(tx *sql.Tx) {
+				ctx := context.Background()
+				testutil.LoadSQLFile(t, tx, tc.fixture)
+
+				repo := New(tx, new(redisRepo.MockRedisClient))
+				actual, err := repo.GetCrawlStatsByDomain(ctx, tc.domain)
+				require.NoError(t, err)
+				require.True(t,
+					cmp.Equal(tc.want, actual, cmp.Comparer(func(a, b time.Time) bool { return a.Equal(b) })),
+					"diff: %v",
+					cmp.Diff(tc.want, actual),
+				)
+			})
+		})
+	}
+}
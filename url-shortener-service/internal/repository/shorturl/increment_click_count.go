@@ -0,0 +1,25 @@
+package shorturl
+
+import (
+	"context"
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/monitoring"
+	pkgerrors "github.com/pkg/errors"
+)
+
+// cacheKeyClickCounts is the Redis hash counting, by short code, the redirects not yet flushed to short_urls
+const cacheKeyClickCounts = "click_counts"
+
+// IncrementClickCount counts a redirect of the given short code in Redis, keeping the database off the
+// redirect path. The counts reach short_urls through FlushClickCounts.
+func (i impl) IncrementClickCount(ctx context.Context, shortCode string) error {
+	var err error
+	ctx, span := monitoring.Start(ctx, "ShortURLRepository.IncrementClickCount")
+	defer monitoring.End(span, &err)
+
+	if err = i.redisClient.HIncrBy(ctx, cacheKeyClickCounts, shortCode, 1).Err(); err != nil {
+		return pkgerrors.WithStack(err)
+	}
+
+	return nil
+}
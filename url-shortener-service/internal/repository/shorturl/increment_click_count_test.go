@@ -0,0 +1,43 @@
+package shorturl
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	redisRepo "github.com/kytruongdev/sturl/url-shortener-service/internal/repository/redis"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIncrementClickCount(t *testing.T) {
+	tcs := map[string]struct {
+		mockErr error
+		wantErr error
+	}{
+		"success": {},
+		"fail - redis error": {
+			mockErr: errors.New("redis down"),
+			wantErr: errors.New("redis down"),
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			mockRedis := new(redisRepo.MockRedisClient)
+			mockRedis.On("HIncrBy", mock.Anything, cacheKeyClickCounts, "hot", int64(1)).
+				Return(redis.NewIntResult(1, tc.mockErr))
+
+			repo := New(nil, mockRedis)
+			err := repo.IncrementClickCount(context.Background(), "hot")
+			if tc.wantErr != nil {
+				require.ErrorContains(t, err, tc.wantErr.Error())
+				return
+			}
+
+			require.NoError(t, err)
+			mockRedis.AssertExpectations(t)
+		})
+	}
+}
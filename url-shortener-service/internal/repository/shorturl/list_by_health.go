@@ -0,0 +1,41 @@
+package shorturl
+
+import (
+	"context"
+
+	"github.com/aarondl/sqlboiler/v4/queries/qm"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/monitoring"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/repository/orm"
+	pkgerrors "github.com/pkg/errors"
+)
+
+// ListByHealth returns up to limit active links of the given health status, with a short code after
+// afterShortCode, ordered by short code so that callers can page through them.
+func (i impl) ListByHealth(ctx context.Context, status model.LinkHealthStatus, afterShortCode string, limit int) ([]model.ShortUrl, error) {
+	var err error
+	ctx, span := monitoring.Start(ctx, "ShortURLRepository.ListByHealth")
+	defer monitoring.End(span, &err)
+
+	os, err := orm.ShortUrls(
+		orm.ShortURLWhere.Status.EQ(model.ShortUrlStatusActive.String()),
+		orm.ShortURLWhere.HealthStatus.EQ(status.String()),
+		orm.ShortURLWhere.ShortCode.GT(afterShortCode),
+		qm.OrderBy(orm.ShortURLColumns.ShortCode),
+		qm.Limit(limit),
+	).All(ctx, i.db)
+	if err != nil {
+		return nil, pkgerrors.WithStack(err)
+	}
+
+	rs := make([]model.ShortUrl, 0, len(os))
+	for _, o := range os {
+		m, err := toShortUrlModel(*o)
+		if err != nil {
+			return nil, err
+		}
+		rs = append(rs, m)
+	}
+
+	return rs, nil
+}
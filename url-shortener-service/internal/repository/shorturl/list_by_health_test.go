@@ -0,0 +1,62 @@
+package shorturl
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/pkg/testutil"
+	redisRepo "github.com/kytruongdev/sturl/url-shortener-service/internal/repository/redis"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListByHealth(t *testing.T) {
+	tcs := map[string]struct {
+		fixture        string
+		status         model.LinkHealthStatus
+		afterShortCode string
+		limit          int
+		want           []string
+	}{
+		"success - broken active links": {
+			fixture: "testdata/link_health.sql",
+			status:  model.LinkHealthBroken,
+			limit:   10,
+			want:    []string{"dead1", "dead3"},
+		},
+		"success - next page": {
+			fixture:        "testdata/link_health.sql",
+			status:         model.LinkHealthBroken,
+			afterShortCode: "dead1",
+			limit:          10,
+			want:           []string{"dead3"},
+		},
+		"success - last page": {
+			fixture:        "testdata/link_health.sql",
+			status:         model.LinkHealthReachable,
+			afterShortCode: "ok1",
+			limit:          10,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			testutil.WithTxDB(t, func(tx *sql.Tx) {
+				ctx := context.Background()
+				testutil.LoadSQLFile(t, tx, tc.fixture)
+
+				repo := New(tx, new(redisRepo.MockRedisClient))
+				actual, err := repo.ListByHealth(ctx, tc.status, tc.afterShortCode, tc.limit)
+				require.NoError(t, err)
+
+				var codes []string
+				for _, su := range actual {
+					require.Equal(t, tc.status, su.Health.Status)
+					codes = append(codes, su.ShortCode)
+				}
+				require.Equal(t, tc.want, codes)
+			})
+		})
+	}
+}
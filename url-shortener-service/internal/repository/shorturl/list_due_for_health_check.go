@@ -0,0 +1,46 @@
+package shorturl
+
+import (
+	"context"
+	"time"
+
+	"github.com/aarondl/null/v8"
+	"github.com/aarondl/sqlboiler/v4/queries/qm"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/monitoring"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/repository/orm"
+	pkgerrors "github.com/pkg/errors"
+)
+
+// ListDueForHealthCheck returns up to limit active, unflagged links whose destination was never checked
+// or last checked before checkedBefore, least recently checked first.
+func (i impl) ListDueForHealthCheck(ctx context.Context, checkedBefore time.Time, limit int) ([]model.ShortUrl, error) {
+	var err error
+	ctx, span := monitoring.Start(ctx, "ShortURLRepository.ListDueForHealthCheck")
+	defer monitoring.End(span, &err)
+
+	os, err := orm.ShortUrls(
+		orm.ShortURLWhere.Status.EQ(model.ShortUrlStatusActive.String()),
+		orm.ShortURLWhere.FlaggedAt.IsNull(),
+		qm.Expr(
+			orm.ShortURLWhere.HealthCheckedAt.IsNull(),
+			qm.Or2(orm.ShortURLWhere.HealthCheckedAt.LT(null.TimeFrom(checkedBefore))),
+		),
+		qm.OrderBy(orm.ShortURLColumns.HealthCheckedAt+" NULLS FIRST, "+orm.ShortURLColumns.ShortCode),
+		qm.Limit(limit),
+	).All(ctx, i.db)
+	if err != nil {
+		return nil, pkgerrors.WithStack(err)
+	}
+
+	rs := make([]model.ShortUrl, 0, len(os))
+	for _, o := range os {
+		m, err := toShortUrlModel(*o)
+		if err != nil {
+			return nil, err
+		}
+		rs = append(rs, m)
+	}
+
+	return rs, nil
+}
@@ -0,0 +1,59 @@
+package shorturl
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/pkg/testutil"
+	redisRepo "github.com/kytruongdev/sturl/url-shortener-service/internal/repository/redis"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListDueForHealthCheck(t *testing.T) {
+	tcs := map[string]struct {
+		fixture       string
+		checkedBefore time.Time
+		limit         int
+		want          []string
+	}{
+		"success - never checked first, inactive and flagged links skipped": {
+			fixture:       "testdata/link_health.sql",
+			checkedBefore: time.Date(2025, 10, 22, 0, 0, 0, 0, time.UTC),
+			limit:         10,
+			want:          []string{"new1", "down1", "ok1", "dead1"},
+		},
+		"success - recently checked links are not due": {
+			fixture:       "testdata/link_health.sql",
+			checkedBefore: time.Date(2025, 10, 20, 0, 0, 0, 0, time.UTC),
+			limit:         10,
+			want:          []string{"new1", "down1"},
+		},
+		"success - limited": {
+			fixture:       "testdata/link_health.sql",
+			checkedBefore: time.Date(2025, 10, 22, 0, 0, 0, 0, time.UTC),
+			limit:         1,
+			want:          []string{"new1"},
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			testutil.WithTxDB(t, func(tx *sql.Tx) {
+				ctx := context.Background()
+				testutil.LoadSQLFile(t, tx, tc.fixture)
+
+				repo := New(tx, new(redisRepo.MockRedisClient))
+				actual, err := repo.ListDueForHealthCheck(ctx, tc.checkedBefore, tc.limit)
+				require.NoError(t, err)
+
+				var codes []string
+				for _, su := range actual {
+					codes = append(codes, su.ShortCode)
+				}
+				require.Equal(t, tc.want, codes)
+			})
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package shorturl
+
+import (
+	"context"
+	"time"
+
+	"github.com/aarondl/null/v8"
+	"github.com/aarondl/sqlboiler/v4/queries/qm"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/monitoring"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/repository/orm"
+	pkgerrors "github.com/pkg/errors"
+)
+
+// ListStaleForRecrawl returns up to limit active links whose metadata was last crawled before crawledBefore
+// and which have not been enqueued for a crawl since then, most clicked links first.
+func (i impl) ListStaleForRecrawl(ctx context.Context, crawledBefore time.Time, limit int) ([]model.ShortUrl, error) {
+	var err error
+	ctx, span := monitoring.Start(ctx, "ShortURLRepository.ListStaleForRecrawl")
+	defer monitoring.End(span, &err)
+
+	os, err := orm.ShortUrls(
+		orm.ShortURLWhere.Status.EQ(model.ShortUrlStatusActive.String()),
+		qm.Expr(
+			orm.ShortURLWhere.LastCrawledAt.IsNull(),
+			qm.Or2(orm.ShortURLWhere.LastCrawledAt.LT(null.TimeFrom(crawledBefore))),
+		),
+		qm.Expr(
+			orm.ShortURLWhere.CrawlRequestedAt.IsNull(),
+			qm.Or2(orm.ShortURLWhere.CrawlRequestedAt.LT(null.TimeFrom(crawledBefore))),
+		),
+		qm.OrderBy(orm.ShortURLColumns.ClickCount+" DESC, "+orm.ShortURLColumns.ShortCode),
+		qm.Limit(limit),
+	).All(ctx, i.db)
+	if err != nil {
+		return nil, pkgerrors.WithStack(err)
+	}
+
+	rs := make([]model.ShortUrl, 0, len(os))
+	for _, o := range os {
+		m, err := toShortUrlModel(*o)
+		if err != nil {
+			return nil, err
+		}
+		rs = append(rs, m)
+	}
+
+	return rs, nil
+}
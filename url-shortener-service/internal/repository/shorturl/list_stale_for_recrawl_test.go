@@ -0,0 +1,61 @@
+package shorturl
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/pkg/testutil"
+	redisRepo "github.com/kytruongdev/sturl/url-shortener-service/internal/repository/redis"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListStaleForRecrawl(t *testing.T) {
+	tcs := map[string]struct {
+		fixture       string
+		crawledBefore time.Time
+		limit         int
+		want          []string
+	}{
+		"success - most clicked stale links first": {
+			fixture:       "testdata/recrawl.sql",
+			crawledBefore: time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC),
+			limit:         10,
+			want:          []string{"hot", "warm", "new"},
+		},
+		"success - limited": {
+			fixture:       "testdata/recrawl.sql",
+			crawledBefore: time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC),
+			limit:         1,
+			want:          []string{"hot"},
+		},
+		"success - only never crawled links": {
+			fixture:       "testdata/recrawl.sql",
+			crawledBefore: time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC),
+			limit:         10,
+			want:          []string{"new"},
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			testutil.WithTxDB(t, func(tx *sql.Tx) {
+				ctx := context.Background()
+				testutil.LoadSQLFile(t, tx, tc.fixture)
+
+				repo := New(tx, new(redisRepo.MockRedisClient))
+				actual, err := repo.ListStaleForRecrawl(ctx, tc.crawledBefore, tc.limit)
+				require.NoError(t, err)
+
+				var codes []string
+				for _, su := range actual {
+					require.Equal(t, model.ShortUrlStatusActive, su.Status)
+					codes = append(codes, su.ShortCode)
+				}
+				require.Equal(t, tc.want, codes)
+			})
+		})
+	}
+}
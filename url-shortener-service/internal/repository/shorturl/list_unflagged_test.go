@@ -0,0 +1,57 @@
+package shorturl
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/pkg/testutil"
+	redisRepo "github.com/kytruongdev/sturl/url-shortener-service/internal/repository/redis"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListUnflagged(t *testing.T) {
+	tcs := map[string]struct {
+		fixture        string
+		afterShortCode string
+		limit          int
+		want           []string
+	}{
+		"success - first page": {
+			fixture: "testdata/policy_sweep.sql",
+			limit:   2,
+			want:    []string{"aaa", "bbb"},
+		},
+		"success - next page skips flagged and inactive links": {
+			fixture:        "testdata/policy_sweep.sql",
+			afterShortCode: "bbb",
+			limit:          2,
+			want:           []string{"eee"},
+		},
+		"success - last page": {
+			fixture:        "testdata/policy_sweep.sql",
+			afterShortCode: "eee",
+			limit:          2,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			testutil.WithTxDB(t, func(tx *sql.Tx) {
+				ctx := context.Background()
+				testutil.LoadSQLFile(t, tx, tc.fixture)
+
+				repo := New(tx, new(redisRepo.MockRedisClient))
+				actual, err := repo.ListUnflagged(ctx, tc.afterShortCode, tc.limit)
+				require.NoError(t, err)
+
+				var codes []string
+				for _, su := range actual {
+					require.True(t, su.FlaggedAt.IsZero())
+					codes = append(codes, su.ShortCode)
+				}
+				require.Equal(t, tc.want, codes)
+			})
+		})
+	}
+}
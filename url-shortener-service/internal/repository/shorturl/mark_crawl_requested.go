@@ -0,0 +1,38 @@
+package shorturl
+
+import (
+	"context"
+
+	"github.com/aarondl/sqlboiler/v4/queries"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/monitoring"
+	pkgerrors "github.com/pkg/errors"
+)
+
+const markCrawlRequestedQuery = `
+UPDATE short_urls
+SET crawl_requested_at = NOW(),
+    updated_at         = NOW()
+WHERE short_code = $1`
+
+// MarkCrawlRequested stamps crawl_requested_at, so the link is not enqueued again until the crawl is stale.
+func (i impl) MarkCrawlRequested(ctx context.Context, shortCode string) error {
+	var err error
+	ctx, span := monitoring.Start(ctx, "ShortURLRepository.MarkCrawlRequested")
+	defer monitoring.End(span, &err)
+
+	rs, err := queries.Raw(markCrawlRequestedQuery, shortCode).ExecContext(ctx, i.db)
+	if err != nil {
+		return pkgerrors.WithStack(err)
+	}
+
+	affected, err := rs.RowsAffected()
+	if err != nil {
+		return pkgerrors.WithStack(err)
+	}
+
+	if affected == 0 {
+		return pkgerrors.WithStack(ErrNotFound)
+	}
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package shorturl
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/pkg/testutil"
+	redisRepo "github.com/kytruongdev/sturl/url-shortener-service/internal/repository/redis"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarkCrawlRequested(t *testing.T) {
+	tcs := map[string]struct {
+		fixture   string
+		shortCode string
+		wantErr   error
+	}{
+		"success": {
+			fixture:   "testdata/recrawl.sql",
+			shortCode: "warm",
+		},
+		"fail - short code not found": {
+			fixture:   "testdata/recrawl.sql",
+			shortCode: "notfound",
+			wantErr:   ErrNotFound,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			testutil.WithTxDB(t, func(tx *sql.Tx) {
+				ctx := context.Background()
+				testutil.LoadSQLFile(t, tx, tc.fixture)
+
+				mockRedis := new(redisRepo.MockRedisClient)
+				mockRedis.On("GetBytes", mock.Anything, mock.Anything).Return(nil, sql.ErrNoRows)
+				mockRedis.On("Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+				repo := New(tx, mockRedis)
+				err := repo.MarkCrawlRequested(ctx, tc.shortCode)
+				if tc.wantErr != nil {
+					require.ErrorIs(t, err, tc.wantErr)
+					return
+				}
+
+				require.NoError(t, err)
+
+				updated, err := repo.GetByShortCode(ctx, tc.shortCode)
+				require.NoError(t, err)
+				require.WithinDuration(t, time.Now(), updated.Crawl.RequestedAt, time.Minute)
+			})
+		})
+	}
+}
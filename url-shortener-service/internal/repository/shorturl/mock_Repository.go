@@ -7,6 +7,8 @@ import (
 
 	model "github.com/kytruongdev/sturl/url-shortener-service/internal/model"
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // MockRepository is an autogenerated mock type for the Repository type
@@ -14,6 +16,80 @@ type MockRepository struct {
 	mock.Mock
 }
 
+// AcquireMetadataRefresh provides a mock function with given fields: ctx, shortCode, interval
+func (_m *MockRepository) AcquireMetadataRefresh(ctx context.Context, shortCode string, interval time.Duration) (bool, error) {
+	ret := _m.Called(ctx, shortCode, interval)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AcquireMetadataRefresh")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) (bool, error)); ok {
+		return rf(ctx, shortCode, interval)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) bool); ok {
+		r0 = rf(ctx, shortCode, interval)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Duration) error); ok {
+		r1 = rf(ctx, shortCode, interval)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Flag provides a mock function with given fields: ctx, shortCode, reason
+func (_m *MockRepository) Flag(ctx context.Context, shortCode string, reason string) error {
+	ret := _m.Called(ctx, shortCode, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Flag")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, shortCode, reason)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FlushClickCounts provides a mock function with given fields: ctx
+func (_m *MockRepository) FlushClickCounts(ctx context.Context) (int64, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FlushClickCounts")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int64, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int64); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetByOriginalURL provides a mock function with given fields: _a0, _a1
 func (_m *MockRepository) GetByOriginalURL(_a0 context.Context, _a1 string) (model.ShortUrl, error) {
 	ret := _m.Called(_a0, _a1)
@@ -70,6 +146,54 @@ func (_m *MockRepository) GetByShortCode(_a0 context.Context, _a1 string) (model
 	return r0, r1
 }
 
+// GetCrawlStatsByDomain provides a mock function with given fields: ctx, domain
+func (_m *MockRepository) GetCrawlStatsByDomain(ctx context.Context, domain string) ([]model.CrawlDomainStats, error) {
+	ret := _m.Called(ctx, domain)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCrawlStatsByDomain")
+	}
+
+	var r0 []model.CrawlDomainStats
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]model.CrawlDomainStats, error)); ok {
+		return rf(ctx, domain)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []model.CrawlDomainStats); ok {
+		r0 = rf(ctx, domain)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.CrawlDomainStats)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, domain)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IncrementClickCount provides a mock function with given fields: ctx, shortCode
+func (_m *MockRepository) IncrementClickCount(ctx context.Context, shortCode string) error {
+	ret := _m.Called(ctx, shortCode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IncrementClickCount")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, shortCode)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Insert provides a mock function with given fields: _a0, _a1
 func (_m *MockRepository) Insert(_a0 context.Context, _a1 model.ShortUrl) (model.ShortUrl, error) {
 	ret := _m.Called(_a0, _a1)
@@ -98,6 +222,208 @@ func (_m *MockRepository) Insert(_a0 context.Context, _a1 model.ShortUrl) (model
 	return r0, r1
 }
 
+// ListByHealth provides a mock function with given fields: ctx, status, afterShortCode, limit
+func (_m *MockRepository) ListByHealth(ctx context.Context, status model.LinkHealthStatus, afterShortCode string, limit int) ([]model.ShortUrl, error) {
+	ret := _m.Called(ctx, status, afterShortCode, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByHealth")
+	}
+
+	var r0 []model.ShortUrl
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.LinkHealthStatus, string, int) ([]model.ShortUrl, error)); ok {
+		return rf(ctx, status, afterShortCode, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.LinkHealthStatus, string, int) []model.ShortUrl); ok {
+		r0 = rf(ctx, status, afterShortCode, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.ShortUrl)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.LinkHealthStatus, string, int) error); ok {
+		r1 = rf(ctx, status, afterShortCode, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListDueForHealthCheck provides a mock function with given fields: ctx, checkedBefore, limit
+func (_m *MockRepository) ListDueForHealthCheck(ctx context.Context, checkedBefore time.Time, limit int) ([]model.ShortUrl, error) {
+	ret := _m.Called(ctx, checkedBefore, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDueForHealthCheck")
+	}
+
+	var r0 []model.ShortUrl
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) ([]model.ShortUrl, error)); ok {
+		return rf(ctx, checkedBefore, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) []model.ShortUrl); ok {
+		r0 = rf(ctx, checkedBefore, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.ShortUrl)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, int) error); ok {
+		r1 = rf(ctx, checkedBefore, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListStaleForRecrawl provides a mock function with given fields: ctx, crawledBefore, limit
+func (_m *MockRepository) ListStaleForRecrawl(ctx context.Context, crawledBefore time.Time, limit int) ([]model.ShortUrl, error) {
+	ret := _m.Called(ctx, crawledBefore, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListStaleForRecrawl")
+	}
+
+	var r0 []model.ShortUrl
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) ([]model.ShortUrl, error)); ok {
+		return rf(ctx, crawledBefore, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) []model.ShortUrl); ok {
+		r0 = rf(ctx, crawledBefore, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.ShortUrl)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, int) error); ok {
+		r1 = rf(ctx, crawledBefore, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListUnflagged provides a mock function with given fields: ctx, afterShortCode, limit
+func (_m *MockRepository) ListUnflagged(ctx context.Context, afterShortCode string, limit int) ([]model.ShortUrl, error) {
+	ret := _m.Called(ctx, afterShortCode, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUnflagged")
+	}
+
+	var r0 []model.ShortUrl
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) ([]model.ShortUrl, error)); ok {
+		return rf(ctx, afterShortCode, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) []model.ShortUrl); ok {
+		r0 = rf(ctx, afterShortCode, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.ShortUrl)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, afterShortCode, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MarkCrawlRequested provides a mock function with given fields: ctx, shortCode
+func (_m *MockRepository) MarkCrawlRequested(ctx context.Context, shortCode string) error {
+	ret := _m.Called(ctx, shortCode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkCrawlRequested")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, shortCode)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RecordCrawlAttempt provides a mock function with given fields: ctx, shortCode, status, errCode
+func (_m *MockRepository) RecordCrawlAttempt(ctx context.Context, shortCode string, status model.CrawlStatus, errCode model.CrawlErrorCode) error {
+	ret := _m.Called(ctx, shortCode, status, errCode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordCrawlAttempt")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, model.CrawlStatus, model.CrawlErrorCode) error); ok {
+		r0 = rf(ctx, shortCode, status, errCode)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RecordHealthCheck provides a mock function with given fields: ctx, shortCode, check, brokenAfter
+func (_m *MockRepository) RecordHealthCheck(ctx context.Context, shortCode string, check model.HealthCheck, brokenAfter int) (model.LinkHealth, error) {
+	ret := _m.Called(ctx, shortCode, check, brokenAfter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordHealthCheck")
+	}
+
+	var r0 model.LinkHealth
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, model.HealthCheck, int) (model.LinkHealth, error)); ok {
+		return rf(ctx, shortCode, check, brokenAfter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, model.HealthCheck, int) model.LinkHealth); ok {
+		r0 = rf(ctx, shortCode, check, brokenAfter)
+	} else {
+		r0 = ret.Get(0).(model.LinkHealth)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, model.HealthCheck, int) error); ok {
+		r1 = rf(ctx, shortCode, check, brokenAfter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReleaseMetadataRefresh provides a mock function with given fields: ctx, shortCode
+func (_m *MockRepository) ReleaseMetadataRefresh(ctx context.Context, shortCode string) error {
+	ret := _m.Called(ctx, shortCode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleaseMetadataRefresh")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, shortCode)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Update provides a mock function with given fields: _a0, _a1, _a2
 func (_m *MockRepository) Update(_a0 context.Context, _a1 model.ShortUrl, _a2 string) error {
 	ret := _m.Called(_a0, _a1, _a2)
@@ -2,6 +2,7 @@ package shorturl
 
 import (
 	"context"
+	"time"
 
 	"github.com/aarondl/sqlboiler/v4/boil"
 	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
@@ -15,6 +16,19 @@ type Repository interface {
 	GetByShortCode(context.Context, string) (model.ShortUrl, error)
 	Insert(context.Context, model.ShortUrl) (model.ShortUrl, error)
 	Update(context.Context, model.ShortUrl, string) error
+	RecordCrawlAttempt(ctx context.Context, shortCode string, status model.CrawlStatus, errCode model.CrawlErrorCode) error
+	RecordHealthCheck(ctx context.Context, shortCode string, check model.HealthCheck, brokenAfter int) (model.LinkHealth, error)
+	ListDueForHealthCheck(ctx context.Context, checkedBefore time.Time, limit int) ([]model.ShortUrl, error)
+	ListByHealth(ctx context.Context, status model.LinkHealthStatus, afterShortCode string, limit int) ([]model.ShortUrl, error)
+	GetCrawlStatsByDomain(ctx context.Context, domain string) ([]model.CrawlDomainStats, error)
+	IncrementClickCount(ctx context.Context, shortCode string) error
+	FlushClickCounts(ctx context.Context) (int64, error)
+	ListStaleForRecrawl(ctx context.Context, crawledBefore time.Time, limit int) ([]model.ShortUrl, error)
+	MarkCrawlRequested(ctx context.Context, shortCode string) error
+	AcquireMetadataRefresh(ctx context.Context, shortCode string, interval time.Duration) (bool, error)
+	ReleaseMetadataRefresh(ctx context.Context, shortCode string) error
+	ListUnflagged(ctx context.Context, afterShortCode string, limit int) ([]model.ShortUrl, error)
+	Flag(ctx context.Context, shortCode, reason string) error
 }
 
 // impl is the implementation of the repository
@@ -0,0 +1,45 @@
+package shorturl
+
+import (
+	"context"
+
+	"github.com/aarondl/null/v8"
+	"github.com/aarondl/sqlboiler/v4/queries"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/monitoring"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+	pkgerrors "github.com/pkg/errors"
+)
+
+// recordCrawlAttemptQuery increments the attempt counter in place, so concurrent attempts are all counted
+const recordCrawlAttemptQuery = `
+UPDATE short_urls
+SET crawl_status          = $1,
+    last_crawl_error_code = $2,
+    crawl_attempts        = crawl_attempts + 1,
+    last_crawled_at       = NOW(),
+    updated_at            = NOW()
+WHERE short_code = $3`
+
+// RecordCrawlAttempt stores the outcome of a metadata crawl attempt: it sets the crawl status and error code,
+// stamps last_crawled_at and increments the attempt counter in a single statement.
+func (i impl) RecordCrawlAttempt(ctx context.Context, shortCode string, status model.CrawlStatus, errCode model.CrawlErrorCode) error {
+	var err error
+	ctx, span := monitoring.Start(ctx, "ShortURLRepository.RecordCrawlAttempt")
+	defer monitoring.End(span, &err)
+
+	rs, err := queries.Raw(recordCrawlAttemptQuery, status.String(), null.NewString(errCode.String(), errCode != ""), shortCode).ExecContext(ctx, i.db)
+	if err != nil {
+		return pkgerrors.WithStack(err)
+	}
+
+	affected, err := rs.RowsAffected()
+	if err != nil {
+		return pkgerrors.WithStack(err)
+	}
+
+	if affected == 0 {
+		return pkgerrors.WithStack(ErrNotFound)
+	}
+
+	return nil
+}
@@ -0,0 +1,81 @@
+package shorturl
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/pkg/testutil"
+	redisRepo "github.com/kytruongdev/sturl/url-shortener-service/internal/repository/redis"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordCrawlAttempt(t *testing.T) {
+	tcs := map[string]struct {
+		fixture   string
+		shortCode string
+		status    model.CrawlStatus
+		errCode   model.CrawlErrorCode
+		want      model.CrawlState
+		wantErr   error
+	}{
+		"success - first attempt failed": {
+			fixture:   "testdata/crawl_status.sql",
+			shortCode: "ex1",
+			status:    model.CrawlStatusFailed,
+			errCode:   model.CrawlErrorDNS,
+			want: model.CrawlState{
+				Status:        model.CrawlStatusFailed,
+				Attempts:      1,
+				LastErrorCode: model.CrawlErrorDNS,
+			},
+		},
+		"success - retry succeeded clears error code": {
+			fixture:   "testdata/crawl_status.sql",
+			shortCode: "gg456",
+			status:    model.CrawlStatusCrawled,
+			want: model.CrawlState{
+				Status:   model.CrawlStatusCrawled,
+				Attempts: 4,
+			},
+		},
+		"fail - short code not found": {
+			fixture:   "testdata/crawl_status.sql",
+			shortCode: "notfound",
+			status:    model.CrawlStatusCrawled,
+			wantErr:   ErrNotFound,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			testutil.WithTxDB(t, func(tx *sql.Tx) {
+				ctx := context.Background()
+				testutil.LoadSQLFile(t, tx, tc.fixture)
+
+				mockRedis := new(redisRepo.MockRedisClient)
+				mockRedis.On("GetBytes", mock.Anything, mock.Anything).Return(nil, sql.ErrNoRows)
+				mockRedis.On("Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+				repo := New(tx, mockRedis)
+				err := repo.RecordCrawlAttempt(ctx, tc.shortCode, tc.status, tc.errCode)
+				if tc.wantErr != nil {
+					require.ErrorIs(t, err, tc.wantErr)
+					return
+				}
+
+				require.NoError(t, err)
+
+				updated, err := repo.GetByShortCode(ctx, tc.shortCode)
+				require.NoError(t, err)
+				require.Equal(t, tc.want.Status, updated.Crawl.Status)
+				require.Equal(t, tc.want.Attempts, updated.Crawl.Attempts)
+				require.Equal(t, tc.want.LastErrorCode, updated.Crawl.LastErrorCode)
+				require.WithinDuration(t, time.Now(), updated.Crawl.LastCrawledAt, time.Minute)
+			})
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package shorturl
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+
+	"github.com/aarondl/null/v8"
+	"github.com/aarondl/sqlboiler/v4/queries"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/monitoring"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/repository/orm"
+	pkgerrors "github.com/pkg/errors"
+)
+
+// recordHealthCheckQuery counts consecutive failures in place, so concurrent checks are all counted,
+// and only moves last_ok_at forward when the destination was reachable
+const recordHealthCheckQuery = `
+UPDATE short_urls
+SET health_status               = CASE WHEN $1 THEN 'REACHABLE'
+                                       WHEN health_consecutive_failures + 1 >= $4 THEN 'BROKEN'
+                                       ELSE 'UNREACHABLE' END,
+    health_consecutive_failures = CASE WHEN $1 THEN 0 ELSE health_consecutive_failures + 1 END,
+    health_http_status          = $2,
+    health_redirect_chain       = $3,
+    health_checked_at           = NOW(),
+    last_ok_at                  = CASE WHEN $1 THEN NOW() ELSE last_ok_at END,
+    updated_at                  = NOW()
+WHERE short_code = $5
+RETURNING *`
+
+// RecordHealthCheck stores the outcome of a reachability check of the destination: the answered status code
+// (0 when the destination did not answer), the redirect chain and the check time. The link turns BROKEN once
+// brokenAfter checks failed in a row. It returns the resulting link health.
+func (i impl) RecordHealthCheck(ctx context.Context, shortCode string, check model.HealthCheck, brokenAfter int) (model.LinkHealth, error) {
+	var err error
+	ctx, span := monitoring.Start(ctx, "ShortURLRepository.RecordHealthCheck")
+	defer monitoring.End(span, &err)
+
+	var chain null.JSON
+	if len(check.RedirectChain) > 0 {
+		b, err := json.Marshal(check.RedirectChain)
+		if err != nil {
+			return model.LinkHealth{}, pkgerrors.WithStack(err)
+		}
+		chain = null.JSONFrom(b)
+	}
+
+	var o orm.ShortURL
+	if err = queries.Raw(recordHealthCheckQuery,
+		check.Reachable(),
+		null.NewInt(check.HTTPStatus, check.HTTPStatus != 0),
+		chain,
+		brokenAfter,
+		shortCode,
+	).Bind(ctx, i.db, &o); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.LinkHealth{}, pkgerrors.WithStack(ErrNotFound)
+		}
+		return model.LinkHealth{}, pkgerrors.WithStack(err)
+	}
+
+	su, err := toShortUrlModel(o)
+	if err != nil {
+		return model.LinkHealth{}, err
+	}
+
+	return su.Health, nil
+}
@@ -0,0 +1,102 @@
+package shorturl
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/model"
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/pkg/testutil"
+	redisRepo "github.com/kytruongdev/sturl/url-shortener-service/internal/repository/redis"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordHealthCheck(t *testing.T) {
+	tcs := map[string]struct {
+		fixture      string
+		shortCode    string
+		check        model.HealthCheck
+		brokenAfter  int
+		want         model.LinkHealth
+		wantLastOKAt time.Time // zero means last_ok_at is expected to be refreshed
+		wantErr      error
+	}{
+		"success - first check reachable": {
+			fixture:     "testdata/link_health.sql",
+			shortCode:   "new1",
+			check:       model.HealthCheck{HTTPStatus: 200, RedirectChain: []string{"https://www.example.org/"}},
+			brokenAfter: 3,
+			want: model.LinkHealth{
+				Status:        model.LinkHealthReachable,
+				HTTPStatus:    200,
+				RedirectChain: []string{"https://www.example.org/"},
+			},
+		},
+		"success - reachable again resets failures": {
+			fixture:     "testdata/link_health.sql",
+			shortCode:   "down1",
+			check:       model.HealthCheck{HTTPStatus: 200},
+			brokenAfter: 3,
+			want:        model.LinkHealth{Status: model.LinkHealthReachable, HTTPStatus: 200},
+		},
+		"success - first failure keeps last ok time": {
+			fixture:      "testdata/link_health.sql",
+			shortCode:    "ok1",
+			check:        model.HealthCheck{HTTPStatus: 503},
+			brokenAfter:  3,
+			want:         model.LinkHealth{Status: model.LinkHealthUnreachable, HTTPStatus: 503, ConsecutiveFailures: 1},
+			wantLastOKAt: time.Date(2025, 10, 20, 10, 0, 0, 0, time.UTC),
+		},
+		"success - too many failures break the link": {
+			fixture:      "testdata/link_health.sql",
+			shortCode:    "down1",
+			brokenAfter:  3,
+			want:         model.LinkHealth{Status: model.LinkHealthBroken, ConsecutiveFailures: 3},
+			wantLastOKAt: time.Date(2025, 10, 1, 10, 0, 0, 0, time.UTC),
+		},
+		"fail - short code not found": {
+			fixture:   "testdata/link_health.sql",
+			shortCode: "notfound",
+			check:     model.HealthCheck{HTTPStatus: 200},
+			wantErr:   ErrNotFound,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			testutil.WithTxDB(t, func(tx *sql.Tx) {
+				ctx := context.Background()
+				testutil.LoadSQLFile(t, tx, tc.fixture)
+
+				mockRedis := new(redisRepo.MockRedisClient)
+				mockRedis.On("GetBytes", mock.Anything, mock.Anything).Return(nil, sql.ErrNoRows)
+				mockRedis.On("Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+				repo := New(tx, mockRedis)
+				actual, err := repo.RecordHealthCheck(ctx, tc.shortCode, tc.check, tc.brokenAfter)
+				if tc.wantErr != nil {
+					require.ErrorIs(t, err, tc.wantErr)
+					return
+				}
+
+				require.NoError(t, err)
+				require.Equal(t, tc.want.Status, actual.Status)
+				require.Equal(t, tc.want.HTTPStatus, actual.HTTPStatus)
+				require.Equal(t, tc.want.RedirectChain, actual.RedirectChain)
+				require.Equal(t, tc.want.ConsecutiveFailures, actual.ConsecutiveFailures)
+				require.WithinDuration(t, time.Now(), actual.CheckedAt, time.Minute)
+				if tc.wantLastOKAt.IsZero() {
+					require.WithinDuration(t, time.Now(), actual.LastOKAt, time.Minute)
+				} else {
+					require.True(t, tc.wantLastOKAt.Equal(actual.LastOKAt))
+				}
+
+				updated, err := repo.GetByShortCode(ctx, tc.shortCode)
+				require.NoError(t, err)
+				require.Equal(t, actual.Status, updated.Health.Status)
+			})
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package shorturl
+
+import (
+	"context"
+
+	"github.com/kytruongdev/sturl/url-shortener-service/internal/infra/monitoring"
+	pkgerrors "github.com/pkg/errors"
+)
+
+// ReleaseMetadataRefresh gives back the on-demand metadata refresh of a short code acquired by
+// AcquireMetadataRefresh, so that the link can be refreshed again right away.
+func (i impl) ReleaseMetadataRefresh(ctx context.Context, shortCode string) error {
+	var err error
+	ctx, span := monitoring.Start(ctx, "ShortURLRepository.ReleaseMetadataRefresh")
+	defer monitoring.End(span, &err)
+
+	if err = i.redisClient.Del(ctx, cacheKeyMetadataRefresh+shortCode).Err(); err != nil {
+		return pkgerrors.WithStack(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,47 @@
+package shorturl
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	redisRepo "github.com/kytruongdev/sturl/url-shortener-service/internal/repository/redis"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReleaseMetadataRefresh(t *testing.T) {
+	tcs := map[string]struct {
+		mockDel func() *redis.IntCmd
+		wantErr error
+	}{
+		"success - released": {
+			mockDel: func() *redis.IntCmd { return redis.NewIntResult(1, nil) },
+		},
+		"success - not acquired": {
+			mockDel: func() *redis.IntCmd { return redis.NewIntResult(0, nil) },
+		},
+		"fail - redis error": {
+			mockDel: func() *redis.IntCmd { return redis.NewIntResult(0, errors.New("redis down")) },
+			wantErr: errors.New("redis down"),
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			mockRedis := new(redisRepo.MockRedisClient)
+			mockRedis.On("Del", mock.Anything, cacheKeyMetadataRefresh+"gg123").
+				Return(tc.mockDel())
+
+			repo := New(nil, mockRedis)
+			err := repo.ReleaseMetadataRefresh(context.Background(), "gg123")
+			if tc.wantErr != nil {
+				require.ErrorContains(t, err, tc.wantErr.Error())
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,6 @@
+INSERT INTO short_urls (short_code, original_url, status, crawl_status, crawl_attempts, last_crawled_at, last_crawl_error_code)
+VALUES ('gg123', 'https://google.com', 'ACTIVE', 'CRAWLED', 1, '2025-10-20 10:00:00+00', NULL),
+       ('gg456', 'https://www.Google.com/search?q=go', 'ACTIVE', 'FAILED', 3, '2025-10-21 10:00:00+00', 'HTTP_5XX'),
+       ('gh1', 'https://user@github.com:443/golang/go', 'ACTIVE', 'FAILED', 2, '2025-10-19 10:00:00+00', 'TIMEOUT'),
+       ('gh2', 'https://github.com/kytruongdev/sturl', 'INACTIVE', 'SKIPPED', 1, '2025-10-18 10:00:00+00', NULL),
+       ('ex1', 'http://example.com', 'ACTIVE', 'PENDING', 0, NULL, NULL);
@@ -0,0 +1,7 @@
+INSERT INTO short_urls (short_code, original_url, status, flagged_at, health_status, health_http_status, health_consecutive_failures, health_checked_at, last_ok_at)
+VALUES ('ok1', 'https://example.com', 'ACTIVE', NULL, 'REACHABLE', 200, 0, '2025-10-20 10:00:00+00', '2025-10-20 10:00:00+00'),
+       ('new1', 'https://example.org', 'ACTIVE', NULL, 'UNKNOWN', NULL, 0, NULL, NULL),
+       ('down1', 'https://down.example', 'ACTIVE', NULL, 'UNREACHABLE', 503, 2, '2025-10-19 10:00:00+00', '2025-10-01 10:00:00+00'),
+       ('dead1', 'https://dead.example', 'ACTIVE', NULL, 'BROKEN', NULL, 5, '2025-10-21 10:00:00+00', NULL),
+       ('dead2', 'https://gone.example', 'INACTIVE', NULL, 'BROKEN', 404, 4, '2025-10-18 10:00:00+00', NULL),
+       ('dead3', 'https://bit.ly/gone', 'ACTIVE', '2025-10-01 10:00:00+00', 'BROKEN', 404, 3, '2025-10-17 10:00:00+00', NULL);
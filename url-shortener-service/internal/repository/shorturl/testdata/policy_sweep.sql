@@ -0,0 +1,6 @@
+INSERT INTO short_urls (short_code, original_url, status, flagged_at, flag_reason)
+VALUES ('aaa', 'https://google.com', 'ACTIVE', NULL, NULL),
+       ('bbb', 'https://phishing.example/login', 'ACTIVE', NULL, NULL),
+       ('ccc', 'https://bit.ly/abc', 'ACTIVE', '2025-10-01 10:00:00+00', 'shortener: bit.ly'),
+       ('ddd', 'https://bing.com', 'INACTIVE', NULL, NULL),
+       ('eee', 'https://github.com', 'ACTIVE', NULL, NULL);
@@ -0,0 +1,7 @@
+INSERT INTO short_urls (short_code, original_url, status, click_count, crawl_status, last_crawled_at, crawl_requested_at)
+VALUES ('hot', 'https://google.com', 'ACTIVE', 120, 'CRAWLED', '2025-09-01 10:00:00+00', '2025-09-01 09:59:00+00'),
+       ('warm', 'https://github.com', 'ACTIVE', 40, 'FAILED', '2025-09-15 10:00:00+00', NULL),
+       ('new', 'https://example.com', 'ACTIVE', 5, 'PENDING', NULL, NULL),
+       ('fresh', 'https://go.dev', 'ACTIVE', 500, 'CRAWLED', '2025-10-20 10:00:00+00', NULL),
+       ('queued', 'https://golang.org', 'ACTIVE', 300, 'CRAWLED', '2025-09-01 10:00:00+00', '2025-10-20 09:00:00+00'),
+       ('off', 'https://bing.com', 'INACTIVE', 900, 'SKIPPED', '2025-09-01 10:00:00+00', NULL);
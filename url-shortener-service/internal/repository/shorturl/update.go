@@ -38,6 +38,11 @@ func (i impl) Update(ctx context.Context, m model.ShortUrl, shortCode string) er
 		}
 		current.Metadata = null.JSONFrom(b)
 		whitelist = append(whitelist, orm.ShortURLColumns.Metadata)
+
+		// validators always describe the response the stored metadata comes from
+		current.MetadataEtag = null.NewString(m.Crawl.ETag, m.Crawl.ETag != "")
+		current.MetadataLastModified = null.NewString(m.Crawl.LastModified, m.Crawl.LastModified != "")
+		whitelist = append(whitelist, orm.ShortURLColumns.MetadataEtag, orm.ShortURLColumns.MetadataLastModified)
 	}
 
 	_, err = current.Update(ctx, i.db, boil.Whitelist(whitelist...))
@@ -32,6 +32,7 @@ func TestUpdate(t *testing.T) {
 				ShortCode:   "gg123",
 				OriginalURL: "https://google.com",
 				Status:      model.ShortUrlStatusInactive,
+				Crawl:       model.CrawlState{Status: model.CrawlStatusPending},
 			},
 			wantErr: false,
 		},
@@ -59,6 +60,7 @@ func TestUpdate(t *testing.T) {
 					Image:       "https://google.com/logo.png",
 					Favicon:     "https://google.com/favicon.ico",
 				},
+				Crawl: model.CrawlState{Status: model.CrawlStatusPending},
 			},
 			wantErr: false,
 		},
@@ -87,6 +89,35 @@ func TestUpdate(t *testing.T) {
 					Image:       "https://google.com/image.jpg",
 					Favicon:     "https://google.com/fav.ico",
 				},
+				Crawl: model.CrawlState{Status: model.CrawlStatusPending},
+			},
+			wantErr: false,
+		},
+
+		"success - update metadata with cache validators": {
+			fixture:   "testdata/accounts.sql",
+			shortCode: "gg123",
+			update: model.ShortUrl{
+				Metadata: model.UrlMetadata{
+					Title: "Google",
+				},
+				Crawl: model.CrawlState{
+					ETag:         `"abc"`,
+					LastModified: "Mon, 20 Oct 2025 10:00:00 GMT",
+				},
+			},
+			want: model.ShortUrl{
+				ShortCode:   "gg123",
+				OriginalURL: "https://google.com",
+				Status:      model.ShortUrlStatusActive,
+				Metadata: model.UrlMetadata{
+					Title: "Google",
+				},
+				Crawl: model.CrawlState{
+					Status:       model.CrawlStatusPending,
+					ETag:         `"abc"`,
+					LastModified: "Mon, 20 Oct 2025 10:00:00 GMT",
+				},
 			},
 			wantErr: false,
 		},
@@ -115,6 +146,7 @@ func TestUpdate(t *testing.T) {
 				Metadata: model.UrlMetadata{
 					Title: "Updated Title",
 				},
+				Crawl: model.CrawlState{Status: model.CrawlStatusPending},
 			},
 			wantErr: false,
 		},
@@ -154,4 +186,3 @@ func TestUpdate(t *testing.T) {
 		})
 	}
 }
-